@@ -0,0 +1,244 @@
+package darwinium
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	modulePath = "github.com/tomhoffer/darwinium"
+	apiFile    = "api/v1.txt"
+)
+
+var update = flag.Bool("update", false, "rewrite the recorded public API in "+apiFile)
+
+func TestVersion(t *testing.T) {
+	assert.Regexp(t, regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z.-]+)?$`), Version)
+	assert.True(t, strings.HasPrefix(Version, "1."), "api/v1.txt records the API of major version 1")
+}
+
+// TestAPICompatibility compares the exported API of the pkg/ packages with the recorded one.
+// Removed or changed declarations break compatibility; new declarations must be recorded by
+// running the test with -update.
+func TestAPICompatibility(t *testing.T) {
+	current, err := exportedAPI("pkg")
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(apiFile, []byte(strings.Join(current, "\n")+"\n"), 0o644))
+		return
+	}
+
+	data, err := os.ReadFile(apiFile)
+	require.NoError(t, err)
+	recorded := strings.Split(strings.TrimSpace(string(data)), "\n")
+
+	for _, line := range recorded {
+		if _, found := slices.BinarySearch(current, line); !found {
+			t.Errorf("incompatible API change, declaration removed or changed: %s", line)
+		}
+	}
+	for _, line := range current {
+		if !slices.Contains(recorded, line) {
+			t.Errorf("declaration not recorded in %s (run go test -run TestAPICompatibility -update): %s", apiFile, line)
+		}
+	}
+}
+
+// exportedAPI is a helper function listing the exported declarations of all non-test
+// packages under root, one sorted line per declaration.
+func exportedAPI(root string) ([]string, error) {
+	fset := token.NewFileSet()
+	var api []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		prefix := "pkg " + modulePath + "/" + filepath.ToSlash(filepath.Dir(path)) + ", "
+		for _, decl := range file.Decls {
+			for _, line := range declarationAPI(fset, decl) {
+				api = append(api, prefix+line)
+			}
+		}
+		return nil
+	})
+	slices.Sort(api)
+	return slices.Compact(api), err
+}
+
+// declarationAPI is a helper function describing the exported parts of a top-level declaration.
+func declarationAPI(fset *token.FileSet, decl ast.Decl) []string {
+	var api []string
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if !d.Name.IsExported() {
+			return nil
+		}
+		name := "func " + d.Name.Name
+		if d.Recv != nil {
+			receiver := d.Recv.List[0].Type
+			if star, ok := receiver.(*ast.StarExpr); ok {
+				receiver = star.X
+			}
+			if !receiverName(receiver).IsExported() {
+				return nil
+			}
+			name = "method (" + nodeString(fset, d.Recv.List[0].Type) + ") " + d.Name.Name
+		}
+		api = append(api, name+typeParams(fset, d.Type.TypeParams)+signature(fset, d.Type))
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				api = append(api, typeAPI(fset, s)...)
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					if !name.IsExported() {
+						continue
+					}
+					line := d.Tok.String() + " " + name.Name
+					if s.Type != nil {
+						line += " " + nodeString(fset, s.Type)
+					}
+					api = append(api, line)
+				}
+			}
+		}
+	}
+	return api
+}
+
+// typeAPI is a helper function describing an exported type together with its exported
+// struct fields and interface methods.
+func typeAPI(fset *token.FileSet, spec *ast.TypeSpec) []string {
+	if !spec.Name.IsExported() {
+		return nil
+	}
+	name := "type " + spec.Name.Name + typeParams(fset, spec.TypeParams)
+	if spec.Assign.IsValid() {
+		return []string{name + " = " + nodeString(fset, spec.Type)}
+	}
+
+	var members *ast.FieldList
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		name += " struct"
+		members = t.Fields
+	case *ast.InterfaceType:
+		name += " interface"
+		members = t.Methods
+	default:
+		return []string{name + " " + nodeString(fset, spec.Type)}
+	}
+
+	api := []string{name}
+	for _, field := range members.List {
+		if len(field.Names) == 0 {
+			api = append(api, name+", embedded "+nodeString(fset, field.Type))
+			continue
+		}
+		for _, fieldName := range field.Names {
+			if !fieldName.IsExported() {
+				continue
+			}
+			if function, ok := field.Type.(*ast.FuncType); ok && strings.HasSuffix(name, " interface") {
+				api = append(api, name+", method "+fieldName.Name+signature(fset, function))
+			} else {
+				api = append(api, name+", "+fieldName.Name+" "+nodeString(fset, field.Type))
+			}
+		}
+	}
+	return api
+}
+
+// signature is a helper function formatting parameter and result types without their names,
+// so that renaming a parameter is not reported as an API change.
+func signature(fset *token.FileSet, function *ast.FuncType) string {
+	result := "(" + strings.Join(fieldTypes(fset, function.Params), ", ") + ")"
+	results := fieldTypes(fset, function.Results)
+	switch {
+	case len(results) == 1:
+		result += " " + results[0]
+	case len(results) > 1:
+		result += " (" + strings.Join(results, ", ") + ")"
+	}
+	return result
+}
+
+// typeParams is a helper function formatting a type parameter list.
+func typeParams(fset *token.FileSet, params *ast.FieldList) string {
+	if params == nil || len(params.List) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, field := range params.List {
+		var names []string
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+		}
+		parts = append(parts, strings.Join(names, ", ")+" "+nodeString(fset, field.Type))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// fieldTypes is a helper function listing the type of every entry of a field list.
+func fieldTypes(fset *token.FileSet, fields *ast.FieldList) []string {
+	if fields == nil {
+		return nil
+	}
+	var types []string
+	for _, field := range fields.List {
+		count := max(len(field.Names), 1)
+		for range count {
+			types = append(types, nodeString(fset, field.Type))
+		}
+	}
+	return types
+}
+
+// receiverName is a helper function returning the base type name of a method receiver.
+func receiverName(receiver ast.Expr) *ast.Ident {
+	switch r := receiver.(type) {
+	case *ast.IndexExpr:
+		return receiverName(r.X)
+	case *ast.IndexListExpr:
+		return receiverName(r.X)
+	case *ast.Ident:
+		return r
+	}
+	return ast.NewIdent("_")
+}
+
+// nodeString is a helper function printing a syntax node on a single line.
+func nodeString(fset *token.FileSet, node ast.Node) string {
+	var buffer bytes.Buffer
+	_ = printer.Fprint(&buffer, fset, node)
+	return strings.Join(strings.Fields(buffer.String()), " ")
+}
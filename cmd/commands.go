@@ -0,0 +1,430 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tomhoffer/darwinium/pkg/config"
+)
+
+// overrideFlag collects repeated -set path=value flags.
+type overrideFlag []config.Override
+
+func (o *overrideFlag) String() string {
+	return ""
+}
+
+func (o *overrideFlag) Set(value string) error {
+	override, err := config.ParseOverride(value)
+	if err != nil {
+		return err
+	}
+	*o = append(*o, override)
+	return nil
+}
+
+// experimentFlags are the flags shared by the commands running experiments.
+type experimentFlags struct {
+	overrides overrideFlag
+	seed      int64
+	json      bool
+}
+
+func (f *experimentFlags) register(flags *flag.FlagSet) {
+	flags.Var(&f.overrides, "set", "override a configuration field, e.g. -set population.size=500 (repeatable)")
+	flags.Int64Var(&f.seed, "seed", 0, "seed of the random number generator, overrides the seed field; runs are reproducible with one worker")
+	flags.BoolVar(&f.json, "json", false, "write machine-readable JSON output and hide the progress bar")
+}
+
+// apply applies the overrides, the seed and the output format to the configuration.
+func (f *experimentFlags) apply(flags *flag.FlagSet, cfg *config.Config) error {
+	overrides := slices.Clone(f.overrides)
+	if isSet(flags, "seed") {
+		overrides = append(overrides, config.Override{Path: "seed", Value: f.seed})
+	}
+	if f.json {
+		overrides = append(overrides, config.Override{Path: "output.format", Value: config.JSONFormat})
+	}
+	return cfg.Apply(overrides...)
+}
+
+func newFlagSet(name, usageLine string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: darwinium %s\n\nFlags:\n", usageLine)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses the flags of a command and checks the number of positional arguments.
+// It returns false together with the exit code if the command must not continue.
+func parseFlags(flags *flag.FlagSet, args []string, positional int) (int, bool) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+	if flags.NArg() != positional {
+		fmt.Fprintf(flags.Output(), "expected %d argument(s), got %d\n\n", positional, flags.NArg())
+		flags.Usage()
+		return exitUsage, false
+	}
+	return exitOK, true
+}
+
+func isSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// report prints every invalid configuration field, or the error itself, and returns the exit code.
+func report(stderr io.Writer, err error) int {
+	fieldErrors := config.FieldErrors(err)
+	if len(fieldErrors) == 0 {
+		fmt.Fprintln(stderr, "error:", err)
+	}
+	for _, fieldError := range fieldErrors {
+		fmt.Fprintln(stderr, "error:", fieldError)
+	}
+	return exitError
+}
+
+// loadConfig loads the configuration file at path, or the defaults if path is empty.
+func loadConfig(path string) (*config.Config, error) {
+	if path == "" {
+		cfg := config.Default()
+		return &cfg, nil
+	}
+	return config.Load(path)
+}
+
+// runExperiment runs the experiment until it finishes or the process is interrupted
+// and writes the result as configured.
+func runExperiment(experiment *config.Experiment, quiet bool, stdout, stderr io.Writer) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	experiment.SetLogOutput(stderr)
+	experiment.SetProgress(!quiet)
+	result, err := experiment.Run(ctx)
+	if err != nil {
+		return report(stderr, fmt.Errorf("genetic algorithm failed: %w", err))
+	}
+
+	if err := writeResult(result, experiment.Config().Output, stdout); err != nil {
+		return report(stderr, err)
+	}
+	return exitOK
+}
+
+// writeResult writes the result to the configured output file, or to stdout if no path is set.
+// Errors closing the file are returned, so results which were not fully written are reported.
+func writeResult(result *config.Result, output config.Output, stdout io.Writer) (err error) {
+	if output.Path == "" {
+		return result.Write(stdout, output.Format)
+	}
+	file, err := os.Create(output.Path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("cannot close %s: %w", output.Path, closeErr))
+		}
+	}()
+	return result.Write(file, output.Format)
+}
+
+func runCommand(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("run", "run [-config file] [-set path=value]... [-seed n] [-json]", stderr)
+	configPath := flags.String("config", "", "YAML or JSON configuration file, defaults apply to missing fields")
+	var experimentFlags experimentFlags
+	experimentFlags.register(flags)
+	if code, ok := parseFlags(flags, args, 0); !ok {
+		return code
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return report(stderr, err)
+	}
+	if err := experimentFlags.apply(flags, cfg); err != nil {
+		return report(stderr, err)
+	}
+	experiment, err := config.Build(cfg)
+	if err != nil {
+		return report(stderr, err)
+	}
+	return runExperiment(experiment, experimentFlags.json, stdout, stderr)
+}
+
+func resumeCommand(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("resume", "resume [-set path=value]... [-seed n] [-json] <checkpoint>", stderr)
+	var experimentFlags experimentFlags
+	experimentFlags.register(flags)
+	if code, ok := parseFlags(flags, args, 1); !ok {
+		return code
+	}
+
+	checkpoint, err := config.LoadCheckpoint(flags.Arg(0))
+	if err != nil {
+		return report(stderr, err)
+	}
+	cfg := checkpoint.Config
+	if err := experimentFlags.apply(flags, &cfg); err != nil {
+		return report(stderr, err)
+	}
+	experiment, err := config.Resume(&cfg, checkpoint)
+	if err != nil {
+		return report(stderr, err)
+	}
+	return runExperiment(experiment, experimentFlags.json, stdout, stderr)
+}
+
+func inspectCommand(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("inspect", "inspect [-json] <checkpoint|results>", stderr)
+	asJSON := flags.Bool("json", false, "write the summary as JSON")
+	if code, ok := parseFlags(flags, args, 1); !ok {
+		return code
+	}
+
+	path := flags.Arg(0)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return report(stderr, err)
+	}
+	var probe struct {
+		FormatVersion *int     `json:"format_version"`
+		BestFitness   *float64 `json:"best_fitness"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return report(stderr, fmt.Errorf("%s is neither a checkpoint nor a JSON results file: %w", path, err))
+	}
+
+	switch {
+	case probe.FormatVersion != nil:
+		checkpoint, err := config.LoadCheckpoint(path)
+		if err != nil {
+			return report(stderr, err)
+		}
+		summary, err := checkpoint.Summary()
+		if err != nil {
+			return report(stderr, err)
+		}
+		if *asJSON {
+			return writeJSON(stdout, stderr, summary)
+		}
+		fmt.Fprintf(stdout, "Checkpoint: %s\n", path)
+		fmt.Fprintf(stdout, "Representation: %s\n", summary.Representation)
+		fmt.Fprintf(stdout, "Generation: %d (evaluations %d, restarts %d)\n", summary.Generation, summary.Evaluations, summary.Restarts)
+		fmt.Fprintf(stdout, "Population: %d individuals, %d unique genotypes\n", summary.PopulationSize, summary.UniqueGenotypes)
+		fmt.Fprintf(stdout, "Fitness: best %.6g, mean %.6g, worst %.6g, std %.6g\n", summary.BestFitness, summary.MeanFitness, summary.WorstFitness, summary.StdDevFitness)
+		fmt.Fprintf(stdout, "Best ever fitness: %.6g\n", summary.BestEverFitness)
+		fmt.Fprintf(stdout, "Best chromosome: %v\n", summary.BestChromosome)
+		if summary.HallOfFameSize > 0 {
+			fmt.Fprintf(stdout, "Hall of fame: %d entries\n", summary.HallOfFameSize)
+		}
+	case probe.BestFitness != nil:
+		var result config.Result
+		if err := json.Unmarshal(data, &result); err != nil {
+			return report(stderr, err)
+		}
+		format := config.TextFormat
+		if *asJSON {
+			format = config.JSONFormat
+		}
+		if err := result.Write(stdout, format); err != nil {
+			return report(stderr, err)
+		}
+	default:
+		return report(stderr, fmt.Errorf("%s is neither a checkpoint nor a JSON results file", path))
+	}
+	return exitOK
+}
+
+func listOperatorsCommand(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("list-operators", "list-operators [-kind kind] [-json]", stderr)
+	kind := flags.String("kind", "", "only list operators of this kind, e.g. selection")
+	asJSON := flags.Bool("json", false, "write the operators as JSON")
+	if code, ok := parseFlags(flags, args, 0); !ok {
+		return code
+	}
+
+	var operators []config.Operator
+	for _, operator := range config.AvailableOperators() {
+		if *kind == "" || string(operator.Kind) == *kind {
+			operators = append(operators, operator)
+		}
+	}
+	if len(operators) == 0 {
+		return report(stderr, fmt.Errorf("no operators of kind %q", *kind))
+	}
+	if *asJSON {
+		return writeJSON(stdout, stderr, operators)
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "KIND\tNAME\tPARAMS\tDESCRIPTION")
+	for _, operator := range operators {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", operator.Kind, operator.Name, formatParams(operator.Params), operator.Description)
+	}
+	if err := writer.Flush(); err != nil {
+		return report(stderr, err)
+	}
+	return exitOK
+}
+
+// formatParams formats default parameters as sorted key=value pairs.
+func formatParams(params map[string]any) string {
+	if len(params) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%v", key, params[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// benchRun is the outcome of a single benchmark run.
+type benchRun struct {
+	Seed           int64   `json:"seed"`
+	BestFitness    float64 `json:"best_fitness"`
+	Generations    int     `json:"generations"`
+	Evaluations    int     `json:"evaluations"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+}
+
+// benchReport summarizes repeated runs of an experiment.
+type benchReport struct {
+	Runs               []benchRun `json:"runs"`
+	MeanBestFitness    float64    `json:"mean_best_fitness"`
+	StdDevBestFitness  float64    `json:"std_dev_best_fitness"`
+	MinBestFitness     float64    `json:"min_best_fitness"`
+	MaxBestFitness     float64    `json:"max_best_fitness"`
+	MeanEvaluations    float64    `json:"mean_evaluations"`
+	MeanElapsedSeconds float64    `json:"mean_elapsed_seconds"`
+}
+
+func benchCommand(args []string, stdout, stderr io.Writer) int {
+	flags := newFlagSet("bench", "bench [-config file] [-set path=value]... [-runs n] [-seed n] [-json]", stderr)
+	configPath := flags.String("config", "", "YAML or JSON configuration file, defaults apply to missing fields")
+	runs := flags.Int("runs", 10, "number of runs")
+	var experimentFlags experimentFlags
+	experimentFlags.register(flags)
+	if code, ok := parseFlags(flags, args, 0); !ok {
+		return code
+	}
+	if *runs < 1 {
+		fmt.Fprintf(stderr, "-runs must be at least 1, got %d\n", *runs)
+		return exitUsage
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		return report(stderr, err)
+	}
+	if err := experimentFlags.apply(flags, cfg); err != nil {
+		return report(stderr, err)
+	}
+	firstSeed := int64(1)
+	if cfg.Seed != nil {
+		firstSeed = *cfg.Seed
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var benchmark benchReport
+	for i := 0; i < *runs; i++ {
+		runConfig := *cfg
+		seed := firstSeed + int64(i)
+		runConfig.Seed = &seed
+		runConfig.Output.LogInterval = 0
+		runConfig.Output.Checkpoint = ""
+		runConfig.Output.CheckpointInterval = 0
+
+		experiment, err := config.Build(&runConfig)
+		if err != nil {
+			return report(stderr, err)
+		}
+		experiment.SetProgress(false)
+		result, err := experiment.Run(ctx)
+		if err != nil {
+			return report(stderr, fmt.Errorf("run %d failed: %w", i+1, err))
+		}
+		benchmark.Runs = append(benchmark.Runs, benchRun{
+			Seed:           seed,
+			BestFitness:    result.BestFitness,
+			Generations:    result.Generations,
+			Evaluations:    result.Evaluations,
+			ElapsedSeconds: result.ElapsedSeconds,
+		})
+	}
+	benchmark.summarize()
+
+	if cfg.Output.Format == config.JSONFormat {
+		return writeJSON(stdout, stderr, benchmark)
+	}
+	writer := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "SEED\tBEST FITNESS\tGENERATIONS\tEVALUATIONS\tELAPSED")
+	for _, run := range benchmark.Runs {
+		fmt.Fprintf(writer, "%d\t%.6g\t%d\t%d\t%.3fs\n", run.Seed, run.BestFitness, run.Generations, run.Evaluations, run.ElapsedSeconds)
+	}
+	if err := writer.Flush(); err != nil {
+		return report(stderr, err)
+	}
+	fmt.Fprintf(stdout, "\nBest fitness: mean %.6g, std %.6g, min %.6g, max %.6g\n", benchmark.MeanBestFitness, benchmark.StdDevBestFitness, benchmark.MinBestFitness, benchmark.MaxBestFitness)
+	fmt.Fprintf(stdout, "Mean evaluations: %.1f, mean elapsed: %.3fs\n", benchmark.MeanEvaluations, benchmark.MeanElapsedSeconds)
+	return exitOK
+}
+
+// summarize computes the aggregate statistics of the runs.
+func (b *benchReport) summarize() {
+	b.MinBestFitness, b.MaxBestFitness = math.Inf(1), math.Inf(-1)
+	for _, run := range b.Runs {
+		b.MeanBestFitness += run.BestFitness
+		b.MeanEvaluations += float64(run.Evaluations)
+		b.MeanElapsedSeconds += run.ElapsedSeconds
+		b.MinBestFitness = math.Min(b.MinBestFitness, run.BestFitness)
+		b.MaxBestFitness = math.Max(b.MaxBestFitness, run.BestFitness)
+	}
+	count := float64(len(b.Runs))
+	b.MeanBestFitness /= count
+	b.MeanEvaluations /= count
+	b.MeanElapsedSeconds /= count
+	for _, run := range b.Runs {
+		b.StdDevBestFitness += (run.BestFitness - b.MeanBestFitness) * (run.BestFitness - b.MeanBestFitness)
+	}
+	b.StdDevBestFitness = math.Sqrt(b.StdDevBestFitness / count)
+}
+
+func writeJSON(stdout, stderr io.Writer, value any) int {
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return report(stderr, err)
+	}
+	return exitOK
+}
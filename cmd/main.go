@@ -1,76 +1,79 @@
+// Command darwinium runs and inspects genetic algorithm experiments described by configuration files.
+//
+// Usage:
+//
+//	darwinium run [-config file] [-set path=value]... [-seed n] [-json]
+//	darwinium resume [-set path=value]... [-seed n] [-json] <checkpoint>
+//	darwinium inspect [-json] <checkpoint|results>
+//	darwinium list-operators [-kind kind] [-json]
+//	darwinium bench [-config file] [-set path=value]... [-runs n] [-seed n] [-json]
+//
+// Any configuration field can be overridden with -set, e.g. -set population.size=500,
+// -set operators.selection.params.size=3 or -set operators.selection=tournament{size:3};
+// values are parsed as YAML.
 package main
 
 import (
-	"context"
 	"fmt"
-	"math/rand"
-
-	"github.com/tomhoffer/darwinium/internal/core"
-	"github.com/tomhoffer/darwinium/internal/ga/crossover"
-	"github.com/tomhoffer/darwinium/internal/ga/executor"
-	"github.com/tomhoffer/darwinium/internal/ga/fitness"
-	"github.com/tomhoffer/darwinium/internal/ga/mutation"
-	"github.com/tomhoffer/darwinium/internal/ga/selection"
+	"io"
+	"os"
 )
 
+// Exit codes of the command.
 const (
-	populationSize   = 100000
-	chromosomeLength = 20
-	generations      = 500
-	tournamentSize   = 5
-	elitismCount     = 1
-	mutationRate     = 0.01 // Per-gene mutation probability
-	geneMin          = -100
-	geneMax          = 100
-	numWorkers       = -1
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
 )
 
-// Custom chromosome type
-type chromosomeType int
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
 
-func generateRandomPopulation(populationFactory *core.PopulationFactory[chromosomeType], solutionFactory *core.SolutionFactory[chromosomeType]) *core.Population[chromosomeType] {
-	var individuals []core.Solution[chromosomeType]
-	for i := 0; i < populationSize; i++ {
-		chromosome := make([]chromosomeType, chromosomeLength)
-		for j := 0; j < chromosomeLength; j++ {
-			chromosome[j] = chromosomeType(rand.Intn(geneMax-geneMin+1) + geneMin)
-		}
-		individuals = append(individuals, *solutionFactory.CreateSolution(chromosome))
+func commands() []command {
+	return []command{
+		{"run", "run [-config file] [-set path=value]... [-seed n] [-json]", "run an experiment", runCommand},
+		{"resume", "resume [-set path=value]... [-seed n] [-json] <checkpoint>", "continue an experiment from a checkpoint", resumeCommand},
+		{"inspect", "inspect [-json] <checkpoint|results>", "summarize a checkpoint or a results file", inspectCommand},
+		{"list-operators", "list-operators [-kind kind] [-json]", "list the operators available to configurations", listOperatorsCommand},
+		{"bench", "bench [-config file] [-set path=value]... [-runs n] [-seed n] [-json]", "run an experiment repeatedly and summarize the results", benchCommand},
 	}
-	return populationFactory.CreatePopulation(individuals)
 }
 
 func main() {
-	// 1. Dependency injection
-	solutionFactory := core.NewSolutionFactory[chromosomeType]()
-	populationFactory := core.NewPopulationFactory[chromosomeType]()
-	fitnessEvaluator := fitness.NewSimpleSumFitnessEvaluator[chromosomeType]()
-	crossoverer := crossover.NewSinglePointCrossover[chromosomeType]()
-	mutator := mutation.NewSimpleSwapMutator[chromosomeType](mutationRate)
-	selector, err := selection.NewTournamentSelector[chromosomeType](tournamentSize, elitismCount)
-	if err != nil {
-		panic(fmt.Sprintf("failed to create selector: %v", err))
-	}
-
-	// 2. Generate a random population
-	population := generateRandomPopulation(populationFactory, solutionFactory)
-
-	// 3. Instantiate the GeneticAlgorithmExecutor
-	executor := executor.NewGeneticAlgorithmExecutor(population, fitnessEvaluator, mutator, selector, crossoverer, generations, numWorkers)
+	os.Exit(execute(os.Args[1:], os.Stdout, os.Stderr))
+}
 
-	// 4. Run the GA loop
-	ctx := context.Background()
-	finalPopulation, err := executor.Loop(ctx, generations)
-	if err != nil {
-		panic(fmt.Sprintf("genetic algorithm failed: %v", err))
+// execute runs the subcommand named by the first argument and returns the exit code.
+func execute(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
 	}
-
-	// 5. Print the final result
-	bestSolution, err := finalPopulation.BestSolution()
-	if err != nil {
-		panic(fmt.Sprintf("failed to get best solution: %v", err))
+	for _, cmd := range commands() {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
 	}
+	fmt.Fprintf(stderr, "unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
 
-	fmt.Printf("Best solution found with fitness %.2f:\n", bestSolution.Fitness)
-	fmt.Printf("Chromosome: %v\n", bestSolution.Chromosome)
+func usage(writer io.Writer) {
+	fmt.Fprintln(writer, "Usage: darwinium <command> [flags]")
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "Commands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(writer, "  %-16s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "Run 'darwinium <command> -h' for the flags of a command.")
 }
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executeCommand is a helper function running the CLI and returning its exit code and outputs.
+func executeCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := execute(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("prints usage", func(t *testing.T) {
+		code, _, stderr := executeCommand()
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, "list-operators")
+
+		code, _, _ = executeCommand("help")
+		assert.Equal(t, exitOK, code)
+
+		code, _, stderr = executeCommand("evolve")
+		assert.Equal(t, exitUsage, code)
+		assert.Contains(t, stderr, `unknown command "evolve"`)
+	})
+
+	t.Run("rejects invalid flags and arguments", func(t *testing.T) {
+		code, _, _ := executeCommand("run", "-generations", "5")
+		assert.Equal(t, exitUsage, code)
+
+		code, _, _ = executeCommand("resume")
+		assert.Equal(t, exitUsage, code)
+
+		code, _, _ = executeCommand("run", "-h")
+		assert.Equal(t, exitOK, code)
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		code, _, stderr := executeCommand("run", "-set", "population.size=0", "-set", "operators.mutation.name=flip")
+		assert.Equal(t, exitError, code)
+		assert.Contains(t, stderr, "error: population.size:")
+		assert.Contains(t, stderr, "error: operators.mutation.name:")
+	})
+}
+
+func TestRunAndResume(t *testing.T) {
+	directory := t.TempDir()
+	checkpoint := filepath.Join(directory, "checkpoint.json")
+	results := filepath.Join(directory, "results.json")
+
+	code, stdout, stderr := executeCommand("run",
+		"-config", "../configs/example.yaml",
+		"-set", "population.size=20",
+		"-set", "termination.generations=10",
+		"-set", "termination.criteria=[]",
+		"-set", "workers=1",
+		"-set", "output.checkpoint="+checkpoint,
+		"-set", "output.checkpoint_interval=5",
+		"-seed", "3",
+		"-json",
+	)
+	require.Equal(t, exitOK, code, stderr)
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(stdout), &first))
+	assert.Equal(t, 10.0, first["generations"])
+	assert.Equal(t, 3.0, first["seed"])
+
+	t.Run("seeded runs are reproducible", func(t *testing.T) {
+		code, stdout, _ := executeCommand("run",
+			"-config", "../configs/example.yaml",
+			"-set", "population.size=20",
+			"-set", "termination.generations=10",
+			"-set", "termination.criteria=[]",
+			"-set", "workers=1",
+			"-seed", "3",
+			"-json",
+		)
+		require.Equal(t, exitOK, code)
+		var second map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &second))
+		assert.Equal(t, first["best_chromosome"], second["best_chromosome"])
+	})
+
+	t.Run("inspects the checkpoint", func(t *testing.T) {
+		code, stdout, _ := executeCommand("inspect", checkpoint)
+		require.Equal(t, exitOK, code)
+		assert.Contains(t, stdout, "Generation: 10 (evaluations 220, restarts 0)")
+
+		code, stdout, _ = executeCommand("inspect", "-json", checkpoint)
+		require.Equal(t, exitOK, code)
+		var summary map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &summary))
+		assert.Equal(t, 20.0, summary["population_size"])
+	})
+
+	t.Run("resumes from the checkpoint", func(t *testing.T) {
+		code, _, stderr := executeCommand("resume",
+			"-set", "termination.generations=15",
+			"-set", "output.path="+results,
+			"-json",
+			checkpoint,
+		)
+		require.Equal(t, exitOK, code, stderr)
+		data, err := os.ReadFile(results)
+		require.NoError(t, err)
+		var resumed map[string]any
+		require.NoError(t, json.Unmarshal(data, &resumed))
+		assert.Equal(t, 15.0, resumed["generations"])
+		assert.Equal(t, 320.0, resumed["evaluations"])
+	})
+
+	t.Run("reports results which cannot be written", func(t *testing.T) {
+		if _, err := os.Stat("/dev/full"); err != nil {
+			t.Skip("/dev/full is not available")
+		}
+		code, _, stderr := executeCommand("run",
+			"-set", "population.size=10",
+			"-set", "termination.generations=2",
+			"-set", "output.path=/dev/full",
+		)
+		assert.Equal(t, exitError, code)
+		assert.Contains(t, stderr, "no space left on device")
+	})
+
+	t.Run("inspects the results", func(t *testing.T) {
+		code, stdout, _ := executeCommand("inspect", results)
+		require.Equal(t, exitOK, code)
+		assert.Contains(t, stdout, "Generations: 15")
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		code, _, stderr := executeCommand("inspect", "../configs/example.json")
+		assert.Equal(t, exitError, code)
+		assert.Contains(t, stderr, "neither a checkpoint nor a JSON results file")
+	})
+}
+
+func TestListOperators(t *testing.T) {
+	t.Run("lists operators as a table", func(t *testing.T) {
+		code, stdout, _ := executeCommand("list-operators")
+		require.Equal(t, exitOK, code)
+		assert.Contains(t, stdout, "tournament")
+		assert.Contains(t, stdout, "elites=1 size=5")
+	})
+
+	t.Run("filters by kind as JSON", func(t *testing.T) {
+		code, stdout, _ := executeCommand("list-operators", "-kind", "selection", "-json")
+		require.Equal(t, exitOK, code)
+		var operators []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(stdout), &operators))
+		for _, operator := range operators {
+			assert.Equal(t, "selection", operator["kind"])
+		}
+	})
+
+	t.Run("rejects unknown kinds", func(t *testing.T) {
+		code, _, _ := executeCommand("list-operators", "-kind", "magic")
+		assert.Equal(t, exitError, code)
+	})
+}
+
+func TestBench(t *testing.T) {
+	t.Run("summarizes repeated seeded runs", func(t *testing.T) {
+		args := []string{"bench", "-runs", "3", "-seed", "5",
+			"-set", "population.size=10",
+			"-set", "termination.generations=5",
+			"-json",
+		}
+		code, stdout, stderr := executeCommand(args...)
+		require.Equal(t, exitOK, code, stderr)
+		var report benchReport
+		require.NoError(t, json.Unmarshal([]byte(stdout), &report))
+		require.Len(t, report.Runs, 3)
+		for i, run := range report.Runs {
+			assert.Equal(t, int64(5+i), run.Seed)
+			assert.GreaterOrEqual(t, run.BestFitness, report.MinBestFitness)
+			assert.LessOrEqual(t, run.BestFitness, report.MaxBestFitness)
+		}
+		assert.Equal(t, 60.0, report.MeanEvaluations)
+	})
+
+	t.Run("requires at least one run", func(t *testing.T) {
+		code, _, _ := executeCommand("bench", "-runs", "0")
+		assert.Equal(t, exitUsage, code)
+	})
+}
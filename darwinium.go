@@ -0,0 +1,26 @@
+// Package darwinium is the root of the darwinium evolutionary computation library.
+//
+// The public API lives in the packages under pkg/:
+//   - pkg/core: chromosomes, individuals, populations, statistics and the hall of fame
+//   - pkg/ga/...: the genetic algorithm executor and its operators (selection, crossover,
+//     mutation, replacement, termination, fitness evaluation, constraints, niching,
+//     distance metrics, local search and observers)
+//   - pkg/bitstring, pkg/schema: binary and schema-driven representations
+//   - pkg/gp, pkg/pso, pkg/de, pkg/cmaes: genetic programming, particle swarm optimization,
+//     differential evolution and CMA-ES engines
+//   - pkg/problems/...: benchmark problems with known optima for validating operators and tuning
+//     parameters, e.g. continuous test functions, the traveling salesman problem (pkg/problems/tsp),
+//     the knapsack problem (pkg/problems/knapsack), bin packing (pkg/problems/binpacking),
+//     weighted MaxSAT (pkg/problems/maxsat) and job-shop and flow-shop scheduling (pkg/problems/scheduling)
+//   - pkg/registry: named, parameterized operators for configuration-driven runs
+//   - pkg/config: declarative YAML/JSON run configurations
+//
+// Packages under internal/ are implementation details and may change at any time.
+//
+// The library follows semantic versioning (https://semver.org). Within a major version,
+// exported identifiers of the pkg/ packages are only ever added, never removed or changed.
+// The exported API is recorded in api/v1.txt and checked by TestAPICompatibility.
+package darwinium
+
+// Version is the semantic version of the library.
+const Version = "1.12.0"
@@ -0,0 +1,268 @@
+// Package decode stores the generic documents produced by the YAML and JSON parsers into
+// typed Go values, collecting every problem together with the path of the offending field.
+package decode
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Error is a problem decoding the value at Path.
+type Error struct {
+	Path    string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Decode stores document into the value target points to. Struct fields are matched by their
+// json tag; fields missing from the document are left unchanged and unknown keys are reported.
+// Strings are decoded into types implementing encoding.TextUnmarshaler and time.Duration values
+// are parsed from strings such as "30s". Every problem is returned with its path prefixed by path.
+func Decode(document any, path string, target any) []*Error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return []*Error{{Path: path, Message: "decoding target must be a non-nil pointer"}}
+	}
+	var d decoder
+	d.decode(document, path, value.Elem())
+	return d.errs
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decoder collects the errors of a single Decode call.
+type decoder struct {
+	errs []*Error
+}
+
+func (d *decoder) fail(path, format string, args ...any) {
+	d.errs = append(d.errs, &Error{Path: displayPath(path), Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *decoder) decode(document any, path string, target reflect.Value) {
+	if document == nil {
+		target.SetZero()
+		return
+	}
+
+	if text, ok := document.(string); ok && target.Kind() != reflect.Pointer && reflect.PointerTo(target.Type()).Implements(textUnmarshalerType) {
+		if err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			d.fail(path, "%v", err)
+		}
+		return
+	}
+
+	switch {
+	case target.Type() == durationType:
+		d.decodeDuration(document, path, target)
+		return
+	case target.Kind() == reflect.Pointer:
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		d.decode(document, path, target.Elem())
+		return
+	case target.Kind() == reflect.Interface:
+		target.Set(reflect.ValueOf(document))
+		return
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		d.decodeStruct(document, path, target)
+	case reflect.Map:
+		d.decodeMap(document, path, target)
+	case reflect.Slice:
+		items, ok := document.([]any)
+		if !ok {
+			d.fail(path, "expected a list, got %s", describe(document))
+			return
+		}
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			d.decode(item, fmt.Sprintf("%s[%d]", path, i), slice.Index(i))
+		}
+		target.Set(slice)
+	case reflect.String:
+		s, ok := document.(string)
+		if !ok {
+			d.fail(path, "expected a string, got %s", describe(document))
+			return
+		}
+		target.SetString(s)
+	case reflect.Bool:
+		b, ok := document.(bool)
+		if !ok {
+			d.fail(path, "expected a boolean, got %s", describe(document))
+			return
+		}
+		target.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if exact, ok := document.(json.Number); ok {
+			if i, err := exact.Int64(); err == nil && !target.OverflowInt(i) {
+				target.SetInt(i)
+				return
+			}
+		}
+		n, ok := number(document)
+		if !ok || n != math.Trunc(n) {
+			d.fail(path, "expected an integer, got %s", describe(document))
+			return
+		}
+		if target.OverflowInt(int64(n)) {
+			d.fail(path, "integer %v out of range", n)
+			return
+		}
+		target.SetInt(int64(n))
+	case reflect.Float32, reflect.Float64:
+		n, ok := number(document)
+		if !ok {
+			d.fail(path, "expected a number, got %s", describe(document))
+			return
+		}
+		target.SetFloat(n)
+	default:
+		d.fail(path, "unsupported field type %s", target.Type())
+	}
+}
+
+func (d *decoder) decodeStruct(document any, path string, target reflect.Value) {
+	object, ok := document.(map[string]any)
+	if !ok {
+		d.fail(path, "expected an object, got %s", describe(document))
+		return
+	}
+	fields := make(map[string]int)
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := FieldName(field)
+		if name != "-" {
+			fields[name] = i
+		}
+	}
+	for _, key := range sortedKeys(object) {
+		index, ok := fields[key]
+		if !ok {
+			d.fail(Join(path, key), "unknown field")
+			continue
+		}
+		d.decode(object[key], Join(path, key), target.Field(index))
+	}
+}
+
+func (d *decoder) decodeMap(document any, path string, target reflect.Value) {
+	object, ok := document.(map[string]any)
+	if !ok {
+		d.fail(path, "expected an object, got %s", describe(document))
+		return
+	}
+	if target.Type().Key().Kind() != reflect.String {
+		d.fail(path, "unsupported field type %s", target.Type())
+		return
+	}
+	result := reflect.MakeMapWithSize(target.Type(), len(object))
+	for _, key := range sortedKeys(object) {
+		element := reflect.New(target.Type().Elem()).Elem()
+		d.decode(object[key], Join(path, key), element)
+		result.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+	}
+	target.Set(result)
+}
+
+func (d *decoder) decodeDuration(document any, path string, target reflect.Value) {
+	s, ok := document.(string)
+	if !ok {
+		d.fail(path, "expected a duration such as \"30s\", got %s", describe(document))
+		return
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		d.fail(path, "invalid duration %q", s)
+		return
+	}
+	target.SetInt(int64(duration))
+}
+
+// number converts the numeric values produced by the YAML and JSON parsers to float64.
+func number(document any) (float64, bool) {
+	switch n := document.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// describe names the kind of a generic document value for error messages.
+func describe(document any) string {
+	switch v := document.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "a list"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return fmt.Sprintf("boolean %v", v)
+	}
+	if n, ok := number(document); ok {
+		return fmt.Sprintf("number %v", n)
+	}
+	return fmt.Sprintf("%T", document)
+}
+
+// FieldName returns the key of a struct field as defined by its json tag.
+func FieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// Join appends key to path, separating them with a dot.
+func Join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+func sortedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+// Package random lets operators draw random numbers from a generator of their own, see
+// core.IRandomized, and from the top-level functions of math/rand otherwise.
+package random
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Rand draws random numbers from a generator. A nil *Rand draws them from the top-level
+// functions of math/rand, so operators without a generator of their own keep drawing from
+// the lock-free global source.
+type Rand struct {
+	generator *rand.Rand
+}
+
+// New wraps generator. It returns nil for a nil generator.
+func New(generator *rand.Rand) *Rand {
+	if generator == nil {
+		return nil
+	}
+	return &Rand{generator: generator}
+}
+
+// Float64 returns a pseudo-random number in [0.0, 1.0).
+func (r *Rand) Float64() float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.generator.Float64()
+}
+
+// Intn returns a pseudo-random number in [0, n). It panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.generator.Intn(n)
+}
+
+// Shuffle pseudo-randomizes the order of n elements using swap.
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	if r == nil {
+		rand.Shuffle(n, swap)
+		return
+	}
+	r.generator.Shuffle(n, swap)
+}
+
+// lockedSource is a rand.Source64 safe for concurrent use.
+type lockedSource struct {
+	mutex  sync.Mutex
+	source rand.Source64
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.source.Int63()
+}
+
+func (s *lockedSource) Uint64() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.source.Uint64()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.source.Seed(seed)
+}
+
+// NewLocked returns a generator seeded with seed which is safe for concurrent use, so that the
+// operators of a single run can share it between their workers.
+func NewLocked(seed int64) *rand.Rand {
+	return rand.New(&lockedSource{source: rand.NewSource(seed).(rand.Source64)})
+}
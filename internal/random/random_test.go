@@ -0,0 +1,76 @@
+package random
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRand(t *testing.T) {
+	t.Run("draws from the given generator", func(t *testing.T) {
+		rng := New(rand.New(rand.NewSource(1)))
+		expected := rand.New(rand.NewSource(1))
+		assert.Equal(t, expected.Float64(), rng.Float64())
+		assert.Equal(t, expected.Intn(10), rng.Intn(10))
+
+		values, expectedValues := []int{0, 1, 2, 3, 4}, []int{0, 1, 2, 3, 4}
+		rng.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+		expected.Shuffle(len(expectedValues), func(i, j int) { expectedValues[i], expectedValues[j] = expectedValues[j], expectedValues[i] })
+		assert.Equal(t, expectedValues, values)
+	})
+
+	t.Run("nil draws from math/rand", func(t *testing.T) {
+		var rng *Rand
+		assert.Nil(t, New(nil))
+		value := rng.Float64()
+		assert.True(t, value >= 0 && value < 1)
+		assert.Less(t, rng.Intn(3), 3)
+		values := []int{0, 1, 2}
+		rng.Shuffle(len(values), func(i, j int) { values[i], values[j] = values[j], values[i] })
+		assert.ElementsMatch(t, []int{0, 1, 2}, values)
+	})
+}
+
+func TestNewLocked(t *testing.T) {
+	t.Run("is reproducible", func(t *testing.T) {
+		first, second := NewLocked(7), NewLocked(7)
+		for range 10 {
+			assert.Equal(t, first.Int63(), second.Int63())
+			assert.Equal(t, first.Uint64(), second.Uint64())
+		}
+		first.Seed(3)
+		assert.Equal(t, rand.New(rand.NewSource(3)).Int63(), first.Int63())
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		generator := NewLocked(1)
+		var wg sync.WaitGroup
+		values := make([][]float64, 8)
+		for i := range values {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 1000 {
+					values[i] = append(values[i], generator.Float64())
+				}
+			}()
+		}
+		wg.Wait()
+
+		// The workers drew every number of the sequence exactly once
+		expected := rand.New(rand.NewSource(1))
+		drawn := make(map[float64]int)
+		for _, worker := range values {
+			for _, value := range worker {
+				drawn[value]++
+			}
+		}
+		for range 8000 {
+			value := expected.Float64()
+			assert.Positive(t, drawn[value])
+			drawn[value]--
+		}
+	})
+}
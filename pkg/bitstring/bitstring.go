@@ -0,0 +1,317 @@
+// Package bitstring provides a packed bitstring genome backed by 64-bit words together with
+// bit-level operators, Hamming distance and Gray-code decoding.
+package bitstring
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"strings"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// wordSize is the number of bits stored per word.
+const wordSize = 64
+
+// Bitstring is a fixed-length string of bits packed into 64-bit words. Bit i is stored in
+// Words[i/64] at position i%64, counting from the least significant bit. Bits beyond Length
+// are always zero.
+type Bitstring struct {
+	// Words holds the packed bits.
+	Words []uint64
+	// Length is the number of bits.
+	Length int
+}
+
+// New creates a bitstring of the given length with every bit cleared.
+func New(length int) Bitstring {
+	return Bitstring{Words: make([]uint64, wordsFor(length)), Length: length}
+}
+
+// Random creates a bitstring of the given length with uniformly random bits.
+func Random(length int) Bitstring {
+	b := New(length)
+	for i := range b.Words {
+		b.Words[i] = rand.Uint64()
+	}
+	b.clearTail()
+	return b
+}
+
+// FromBools creates a bitstring holding the given bits.
+func FromBools(values []bool) Bitstring {
+	b := New(len(values))
+	for i, value := range values {
+		b.Set(i, value)
+	}
+	return b
+}
+
+// wordsFor returns the number of words needed to store length bits.
+func wordsFor(length int) int {
+	return (length + wordSize - 1) / wordSize
+}
+
+// clearTail clears the unused bits of the last word.
+func (b Bitstring) clearTail() {
+	if rem := b.Length % wordSize; rem != 0 {
+		b.Words[len(b.Words)-1] &= (1 << rem) - 1
+	}
+}
+
+// Clone returns a deep copy of the bitstring. It implements core.Cloner.
+func (b Bitstring) Clone() Bitstring {
+	return Bitstring{Words: slices.Clone(b.Words), Length: b.Length}
+}
+
+// Get returns bit i.
+func (b Bitstring) Get(i int) bool {
+	return b.Words[i/wordSize]&(1<<(i%wordSize)) != 0
+}
+
+// Set sets bit i to value.
+func (b Bitstring) Set(i int, value bool) {
+	if value {
+		b.Words[i/wordSize] |= 1 << (i % wordSize)
+	} else {
+		b.Words[i/wordSize] &^= 1 << (i % wordSize)
+	}
+}
+
+// Flip inverts bit i.
+func (b Bitstring) Flip(i int) {
+	b.Words[i/wordSize] ^= 1 << (i % wordSize)
+}
+
+// OnesCount returns the number of set bits.
+func (b Bitstring) OnesCount() int {
+	count := 0
+	for _, word := range b.Words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// String returns the bits as a string of '0' and '1', starting with bit 0.
+func (b Bitstring) String() string {
+	var sb strings.Builder
+	sb.Grow(b.Length)
+	for i := 0; i < b.Length; i++ {
+		if b.Get(i) {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// HammingDistance returns the number of positions at which two bitstrings differ.
+// It returns an error if the bitstrings differ in length.
+func HammingDistance(a, b Bitstring) (int, error) {
+	if a.Length != b.Length {
+		return 0, NewBitstringError("cannot compute Hamming distance", fmt.Errorf("lengths differ: %d and %d", a.Length, b.Length))
+	}
+	distance := 0
+	for i := range a.Words {
+		distance += bits.OnesCount64(a.Words[i] ^ b.Words[i])
+	}
+	return distance, nil
+}
+
+// Genome implements core.Genome for bitstrings.
+type Genome struct {
+	seed maphash.Seed
+}
+
+// NewGenome creates a bitstring Genome.
+func NewGenome() *Genome {
+	return &Genome{seed: maphash.MakeSeed()}
+}
+
+// Clone implements core.Genome.
+func (g *Genome) Clone(chromosome Bitstring) Bitstring {
+	return chromosome.Clone()
+}
+
+// Len implements core.Genome and returns the number of bits.
+func (g *Genome) Len(chromosome Bitstring) int {
+	return chromosome.Length
+}
+
+// Equal implements core.Genome.
+func (g *Genome) Equal(a, b Bitstring) bool {
+	return a.Length == b.Length && slices.Equal(a.Words, b.Words)
+}
+
+// Hash implements core.Genome.
+func (g *Genome) Hash(chromosome Bitstring) uint64 {
+	var h maphash.Hash
+	h.SetSeed(g.seed)
+	var buffer [8]byte
+	binary.LittleEndian.PutUint64(buffer[:], uint64(chromosome.Length))
+	_, _ = h.Write(buffer[:])
+	for _, word := range chromosome.Words {
+		binary.LittleEndian.PutUint64(buffer[:], word)
+		_, _ = h.Write(buffer[:])
+	}
+	return h.Sum64()
+}
+
+// NewRandomPopulation creates a population of size random bitstrings of the given length.
+func NewRandomPopulation(size, length int) *core.GenomePopulation[Bitstring] {
+	individuals := make([]core.Individual[Bitstring], size)
+	for i := range individuals {
+		individuals[i] = core.Individual[Bitstring]{Chromosome: Random(length)}
+	}
+	return &core.GenomePopulation[Bitstring]{Individuals: individuals}
+}
+
+// OneMaxEvaluator evaluates a bitstring by its number of set bits.
+type OneMaxEvaluator struct{}
+
+// NewOneMaxEvaluator creates a OneMaxEvaluator.
+func NewOneMaxEvaluator() *OneMaxEvaluator {
+	return &OneMaxEvaluator{}
+}
+
+// Evaluate implements fitness.IGenomeEvaluator.
+func (o *OneMaxEvaluator) Evaluate(ctx context.Context, chromosome *Bitstring) (float64, error) {
+	return float64(chromosome.OnesCount()), nil
+}
+
+// BinaryToGray converts a binary number into its reflected Gray code.
+func BinaryToGray(value uint64) uint64 {
+	return value ^ (value >> 1)
+}
+
+// GrayToBinary converts a reflected Gray code into the binary number it encodes.
+func GrayToBinary(gray uint64) uint64 {
+	for shift := uint(1); shift < wordSize; shift <<= 1 {
+		gray ^= gray >> shift
+	}
+	return gray
+}
+
+// checkRange validates that the n bits starting at offset lie within b and fit a uint64.
+func (b Bitstring) checkRange(offset, n int) error {
+	if n < 1 || n > wordSize || offset < 0 || offset+n > b.Length {
+		return NewBitstringError("invalid bit range", fmt.Errorf("cannot access %d bits at offset %d of a bitstring of length %d", n, offset, b.Length))
+	}
+	return nil
+}
+
+// Uint reads the n bits starting at offset as an unsigned binary number whose most
+// significant bit is the bit at offset. n must be within [1, 64].
+func (b Bitstring) Uint(offset, n int) (uint64, error) {
+	if err := b.checkRange(offset, n); err != nil {
+		return 0, err
+	}
+	var value uint64
+	for i := offset; i < offset+n; i++ {
+		value <<= 1
+		if b.Get(i) {
+			value |= 1
+		}
+	}
+	return value, nil
+}
+
+// SetUint writes the n least significant bits of value at offset, most significant bit first.
+func (b Bitstring) SetUint(offset, n int, value uint64) error {
+	if err := b.checkRange(offset, n); err != nil {
+		return err
+	}
+	for i := offset + n - 1; i >= offset; i-- {
+		b.Set(i, value&1 == 1)
+		value >>= 1
+	}
+	return nil
+}
+
+// DecodeGray reads the n bits starting at offset as a Gray code and returns the integer it encodes.
+// Neighboring integers differ in a single bit under Gray coding, which makes bit-flip
+// mutation a local move in parameter space.
+func (b Bitstring) DecodeGray(offset, n int) (uint64, error) {
+	gray, err := b.Uint(offset, n)
+	if err != nil {
+		return 0, err
+	}
+	return GrayToBinary(gray), nil
+}
+
+// EncodeGray writes value as an n-bit Gray code at offset.
+func (b Bitstring) EncodeGray(offset, n int, value uint64) error {
+	return b.SetUint(offset, n, BinaryToGray(value))
+}
+
+// DecodeFloat reads the n bits starting at offset as a Gray code and maps the encoded integer
+// linearly onto [lower, upper], so that all zeros decode to lower and all ones to upper.
+func (b Bitstring) DecodeFloat(offset, n int, lower, upper float64) (float64, error) {
+	value, err := b.DecodeGray(offset, n)
+	if err != nil {
+		return 0, err
+	}
+	maxValue := math.Ldexp(1, n) - 1
+	return lower + (upper-lower)*float64(value)/maxValue, nil
+}
+
+// DecodeFloats splits the bitstring into bounds.Dimension() consecutive fields of bitsPerParameter
+// bits and decodes every field with DecodeFloat into the corresponding bounds.
+func (b Bitstring) DecodeFloats(bitsPerParameter int, bounds *core.Bounds) ([]float64, error) {
+	if bounds == nil {
+		return nil, NewBitstringError("cannot decode parameters", core.ErrInvalidBounds)
+	}
+	n := bounds.Dimension()
+	if n*bitsPerParameter != b.Length {
+		return nil, NewBitstringError("cannot decode parameters", fmt.Errorf("%d parameters of %d bits need %d bits, but the bitstring has %d", n, bitsPerParameter, n*bitsPerParameter, b.Length))
+	}
+	parameters := make([]float64, n)
+	for i := range parameters {
+		value, err := b.DecodeFloat(i*bitsPerParameter, bitsPerParameter, bounds.Lower[i], bounds.Upper[i])
+		if err != nil {
+			return nil, err
+		}
+		parameters[i] = value
+	}
+	return parameters, nil
+}
+
+// BitstringError represents an error that occurs while handling bitstrings.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type BitstringError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *BitstringError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *BitstringError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewBitstringError constructs a *BitstringError with the provided message and wrapped error.
+func NewBitstringError(message string, wrapped error) *BitstringError {
+	return &BitstringError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
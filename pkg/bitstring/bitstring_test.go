@@ -0,0 +1,157 @@
+package bitstring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+func TestBitstring(t *testing.T) {
+	t.Run("get set and flip", func(t *testing.T) {
+		b := New(130)
+		require.Len(t, b.Words, 3)
+		b.Set(0, true)
+		b.Set(64, true)
+		b.Flip(129)
+		assert.True(t, b.Get(0))
+		assert.True(t, b.Get(64))
+		assert.True(t, b.Get(129))
+		assert.False(t, b.Get(1))
+		assert.Equal(t, 3, b.OnesCount())
+
+		b.Set(64, false)
+		b.Flip(129)
+		assert.Equal(t, 1, b.OnesCount())
+	})
+
+	t.Run("random bitstrings keep the tail clear", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			b := Random(70)
+			assert.Zero(t, b.Words[1]>>6)
+		}
+	})
+
+	t.Run("string and from bools", func(t *testing.T) {
+		b := FromBools([]bool{true, false, true, true})
+		assert.Equal(t, "1011", b.String())
+		assert.Equal(t, 4, b.Length)
+	})
+
+	t.Run("clone is independent of the original", func(t *testing.T) {
+		b := New(10)
+		clone := b.Clone()
+		clone.Set(3, true)
+		assert.False(t, b.Get(3))
+
+		individual := core.Individual[Bitstring]{Chromosome: b}
+		copied := individual.DeepCopy()
+		copied.Chromosome.Set(3, true)
+		assert.False(t, b.Get(3))
+	})
+}
+
+func TestHammingDistance(t *testing.T) {
+	a := FromBools([]bool{true, true, false, false})
+	b := FromBools([]bool{true, false, true, false})
+
+	distance, err := HammingDistance(a, b)
+	require.NoError(t, err)
+	assert.Equal(t, 2, distance)
+
+	long1, long2 := Random(1000), Random(1000)
+	distance, err = HammingDistance(long1, long2)
+	require.NoError(t, err)
+	expected := 0
+	for i := 0; i < 1000; i++ {
+		if long1.Get(i) != long2.Get(i) {
+			expected++
+		}
+	}
+	assert.Equal(t, expected, distance)
+
+	_, err = HammingDistance(a, New(5))
+	var be *BitstringError
+	assert.ErrorAs(t, err, &be)
+}
+
+func TestGenome(t *testing.T) {
+	genome := NewGenome()
+	a := FromBools([]bool{true, false, true})
+	b := FromBools([]bool{true, false, true})
+	c := FromBools([]bool{true, true, true})
+
+	assert.Equal(t, 3, genome.Len(a))
+	assert.True(t, genome.Equal(a, b))
+	assert.False(t, genome.Equal(a, c))
+	assert.False(t, genome.Equal(New(3), New(4)))
+	assert.Equal(t, genome.Hash(a), genome.Hash(b))
+	assert.NotEqual(t, genome.Hash(a), genome.Hash(c))
+	assert.NotEqual(t, genome.Hash(New(3)), genome.Hash(New(4)))
+
+	clone := genome.Clone(a)
+	clone.Flip(0)
+	assert.True(t, a.Get(0))
+}
+
+func TestGrayCode(t *testing.T) {
+	t.Run("round trip and single bit neighbors", func(t *testing.T) {
+		for value := uint64(0); value < 1024; value++ {
+			assert.Equal(t, value, GrayToBinary(BinaryToGray(value)))
+			if value > 0 {
+				diff := BinaryToGray(value) ^ BinaryToGray(value-1)
+				assert.Equal(t, uint64(0), diff&(diff-1), "neighbors must differ in one bit")
+			}
+		}
+		assert.Equal(t, uint64(1<<63|12345), GrayToBinary(BinaryToGray(1<<63|12345)))
+	})
+
+	t.Run("encode and decode fields", func(t *testing.T) {
+		b := New(100)
+		require.NoError(t, b.EncodeGray(60, 10, 777))
+		value, err := b.DecodeGray(60, 10)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(777), value)
+
+		require.NoError(t, b.SetUint(0, 4, 0b1011))
+		raw, err := b.Uint(0, 4)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0b1011), raw)
+		assert.True(t, b.Get(0))
+		assert.False(t, b.Get(1))
+	})
+
+	t.Run("decode floats", func(t *testing.T) {
+		bounds, err := core.NewBounds([]float64{-1, 0}, []float64{1, 10})
+		require.NoError(t, err)
+		b := New(16)
+		require.NoError(t, b.EncodeGray(8, 8, 255))
+
+		values, err := b.DecodeFloats(8, bounds)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{-1, 10}, values)
+
+		_, err = b.DecodeFloats(4, bounds)
+		var be *BitstringError
+		assert.ErrorAs(t, err, &be)
+	})
+
+	t.Run("invalid ranges return error", func(t *testing.T) {
+		b := New(10)
+		var be *BitstringError
+		_, err := b.Uint(5, 6)
+		assert.ErrorAs(t, err, &be)
+		_, err = b.Uint(0, 0)
+		assert.ErrorAs(t, err, &be)
+		assert.ErrorAs(t, b.SetUint(-1, 2, 0), &be)
+	})
+}
+
+func TestOneMaxEvaluator(t *testing.T) {
+	b := FromBools([]bool{true, false, true})
+	fitness, err := NewOneMaxEvaluator().Evaluate(context.Background(), &b)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, fitness)
+}
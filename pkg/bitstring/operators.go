@@ -0,0 +1,154 @@
+package bitstring
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+)
+
+// BitFlipMutator flips every bit independently with probability Rate.
+// Flipped positions are sampled with geometrically distributed gaps, so the cost of a
+// mutation is proportional to the number of flipped bits rather than to the length.
+type BitFlipMutator struct {
+	Rate float64
+}
+
+// NewBitFlipMutator creates a BitFlipMutator flipping every bit with the given probability.
+// A common choice is 1/length.
+func NewBitFlipMutator(rate float64) (*BitFlipMutator, error) {
+	if rate < 0 || rate > 1 {
+		return nil, NewBitstringError("invalid mutation rate", fmt.Errorf("mutation rate must be within [0, 1], but was %g", rate))
+	}
+	return &BitFlipMutator{Rate: rate}, nil
+}
+
+// Mutate implements mutation.IGenomeMutator. The bitstring is modified in place.
+func (m *BitFlipMutator) Mutate(ctx context.Context, chromosome *Bitstring) error {
+	if chromosome == nil || chromosome.Length == 0 {
+		return mutation.NewMutationError("cannot mutate chromosome", core.NewInvalidChromosomeError("empty chromosome found", nil))
+	}
+	if ctx.Err() != nil {
+		return mutation.NewMutationError("context cancelled", ctx.Err())
+	}
+
+	b := *chromosome
+	switch {
+	case m.Rate == 0:
+		return nil
+	case m.Rate == 1:
+		for i := range b.Words {
+			b.Words[i] = ^b.Words[i]
+		}
+		b.clearTail()
+		return nil
+	}
+
+	logQ := math.Log1p(-m.Rate)
+	for i := -1; ; {
+		// Gap to the next flipped bit: floor(log(U) / log(1-p)) with U uniform in (0, 1]
+		gap := math.Floor(math.Log(1-rand.Float64()) / logQ)
+		if gap >= float64(b.Length-i-1) {
+			return nil
+		}
+		i += 1 + int(gap)
+		b.Flip(i)
+	}
+}
+
+// validateParents checks that both parents are non-empty and of the same length.
+func validateParents(parent1, parent2 Bitstring) error {
+	if parent1.Length == 0 || parent2.Length == 0 {
+		return crossover.NewCrossoverError("cannot perform crossover", core.NewInvalidChromosomeError("parent chromosomes cannot be empty", nil))
+	}
+	if parent1.Length != parent2.Length {
+		return crossover.NewCrossoverError("cannot perform crossover", core.NewInvalidChromosomeError("parent chromosomes must be of the same length", nil))
+	}
+	return nil
+}
+
+// UniformCrossover exchanges every bit between the parents with probability 0.5,
+// processing 64 bits at a time with random masks.
+type UniformCrossover struct{}
+
+// NewUniformCrossover creates a UniformCrossover.
+func NewUniformCrossover() *UniformCrossover {
+	return &UniformCrossover{}
+}
+
+// Crossover implements crossover.IGenomeCrossover.
+func (u *UniformCrossover) Crossover(parent1, parent2 Bitstring) (Bitstring, Bitstring, error) {
+	if err := validateParents(parent1, parent2); err != nil {
+		return Bitstring{}, Bitstring{}, err
+	}
+	offspring1, offspring2 := parent1.Clone(), parent2.Clone()
+	for i := range offspring1.Words {
+		swap := (offspring1.Words[i] ^ offspring2.Words[i]) & rand.Uint64()
+		offspring1.Words[i] ^= swap
+		offspring2.Words[i] ^= swap
+	}
+	return offspring1, offspring2, nil
+}
+
+// KPointCrossover cuts both parents at Points random positions and exchanges every other
+// segment. Bits between cut points are exchanged a word at a time.
+type KPointCrossover struct {
+	Points int
+}
+
+// NewKPointCrossover creates a KPointCrossover with the given number of cut points.
+func NewKPointCrossover(points int) (*KPointCrossover, error) {
+	if points < 1 {
+		return nil, NewBitstringError("invalid number of crossover points", fmt.Errorf("number of crossover points must be positive, but was %d", points))
+	}
+	return &KPointCrossover{Points: points}, nil
+}
+
+// Crossover implements crossover.IGenomeCrossover. Bitstrings shorter than Points+1 bits are
+// cut at every position.
+func (k *KPointCrossover) Crossover(parent1, parent2 Bitstring) (Bitstring, Bitstring, error) {
+	if err := validateParents(parent1, parent2); err != nil {
+		return Bitstring{}, Bitstring{}, err
+	}
+	offspring1, offspring2 := parent1.Clone(), parent2.Clone()
+	length := parent1.Length
+
+	// Cut points lie within [1, length-1]
+	points := min(k.Points, length-1)
+	cuts := make(map[int]struct{}, points)
+	for len(cuts) < points {
+		cuts[1+rand.Intn(length-1)] = struct{}{}
+	}
+	sorted := make([]int, 0, points+1)
+	for cut := range cuts {
+		sorted = append(sorted, cut)
+	}
+	slices.Sort(sorted)
+	sorted = append(sorted, length)
+
+	for i := 0; i+1 < len(sorted); i += 2 {
+		swapRange(offspring1, offspring2, sorted[i], sorted[i+1])
+	}
+	return offspring1, offspring2, nil
+}
+
+// swapRange exchanges the bits [from, to) between a and b.
+func swapRange(a, b Bitstring, from, to int) {
+	for w := from / wordSize; w*wordSize < to; w++ {
+		mask := ^uint64(0)
+		if start := from - w*wordSize; start > 0 {
+			mask &= ^uint64(0) << start
+		}
+		if end := to - w*wordSize; end < wordSize {
+			mask &= (1 << end) - 1
+		}
+		swap := (a.Words[w] ^ b.Words[w]) & mask
+		a.Words[w] ^= swap
+		b.Words[w] ^= swap
+	}
+}
@@ -0,0 +1,173 @@
+package bitstring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+func TestBitFlipMutator(t *testing.T) {
+	t.Run("flips bits at the expected rate", func(t *testing.T) {
+		mutator, err := NewBitFlipMutator(0.01)
+		require.NoError(t, err)
+
+		flipped := 0
+		for i := 0; i < 100; i++ {
+			b := New(1000)
+			require.NoError(t, mutator.Mutate(context.Background(), &b))
+			flipped += b.OnesCount()
+		}
+		// 100 * 1000 * 0.01 = 1000 expected flips
+		assert.InDelta(t, 1000, flipped, 150)
+	})
+
+	t.Run("rate one complements the bitstring", func(t *testing.T) {
+		mutator, err := NewBitFlipMutator(1)
+		require.NoError(t, err)
+		b := FromBools([]bool{true, false, false})
+		require.NoError(t, mutator.Mutate(context.Background(), &b))
+		assert.Equal(t, "011", b.String())
+		assert.Equal(t, 2, b.OnesCount())
+	})
+
+	t.Run("rate zero keeps the bitstring", func(t *testing.T) {
+		mutator, err := NewBitFlipMutator(0)
+		require.NoError(t, err)
+		b := Random(200)
+		before := b.Clone()
+		require.NoError(t, mutator.Mutate(context.Background(), &b))
+		assert.Equal(t, before, b)
+	})
+
+	t.Run("invalid arguments return error", func(t *testing.T) {
+		_, err := NewBitFlipMutator(1.5)
+		var be *BitstringError
+		assert.ErrorAs(t, err, &be)
+
+		mutator, err := NewBitFlipMutator(0.5)
+		require.NoError(t, err)
+		empty := New(0)
+		var me *mutation.MutationError
+		assert.ErrorAs(t, mutator.Mutate(context.Background(), &empty), &me)
+	})
+}
+
+func TestCrossovers(t *testing.T) {
+	kPoint, err := NewKPointCrossover(3)
+	require.NoError(t, err)
+	onePoint, err := NewKPointCrossover(1)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		crossover crossover.IGenomeCrossover[Bitstring]
+	}{
+		{"uniform", NewUniformCrossover()},
+		{"k-point", kPoint},
+		{"one-point", onePoint},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+" preserves genetic material", func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				parent1, parent2 := Random(300), Random(300)
+				before1, before2 := parent1.Clone(), parent2.Clone()
+
+				offspring1, offspring2, err := tc.crossover.Crossover(parent1, parent2)
+				require.NoError(t, err)
+				assert.Equal(t, before1, parent1)
+				assert.Equal(t, before2, parent2)
+
+				for j := 0; j < 300; j++ {
+					// Every position holds the parents' bits, possibly exchanged
+					if offspring1.Get(j) == parent1.Get(j) {
+						assert.Equal(t, parent2.Get(j), offspring2.Get(j))
+					} else {
+						assert.Equal(t, parent2.Get(j), offspring1.Get(j))
+						assert.Equal(t, parent1.Get(j), offspring2.Get(j))
+					}
+				}
+			}
+		})
+
+		t.Run(tc.name+" rejects invalid parents", func(t *testing.T) {
+			var ce *crossover.CrossoverError
+			_, _, err := tc.crossover.Crossover(New(3), New(4))
+			assert.ErrorAs(t, err, &ce)
+			_, _, err = tc.crossover.Crossover(New(0), New(0))
+			assert.ErrorAs(t, err, &ce)
+		})
+	}
+
+	t.Run("one-point crossover produces two segments", func(t *testing.T) {
+		zeros, ones := New(200), New(200)
+		for i := 0; i < 200; i++ {
+			ones.Set(i, true)
+		}
+		offspring1, offspring2, err := onePoint.Crossover(zeros, ones)
+		require.NoError(t, err)
+
+		cut := 0
+		for cut < 200 && !offspring1.Get(cut) {
+			cut++
+		}
+		assert.Greater(t, cut, 0)
+		assert.Less(t, cut, 200)
+		assert.Equal(t, 200-cut, offspring1.OnesCount())
+		assert.Equal(t, cut, offspring2.OnesCount())
+	})
+
+	t.Run("swap range across words", func(t *testing.T) {
+		a, b := New(200), New(200)
+		for i := 0; i < 200; i++ {
+			b.Set(i, true)
+		}
+		swapRange(a, b, 60, 131)
+		assert.Equal(t, 71, a.OnesCount())
+		assert.False(t, a.Get(59))
+		assert.True(t, a.Get(60))
+		assert.True(t, a.Get(130))
+		assert.False(t, a.Get(131))
+	})
+
+	t.Run("invalid number of points", func(t *testing.T) {
+		_, err := NewKPointCrossover(0)
+		var be *BitstringError
+		assert.ErrorAs(t, err, &be)
+	})
+}
+
+func TestOneMaxWithExecutor(t *testing.T) {
+	mutator, err := NewBitFlipMutator(1.0 / 100)
+	require.NoError(t, err)
+	selector, err := selection.NewGenomeTournamentSelector[Bitstring](3, 2)
+	require.NoError(t, err)
+
+	ga := executor.NewGenomeExecutor[Bitstring](NewGenome(), NewRandomPopulation(50, 100), NewOneMaxEvaluator(), mutator, selector, NewUniformCrossover(), 100)
+	_, err = ga.Loop(context.Background(), 100)
+	require.NoError(t, err)
+
+	last, ok := ga.Statistics().Last()
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, last.BestEverFitness, 95.0)
+}
+
+// BenchmarkOneMax benchmarks 10 generations of OneMax on 1000 bitstrings of 1000 bits.
+func BenchmarkOneMax(b *testing.B) {
+	mutator, err := NewBitFlipMutator(1.0 / 1000)
+	require.NoError(b, err)
+	selector, err := selection.NewGenomeTournamentSelector[Bitstring](2, 0)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ga := executor.NewGenomeExecutor[Bitstring](NewGenome(), NewRandomPopulation(1000, 1000), NewOneMaxEvaluator(), mutator, selector, NewUniformCrossover(), 10, -1)
+		_, _ = ga.Loop(context.Background(), 10)
+	}
+}
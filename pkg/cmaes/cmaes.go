@@ -0,0 +1,602 @@
+// Package cmaes provides a Covariance Matrix Adaptation Evolution Strategy for continuous problems.
+package cmaes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// RestartStrategy selects how the optimizer restarts once a run meets a local stopping criterion.
+type RestartStrategy int
+
+const (
+	// NoRestart stops the optimization after the first run.
+	NoRestart RestartStrategy = iota
+	// IPOP restarts with a population size increased by Config.PopulationIncrease after every run.
+	IPOP
+	// BIPOP interleaves restarts with an increasing large population and restarts with a small,
+	// randomly sized population and step size, always running the regime that used fewer evaluations.
+	BIPOP
+)
+
+// String returns the name of the restart strategy.
+func (r RestartStrategy) String() string {
+	switch r {
+	case NoRestart:
+		return "none"
+	case IPOP:
+		return "IPOP"
+	case BIPOP:
+		return "BIPOP"
+	default:
+		return fmt.Sprintf("RestartStrategy(%d)", int(r))
+	}
+}
+
+// Stop reasons reported by Optimizer.StopReason.
+const (
+	StopMaxEvaluations = "maxevals"
+	StopMaxIterations  = "maxiter"
+	StopTargetFitness  = "target"
+	StopTolFun         = "tolfun"
+	StopTolX           = "tolx"
+	StopNoEffectAxis   = "noeffectaxis"
+	StopNoEffectCoord  = "noeffectcoord"
+	StopConditionCov   = "conditioncov"
+	StopMaxRestarts    = "maxrestarts"
+)
+
+// Config holds the parameters of a CMA-ES run. Only PopulationSize, MaxIterations and
+// PopulationIncrease fall back to the defaults recommended by Hansen when zero; every other
+// field is used as given, so configurations should start from DefaultConfig.
+type Config struct {
+	// Dimension is the number of genes of each chromosome.
+	Dimension int
+	// InitialMean is the starting point of the first run. When nil, the starting point of every
+	// run is sampled uniformly within Bounds.
+	InitialMean []float64
+	// Bounds delimit the region from which starting points are sampled when InitialMean is nil,
+	// and for restarts after the first run. Its dimension must equal Dimension.
+	Bounds *core.Bounds
+	// InitialSigma is the initial step size. It should be about a quarter of the search range.
+	InitialSigma float64
+	// PopulationSize is the number of offspring (λ) of the first run. 0 selects 4+⌊3 ln n⌋.
+	PopulationSize int
+	// MaxEvaluations bounds the total number of fitness evaluations over all runs. 0 means unlimited.
+	MaxEvaluations int
+	// MaxIterations bounds the number of iterations of a single run. 0 selects 100+150(n+3)²/√λ.
+	MaxIterations int
+	// TargetFitness stops the optimization once a solution reaches the given fitness.
+	TargetFitness *float64
+	// TolFun stops a run when the range of recent best fitness values falls below it. 0 disables it.
+	TolFun float64
+	// TolX stops a run when the standard deviations in all coordinates fall below it. 0 disables it.
+	TolX float64
+	// Restart selects the restart strategy applied after a run stops.
+	Restart RestartStrategy
+	// MaxRestarts bounds the number of restarts. 0 disables restarts.
+	MaxRestarts int
+	// PopulationIncrease is the factor by which IPOP and the large BIPOP regime grow λ. 0 selects 2.
+	PopulationIncrease float64
+	// NumWorkers limits the number of concurrent fitness evaluations. It must be positive or -1 for unlimited.
+	NumWorkers int
+}
+
+// DefaultConfig returns a configuration for a problem of the given dimension with the recommended defaults.
+func DefaultConfig(dimension int) Config {
+	return Config{
+		Dimension:          dimension,
+		InitialSigma:       0.3,
+		TolFun:             1e-12,
+		TolX:               1e-12,
+		MaxRestarts:        9,
+		PopulationIncrease: 2,
+		NumWorkers:         1,
+	}
+}
+
+// Optimizer implements the (μ/μ_w, λ)-CMA-ES with optional IPOP/BIPOP restarts.
+// The optimizer maximizes the fitness returned by the evaluator, in line with the rest
+// of the library, and evaluates each generation in parallel using fitness.EvaluatePopulation.
+type Optimizer struct {
+	fitnessEvaluator fitness.IFitnessEvaluator[float64]
+	config           Config
+
+	best        *core.Solution[float64]
+	population  *core.Population[float64]
+	evaluations int
+	iterations  int
+	restarts    int
+	stopReason  string
+}
+
+// NewOptimizer creates a new CMA-ES optimizer for the given evaluator and configuration.
+func NewOptimizer(fitnessEvaluator fitness.IFitnessEvaluator[float64], config Config) (*Optimizer, error) {
+	if fitnessEvaluator == nil {
+		return nil, NewCMAESError("invalid fitness evaluator", fmt.Errorf("fitness evaluator cannot be nil"))
+	}
+	if config.Dimension <= 0 {
+		return nil, NewCMAESError("invalid dimension", fmt.Errorf("dimension must be positive, but was %d", config.Dimension))
+	}
+	if config.InitialSigma <= 0 {
+		return nil, NewCMAESError("invalid initial sigma", fmt.Errorf("initial sigma must be positive, but was %g", config.InitialSigma))
+	}
+	if config.InitialMean != nil && len(config.InitialMean) != config.Dimension {
+		return nil, NewCMAESError("invalid initial mean", fmt.Errorf("initial mean has length %d, expected %d", len(config.InitialMean), config.Dimension))
+	}
+	if config.Bounds != nil && config.Bounds.Dimension() != config.Dimension {
+		return nil, NewCMAESError("invalid initial region", fmt.Errorf("%w: bounds have dimension %d, expected %d", core.ErrInvalidBounds, config.Bounds.Dimension(), config.Dimension))
+	}
+	if config.InitialMean == nil && config.Bounds == nil {
+		return nil, NewCMAESError("invalid initial mean", fmt.Errorf("either an initial mean or bounds must be provided"))
+	}
+	if config.PopulationSize < 0 || config.MaxEvaluations < 0 || config.MaxIterations < 0 || config.MaxRestarts < 0 {
+		return nil, NewCMAESError("invalid configuration", fmt.Errorf("population size, budgets and restarts cannot be negative"))
+	}
+	if config.NumWorkers == 0 || config.NumWorkers < -1 {
+		return nil, NewCMAESError("invalid number of workers", fmt.Errorf("number of workers must be positive or -1 for unlimited, but was %d", config.NumWorkers))
+	}
+	if config.PopulationIncrease == 0 {
+		config.PopulationIncrease = 2
+	}
+	if config.PopulationIncrease < 1 {
+		return nil, NewCMAESError("invalid population increase", fmt.Errorf("population increase must be at least 1, but was %g", config.PopulationIncrease))
+	}
+
+	return &Optimizer{
+		fitnessEvaluator: fitnessEvaluator,
+		config:           config,
+	}, nil
+}
+
+// BestSolution returns the best solution found so far over all runs, or nil before Optimize was called.
+func (o *Optimizer) BestSolution() *core.Solution[float64] {
+	return o.best
+}
+
+// Evaluations returns the number of fitness evaluations performed so far.
+func (o *Optimizer) Evaluations() int {
+	return o.evaluations
+}
+
+// Iterations returns the number of iterations performed so far over all runs.
+func (o *Optimizer) Iterations() int {
+	return o.iterations
+}
+
+// Restarts returns the number of restarts performed so far.
+func (o *Optimizer) Restarts() int {
+	return o.restarts
+}
+
+// StopReason returns the criterion which terminated the last run.
+func (o *Optimizer) StopReason() string {
+	return o.stopReason
+}
+
+// Optimize runs CMA-ES, restarting according to the configured strategy until a global
+// stopping criterion (evaluation budget, target fitness, restart budget) is met.
+// It returns the last evaluated population; the best solution over all runs is available
+// through BestSolution.
+func (o *Optimizer) Optimize(ctx context.Context) (*core.Population[float64], error) {
+	n := o.config.Dimension
+	defaultLambda := o.config.PopulationSize
+	if defaultLambda == 0 {
+		defaultLambda = 4 + int(3*math.Log(float64(n)))
+	}
+
+	o.best, o.population = nil, nil
+	o.evaluations, o.iterations, o.restarts = 0, 0, 0
+
+	largeLambda := defaultLambda
+	largeBudget, smallBudget := 0, 0
+	largeRuns := 0
+
+	for {
+		lambda := defaultLambda
+		sigma := o.config.InitialSigma
+		large := true
+
+		switch o.config.Restart {
+		case IPOP:
+			lambda = int(float64(defaultLambda) * math.Pow(o.config.PopulationIncrease, float64(o.restarts)))
+		case BIPOP:
+			if o.restarts > 0 && smallBudget < largeBudget {
+				// Small regime: random population size between the default and half the last large one.
+				u := rand.Float64()
+				lambda = int(float64(defaultLambda) * math.Pow(0.5*float64(largeLambda)/float64(defaultLambda), u*u))
+				sigma = o.config.InitialSigma * math.Pow(10, -2*rand.Float64())
+				large = false
+			} else {
+				lambda = int(float64(defaultLambda) * math.Pow(o.config.PopulationIncrease, float64(largeRuns)))
+				largeLambda = lambda
+				largeRuns++
+			}
+		}
+		if lambda < 2 {
+			lambda = 2
+		}
+
+		evaluationsBefore := o.evaluations
+		stop, err := o.run(ctx, o.startingPoint(), sigma, lambda)
+		if err != nil {
+			return nil, err
+		}
+		if large {
+			largeBudget += o.evaluations - evaluationsBefore
+		} else {
+			smallBudget += o.evaluations - evaluationsBefore
+		}
+		o.stopReason = stop
+
+		if isGlobalStop(stop) || o.config.Restart == NoRestart {
+			break
+		}
+		if o.restarts >= o.config.MaxRestarts {
+			o.stopReason = StopMaxRestarts
+			break
+		}
+		o.restarts++
+	}
+
+	return o.population, nil
+}
+
+// isGlobalStop reports whether a stop reason terminates the whole optimization rather than a single run.
+func isGlobalStop(reason string) bool {
+	return reason == StopMaxEvaluations || reason == StopTargetFitness
+}
+
+// startingPoint returns the initial mean of the next run.
+func (o *Optimizer) startingPoint() []float64 {
+	if o.config.InitialMean != nil && o.restarts == 0 {
+		return append([]float64{}, o.config.InitialMean...)
+	}
+	if o.config.Bounds == nil {
+		return append([]float64{}, o.config.InitialMean...)
+	}
+	return o.config.Bounds.Sample()
+}
+
+// run performs a single CMA-ES run and returns the reason it stopped.
+func (o *Optimizer) run(ctx context.Context, mean []float64, sigma float64, lambda int) (string, error) {
+	n := o.config.Dimension
+	nf := float64(n)
+	s := newStrategyParameters(n, lambda)
+
+	maxIterations := o.config.MaxIterations
+	if maxIterations == 0 {
+		maxIterations = 100 + int(150*(nf+3)*(nf+3)/math.Sqrt(float64(lambda)))
+	}
+
+	// Dynamic state
+	pc := make([]float64, n)
+	ps := make([]float64, n)
+	c := identity(n)
+	b := identity(n)
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = 1
+	}
+	eigenEvaluation := 0
+	runEvaluations := 0
+	historyLength := 10 + int(math.Ceil(30*nf/float64(lambda)))
+	history := make([]float64, 0, historyLength)
+
+	z := make([][]float64, lambda)
+	y := make([][]float64, lambda)
+	for k := 0; k < lambda; k++ {
+		z[k] = make([]float64, n)
+		y[k] = make([]float64, n)
+	}
+
+	for iteration := 0; ; iteration++ {
+		if ctx.Err() != nil {
+			return "", NewCMAESError("context cancelled", ctx.Err())
+		}
+		if iteration >= maxIterations {
+			return StopMaxIterations, nil
+		}
+
+		// Sample and evaluate λ offspring x_k = m + σ·B·D·z_k
+		individuals := make([]core.Solution[float64], lambda)
+		for k := 0; k < lambda; k++ {
+			for i := 0; i < n; i++ {
+				z[k][i] = rand.NormFloat64()
+			}
+			chromosome := make([]float64, n)
+			for i := 0; i < n; i++ {
+				sum := 0.0
+				for j := 0; j < n; j++ {
+					sum += b[i][j] * d[j] * z[k][j]
+				}
+				y[k][i] = sum
+				chromosome[i] = mean[i] + sigma*sum
+			}
+			individuals[k] = core.Solution[float64]{Chromosome: chromosome}
+		}
+		population := &core.Population[float64]{Individuals: individuals}
+		if err := fitness.EvaluatePopulation(ctx, o.fitnessEvaluator, population, o.config.NumWorkers); err != nil {
+			return "", NewCMAESError(fmt.Sprintf("failed to evaluate population at iteration %d", o.iterations), err)
+		}
+		o.evaluations += lambda
+		runEvaluations += lambda
+		o.iterations++
+		o.population = population
+
+		// Rank offspring by descending fitness
+		order := make([]int, lambda)
+		for k := range order {
+			order[k] = k
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return individuals[order[i]].Fitness > individuals[order[j]].Fitness
+		})
+		if o.best == nil || individuals[order[0]].Fitness > o.best.Fitness {
+			o.best = individuals[order[0]].DeepCopy()
+		}
+
+		// Recombination: m = m + σ·Σ w_i·y_i
+		yw := make([]float64, n)
+		for i := 0; i < s.mu; i++ {
+			for j := 0; j < n; j++ {
+				yw[j] += s.weights[i] * y[order[i]][j]
+			}
+		}
+		for j := 0; j < n; j++ {
+			mean[j] += sigma * yw[j]
+		}
+
+		// Step-size path: p_σ = (1-c_σ)p_σ + √(c_σ(2-c_σ)μ_eff)·C^-1/2·y_w
+		invSqrtCyw := multiplyInvSqrt(b, d, yw)
+		psFactor := math.Sqrt(s.cs * (2 - s.cs) * s.mueff)
+		for j := 0; j < n; j++ {
+			ps[j] = (1-s.cs)*ps[j] + psFactor*invSqrtCyw[j]
+		}
+		psNorm := norm(ps)
+		hsig := 0.0
+		if psNorm/math.Sqrt(1-math.Pow(1-s.cs, 2*float64(iteration+1)))/s.chiN < 1.4+2/(nf+1) {
+			hsig = 1
+		}
+
+		// Covariance path: p_c = (1-c_c)p_c + h_σ·√(c_c(2-c_c)μ_eff)·y_w
+		pcFactor := hsig * math.Sqrt(s.cc*(2-s.cc)*s.mueff)
+		for j := 0; j < n; j++ {
+			pc[j] = (1-s.cc)*pc[j] + pcFactor*yw[j]
+		}
+
+		// Covariance matrix adaptation with rank-one and rank-μ updates
+		deltaH := (1 - hsig) * s.cc * (2 - s.cc)
+		for i := 0; i < n; i++ {
+			for j := 0; j <= i; j++ {
+				rankMu := 0.0
+				for k := 0; k < s.mu; k++ {
+					rankMu += s.weights[k] * y[order[k]][i] * y[order[k]][j]
+				}
+				value := (1-s.c1-s.cmu)*c[i][j] + s.c1*(pc[i]*pc[j]+deltaH*c[i][j]) + s.cmu*rankMu
+				c[i][j] = value
+				c[j][i] = value
+			}
+		}
+
+		// Step-size adaptation
+		sigma *= math.Exp(math.Min(1, (s.cs/s.damps)*(psNorm/s.chiN-1)))
+
+		// Lazy eigen decomposition of C, keeping it O(n²) per evaluation on average
+		if float64(runEvaluations-eigenEvaluation) > float64(lambda)/(s.c1+s.cmu)/nf/10 {
+			eigenEvaluation = runEvaluations
+			values, vectors := symmetricEigen(c)
+			for j := 0; j < n; j++ {
+				d[j] = math.Sqrt(math.Max(values[j], 1e-300))
+			}
+			b = vectors
+		}
+
+		// Stopping criteria
+		best := individuals[order[0]].Fitness
+		if target := o.config.TargetFitness; target != nil && o.best.Fitness >= *target {
+			return StopTargetFitness, nil
+		}
+		if o.config.MaxEvaluations > 0 && o.evaluations >= o.config.MaxEvaluations {
+			return StopMaxEvaluations, nil
+		}
+		if len(history) == historyLength {
+			history = history[1:]
+		}
+		history = append(history, best)
+		if len(history) == historyLength {
+			worst := individuals[order[lambda-1]].Fitness
+			if math.Max(rangeOf(history), best-worst) < o.config.TolFun {
+				return StopTolFun, nil
+			}
+		}
+		if stop := tolX(sigma, c, pc, o.config.TolX); stop {
+			return StopTolX, nil
+		}
+		if condition(d) > 1e14 {
+			return StopConditionCov, nil
+		}
+		if noEffectCoord(mean, sigma, c) {
+			return StopNoEffectCoord, nil
+		}
+		if noEffectAxis(mean, sigma, b, d, iteration) {
+			return StopNoEffectAxis, nil
+		}
+	}
+}
+
+// strategyParameters holds the constant learning rates and weights of a CMA-ES run.
+type strategyParameters struct {
+	mu      int
+	weights []float64
+	mueff   float64
+	cc      float64
+	cs      float64
+	c1      float64
+	cmu     float64
+	damps   float64
+	chiN    float64
+}
+
+// newStrategyParameters computes the default strategy parameters for dimension n and λ offspring.
+func newStrategyParameters(n, lambda int) strategyParameters {
+	nf := float64(n)
+	mu := lambda / 2
+	weights := make([]float64, mu)
+	sum := 0.0
+	for i := 0; i < mu; i++ {
+		weights[i] = math.Log(float64(lambda)/2+0.5) - math.Log(float64(i+1))
+		sum += weights[i]
+	}
+	sumSquares := 0.0
+	for i := range weights {
+		weights[i] /= sum
+		sumSquares += weights[i] * weights[i]
+	}
+	mueff := 1 / sumSquares
+
+	c1 := 2 / ((nf+1.3)*(nf+1.3) + mueff)
+	return strategyParameters{
+		mu:      mu,
+		weights: weights,
+		mueff:   mueff,
+		cc:      (4 + mueff/nf) / (nf + 4 + 2*mueff/nf),
+		cs:      (mueff + 2) / (nf + mueff + 5),
+		c1:      c1,
+		cmu:     math.Min(1-c1, 2*(mueff-2+1/mueff)/((nf+2)*(nf+2)+mueff)),
+		damps:   1 + 2*math.Max(0, math.Sqrt((mueff-1)/(nf+1))-1) + (mueff+2)/(nf+mueff+5),
+		chiN:    math.Sqrt(nf) * (1 - 1/(4*nf) + 1/(21*nf*nf)),
+	}
+}
+
+// identity returns the n×n identity matrix.
+func identity(n int) [][]float64 {
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		m[i][i] = 1
+	}
+	return m
+}
+
+// multiplyInvSqrt returns C^-1/2·v = B·D^-1·Bᵀ·v.
+func multiplyInvSqrt(b [][]float64, d, v []float64) []float64 {
+	n := len(v)
+	tmp := make([]float64, n)
+	for j := 0; j < n; j++ {
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += b[i][j] * v[i]
+		}
+		tmp[j] = sum / d[j]
+	}
+	result := make([]float64, n)
+	for i := 0; i < n; i++ {
+		sum := 0.0
+		for j := 0; j < n; j++ {
+			sum += b[i][j] * tmp[j]
+		}
+		result[i] = sum
+	}
+	return result
+}
+
+// norm returns the Euclidean norm of v.
+func norm(v []float64) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+// rangeOf returns the difference between the largest and smallest value.
+func rangeOf(values []float64) float64 {
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return hi - lo
+}
+
+// tolX reports whether the standard deviation in every coordinate and the evolution path are below tol.
+func tolX(sigma float64, c [][]float64, pc []float64, tol float64) bool {
+	for i := range pc {
+		if sigma*math.Sqrt(c[i][i]) >= tol || sigma*math.Abs(pc[i]) >= tol {
+			return false
+		}
+	}
+	return true
+}
+
+// condition returns the condition number of C given the square roots of its eigenvalues.
+func condition(d []float64) float64 {
+	lo, hi := d[0], d[0]
+	for _, v := range d[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return (hi * hi) / (lo * lo)
+}
+
+// noEffectCoord reports whether adding 0.2 standard deviations in any coordinate leaves the mean unchanged.
+func noEffectCoord(mean []float64, sigma float64, c [][]float64) bool {
+	for i := range mean {
+		if mean[i] == mean[i]+0.2*sigma*math.Sqrt(c[i][i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// noEffectAxis reports whether adding 0.1 standard deviations along the principal axis
+// selected by the iteration leaves the mean unchanged.
+func noEffectAxis(mean []float64, sigma float64, b [][]float64, d []float64, iteration int) bool {
+	axis := iteration % len(mean)
+	for i := range mean {
+		if mean[i] != mean[i]+0.1*sigma*d[axis]*b[i][axis] {
+			return false
+		}
+	}
+	return true
+}
+
+// CMAESError represents an error that occurs during a CMA-ES optimization.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type CMAESError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *CMAESError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *CMAESError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewCMAESError constructs a *CMAESError with the provided message and wrapped error.
+func NewCMAESError(message string, wrapped error) *CMAESError {
+	return &CMAESError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
@@ -0,0 +1,255 @@
+package cmaes
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// sphereEvaluator maximizes the negated sphere function, whose optimum 0 lies at the origin.
+type sphereEvaluator struct{}
+
+func (sphereEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	sum := 0.0
+	for _, x := range *chromosome {
+		sum += x * x
+	}
+	return -sum, nil
+}
+
+// rastriginEvaluator maximizes the negated, highly multimodal Rastrigin function.
+type rastriginEvaluator struct{}
+
+func (rastriginEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	sum := 10 * float64(len(*chromosome))
+	for _, x := range *chromosome {
+		sum += x*x - 10*math.Cos(2*math.Pi*x)
+	}
+	return -sum, nil
+}
+
+// failingEvaluator always fails.
+type failingEvaluator struct{}
+
+func (failingEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	return 0, core.ErrFitnessEvaluationFailed
+}
+
+func TestNewOptimizer(t *testing.T) {
+	t.Parallel()
+	valid := DefaultConfig(3)
+	valid.InitialMean = []float64{1, 1, 1}
+
+	testCases := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{"zero dimension", func(c *Config) { c.Dimension = 0 }},
+		{"non-positive sigma", func(c *Config) { c.InitialSigma = 0 }},
+		{"mean of wrong length", func(c *Config) { c.InitialMean = []float64{1} }},
+		{"no mean nor region", func(c *Config) { c.InitialMean = nil }},
+		{"bounds of wrong dimension", func(c *Config) { c.Bounds = &core.Bounds{Lower: []float64{0}, Upper: []float64{1}} }},
+		{"negative population size", func(c *Config) { c.PopulationSize = -1 }},
+		{"population decrease", func(c *Config) { c.PopulationIncrease = 0.5 }},
+		{"zero workers", func(c *Config) { c.NumWorkers = 0 }},
+		{"negative workers", func(c *Config) { c.NumWorkers = -2 }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			config := valid
+			tc.modify(&config)
+			optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+			assert.Nil(t, optimizer)
+			var ce *CMAESError
+			assert.ErrorAs(t, err, &ce)
+		})
+	}
+
+	t.Run("nil evaluator", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewOptimizer(nil, valid)
+		var ce *CMAESError
+		assert.ErrorAs(t, err, &ce)
+	})
+
+	t.Run("valid configuration", func(t *testing.T) {
+		t.Parallel()
+		optimizer, err := NewOptimizer(sphereEvaluator{}, valid)
+		require.NoError(t, err)
+		assert.NotNil(t, optimizer)
+	})
+}
+
+func TestOptimizer_Optimize(t *testing.T) {
+	t.Run("converges on the sphere function", func(t *testing.T) {
+		config := DefaultConfig(5)
+		config.InitialMean = []float64{3, -2, 1, 4, -3}
+		config.InitialSigma = 2
+		config.TolFun = 1e-14
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+
+		population, err := optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, population)
+		assert.Len(t, population.Individuals, 4+int(3*math.Log(5)))
+
+		best := optimizer.BestSolution()
+		require.NotNil(t, best)
+		assert.Greater(t, best.Fitness, -1e-10)
+		for _, x := range best.Chromosome {
+			assert.InDelta(t, 0, x, 1e-4)
+		}
+		assert.Less(t, optimizer.Evaluations(), 10000)
+	})
+
+	t.Run("stops on target fitness", func(t *testing.T) {
+		config := DefaultConfig(3)
+		config.InitialMean = []float64{1, 1, 1}
+		target := -1e-3
+		config.TargetFitness = &target
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+
+		_, err = optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, StopTargetFitness, optimizer.StopReason())
+		assert.GreaterOrEqual(t, optimizer.BestSolution().Fitness, target)
+	})
+
+	t.Run("respects the evaluation budget", func(t *testing.T) {
+		config := DefaultConfig(4)
+		config.InitialMean = []float64{1, 1, 1, 1}
+		config.PopulationSize = 10
+		config.MaxEvaluations = 95
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+
+		_, err = optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, StopMaxEvaluations, optimizer.StopReason())
+		assert.Equal(t, 100, optimizer.Evaluations())
+	})
+
+	t.Run("IPOP restarts with growing populations", func(t *testing.T) {
+		config := DefaultConfig(2)
+		config.Bounds = &core.Bounds{Lower: []float64{-5.12, -5.12}, Upper: []float64{5.12, 5.12}}
+		config.InitialSigma = 2
+		config.Restart = IPOP
+		config.MaxRestarts = 3
+		config.MaxIterations = 50
+
+		optimizer, err := NewOptimizer(rastriginEvaluator{}, config)
+		require.NoError(t, err)
+
+		population, err := optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, optimizer.Restarts())
+		assert.Equal(t, StopMaxRestarts, optimizer.StopReason())
+		assert.Len(t, population.Individuals, 6*8)
+	})
+
+	t.Run("BIPOP finds the global optimum of Rastrigin", func(t *testing.T) {
+		config := DefaultConfig(2)
+		config.Bounds = &core.Bounds{Lower: []float64{-5.12, -5.12}, Upper: []float64{5.12, 5.12}}
+		config.InitialSigma = 2
+		config.Restart = BIPOP
+		config.MaxRestarts = 100
+		config.MaxEvaluations = 200000
+		target := -1e-6
+		config.TargetFitness = &target
+
+		optimizer, err := NewOptimizer(rastriginEvaluator{}, config)
+		require.NoError(t, err)
+
+		_, err = optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, optimizer.BestSolution().Fitness, target)
+	})
+
+	t.Run("propagates evaluation errors", func(t *testing.T) {
+		config := DefaultConfig(2)
+		config.InitialMean = []float64{0, 0}
+
+		optimizer, err := NewOptimizer(failingEvaluator{}, config)
+		require.NoError(t, err)
+
+		population, err := optimizer.Optimize(context.Background())
+		assert.Nil(t, population)
+		assert.ErrorIs(t, err, core.ErrFitnessEvaluationFailed)
+	})
+
+	t.Run("stops on cancelled context", func(t *testing.T) {
+		config := DefaultConfig(2)
+		config.InitialMean = []float64{0, 0}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+
+		_, err = optimizer.Optimize(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestSymmetricEigen(t *testing.T) {
+	t.Parallel()
+	a := [][]float64{
+		{4, 1, 2},
+		{1, 3, 0},
+		{2, 0, 5},
+	}
+
+	values, vectors := symmetricEigen(a)
+
+	// A·v = λ·v for every eigenpair
+	for k := range values {
+		for i := range a {
+			av := 0.0
+			for j := range a {
+				av += a[i][j] * vectors[j][k]
+			}
+			assert.InDelta(t, values[k]*vectors[i][k], av, 1e-9)
+		}
+	}
+	// The trace is preserved
+	assert.InDelta(t, 12, values[0]+values[1]+values[2], 1e-9)
+	// Input is left untouched
+	assert.Equal(t, 1.0, a[0][1])
+}
+
+func TestRestartStrategy_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "none", NoRestart.String())
+	assert.Equal(t, "IPOP", IPOP.String())
+	assert.Equal(t, "BIPOP", BIPOP.String())
+	assert.Equal(t, "RestartStrategy(7)", RestartStrategy(7).String())
+}
+
+// BenchmarkOptimizer_Optimize benchmarks a full CMA-ES run on a 10-dimensional sphere.
+func BenchmarkOptimizer_Optimize(b *testing.B) {
+	config := DefaultConfig(10)
+	config.InitialMean = make([]float64, 10)
+	for i := range config.InitialMean {
+		config.InitialMean[i] = 1
+	}
+	config.MaxEvaluations = 2000
+
+	optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = optimizer.Optimize(context.Background())
+	}
+}
@@ -0,0 +1,72 @@
+package cmaes
+
+import "math"
+
+// symmetricEigen computes the eigen decomposition of the symmetric matrix a using the
+// cyclic Jacobi method. It returns the eigenvalues and a matrix whose columns are the
+// corresponding orthonormal eigenvectors. The input matrix is not modified.
+func symmetricEigen(a [][]float64) ([]float64, [][]float64) {
+	n := len(a)
+	m := make([][]float64, n)
+	v := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		m[i] = append([]float64{}, a[i]...)
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+	}
+
+	const maxSweeps = 100
+	for sweep := 0; sweep < maxSweeps; sweep++ {
+		offDiagonal := 0.0
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				offDiagonal += m[p][q] * m[p][q]
+			}
+		}
+		if offDiagonal < 1e-30 {
+			break
+		}
+
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if math.Abs(m[p][q]) < 1e-300 {
+					continue
+				}
+				theta := (m[q][q] - m[p][p]) / (2 * m[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				rotate(m, v, p, q, c, s)
+			}
+		}
+	}
+
+	values := make([]float64, n)
+	for i := 0; i < n; i++ {
+		values[i] = m[i][i]
+	}
+	return values, v
+}
+
+// rotate applies the Jacobi rotation annihilating m[p][q] to m and accumulates it into v.
+func rotate(m, v [][]float64, p, q int, c, s float64) {
+	n := len(m)
+	for k := 0; k < n; k++ {
+		mkp, mkq := m[k][p], m[k][q]
+		m[k][p] = c*mkp - s*mkq
+		m[k][q] = s*mkp + c*mkq
+	}
+	for k := 0; k < n; k++ {
+		mpk, mqk := m[p][k], m[q][k]
+		m[p][k] = c*mpk - s*mqk
+		m[q][k] = s*mpk + c*mqk
+	}
+	for k := 0; k < n; k++ {
+		vkp, vkq := v[k][p], v[k][q]
+		v[k][p] = c*vkp - s*vkq
+		v[k][q] = s*vkp + c*vkq
+	}
+}
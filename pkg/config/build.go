@@ -0,0 +1,374 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/tomhoffer/darwinium/internal/random"
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/observer"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+	"github.com/tomhoffer/darwinium/pkg/registry"
+)
+
+// gene is the type of the genes of the supported representations.
+type gene interface {
+	int | float64
+}
+
+// components are the operators of a run assembled from a configuration.
+type components[T gene] struct {
+	evaluator fitness.IFitnessEvaluator[T]
+	selector  selection.ISelector[T]
+	crossover crossover.ICrossover[T]
+	mutator   mutation.IMutator[T]
+	replacer  replacement.IReplacer[T]
+	criteria  []termination.ITerminationCriterion
+}
+
+// assemble is a helper function building every operator named by the configuration from
+// the shared registries. All unknown names and invalid parameters are returned joined as *FieldError values.
+func assemble[T gene](c *Config) (*components[T], error) {
+	var errs []error
+	result := &components[T]{
+		evaluator: buildComponent(registry.Evaluators[T](), c.Problem, "problem", &errs),
+		selector:  buildComponent(registry.Selectors[T](), c.Operators.Selection, "operators.selection", &errs),
+		crossover: buildComponent(registry.Crossovers[T](), c.Operators.Crossover, "operators.crossover", &errs),
+		mutator:   buildComponent(registry.Mutators[T](), c.Operators.Mutation, "operators.mutation", &errs),
+	}
+	// Benchmark problems have a fixed number of variables, which must match the chromosomes.
+	if dimensioned, ok := result.evaluator.(interface{ Dimension() int }); ok && dimensioned.Dimension() != c.Representation.Length {
+		errs = append(errs, NewFieldError("problem.params.dimension", fmt.Sprintf("must match representation.length %d, got %d", c.Representation.Length, dimensioned.Dimension()), nil))
+	}
+	if c.Operators.Replacement != nil {
+		result.replacer = buildComponent(registry.Replacers[T](), *c.Operators.Replacement, "operators.replacement", &errs)
+	}
+	for i, criterion := range c.Termination.Criteria {
+		path := fmt.Sprintf("termination.criteria[%d]", i)
+		result.criteria = append(result.criteria, buildComponent(registry.Criteria(), criterion, path, &errs))
+	}
+	return result, errors.Join(errs...)
+}
+
+// buildComponent is a helper function building a component and converting the registry errors
+// into *FieldError values located by path.
+func buildComponent[O any](r *registry.Registry[O], component Component, path string, errs *[]error) O {
+	built, err := r.Build(component.Spec())
+	if err == nil {
+		return built
+	}
+
+	var registryErr *registry.RegistryError
+	paramErrors := registry.ParamErrors(err)
+	switch {
+	case errors.Is(err, registry.ErrUnknownOperator) && errors.As(err, &registryErr):
+		*errs = append(*errs, NewFieldError(path+".name", registryErr.Message, nil))
+	case len(paramErrors) > 0:
+		for _, paramErr := range paramErrors {
+			paramPath := path + ".params"
+			if paramErr.Param != "" {
+				paramPath += "." + paramErr.Param
+			}
+			*errs = append(*errs, NewFieldError(paramPath, paramErr.Message, paramErr.Wrapped))
+		}
+	default:
+		*errs = append(*errs, NewFieldError(path+".params", "invalid parameters", errors.Unwrap(err)))
+	}
+	var zero O
+	return zero
+}
+
+// runner runs an experiment whose genes are of a concrete type.
+type runner interface {
+	run(ctx context.Context, e *Experiment) (*Result, error)
+}
+
+// Experiment is a genetic algorithm run built from a configuration.
+type Experiment struct {
+	config       Config
+	logOutput    io.Writer
+	showProgress bool
+	runner       runner
+}
+
+// Build validates the configuration and builds the executor it describes, including a random
+// initial population. Returns a ConfigError wrapping the *FieldError values of every invalid field.
+func Build(c *Config) (*Experiment, error) {
+	return newExperiment(c, nil)
+}
+
+// Resume builds an experiment continuing the run saved in checkpoint. The configuration is
+// usually the one of the checkpoint with some fields changed, e.g. a larger number of generations;
+// its representation and population size must match the checkpoint.
+func Resume(c *Config, checkpoint *Checkpoint) (*Experiment, error) {
+	if checkpoint == nil {
+		return nil, NewConfigError("cannot resume experiment", errors.New("checkpoint is nil"))
+	}
+	return newExperiment(c, checkpoint)
+}
+
+func newExperiment(c *Config, checkpoint *Checkpoint) (*Experiment, error) {
+	if c == nil {
+		return nil, NewConfigError("cannot build experiment", errors.New("configuration is nil"))
+	}
+	if err := c.Validate(); err != nil {
+		return nil, NewConfigError("invalid configuration", err)
+	}
+
+	experiment := &Experiment{config: *c, logOutput: os.Stderr, showProgress: true}
+	representation := c.Representation
+	var err error
+	switch representation.Type {
+	case Real:
+		experiment.runner, err = newRun(c, checkpoint, func(rng *random.Rand) float64 {
+			return representation.Min + rng.Float64()*(representation.Max-representation.Min)
+		})
+	case Binary:
+		experiment.runner, err = newRun(c, checkpoint, func(rng *random.Rand) int { return rng.Intn(2) })
+	default:
+		low, high := int(representation.Min), int(representation.Max)
+		experiment.runner, err = newRun(c, checkpoint, func(rng *random.Rand) int { return low + rng.Intn(high-low+1) })
+	}
+	if err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// Config returns the configuration the experiment was built from.
+func (e *Experiment) Config() Config {
+	return e.config
+}
+
+// SetLogOutput sets the writer receiving the statistics logged every Output.LogInterval
+// generations. Defaults to standard error.
+func (e *Experiment) SetLogOutput(writer io.Writer) {
+	e.logOutput = writer
+}
+
+// SetProgress enables or disables the progress bar of the executor. Progress is shown by default.
+func (e *Experiment) SetProgress(show bool) {
+	e.showProgress = show
+}
+
+// Run executes the experiment until a termination criterion is met.
+// If Seed is set, the run draws its random numbers from a generator of its own seeded with it;
+// resumed experiments are seeded with the seed offset by the generation of the checkpoint.
+func (e *Experiment) Run(ctx context.Context) (*Result, error) {
+	return e.runner.run(ctx, e)
+}
+
+// run is the runner of experiments whose genes are of type T.
+type run[T gene] struct {
+	config     *Config
+	randomGene func(rng *random.Rand) T
+	checkpoint *Checkpoint
+	population []core.Solution[T]
+}
+
+// newRun is a helper function creating the run of a validated configuration. When resuming,
+// the population of the checkpoint is decoded and checked against the configuration.
+func newRun[T gene](c *Config, checkpoint *Checkpoint, randomGene func(rng *random.Rand) T) (*run[T], error) {
+	result := &run[T]{config: c, randomGene: randomGene, checkpoint: checkpoint}
+	if checkpoint == nil {
+		return result, nil
+	}
+
+	var errs []error
+	if checkpoint.Config.Representation.Type != c.Representation.Type {
+		errs = append(errs, NewFieldError("representation.type", fmt.Sprintf("must match the checkpoint representation %q", checkpoint.Config.Representation.Type), nil))
+	}
+	if checkpoint.Config.Representation.Length != c.Representation.Length {
+		errs = append(errs, NewFieldError("representation.length", fmt.Sprintf("must match the checkpoint chromosome length %d", checkpoint.Config.Representation.Length), nil))
+	}
+	if checkpoint.Statistics == nil || len(checkpoint.Statistics.History) == 0 {
+		errs = append(errs, errors.New("checkpoint has no recorded generations"))
+	}
+	if len(errs) > 0 {
+		return nil, NewConfigError("checkpoint does not match configuration", errors.Join(errs...))
+	}
+	if err := json.Unmarshal(checkpoint.Population, &result.population); err != nil {
+		return nil, NewConfigError("cannot decode checkpoint population", err)
+	}
+	if len(result.population) != c.Population.Size {
+		return nil, NewConfigError("checkpoint does not match configuration", NewFieldError("population.size", fmt.Sprintf("must match the checkpoint population size %d", len(result.population)), nil))
+	}
+	return result, nil
+}
+
+func (r *run[T]) run(ctx context.Context, e *Experiment) (*Result, error) {
+	c := r.config
+	parts, err := assemble[T](c)
+	if err != nil {
+		return nil, NewConfigError("invalid configuration", err)
+	}
+
+	var generator *rand.Rand
+	if c.Seed != nil {
+		seed := *c.Seed
+		if r.checkpoint != nil {
+			seed += int64(r.checkpoint.Generation())
+		}
+		generator = random.NewLocked(seed)
+	}
+	rng := random.New(generator)
+
+	var population *core.Population[T]
+	if r.checkpoint != nil {
+		individuals := make([]core.Solution[T], len(r.population))
+		for i := range r.population {
+			individuals[i] = *r.population[i].DeepCopy()
+		}
+		population = core.NewPopulationFactory[T]().CreatePopulation(individuals)
+	} else {
+		population = core.NewPopulationFactory[T]().CreateRandomPopulation(c.Population.Size, c.Representation.Length, core.NewSolutionFactory[T](), func() T { return r.randomGene(rng) }, nil)
+	}
+
+	ga := executor.NewGeneticAlgorithmExecutor(population, parts.evaluator, parts.mutator, parts.selector, parts.crossover, c.Termination.Generations, workerCount(c.Workers))
+	ga.SetProgress(e.showProgress)
+	if generator != nil {
+		ga.SetRand(generator)
+	}
+	if parts.replacer != nil {
+		ga.SetReplacer(parts.replacer)
+	}
+	if c.Population.Offspring > 0 {
+		ga.SetOffspringSize(c.Population.Offspring)
+	}
+	if len(parts.criteria) > 0 {
+		ga.SetTerminationCriterion(termination.Any(parts.criteria...))
+	}
+	if c.Output.LogInterval > 0 && e.logOutput != nil {
+		ga.AddObserver(observer.NewLoggingObserver[T](e.logOutput, c.Output.LogInterval))
+	}
+
+	var hallOfFame *core.HallOfFame[[]T]
+	if c.Output.HallOfFame > 0 {
+		hallOfFame, err = core.NewHallOfFame(core.NewSliceGenome[T](), c.Output.HallOfFame)
+		if err != nil {
+			return nil, NewConfigError("invalid configuration", NewFieldError("output.hall_of_fame", "cannot create hall of fame", err))
+		}
+		if r.checkpoint != nil && len(r.checkpoint.HallOfFame) > 0 {
+			if err := json.Unmarshal(r.checkpoint.HallOfFame, hallOfFame); err != nil {
+				return nil, NewConfigError("cannot decode checkpoint hall of fame", err)
+			}
+		}
+		ga.SetHallOfFame(hallOfFame)
+	}
+
+	var checkpointErr error
+	if c.Output.Checkpoint != "" {
+		ga.AddObserver(observer.FuncObserver[T](func(statistics core.GenerationStatistics, population *core.Population[T]) {
+			if checkpointErr == nil && c.Output.CheckpointInterval > 0 && statistics.Generation%c.Output.CheckpointInterval == 0 {
+				checkpointErr = saveCheckpoint(c, ga.Statistics(), population, hallOfFame)
+			}
+		}))
+	}
+
+	start := time.Now()
+	var final *core.Population[T]
+	if r.checkpoint != nil {
+		final, err = ga.Resume(ctx, r.checkpoint.Statistics, c.Termination.Generations)
+	} else {
+		final, err = ga.Loop(ctx, c.Termination.Generations)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.Output.Checkpoint != "" {
+		checkpointErr = errors.Join(checkpointErr, saveCheckpoint(c, ga.Statistics(), final, hallOfFame))
+	}
+	if checkpointErr != nil {
+		return nil, NewConfigError("cannot save checkpoint", checkpointErr)
+	}
+
+	best, err := final.BestSolution()
+	if err != nil {
+		return nil, err
+	}
+	last, _ := ga.Statistics().Last()
+	result := &Result{
+		Representation: c.Representation.Type,
+		BestFitness:    best.Fitness,
+		BestChromosome: best.Chromosome,
+		Generations:    last.Generation,
+		Evaluations:    ga.Evaluations(),
+		ElapsedSeconds: time.Since(start).Seconds(),
+		Seed:           c.Seed,
+	}
+	if hallOfFame != nil {
+		for _, entry := range hallOfFame.Entries() {
+			result.HallOfFame = append(result.HallOfFame, ResultEntry{
+				Fitness:    entry.Individual.Fitness,
+				Chromosome: entry.Individual.Chromosome,
+				Generation: entry.Generation,
+			})
+		}
+	}
+	return result, nil
+}
+
+// Result summarizes a finished experiment.
+type Result struct {
+	Representation string        `json:"representation"`
+	BestFitness    float64       `json:"best_fitness"`
+	BestChromosome any           `json:"best_chromosome"`
+	Generations    int           `json:"generations"`
+	Evaluations    int           `json:"evaluations"`
+	ElapsedSeconds float64       `json:"elapsed_seconds"`
+	Seed           *int64        `json:"seed,omitempty"`
+	HallOfFame     []ResultEntry `json:"hall_of_fame,omitempty"`
+}
+
+// ResultEntry is a solution of the hall of fame of a result.
+type ResultEntry struct {
+	Fitness    float64 `json:"fitness"`
+	Chromosome any     `json:"chromosome"`
+	// Generation is the generation in which the solution was first found.
+	Generation int `json:"generation"`
+}
+
+// Write writes the result to writer in the given output format.
+func (r *Result) Write(writer io.Writer, format string) error {
+	switch format {
+	case JSONFormat:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case TextFormat:
+		_, err := fmt.Fprintf(writer, "Best solution found with fitness %.6g:\nChromosome: %v\nGenerations: %d\nEvaluations: %d\nElapsed: %.3fs\n",
+			r.BestFitness, r.BestChromosome, r.Generations, r.Evaluations, r.ElapsedSeconds)
+		if err != nil {
+			return err
+		}
+		for i, entry := range r.HallOfFame {
+			if _, err := fmt.Fprintf(writer, "Hall of fame #%d: fitness %.6g, generation %d: %v\n", i+1, entry.Fitness, entry.Generation, entry.Chromosome); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return NewFieldError("output.format", fmt.Sprintf("unknown format %q", format), nil)
+}
+
+// workerCount is a helper function resolving the configured number of workers, where -1 uses
+// all CPUs rather than the unlimited concurrency -1 selects in the executor.
+func workerCount(workers int) int {
+	if workers == -1 {
+		return runtime.NumCPU()
+	}
+	return workers
+}
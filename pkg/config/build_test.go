@@ -0,0 +1,154 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smallConfig is a helper function returning a fast configuration of the given representation.
+func smallConfig(representation string) Config {
+	config := Default()
+	config.Representation.Type = representation
+	config.Representation.Length = 6
+	config.Population.Size = 20
+	config.Termination.Generations = 10
+	return config
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("runs every representation", func(t *testing.T) {
+		testCases := []struct {
+			representation string
+			chromosome     any
+		}{
+			{Integer, []int{}},
+			{Real, []float64{}},
+			{Binary, []int{}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.representation, func(t *testing.T) {
+				config := smallConfig(tc.representation)
+				config.Representation.Min, config.Representation.Max = 0, 1
+				experiment, err := Build(&config)
+				require.NoError(t, err)
+
+				result, err := experiment.Run(context.Background())
+				require.NoError(t, err)
+				assert.IsType(t, tc.chromosome, result.BestChromosome)
+				assert.Equal(t, tc.representation, result.Representation)
+				assert.Equal(t, 10, result.Generations)
+				assert.Equal(t, 220, result.Evaluations)
+				assert.LessOrEqual(t, result.BestFitness, 6.0)
+				assert.GreaterOrEqual(t, result.BestFitness, 0.0)
+			})
+		}
+	})
+
+	t.Run("runs a benchmark problem", func(t *testing.T) {
+		config := smallConfig(Real)
+		config.Representation.Min, config.Representation.Max = -5.12, 5.12
+		config.Problem = Component{Name: "rastrigin", Params: map[string]any{"dimension": 6}}
+		experiment, err := Build(&config)
+		require.NoError(t, err)
+
+		result, err := experiment.Run(context.Background())
+		require.NoError(t, err)
+		assert.Less(t, result.BestFitness, 0.0)
+		assert.Len(t, result.BestChromosome, 6)
+	})
+
+	t.Run("wires replacement, termination criteria and logging", func(t *testing.T) {
+		config := smallConfig(Integer)
+		config.Operators.Replacement = &Component{Name: "plus"}
+		config.Population.Offspring = 10
+		config.Termination.Generations = 1000
+		config.Termination.Criteria = []Component{{Name: "max-evaluations", Params: map[string]any{"evaluations": 100}}}
+		config.Output.LogInterval = 1
+
+		experiment, err := Build(&config)
+		require.NoError(t, err)
+		var log bytes.Buffer
+		experiment.SetLogOutput(&log)
+
+		result, err := experiment.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 100, result.Evaluations)
+		assert.Equal(t, 8, result.Generations)
+		assert.Contains(t, log.String(), "generation 8:")
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		config := Default()
+		config.Operators.Selection.Name = "roulette"
+		experiment, err := Build(&config)
+		assert.Nil(t, experiment)
+		var ce *ConfigError
+		require.ErrorAs(t, err, &ce)
+		assert.Equal(t, []string{"operators.selection.name"}, fieldPaths(err))
+
+		_, err = Build(nil)
+		assert.ErrorAs(t, err, &ce)
+	})
+
+	t.Run("stops on cancelled context", func(t *testing.T) {
+		config := smallConfig(Integer)
+		experiment, err := Build(&config)
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = experiment.Run(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestResult_Write(t *testing.T) {
+	result := &Result{Representation: Integer, BestFitness: 3, BestChromosome: []int{1, 2}, Generations: 4, Evaluations: 50}
+
+	t.Run("json", func(t *testing.T) {
+		var buffer bytes.Buffer
+		require.NoError(t, result.Write(&buffer, JSONFormat))
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+		assert.Equal(t, 3.0, decoded["best_fitness"])
+		assert.Equal(t, []any{1.0, 2.0}, decoded["best_chromosome"])
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var buffer bytes.Buffer
+		require.NoError(t, result.Write(&buffer, TextFormat))
+		assert.Contains(t, buffer.String(), "Chromosome: [1 2]")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		assert.Error(t, result.Write(&bytes.Buffer{}, "xml"))
+	})
+}
+
+func TestWorkerCount(t *testing.T) {
+	assert.Equal(t, runtime.NumCPU(), workerCount(-1))
+	assert.Equal(t, 4, workerCount(4))
+}
+
+func TestAvailableOperators(t *testing.T) {
+	operators := AvailableOperators()
+	byName := make(map[string]Operator)
+	for _, operator := range operators {
+		assert.NotEmpty(t, operator.Description, operator.Name)
+		byName[string(operator.Kind)+"/"+operator.Name] = operator
+	}
+
+	assert.Equal(t, map[string]any{"size": 5, "elites": 1}, byName["selection/tournament"].Params)
+	assert.Equal(t, map[string]any{"duration": "1m0s"}, byName["termination/timeout"].Params)
+	assert.Contains(t, byName, "problem/sum")
+	assert.Equal(t, map[string]any{"dimension": 10, "shifted": false, "rotated": false, "seed": int64(0)}, byName["problem/rastrigin"].Params)
+	assert.Contains(t, byName, "replacement/comma")
+	assert.Equal(t, ProblemKind, operators[0].Kind)
+	assert.Equal(t, TerminationKind, operators[len(operators)-1].Kind)
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// checkpointVersion is the version of the checkpoint file format.
+const checkpointVersion = 1
+
+// Checkpoint is the saved state of an experiment from which it can be resumed.
+// It is written as JSON; the population and the hall of fame are stored in the
+// representation of the chromosomes described by Config.
+type Checkpoint struct {
+	// FormatVersion is the version of the checkpoint file format.
+	FormatVersion int `json:"format_version"`
+	// Config is the configuration of the saved experiment.
+	Config Config `json:"config"`
+	// Statistics holds the statistics of every generation up to the saved one.
+	Statistics *core.Statistics `json:"statistics"`
+	// Population holds the evaluated individuals of the last recorded generation.
+	Population json.RawMessage `json:"population"`
+	// HallOfFame holds the hall of fame, if the experiment keeps one.
+	HallOfFame json.RawMessage `json:"hall_of_fame,omitempty"`
+}
+
+// Generation returns the last recorded generation of the checkpoint.
+func (c *Checkpoint) Generation() int {
+	last, _ := c.Statistics.Last()
+	return last.Generation
+}
+
+// Evaluations returns the number of fitness evaluations performed up to the checkpoint.
+func (c *Checkpoint) Evaluations() int {
+	last, _ := c.Statistics.Last()
+	return last.Evaluations
+}
+
+// LoadCheckpoint reads a checkpoint saved by an experiment.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewConfigError("cannot load checkpoint", err)
+	}
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, NewConfigError("cannot parse checkpoint", err)
+	}
+	if checkpoint.FormatVersion != checkpointVersion {
+		return nil, NewConfigError("cannot load checkpoint", fmt.Errorf("unsupported format version %d", checkpoint.FormatVersion))
+	}
+	if checkpoint.Statistics == nil || len(checkpoint.Statistics.History) == 0 || len(checkpoint.Population) == 0 {
+		return nil, NewConfigError("cannot load checkpoint", errors.New("checkpoint has no recorded generation"))
+	}
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to path. The file is replaced atomically, so an interrupted
+// save leaves the previous checkpoint intact.
+func (c *Checkpoint) Save(path string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return NewConfigError("cannot save checkpoint", err)
+	}
+	temporary, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return NewConfigError("cannot save checkpoint", err)
+	}
+	defer os.Remove(temporary.Name())
+	if _, err := temporary.Write(data); err != nil {
+		temporary.Close()
+		return NewConfigError("cannot save checkpoint", err)
+	}
+	if err := temporary.Close(); err != nil {
+		return NewConfigError("cannot save checkpoint", err)
+	}
+	if err := os.Rename(temporary.Name(), path); err != nil {
+		return NewConfigError("cannot save checkpoint", err)
+	}
+	return nil
+}
+
+// saveCheckpoint is a helper function saving the current state of a run to Output.Checkpoint.
+func saveCheckpoint[T gene](c *Config, statistics *core.Statistics, population *core.Population[T], hallOfFame *core.HallOfFame[[]T]) error {
+	checkpoint := Checkpoint{
+		FormatVersion: checkpointVersion,
+		Config:        *c,
+		Statistics:    statistics,
+	}
+	var err error
+	if checkpoint.Population, err = json.Marshal(population.Individuals); err != nil {
+		return err
+	}
+	if hallOfFame != nil {
+		if checkpoint.HallOfFame, err = json.Marshal(hallOfFame); err != nil {
+			return err
+		}
+	}
+	return checkpoint.Save(c.Output.Checkpoint)
+}
+
+// Summary describes the population saved in a checkpoint.
+type Summary struct {
+	Representation  string  `json:"representation"`
+	Generation      int     `json:"generation"`
+	Evaluations     int     `json:"evaluations"`
+	Restarts        int     `json:"restarts"`
+	PopulationSize  int     `json:"population_size"`
+	UniqueGenotypes int     `json:"unique_genotypes"`
+	BestFitness     float64 `json:"best_fitness"`
+	MeanFitness     float64 `json:"mean_fitness"`
+	WorstFitness    float64 `json:"worst_fitness"`
+	StdDevFitness   float64 `json:"std_dev_fitness"`
+	BestEverFitness float64 `json:"best_ever_fitness"`
+	BestChromosome  any     `json:"best_chromosome"`
+	HallOfFameSize  int     `json:"hall_of_fame_size"`
+}
+
+// Summary computes fitness and diversity statistics of the population saved in the checkpoint.
+func (c *Checkpoint) Summary() (*Summary, error) {
+	if c.Config.Representation.Type == Real {
+		return summarize[float64](c)
+	}
+	return summarize[int](c)
+}
+
+func summarize[T gene](c *Checkpoint) (*Summary, error) {
+	var population core.Population[T]
+	if err := json.Unmarshal(c.Population, &population.Individuals); err != nil {
+		return nil, NewConfigError("cannot decode checkpoint population", err)
+	}
+	statistics, err := core.NewGenerationStatistics(&population, c.Generation(), c.Evaluations())
+	if err != nil {
+		return nil, NewConfigError("cannot summarize checkpoint", err)
+	}
+	best, err := population.BestSolution()
+	if err != nil {
+		return nil, NewConfigError("cannot summarize checkpoint", err)
+	}
+	last, _ := c.Statistics.Last()
+
+	summary := &Summary{
+		Representation:  c.Config.Representation.Type,
+		Generation:      statistics.Generation,
+		Evaluations:     statistics.Evaluations,
+		Restarts:        len(c.Statistics.Restarts),
+		PopulationSize:  len(population.Individuals),
+		UniqueGenotypes: population.UniqueGenotypes(core.NewSliceGenome[T]()),
+		BestFitness:     statistics.BestFitness,
+		MeanFitness:     statistics.MeanFitness,
+		WorstFitness:    statistics.WorstFitness,
+		StdDevFitness:   statistics.StdDevFitness,
+		BestEverFitness: last.BestEverFitness,
+		BestChromosome:  best.Chromosome,
+	}
+	if len(c.HallOfFame) > 0 {
+		var hallOfFame struct {
+			Entries []json.RawMessage `json:"entries"`
+		}
+		if err := json.Unmarshal(c.HallOfFame, &hallOfFame); err != nil {
+			return nil, NewConfigError("cannot decode checkpoint hall of fame", err)
+		}
+		summary.HallOfFameSize = len(hallOfFame.Entries)
+	}
+	return summary, nil
+}
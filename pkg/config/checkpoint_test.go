@@ -0,0 +1,111 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkpointedRun is a helper function running a small experiment which saves a checkpoint
+// every 5 generations and returning the loaded final checkpoint.
+func checkpointedRun(t *testing.T, representation string) (Config, *Checkpoint) {
+	t.Helper()
+	config := smallConfig(representation)
+	config.Output.Checkpoint = filepath.Join(t.TempDir(), "checkpoint.json")
+	config.Output.CheckpointInterval = 5
+	config.Output.HallOfFame = 3
+
+	experiment, err := Build(&config)
+	require.NoError(t, err)
+	_, err = experiment.Run(context.Background())
+	require.NoError(t, err)
+
+	checkpoint, err := LoadCheckpoint(config.Output.Checkpoint)
+	require.NoError(t, err)
+	return config, checkpoint
+}
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("saves the last generation of a run", func(t *testing.T) {
+		config, checkpoint := checkpointedRun(t, Integer)
+		assert.Equal(t, 10, checkpoint.Generation())
+		assert.Equal(t, 220, checkpoint.Evaluations())
+		assert.Equal(t, config, checkpoint.Config)
+		assert.NotEmpty(t, checkpoint.HallOfFame)
+	})
+
+	t.Run("summarizes the saved population", func(t *testing.T) {
+		for _, representation := range []string{Integer, Real, Binary} {
+			t.Run(representation, func(t *testing.T) {
+				_, checkpoint := checkpointedRun(t, representation)
+				summary, err := checkpoint.Summary()
+				require.NoError(t, err)
+				assert.Equal(t, representation, summary.Representation)
+				assert.Equal(t, 10, summary.Generation)
+				assert.Equal(t, 20, summary.PopulationSize)
+				assert.GreaterOrEqual(t, summary.UniqueGenotypes, 1)
+				// The mean of equal fitness values may differ from them in the last bits.
+				assert.GreaterOrEqual(t, summary.BestFitness+1e-9, summary.MeanFitness)
+				assert.GreaterOrEqual(t, summary.MeanFitness+1e-9, summary.WorstFitness)
+				assert.GreaterOrEqual(t, summary.BestEverFitness, summary.BestFitness)
+				assert.Equal(t, 3, summary.HallOfFameSize)
+			})
+		}
+	})
+
+	t.Run("rejects missing and unsupported files", func(t *testing.T) {
+		_, err := LoadCheckpoint(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorAs(t, err, new(*ConfigError))
+
+		_, checkpoint := checkpointedRun(t, Integer)
+		checkpoint.FormatVersion = 99
+		path := filepath.Join(t.TempDir(), "future.json")
+		require.NoError(t, checkpoint.Save(path))
+		_, err = LoadCheckpoint(path)
+		assert.ErrorContains(t, err, "unsupported format version 99")
+	})
+}
+
+func TestResume(t *testing.T) {
+	t.Run("continues a run from its checkpoint", func(t *testing.T) {
+		config, checkpoint := checkpointedRun(t, Real)
+		config.Termination.Generations = 15
+
+		experiment, err := Resume(&config, checkpoint)
+		require.NoError(t, err)
+		result, err := experiment.Run(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 15, result.Generations)
+		assert.Equal(t, 320, result.Evaluations)
+		require.Len(t, result.HallOfFame, 3)
+		last, _ := checkpoint.Statistics.Last()
+		assert.GreaterOrEqual(t, result.HallOfFame[0].Fitness, last.BestEverFitness)
+
+		resumed, err := LoadCheckpoint(config.Output.Checkpoint)
+		require.NoError(t, err)
+		assert.Equal(t, 15, resumed.Generation())
+	})
+
+	t.Run("rejects configurations not matching the checkpoint", func(t *testing.T) {
+		config, checkpoint := checkpointedRun(t, Integer)
+		config.Representation.Length = 7
+		config.Population.Size = 30
+
+		_, err := Resume(&config, checkpoint)
+		require.Error(t, err)
+		assert.Equal(t, []string{"representation.length"}, fieldPaths(err))
+
+		config.Representation.Length = 6
+		_, err = Resume(&config, checkpoint)
+		assert.Equal(t, []string{"population.size"}, fieldPaths(err))
+	})
+
+	t.Run("requires a checkpoint", func(t *testing.T) {
+		config := smallConfig(Integer)
+		_, err := Resume(&config, nil)
+		assert.ErrorAs(t, err, new(*ConfigError))
+	})
+}
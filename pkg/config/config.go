@@ -0,0 +1,216 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/registry"
+)
+
+// Representations supported by Representation.Type.
+const (
+	// Integer chromosomes are slices of integers drawn uniformly from [Min, Max].
+	Integer = "integer"
+	// Real chromosomes are slices of float64 values drawn uniformly from [Min, Max).
+	Real = "real"
+	// Binary chromosomes are slices of integers which are either 0 or 1.
+	Binary = "binary"
+)
+
+// Output formats supported by Output.Format.
+const (
+	// TextFormat writes results in a human-readable form.
+	TextFormat = "text"
+	// JSONFormat writes results as a JSON document.
+	JSONFormat = "json"
+)
+
+// Config describes a complete genetic algorithm run: the problem to solve, the representation
+// of its chromosomes, the operators with their parameters, when to stop and what to output.
+type Config struct {
+	// Problem is the fitness evaluator of the run, e.g. {name: sum}.
+	Problem Component `json:"problem" yaml:"problem"`
+	// Representation describes the chromosomes and how the initial population is drawn.
+	Representation Representation `json:"representation" yaml:"representation"`
+	// Population configures the size of the population.
+	Population Population `json:"population" yaml:"population"`
+	// Operators are the variation and selection operators of the run.
+	Operators Operators `json:"operators" yaml:"operators"`
+	// Termination configures when the run stops.
+	Termination Termination `json:"termination" yaml:"termination"`
+	// Output configures progress logging and how results are written.
+	Output Output `json:"output" yaml:"output"`
+	// Workers is the number of workers evaluating and mutating chromosomes, -1 uses all CPUs.
+	Workers int `json:"workers" yaml:"workers"`
+	// Seed seeds a random number generator of the run, which draws the initial population and is
+	// passed to the executor and the built-in operators, see core.IRandomized. Nil leaves the run
+	// unseeded. Runs are only reproducible for a given seed with Workers set to 1, since parallel
+	// workers draw random numbers in the order they are scheduled.
+	Seed *int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+}
+
+// Representation describes fixed-length chromosomes.
+type Representation struct {
+	// Type is one of Integer, Real or Binary.
+	Type string `json:"type" yaml:"type"`
+	// Length is the number of genes of every chromosome.
+	Length int `json:"length" yaml:"length"`
+	// Min is the lower bound of the initial genes of Integer and Real chromosomes.
+	Min float64 `json:"min" yaml:"min"`
+	// Max is the upper bound of the initial genes of Integer and Real chromosomes.
+	Max float64 `json:"max" yaml:"max"`
+}
+
+// Population configures the population of the run.
+type Population struct {
+	// Size is the number of individuals of every generation.
+	Size int `json:"size" yaml:"size"`
+	// Offspring is the number of offspring bred per generation when a replacement is
+	// configured, 0 breeds as many offspring as there are parents.
+	Offspring int `json:"offspring,omitempty" yaml:"offspring,omitempty"`
+}
+
+// Operators names the operators of the run.
+type Operators struct {
+	Selection Component `json:"selection" yaml:"selection"`
+	Crossover Component `json:"crossover" yaml:"crossover"`
+	Mutation  Component `json:"mutation" yaml:"mutation"`
+	// Replacement is the optional survivor selection; without it offspring replace their parents.
+	Replacement *Component `json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+// Termination configures when the run stops.
+type Termination struct {
+	// Generations is the maximum number of generations.
+	Generations int `json:"generations" yaml:"generations"`
+	// Criteria are additional criteria; the run stops as soon as any of them is met.
+	Criteria []Component `json:"criteria,omitempty" yaml:"criteria,omitempty"`
+}
+
+// Output configures progress logging and how results are written.
+type Output struct {
+	// Format is TextFormat or JSONFormat.
+	Format string `json:"format" yaml:"format"`
+	// Path is the file results are written to, empty writes to standard output.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// LogInterval logs statistics every LogInterval generations, 0 disables logging.
+	LogInterval int `json:"log_interval,omitempty" yaml:"log_interval,omitempty"`
+	// Checkpoint is the file the state of the run is saved to at the end of the run
+	// and every CheckpointInterval generations, empty disables checkpoints.
+	Checkpoint string `json:"checkpoint,omitempty" yaml:"checkpoint,omitempty"`
+	// CheckpointInterval saves a checkpoint every CheckpointInterval generations,
+	// 0 only saves the final state.
+	CheckpointInterval int `json:"checkpoint_interval,omitempty" yaml:"checkpoint_interval,omitempty"`
+	// HallOfFame is the number of best distinct solutions reported with the result, 0 disables it.
+	HallOfFame int `json:"hall_of_fame,omitempty" yaml:"hall_of_fame,omitempty"`
+}
+
+// Component selects a named operator and its parameters,
+// e.g. {name: tournament, params: {size: 5, elites: 1}} or "tournament{size:5, elites:1}".
+// The operators are looked up in the shared registries of package registry.
+// Parameters missing from Params keep the defaults of the operator.
+type Component struct {
+	Name   string         `json:"name" yaml:"name"`
+	Params map[string]any `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// Spec returns the registry spec describing the component.
+func (c Component) Spec() registry.Spec {
+	return registry.Spec{Name: c.Name, Params: c.Params}
+}
+
+// UnmarshalText parses the compact form of a component, see registry.ParseSpec, so configurations
+// can write "tournament{size:3}" instead of an object with name and params.
+func (c *Component) UnmarshalText(text []byte) error {
+	spec, err := registry.ParseSpec(string(text))
+	if err != nil {
+		return err
+	}
+	*c = Component(spec)
+	return nil
+}
+
+// UnmarshalJSON decodes a component from either its compact string form or an object with name and params.
+func (c *Component) UnmarshalJSON(data []byte) error {
+	var spec registry.Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+	*c = Component(spec)
+	return nil
+}
+
+// Default returns the configuration used for every field missing from a configuration file.
+func Default() Config {
+	return Config{
+		Problem:        Component{Name: "sum"},
+		Representation: Representation{Type: Integer, Length: 20, Min: -100, Max: 100},
+		Population:     Population{Size: 100},
+		Operators: Operators{
+			Selection: Component{Name: "tournament"},
+			Crossover: Component{Name: "single-point"},
+			Mutation:  Component{Name: "swap"},
+		},
+		Termination: Termination{Generations: 100},
+		Output:      Output{Format: TextFormat},
+		Workers:     1,
+	}
+}
+
+// Validate checks every field of the configuration, including the names and parameters
+// of its operators. All problems are returned joined, each as a *FieldError.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(path, format string, args ...any) {
+		errs = append(errs, NewFieldError(path, fmt.Sprintf(format, args...), nil))
+	}
+
+	representations := []string{Integer, Real, Binary}
+	if !slices.Contains(representations, c.Representation.Type) {
+		invalid("representation.type", "unknown representation %q (available: %v)", c.Representation.Type, representations)
+	}
+	if c.Representation.Length < 2 {
+		invalid("representation.length", "must be at least 2, got %d", c.Representation.Length)
+	}
+	if c.Representation.Min > c.Representation.Max {
+		invalid("representation.min", "must not exceed representation.max (%v), got %v", c.Representation.Max, c.Representation.Min)
+	}
+	if c.Population.Size < 2 {
+		invalid("population.size", "must be at least 2, got %d", c.Population.Size)
+	}
+	if c.Population.Offspring < 0 {
+		invalid("population.offspring", "must not be negative, got %d", c.Population.Offspring)
+	}
+	if c.Termination.Generations < 1 {
+		invalid("termination.generations", "must be at least 1, got %d", c.Termination.Generations)
+	}
+	if c.Workers == 0 || c.Workers < -1 {
+		invalid("workers", "must be positive or -1 for all CPUs, got %d", c.Workers)
+	}
+	if c.Output.Format != TextFormat && c.Output.Format != JSONFormat {
+		invalid("output.format", "unknown format %q (available: [%s %s])", c.Output.Format, TextFormat, JSONFormat)
+	}
+	if c.Output.LogInterval < 0 {
+		invalid("output.log_interval", "must not be negative, got %d", c.Output.LogInterval)
+	}
+	if c.Output.CheckpointInterval < 0 {
+		invalid("output.checkpoint_interval", "must not be negative, got %d", c.Output.CheckpointInterval)
+	}
+	if c.Output.CheckpointInterval > 0 && c.Output.Checkpoint == "" {
+		invalid("output.checkpoint_interval", "requires output.checkpoint to be set")
+	}
+	if c.Output.HallOfFame < 0 {
+		invalid("output.hall_of_fame", "must not be negative, got %d", c.Output.HallOfFame)
+	}
+
+	var err error
+	switch c.Representation.Type {
+	case Real:
+		_, err = assemble[float64](c)
+	default:
+		_, err = assemble[int](c)
+	}
+	return errors.Join(append(errs, err)...)
+}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldPaths is a helper function listing the paths of every FieldError contained in err.
+func fieldPaths(err error) []string {
+	var paths []string
+	for _, fieldError := range FieldErrors(err) {
+		paths = append(paths, fieldError.Path)
+	}
+	return paths
+}
+
+func TestParse(t *testing.T) {
+	t.Run("yaml overrides defaults", func(t *testing.T) {
+		config, err := Parse([]byte(`
+representation: {type: real, length: 5, min: -1, max: 1}
+operators:
+  selection: {name: tournament, params: {size: 3}}
+termination:
+  generations: 7
+  criteria:
+    - {name: timeout, params: {duration: 2s}}
+`), YAML)
+		require.NoError(t, err)
+
+		expected := Default()
+		expected.Representation = Representation{Type: Real, Length: 5, Min: -1, Max: 1}
+		expected.Operators.Selection = Component{Name: "tournament", Params: map[string]any{"size": 3}}
+		expected.Termination = Termination{Generations: 7, Criteria: []Component{{Name: "timeout", Params: map[string]any{"duration": "2s"}}}}
+		assert.Equal(t, &expected, config)
+	})
+
+	t.Run("json is decoded like yaml", func(t *testing.T) {
+		config, err := Parse([]byte(`{"population": {"size": 30}, "operators": {"replacement": {"name": "plus", "params": {"mu": 30}}}}`), JSON)
+		require.NoError(t, err)
+		assert.Equal(t, 30, config.Population.Size)
+		require.NotNil(t, config.Operators.Replacement)
+		assert.Equal(t, "plus", config.Operators.Replacement.Name)
+	})
+
+	t.Run("components can be written as specs", func(t *testing.T) {
+		config, err := Parse([]byte(`
+operators:
+  selection: "tournament{size:3, elites:0}"
+  mutation: swap
+termination:
+  criteria: ["stagnation{generations:5}", {name: timeout}]
+`), YAML)
+		require.NoError(t, err)
+		assert.Equal(t, Component{Name: "tournament", Params: map[string]any{"size": 3, "elites": 0}}, config.Operators.Selection)
+		assert.Equal(t, Component{Name: "swap"}, config.Operators.Mutation)
+		assert.Equal(t, []Component{{Name: "stagnation", Params: map[string]any{"generations": 5}}, {Name: "timeout"}}, config.Termination.Criteria)
+
+		_, err = Parse([]byte(`operators: {selection: "tournament{size:3"}`), YAML)
+		assert.Equal(t, []string{"operators.selection"}, fieldPaths(err))
+	})
+
+	t.Run("empty document yields defaults", func(t *testing.T) {
+		config, err := Parse(nil, YAML)
+		require.NoError(t, err)
+		assert.Equal(t, Default(), *config)
+	})
+
+	t.Run("syntax errors are reported", func(t *testing.T) {
+		_, err := Parse([]byte(`{"population": `), JSON)
+		var ce *ConfigError
+		assert.ErrorAs(t, err, &ce)
+		assert.Empty(t, FieldErrors(err))
+	})
+
+	t.Run("invalid fields are reported by path", func(t *testing.T) {
+		_, err := Parse([]byte(`
+representation: {type: tree, length: 1, min: 2, max: 1}
+population: {size: many}
+operators:
+  selection: {name: tournament, params: {size: 0}}
+  crossover: {name: two-point}
+  mutation: {name: swap, params: {rate: 2, strength: 1}}
+termination:
+  generations: 0
+  criteria:
+    - {name: stagnation}
+    - {name: max-evaluations, params: {evaluations: 1.5}}
+output: {format: xml}
+workers: -2
+unknown: true
+`), YAML)
+
+		var ce *ConfigError
+		require.ErrorAs(t, err, &ce)
+		assert.ElementsMatch(t, []string{
+			"representation.type",
+			"representation.length",
+			"representation.min",
+			"population.size",
+			"operators.selection.params.size",
+			"operators.crossover.name",
+			"operators.mutation.params.strength",
+			"termination.generations",
+			"termination.criteria[1].params.evaluations",
+			"output.format",
+			"workers",
+			"unknown",
+		}, fieldPaths(err))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := Parse([]byte(`a: 1`), Format("toml"))
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Run("default configuration is valid", func(t *testing.T) {
+		config := Default()
+		assert.NoError(t, config.Validate())
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		config := Default()
+		config.Representation = Representation{Type: "tree", Length: 1, Min: 2, Max: 1}
+		config.Population.Size = 1
+		config.Operators.Selection.Params = map[string]any{"size": 0}
+		config.Operators.Crossover.Name = "two-point"
+		config.Operators.Mutation.Params = map[string]any{"rate": 2.0, "strength": 1}
+		config.Termination.Generations = 0
+		config.Termination.Criteria = []Component{{Name: "stagnation"}, {Name: "timeout", Params: map[string]any{"duration": "-1s"}}}
+		config.Output.Format = "xml"
+		config.Workers = -2
+
+		assert.ElementsMatch(t, []string{
+			"representation.type",
+			"representation.length",
+			"representation.min",
+			"population.size",
+			"operators.selection.params.size",
+			"operators.crossover.name",
+			"operators.mutation.params.strength",
+			"termination.generations",
+			"termination.criteria[1].params.duration",
+			"output.format",
+			"workers",
+		}, fieldPaths(config.Validate()))
+	})
+
+	t.Run("benchmark problems must match the chromosome length", func(t *testing.T) {
+		config := Default()
+		config.Representation = Representation{Type: Real, Length: 20, Min: -1, Max: 1}
+		config.Problem = Component{Name: "sphere", Params: map[string]any{"dimension": 10}}
+		err := config.Validate()
+		require.Len(t, FieldErrors(err), 1)
+		assert.Equal(t, "problem.params.dimension", FieldErrors(err)[0].Path)
+
+		config.Problem.Params["dimension"] = 20
+		assert.NoError(t, config.Validate())
+
+		config.Representation.Type = Integer
+		assert.Equal(t, []string{"problem.name"}, fieldPaths(config.Validate()))
+	})
+
+	t.Run("operator parameters are validated after decoding", func(t *testing.T) {
+		config := Default()
+		config.Operators.Mutation.Params = map[string]any{"rate": 2.0}
+		err := config.Validate()
+		require.Len(t, FieldErrors(err), 1)
+		assert.Equal(t, "operators.mutation.params.rate", FieldErrors(err)[0].Path)
+		assert.ErrorContains(t, err, "must be within [0, 1]")
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("loads example configurations", func(t *testing.T) {
+		for _, name := range []string{"example.yaml", "example.json"} {
+			config, err := Load(filepath.Join("..", "..", "configs", name))
+			require.NoError(t, err, name)
+			assert.NoError(t, config.Validate(), name)
+		}
+	})
+
+	t.Run("format is chosen by extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "run.yml")
+		require.NoError(t, os.WriteFile(path, []byte("population:\n  size: 12\n"), 0o644))
+		config, err := Load(path)
+		require.NoError(t, err)
+		assert.Equal(t, 12, config.Population.Size)
+
+		_, err = Load(filepath.Join(t.TempDir(), "run.toml"))
+		assert.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "missing.yaml"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	type nested struct {
+		Timeout time.Duration `json:"timeout"`
+		Weights []float64     `json:"weights"`
+	}
+	type target struct {
+		Name    string         `json:"name"`
+		Count   int            `json:"count"`
+		Enabled bool           `json:"enabled"`
+		Nested  *nested        `json:"nested"`
+		Extra   map[string]any `json:"extra"`
+		Ignored string         `json:"-"`
+	}
+
+	t.Run("decodes generic documents", func(t *testing.T) {
+		var result target
+		err := Decode(map[string]any{
+			"name":    "a",
+			"count":   3.0,
+			"enabled": true,
+			"nested":  map[string]any{"timeout": "1m", "weights": []any{1, 2.5}},
+			"extra":   map[string]any{"x": 1},
+		}, "", &result)
+		require.NoError(t, err)
+		assert.Equal(t, target{
+			Name:    "a",
+			Count:   3,
+			Enabled: true,
+			Nested:  &nested{Timeout: time.Minute, Weights: []float64{1, 2.5}},
+			Extra:   map[string]any{"x": 1},
+		}, result)
+	})
+
+	t.Run("reports paths of invalid values", func(t *testing.T) {
+		var result target
+		err := Decode(map[string]any{
+			"name":    1,
+			"count":   1.5,
+			"enabled": "yes",
+			"nested":  map[string]any{"timeout": 5, "weights": []any{1, "a"}},
+			"Ignored": "x",
+		}, "root", &result)
+		assert.ElementsMatch(t, []string{
+			"root.name",
+			"root.count",
+			"root.enabled",
+			"root.nested.timeout",
+			"root.nested.weights[1]",
+			"root.Ignored",
+		}, fieldPaths(err))
+	})
+
+	t.Run("target must be a pointer", func(t *testing.T) {
+		assert.Error(t, Decode(map[string]any{}, "", target{}))
+	})
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tomhoffer/darwinium/internal/decode"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the serialization format of a configuration file.
+type Format string
+
+const (
+	// YAML is the YAML format, used for the .yaml and .yml extensions.
+	YAML Format = "yaml"
+	// JSON is the JSON format, used for the .json extension.
+	JSON Format = "json"
+)
+
+// FormatOf returns the format of a configuration file based on its extension.
+func FormatOf(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return YAML, nil
+	case ".json":
+		return JSON, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnsupportedFormat, filepath.Ext(path))
+}
+
+// Load reads, parses and validates the configuration file at path.
+// Fields missing from the file keep the values of Default.
+func Load(path string) (*Config, error) {
+	format, err := FormatOf(path)
+	if err != nil {
+		return nil, NewConfigError("cannot load configuration", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewConfigError("cannot load configuration", err)
+	}
+	return Parse(data, format)
+}
+
+// Parse parses and validates a configuration in the given format.
+// Fields missing from data keep the values of Default.
+func Parse(data []byte, format Format) (*Config, error) {
+	var document any
+	var err error
+	switch format {
+	case YAML:
+		err = yaml.Unmarshal(data, &document)
+	case JSON:
+		err = unmarshalJSON(data, &document)
+	default:
+		err = fmt.Errorf("%w: %q", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, NewConfigError("cannot parse configuration", err)
+	}
+
+	// Fields which fail to decode keep their defaults, so validation reports the remaining problems.
+	config := Default()
+	var decodeErr error
+	if document != nil {
+		decodeErr = Decode(document, "", &config)
+	}
+	if err := errors.Join(decodeErr, config.Validate()); err != nil {
+		return nil, NewConfigError("invalid configuration", err)
+	}
+	return &config, nil
+}
+
+// Decode stores a generic document, as produced by the YAML and JSON parsers, into target.
+// Struct fields are matched by their json tag; fields missing from the document are left
+// unchanged and unknown keys are reported. Every problem is reported as a FieldError whose
+// path is prefixed by path, and all of them are returned joined.
+func Decode(document any, path string, target any) error {
+	var errs []error
+	for _, e := range decode.Decode(document, path, target) {
+		errs = append(errs, NewFieldError(e.Path, e.Message, nil))
+	}
+	return errors.Join(errs...)
+}
+
+// unmarshalJSON is a helper function parsing JSON numbers as json.Number,
+// which keeps large integers such as seeds exact.
+func unmarshalJSON(data []byte, document *any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(document); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after the JSON document")
+	}
+	return nil
+}
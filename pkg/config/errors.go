@@ -0,0 +1,100 @@
+// Package config describes genetic algorithm runs declaratively and builds executors from them.
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConfigError represents an error that occurs while loading or building a run configuration.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type ConfigError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *ConfigError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *ConfigError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewConfigError constructs a *ConfigError with the provided message and wrapped error.
+func NewConfigError(message string, wrapped error) *ConfigError {
+	return &ConfigError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// FieldError reports an invalid value of a single configuration field.
+// Path locates the field, e.g. "operators.selection.params.size" or "termination.criteria[1].name".
+type FieldError struct {
+	// Path is the dotted path of the offending field.
+	Path string
+	// Message describes what is wrong with the field.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Path, e.Message, e.Wrapped)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *FieldError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewFieldError constructs a *FieldError for the field at path.
+func NewFieldError(path, message string, wrapped error) *FieldError {
+	return &FieldError{
+		Path:    path,
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// FieldErrors returns every FieldError contained in err, in the order they were reported.
+func FieldErrors(err error) []*FieldError {
+	var fieldErrors []*FieldError
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case *FieldError:
+			fieldErrors = append(fieldErrors, e)
+		case interface{ Unwrap() []error }:
+			for _, wrapped := range e.Unwrap() {
+				walk(wrapped)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+	walk(err)
+	return fieldErrors
+}
+
+// ErrUnsupportedFormat indicates that a configuration file has an unknown format.
+// This error occurs when a file extension is neither .yaml, .yml nor .json.
+var ErrUnsupportedFormat = errors.New("unsupported configuration format")
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"github.com/tomhoffer/darwinium/pkg/registry"
+)
+
+// Kind is the kind of component an operator provides.
+type Kind string
+
+// Kinds of operators, one per component of a Config.
+const (
+	ProblemKind     Kind = "problem"
+	SelectionKind   Kind = "selection"
+	CrossoverKind   Kind = "crossover"
+	MutationKind    Kind = "mutation"
+	ReplacementKind Kind = "replacement"
+	TerminationKind Kind = "termination"
+)
+
+// Operator describes a registered operator which configurations refer to by name.
+type Operator struct {
+	Kind        Kind   `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Params holds the default value of every parameter of the operator.
+	Params map[string]any `json:"params"`
+}
+
+// AvailableOperators lists the operators registered in the shared registries of package registry,
+// including those registered by other packages, ordered by kind and name.
+func AvailableOperators() []Operator {
+	var operators []Operator
+	for _, entry := range registry.Entries() {
+		kind := Kind(entry.Kind)
+		if entry.Kind == registry.EvaluatorKind {
+			kind = ProblemKind
+		}
+		operators = append(operators, Operator{Kind: kind, Name: entry.Name, Description: entry.Description, Params: entry.Params})
+	}
+	return operators
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Override assigns a value to a single configuration field, e.g. from a command line flag.
+type Override struct {
+	// Path locates the field like the paths of FieldError, e.g. "operators.selection.params.size".
+	Path string
+	// Value is the generic value of the field as produced by the YAML and JSON parsers.
+	Value any
+}
+
+// ParseOverride parses an assignment of the form "path=value". The value is parsed as YAML,
+// so "5" yields a number, "true" a boolean and "{size: 3}" an object.
+func ParseOverride(assignment string) (Override, error) {
+	path, text, found := strings.Cut(assignment, "=")
+	path = strings.TrimSpace(path)
+	if !found || path == "" {
+		return Override{}, NewConfigError("invalid override", fmt.Errorf("expected path=value, got %q", assignment))
+	}
+	var value any
+	if err := yaml.Unmarshal([]byte(text), &value); err != nil {
+		return Override{}, NewConfigError("invalid override", NewFieldError(path, "cannot parse value", err))
+	}
+	return Override{Path: path, Value: value}, nil
+}
+
+// Apply assigns the overrides in order and validates the resulting configuration.
+// The configuration is only modified if the result is valid; otherwise a ConfigError wrapping
+// the *FieldError values of every invalid field is returned.
+func (c *Config) Apply(overrides ...Override) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return NewConfigError("cannot apply overrides", err)
+	}
+	var document any
+	if err := unmarshalJSON(data, &document); err != nil {
+		return NewConfigError("cannot apply overrides", err)
+	}
+
+	var errs []error
+	for _, override := range overrides {
+		steps, err := parsePath(override.Path)
+		if err == nil {
+			document, err = assign(document, steps, override.Value)
+		}
+		if err != nil {
+			errs = append(errs, NewFieldError(override.Path, "cannot override field", err))
+		}
+	}
+	if len(errs) > 0 {
+		return NewConfigError("invalid overrides", errors.Join(errs...))
+	}
+
+	result := Default()
+	if err := errors.Join(Decode(document, "", &result), result.Validate()); err != nil {
+		return NewConfigError("invalid configuration", err)
+	}
+	*c = result
+	return nil
+}
+
+// step is a single element of a field path: either an object key or a list index.
+type step struct {
+	key   string
+	index int
+}
+
+// parsePath is a helper function splitting paths such as "termination.criteria[0].name".
+func parsePath(path string) ([]step, error) {
+	var steps []step
+	for _, segment := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if key == "" {
+			return nil, fmt.Errorf("empty key in path %q", path)
+		}
+		steps = append(steps, step{key: key, index: -1})
+		for rest != "" {
+			number, remainder, found := strings.Cut(rest, "]")
+			index, err := strconv.Atoi(number)
+			if !found || err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index in path %q", path)
+			}
+			steps = append(steps, step{index: index})
+			rest = strings.TrimPrefix(remainder, "[")
+			if remainder != "" && !strings.HasPrefix(remainder, "[") {
+				return nil, fmt.Errorf("invalid index in path %q", path)
+			}
+		}
+	}
+	return steps, nil
+}
+
+// assign is a helper function setting the value at the path described by steps, creating
+// missing objects and appending to lists when the index equals their length.
+func assign(node any, steps []step, value any) (any, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+	current, rest := steps[0], steps[1:]
+
+	if current.key != "" {
+		object, ok := node.(map[string]any)
+		if node == nil {
+			object, ok = make(map[string]any), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot set %q on a value which is not an object", current.key)
+		}
+		child, err := assign(object[current.key], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		object[current.key] = child
+		return object, nil
+	}
+
+	list, ok := node.([]any)
+	if node != nil && !ok {
+		return nil, fmt.Errorf("index %d applied to a value which is not a list", current.index)
+	}
+	switch {
+	case current.index < len(list):
+		child, err := assign(list[current.index], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		list[current.index] = child
+	case current.index == len(list):
+		child, err := assign(nil, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, child)
+	default:
+		return nil, fmt.Errorf("index %d out of range, the list has %d elements", current.index, len(list))
+	}
+	return list, nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOverride(t *testing.T) {
+	testCases := []struct {
+		name       string
+		assignment string
+		expected   Override
+	}{
+		{"integer", "population.size=50", Override{"population.size", 50}},
+		{"string", "problem.name=sum", Override{"problem.name", "sum"}},
+		{"boolean", "x=true", Override{"x", true}},
+		{"object", "operators.selection={name: tournament, params: {size: 3}}", Override{
+			"operators.selection",
+			map[string]any{"name": "tournament", "params": map[string]any{"size": 3}},
+		}},
+		{"empty value", "termination.criteria=", Override{"termination.criteria", nil}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			override, err := ParseOverride(tc.assignment)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, override)
+		})
+	}
+
+	t.Run("rejects assignments without a path", func(t *testing.T) {
+		for _, assignment := range []string{"population.size", "=5"} {
+			_, err := ParseOverride(assignment)
+			assert.ErrorAs(t, err, new(*ConfigError), assignment)
+		}
+	})
+}
+
+func TestConfig_Apply(t *testing.T) {
+	t.Run("overrides nested fields and list elements", func(t *testing.T) {
+		config := Default()
+		err := config.Apply(
+			Override{"population.size", 50},
+			Override{"operators.selection.params.size", 3},
+			Override{"termination.criteria[0]", map[string]any{"name": "stagnation"}},
+			Override{"termination.criteria[0].params.generations", 5},
+			Override{"seed", int64(1) << 60},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, 50, config.Population.Size)
+		assert.Equal(t, map[string]any{"size": 3}, config.Operators.Selection.Params)
+		require.Len(t, config.Termination.Criteria, 1)
+		assert.Equal(t, "stagnation", config.Termination.Criteria[0].Name)
+		require.NotNil(t, config.Seed)
+		assert.Equal(t, int64(1)<<60, *config.Seed)
+	})
+
+	t.Run("accepts components written as specs", func(t *testing.T) {
+		config := Default()
+		override, err := ParseOverride("operators.selection=tournament{size:3}")
+		require.NoError(t, err)
+		require.NoError(t, config.Apply(override))
+		assert.Equal(t, Component{Name: "tournament", Params: map[string]any{"size": 3}}, config.Operators.Selection)
+	})
+
+	t.Run("leaves the configuration unchanged if the result is invalid", func(t *testing.T) {
+		config := Default()
+		err := config.Apply(
+			Override{"population.size", 50},
+			Override{"operators.selection.params.size", 0},
+			Override{"population.colour", "red"},
+		)
+		require.Error(t, err)
+		assert.ElementsMatch(t, []string{"operators.selection.params.size", "population.colour"}, fieldPaths(err))
+		assert.Equal(t, Default(), config)
+	})
+
+	t.Run("reports invalid paths", func(t *testing.T) {
+		testCases := []string{"population..size", "termination.criteria[x]", "termination.criteria[3]", "population.size.value"}
+		for _, path := range testCases {
+			config := Default()
+			err := config.Apply(Override{path, 1})
+			assert.Equal(t, []string{path}, fieldPaths(err), path)
+		}
+	})
+}
@@ -0,0 +1,150 @@
+// Package core provides data structures and interfaces for genetic algorithm solutions.
+package core
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// BoundaryHandling selects how a numeric chromosome that left its bounds is brought back into them.
+type BoundaryHandling int
+
+const (
+	// BoundaryClip sets every out-of-bounds gene to the violated bound.
+	BoundaryClip BoundaryHandling = iota
+	// BoundaryReflect mirrors every out-of-bounds gene at the violated bound.
+	BoundaryReflect
+	// BoundaryWrap treats the search space as periodic and wraps genes around to the opposite bound.
+	BoundaryWrap
+	// BoundaryResample replaces every out-of-bounds gene by a uniformly sampled value within the bounds.
+	BoundaryResample
+)
+
+// String returns the name of the boundary handling strategy.
+func (h BoundaryHandling) String() string {
+	switch h {
+	case BoundaryClip:
+		return "clip"
+	case BoundaryReflect:
+		return "reflect"
+	case BoundaryWrap:
+		return "wrap"
+	case BoundaryResample:
+		return "resample"
+	default:
+		return fmt.Sprintf("BoundaryHandling(%d)", int(h))
+	}
+}
+
+// Bounds describes a box-constrained search space for float64 chromosomes.
+// Lower[i] and Upper[i] delimit the allowed values of the i-th gene, inclusive.
+type Bounds struct {
+	// Lower holds the lower bound of every gene.
+	Lower []float64
+	// Upper holds the upper bound of every gene.
+	Upper []float64
+}
+
+// NewBounds creates new Bounds from per-gene lower and upper bounds.
+// It returns an error wrapping ErrInvalidBounds if the slices differ in length,
+// are empty, contain non-finite values or a lower bound exceeds its upper bound.
+func NewBounds(lower, upper []float64) (*Bounds, error) {
+	if len(lower) == 0 || len(lower) != len(upper) {
+		return nil, fmt.Errorf("%w: lower and upper must be non-empty and of the same length, but were %d and %d", ErrInvalidBounds, len(lower), len(upper))
+	}
+	for i := range lower {
+		if math.IsNaN(lower[i]) || math.IsNaN(upper[i]) || math.IsInf(lower[i], 0) || math.IsInf(upper[i], 0) {
+			return nil, fmt.Errorf("%w: bounds at index %d must be finite", ErrInvalidBounds, i)
+		}
+		if lower[i] > upper[i] {
+			return nil, fmt.Errorf("%w: lower bound %g exceeds upper bound %g at index %d", ErrInvalidBounds, lower[i], upper[i], i)
+		}
+	}
+	return &Bounds{
+		Lower: append([]float64{}, lower...),
+		Upper: append([]float64{}, upper...),
+	}, nil
+}
+
+// NewUniformBounds creates new Bounds of the given dimension where every gene shares the same bounds.
+func NewUniformBounds(dimension int, lower, upper float64) (*Bounds, error) {
+	if dimension <= 0 {
+		return nil, fmt.Errorf("%w: dimension must be positive, but was %d", ErrInvalidBounds, dimension)
+	}
+	lowers := make([]float64, dimension)
+	uppers := make([]float64, dimension)
+	for i := 0; i < dimension; i++ {
+		lowers[i] = lower
+		uppers[i] = upper
+	}
+	return NewBounds(lowers, uppers)
+}
+
+// Dimension returns the number of genes described by the bounds.
+func (b *Bounds) Dimension() int {
+	return len(b.Lower)
+}
+
+// Contains reports whether every gene of the chromosome lies within its bounds.
+func (b *Bounds) Contains(chromosome []float64) bool {
+	if len(chromosome) != len(b.Lower) {
+		return false
+	}
+	for i, x := range chromosome {
+		if x < b.Lower[i] || x > b.Upper[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Sample returns a chromosome sampled uniformly at random within the bounds.
+func (b *Bounds) Sample() []float64 {
+	chromosome := make([]float64, len(b.Lower))
+	for i := range chromosome {
+		chromosome[i] = b.Lower[i] + rand.Float64()*(b.Upper[i]-b.Lower[i])
+	}
+	return chromosome
+}
+
+// Repair brings every out-of-bounds gene of the chromosome back into the bounds in place,
+// using the given boundary handling strategy. Genes beyond the dimension of the bounds are left untouched.
+func (b *Bounds) Repair(chromosome []float64, handling BoundaryHandling) {
+	n := min(len(chromosome), len(b.Lower))
+	for i := 0; i < n; i++ {
+		lo, hi := b.Lower[i], b.Upper[i]
+		x := chromosome[i]
+		if x >= lo && x <= hi {
+			continue
+		}
+		width := hi - lo
+		if width == 0 {
+			chromosome[i] = lo
+			continue
+		}
+
+		switch handling {
+		case BoundaryReflect:
+			// Reflect back and forth within a period of twice the width
+			offset := math.Mod(x-lo, 2*width)
+			if offset < 0 {
+				offset += 2 * width
+			}
+			if offset > width {
+				offset = 2*width - offset
+			}
+			chromosome[i] = lo + offset
+		case BoundaryWrap:
+			offset := math.Mod(x-lo, width)
+			if offset < 0 {
+				offset += width
+			}
+			chromosome[i] = lo + offset
+		case BoundaryResample:
+			chromosome[i] = lo + rand.Float64()*width
+		default:
+			chromosome[i] = math.Max(lo, math.Min(hi, x))
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBounds(t *testing.T) {
+	t.Run("valid bounds are copied", func(t *testing.T) {
+		lower := []float64{-1, 0}
+		upper := []float64{1, 0}
+
+		bounds, err := NewBounds(lower, upper)
+		require.NoError(t, err)
+		lower[0] = 100
+		assert.Equal(t, []float64{-1, 0}, bounds.Lower)
+		assert.Equal(t, 2, bounds.Dimension())
+	})
+
+	t.Run("invalid bounds return ErrInvalidBounds", func(t *testing.T) {
+		testCases := []struct {
+			name         string
+			lower, upper []float64
+		}{
+			{"empty", nil, nil},
+			{"length mismatch", []float64{0}, []float64{1, 2}},
+			{"inverted", []float64{1}, []float64{0}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewBounds(tc.lower, tc.upper)
+				assert.ErrorIs(t, err, ErrInvalidBounds)
+			})
+		}
+	})
+
+	t.Run("uniform bounds", func(t *testing.T) {
+		bounds, err := NewUniformBounds(3, -2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{-2, -2, -2}, bounds.Lower)
+		assert.Equal(t, []float64{2, 2, 2}, bounds.Upper)
+
+		_, err = NewUniformBounds(0, -2, 2)
+		assert.ErrorIs(t, err, ErrInvalidBounds)
+	})
+}
+
+func TestBounds_SampleAndContains(t *testing.T) {
+	bounds, err := NewBounds([]float64{-1, 10}, []float64{1, 20})
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, bounds.Contains(bounds.Sample()))
+	}
+	assert.False(t, bounds.Contains([]float64{0, 25}))
+	assert.False(t, bounds.Contains([]float64{0}))
+}
+
+func TestBounds_Repair(t *testing.T) {
+	bounds, err := NewUniformBounds(4, 0, 10)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		handling BoundaryHandling
+		expected []float64
+	}{
+		{BoundaryClip, []float64{0, 10, 5, 0}},
+		{BoundaryReflect, []float64{2, 7, 5, 5}},
+		{BoundaryWrap, []float64{8, 3, 5, 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.handling.String(), func(t *testing.T) {
+			chromosome := []float64{-2, 13, 5, -25}
+			bounds.Repair(chromosome, tc.handling)
+			for i := range chromosome {
+				assert.InDelta(t, tc.expected[i], chromosome[i], 1e-12)
+			}
+		})
+	}
+
+	t.Run("resample", func(t *testing.T) {
+		chromosome := []float64{-2, 13, 5, -25}
+		bounds.Repair(chromosome, BoundaryResample)
+		assert.True(t, bounds.Contains(chromosome))
+		assert.Equal(t, 5.0, chromosome[2])
+	})
+}
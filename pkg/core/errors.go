@@ -49,4 +49,12 @@ var (
 	// ErrPopulationEmpty indicates that a population has no individuals.
 	// This error occurs when trying to perform operations on an empty population.
 	ErrPopulationEmpty = errors.New("population is empty")
+
+	// ErrInvalidBounds indicates that the bounds of a search space are inconsistent.
+	// This error occurs when lower and upper bounds differ in length or a lower bound exceeds its upper bound.
+	ErrInvalidBounds = errors.New("invalid bounds")
+
+	// ErrInvalidLengthLimits indicates that the length limits of variable-length chromosomes are inconsistent.
+	// This error occurs when a minimum length is negative or exceeds the maximum length.
+	ErrInvalidLengthLimits = errors.New("invalid length limits")
 )
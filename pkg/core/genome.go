@@ -0,0 +1,83 @@
+// Package core provides data structures and interfaces for genetic algorithm solutions.
+package core
+
+import (
+	"hash/maphash"
+	"reflect"
+	"slices"
+)
+
+// Genome describes the operations the library needs on a chromosome representation G.
+// Executors, operators and evaluators are generic over G, so any representation works as
+// long as a Genome for it is provided: slices of genes, packed bitsets, structs, trees, ...
+type Genome[G any] interface {
+	// Clone returns a deep copy of the chromosome.
+	Clone(chromosome G) G
+	// Len returns the number of genes of the chromosome.
+	Len(chromosome G) int
+	// Equal reports whether two chromosomes hold the same genes.
+	Equal(a, b G) bool
+	// Hash returns a hash of the chromosome. Equal chromosomes must have equal hashes.
+	// Hashes are only stable within a single process.
+	Hash(chromosome G) uint64
+}
+
+// Cloner is implemented by chromosome types that know how to deep copy themselves.
+// Individual.DeepCopy uses it for chromosomes other than slices; Individual.Clone copies
+// with the Genome instead.
+type Cloner[G any] interface {
+	Clone() G
+}
+
+// hashSeed seeds the hashes of every Genome of the package.
+var hashSeed = maphash.MakeSeed()
+
+// SliceGenome implements Genome for chromosomes represented as slices of comparable genes.
+// It is the genome used by GeneticAlgorithmExecutor and all []T operators of the library.
+type SliceGenome[T comparable] struct{}
+
+// NewSliceGenome creates a SliceGenome.
+func NewSliceGenome[T comparable]() SliceGenome[T] {
+	return SliceGenome[T]{}
+}
+
+// Clone implements Genome. Cloning a nil chromosome returns an empty, non-nil one.
+func (SliceGenome[T]) Clone(chromosome []T) []T {
+	return append([]T{}, chromosome...)
+}
+
+// Len implements Genome.
+func (SliceGenome[T]) Len(chromosome []T) int {
+	return len(chromosome)
+}
+
+// Equal implements Genome.
+func (SliceGenome[T]) Equal(a, b []T) bool {
+	return slices.Equal(a, b)
+}
+
+// Hash implements Genome.
+func (SliceGenome[T]) Hash(chromosome []T) uint64 {
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	for _, gene := range chromosome {
+		maphash.WriteComparable(&h, gene)
+	}
+	return h.Sum64()
+}
+
+// cloneChromosome deep copies a chromosome without knowing its Genome. Chromosomes implementing
+// Cloner are cloned by their Clone method, slices are copied element by element and any other
+// value is copied by assignment.
+func cloneChromosome[G any](chromosome G) G {
+	if cloner, ok := any(chromosome).(Cloner[G]); ok {
+		return cloner.Clone()
+	}
+	value := reflect.ValueOf(chromosome)
+	if value.Kind() != reflect.Slice {
+		return chromosome
+	}
+	clone := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+	reflect.Copy(clone, value)
+	return clone.Interface().(G)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bitset is a chromosome type implementing Cloner.
+type bitset struct {
+	words []uint64
+}
+
+func (b bitset) Clone() bitset {
+	return bitset{words: append([]uint64{}, b.words...)}
+}
+
+// point is a chromosome type copied by assignment.
+type point struct {
+	X, Y int
+}
+
+// route is a chromosome type holding a slice without implementing Cloner.
+type route struct {
+	Stops []int
+}
+
+// routeGenome implements Genome for route.
+type routeGenome struct{}
+
+func (routeGenome) Clone(r route) route   { return route{Stops: append([]int{}, r.Stops...)} }
+func (routeGenome) Len(r route) int       { return len(r.Stops) }
+func (routeGenome) Equal(a, b route) bool { return NewSliceGenome[int]().Equal(a.Stops, b.Stops) }
+func (routeGenome) Hash(r route) uint64   { return NewSliceGenome[int]().Hash(r.Stops) }
+
+func TestSliceGenome(t *testing.T) {
+	genome := NewSliceGenome[string]()
+
+	t.Run("clone is independent of the original", func(t *testing.T) {
+		original := []string{"a", "b"}
+		clone := genome.Clone(original)
+		clone[0] = "z"
+		assert.Equal(t, []string{"a", "b"}, original)
+		assert.Equal(t, []string{}, genome.Clone(nil))
+	})
+
+	t.Run("len", func(t *testing.T) {
+		assert.Equal(t, 2, genome.Len([]string{"a", "b"}))
+		assert.Equal(t, 0, genome.Len(nil))
+	})
+
+	t.Run("equal chromosomes have equal hashes", func(t *testing.T) {
+		a, b, c := []string{"a", "b"}, []string{"a", "b"}, []string{"b", "a"}
+		assert.True(t, genome.Equal(a, b))
+		assert.False(t, genome.Equal(a, c))
+		assert.Equal(t, genome.Hash(a), genome.Hash(b))
+		assert.NotEqual(t, genome.Hash(a), genome.Hash(c))
+	})
+
+	t.Run("works with struct genes", func(t *testing.T) {
+		points := NewSliceGenome[point]()
+		assert.Equal(t, points.Hash([]point{{1, 2}}), points.Hash([]point{{1, 2}}))
+		assert.NotEqual(t, points.Hash([]point{{1, 2}}), points.Hash([]point{{2, 1}}))
+	})
+}
+
+func TestIndividual_DeepCopy(t *testing.T) {
+	t.Run("slice chromosome", func(t *testing.T) {
+		original := &Solution[int]{Chromosome: []int{1, 2, 3}, Fitness: 4}
+		clone := original.DeepCopy()
+		clone.Chromosome[0] = 9
+		assert.Equal(t, []int{1, 2, 3}, original.Chromosome)
+		assert.Equal(t, 4.0, clone.Fitness)
+	})
+
+	t.Run("cloner chromosome", func(t *testing.T) {
+		original := &Individual[bitset]{Chromosome: bitset{words: []uint64{1}}, Fitness: 2}
+		clone := original.DeepCopy()
+		clone.Chromosome.words[0] = 0
+		assert.Equal(t, []uint64{1}, original.Chromosome.words)
+		assert.Equal(t, 2.0, clone.Fitness)
+	})
+
+	t.Run("value chromosome", func(t *testing.T) {
+		original := &Individual[point]{Chromosome: point{1, 2}, Fitness: 3}
+		clone := original.DeepCopy()
+		clone.Chromosome.X = 5
+		assert.Equal(t, point{1, 2}, original.Chromosome)
+	})
+
+	t.Run("best solution of generic population", func(t *testing.T) {
+		population := &GenomePopulation[point]{Individuals: []Individual[point]{
+			{Chromosome: point{1, 1}, Fitness: 1},
+			{Chromosome: point{2, 2}, Fitness: 5},
+		}}
+		best, err := population.BestSolution()
+		assert.NoError(t, err)
+		assert.Equal(t, point{2, 2}, best.Chromosome)
+	})
+}
+
+func TestIndividual_Clone(t *testing.T) {
+	original := &Individual[route]{Chromosome: route{Stops: []int{1, 2, 3}}, Fitness: 4, Violation: 1}
+
+	t.Run("copies the chromosome with the genome", func(t *testing.T) {
+		clone := original.Clone(routeGenome{})
+		clone.Chromosome.Stops[0] = 9
+		assert.Equal(t, []int{1, 2, 3}, original.Chromosome.Stops)
+		assert.Equal(t, 4.0, clone.Fitness)
+		assert.Equal(t, 1.0, clone.Violation)
+	})
+
+	t.Run("without a genome falls back to DeepCopy", func(t *testing.T) {
+		clone := original.Clone(nil)
+		assert.Same(t, &original.Chromosome.Stops[0], &clone.Chromosome.Stops[0])
+	})
+}
@@ -0,0 +1,163 @@
+// Package core provides data structures and interfaces for genetic algorithm solutions.
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// HallOfFameEntry is a solution kept by a HallOfFame.
+type HallOfFameEntry[G any] struct {
+	// Individual is a copy of the solution.
+	Individual Individual[G] `json:"individual"`
+	// Generation is the generation in which the solution was first seen.
+	Generation int `json:"generation"`
+}
+
+// HallOfFame keeps the best distinct solutions seen during a run. Feasible solutions rank above
+// infeasible ones; feasible solutions are ranked by fitness and infeasible ones by violation.
+// A solution whose chromosome is already in the hall of fame is ignored, so every entry holds a
+// different chromosome. It is safe for concurrent use, so it can be read while a run progresses.
+type HallOfFame[G any] struct {
+	mu       sync.RWMutex
+	genome   Genome[G]
+	capacity int
+	entries  []HallOfFameEntry[G]
+	hashes   []uint64
+}
+
+// NewHallOfFame creates a hall of fame keeping the capacity best distinct solutions.
+// The genome is used to copy chromosomes and to tell whether two chromosomes are equal.
+func NewHallOfFame[G any](genome Genome[G], capacity int) (*HallOfFame[G], error) {
+	if genome == nil {
+		return nil, errors.New("hall of fame requires a genome")
+	}
+	if capacity < 1 {
+		return nil, fmt.Errorf("hall of fame capacity must be positive, but was %d", capacity)
+	}
+	return &HallOfFame[G]{genome: genome, capacity: capacity}, nil
+}
+
+// Capacity returns the maximum number of entries.
+func (h *HallOfFame[G]) Capacity() int {
+	return h.capacity
+}
+
+// Update offers every individual of the evaluated population to the hall of fame and reports
+// whether any entry was added.
+func (h *HallOfFame[G]) Update(population *GenomePopulation[G], generation int) bool {
+	if population == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	changed := false
+	for i := range population.Individuals {
+		if h.insert(&population.Individuals[i], generation) {
+			changed = true
+		}
+	}
+	return changed
+}
+
+// insert adds a copy of the individual if it ranks among the best and is not yet present.
+func (h *HallOfFame[G]) insert(individual *Individual[G], generation int) bool {
+	if len(h.entries) == h.capacity && !individual.RanksAbove(&h.entries[len(h.entries)-1].Individual) {
+		return false
+	}
+	hash := h.genome.Hash(individual.Chromosome)
+	for i := range h.entries {
+		if h.hashes[i] == hash && h.genome.Equal(h.entries[i].Individual.Chromosome, individual.Chromosome) {
+			return false
+		}
+	}
+
+	position := len(h.entries)
+	for position > 0 && individual.RanksAbove(&h.entries[position-1].Individual) {
+		position--
+	}
+	entry := HallOfFameEntry[G]{
+		Individual: Individual[G]{Chromosome: h.genome.Clone(individual.Chromosome), Fitness: individual.Fitness, Violation: individual.Violation},
+		Generation: generation,
+	}
+	h.entries = append(h.entries[:position], append([]HallOfFameEntry[G]{entry}, h.entries[position:]...)...)
+	h.hashes = append(h.hashes[:position], append([]uint64{hash}, h.hashes[position:]...)...)
+	if len(h.entries) > h.capacity {
+		h.entries = h.entries[:h.capacity]
+		h.hashes = h.hashes[:h.capacity]
+	}
+	return true
+}
+
+// Entries returns copies of the entries, best first.
+func (h *HallOfFame[G]) Entries() []HallOfFameEntry[G] {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	entries := make([]HallOfFameEntry[G], len(h.entries))
+	for i, entry := range h.entries {
+		entry.Individual.Chromosome = h.genome.Clone(entry.Individual.Chromosome)
+		entries[i] = entry
+	}
+	return entries
+}
+
+// Best returns a copy of the best entry. The boolean is false if the hall of fame is empty.
+func (h *HallOfFame[G]) Best() (HallOfFameEntry[G], bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.entries) == 0 {
+		return HallOfFameEntry[G]{}, false
+	}
+	best := h.entries[0]
+	best.Individual.Chromosome = h.genome.Clone(best.Individual.Chromosome)
+	return best, true
+}
+
+// Len returns the number of entries.
+func (h *HallOfFame[G]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.entries)
+}
+
+// hallOfFameJSON is the serialized form of a HallOfFame.
+type hallOfFameJSON[G any] struct {
+	Capacity int                  `json:"capacity"`
+	Entries  []HallOfFameEntry[G] `json:"entries"`
+}
+
+// MarshalJSON implements json.Marshaler, so the hall of fame can be stored with a checkpoint.
+// The chromosomes must be JSON serializable.
+func (h *HallOfFame[G]) MarshalJSON() ([]byte, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return json.Marshal(hallOfFameJSON[G]{Capacity: h.capacity, Entries: h.entries})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It restores the entries into a hall of fame
+// created by NewHallOfFame, which provides the genome; the serialized capacity replaces the
+// current one. Entries are re-ranked and deduplicated while being restored.
+func (h *HallOfFame[G]) UnmarshalJSON(data []byte) error {
+	if h.genome == nil {
+		return errors.New("hall of fame must be created by NewHallOfFame before being restored")
+	}
+	var decoded hallOfFameJSON[G]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Capacity < 1 {
+		return fmt.Errorf("hall of fame capacity must be positive, but was %d", decoded.Capacity)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.capacity = decoded.Capacity
+	h.entries, h.hashes = nil, nil
+	for i := range decoded.Entries {
+		h.insert(&decoded.Entries[i].Individual, decoded.Entries[i].Generation)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHallOfFame(t *testing.T) {
+	genome := NewSliceGenome[int]()
+
+	t.Run("keeps the best distinct solutions with their generation", func(t *testing.T) {
+		hof, err := NewHallOfFame[[]int](genome, 3)
+		require.NoError(t, err)
+
+		assert.True(t, hof.Update(&Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{1}, Fitness: 1},
+			{Chromosome: []int{2}, Fitness: 5},
+			{Chromosome: []int{2}, Fitness: 5},
+		}}, 0))
+		assert.True(t, hof.Update(&Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{3}, Fitness: 3},
+			{Chromosome: []int{4}, Fitness: 0},
+			{Chromosome: []int{2}, Fitness: 5},
+		}}, 1))
+		assert.False(t, hof.Update(&Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{5}, Fitness: 0.5},
+		}}, 2))
+
+		entries := hof.Entries()
+		require.Len(t, entries, 3)
+		assert.Equal(t, []int{2}, entries[0].Individual.Chromosome)
+		assert.Equal(t, 0, entries[0].Generation)
+		assert.Equal(t, []int{3}, entries[1].Individual.Chromosome)
+		assert.Equal(t, 1, entries[1].Generation)
+		assert.Equal(t, []int{1}, entries[2].Individual.Chromosome)
+
+		best, ok := hof.Best()
+		require.True(t, ok)
+		assert.Equal(t, 5.0, best.Individual.Fitness)
+	})
+
+	t.Run("entries do not share chromosomes with the population", func(t *testing.T) {
+		hof, err := NewHallOfFame[[]int](genome, 1)
+		require.NoError(t, err)
+		population := &Population[int]{Individuals: []Solution[int]{{Chromosome: []int{7}, Fitness: 1}}}
+		hof.Update(population, 0)
+		population.Individuals[0].Chromosome[0] = 8
+
+		entries := hof.Entries()
+		assert.Equal(t, []int{7}, entries[0].Individual.Chromosome)
+		entries[0].Individual.Chromosome[0] = 9
+		best, _ := hof.Best()
+		assert.Equal(t, []int{7}, best.Individual.Chromosome)
+	})
+
+	t.Run("feasible solutions rank above infeasible ones", func(t *testing.T) {
+		hof, err := NewHallOfFame[[]int](genome, 2)
+		require.NoError(t, err)
+		hof.Update(&Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{1}, Fitness: 100, Violation: 2},
+			{Chromosome: []int{2}, Fitness: 1},
+			{Chromosome: []int{3}, Fitness: 50, Violation: 1},
+		}}, 0)
+		entries := hof.Entries()
+		assert.Equal(t, []int{2}, entries[0].Individual.Chromosome)
+		assert.Equal(t, []int{3}, entries[1].Individual.Chromosome)
+	})
+
+	t.Run("round trips through JSON", func(t *testing.T) {
+		hof, err := NewHallOfFame[[]int](genome, 2)
+		require.NoError(t, err)
+		hof.Update(&Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{1, 2}, Fitness: 3},
+			{Chromosome: []int{3, 4}, Fitness: 7},
+		}}, 4)
+
+		data, err := json.Marshal(hof)
+		require.NoError(t, err)
+
+		restored, err := NewHallOfFame[[]int](genome, 10)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, restored))
+		assert.Equal(t, 2, restored.Capacity())
+		assert.Equal(t, hof.Entries(), restored.Entries())
+	})
+
+	t.Run("invalid arguments return error", func(t *testing.T) {
+		_, err := NewHallOfFame[[]int](genome, 0)
+		assert.Error(t, err)
+		_, err = NewHallOfFame[[]int](nil, 1)
+		assert.Error(t, err)
+
+		var zero HallOfFame[[]int]
+		assert.Error(t, json.Unmarshal([]byte(`{"capacity":1}`), &zero))
+	})
+}
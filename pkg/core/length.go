@@ -0,0 +1,60 @@
+// Package core provides data structures and interfaces for genetic algorithm solutions.
+package core
+
+import "fmt"
+
+// LengthLimits constrains the length of variable-length chromosomes.
+// Operators changing the length of a chromosome never produce lengths outside the limits.
+type LengthLimits struct {
+	// Min is the smallest allowed length.
+	Min int
+	// Max is the largest allowed length. 0 means unlimited.
+	Max int
+}
+
+// NewLengthLimits creates length limits allowing lengths within [min, max].
+// A max of 0 leaves the length unbounded from above.
+// It returns an error wrapping ErrInvalidLengthLimits if min is negative or exceeds a non-zero max.
+func NewLengthLimits(min, max int) (LengthLimits, error) {
+	limits := LengthLimits{Min: min, Max: max}
+	if err := limits.Validate(); err != nil {
+		return LengthLimits{}, err
+	}
+	return limits, nil
+}
+
+// Validate checks that the limits are consistent.
+func (l LengthLimits) Validate() error {
+	if l.Min < 0 || l.Max < 0 || (l.Max > 0 && l.Min > l.Max) {
+		return fmt.Errorf("%w: length limits must satisfy 0 <= min <= max, but were [%d, %d]", ErrInvalidLengthLimits, l.Min, l.Max)
+	}
+	return nil
+}
+
+// Allows reports whether a chromosome of the given length satisfies the limits.
+func (l LengthLimits) Allows(length int) bool {
+	return length >= l.Min && (l.Max == 0 || length <= l.Max)
+}
+
+// String formats the limits as an interval, e.g. "[2, 10]" or "[2, unlimited]".
+func (l LengthLimits) String() string {
+	if l.Max == 0 {
+		return fmt.Sprintf("[%d, unlimited]", l.Min)
+	}
+	return fmt.Sprintf("[%d, %d]", l.Min, l.Max)
+}
+
+// CheckLengths checks that every chromosome of the population satisfies the limits.
+// It returns an *InvalidChromosomeError describing the first chromosome outside of the limits.
+func CheckLengths[T any](population *Population[T], limits LengthLimits) error {
+	if population == nil {
+		return nil
+	}
+	for i, individual := range population.Individuals {
+		if !limits.Allows(len(individual.Chromosome)) {
+			return NewInvalidChromosomeError(
+				fmt.Sprintf("chromosome %d has %d genes, outside of the supported lengths %s", i, len(individual.Chromosome), limits), nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLengthLimits(t *testing.T) {
+	t.Run("valid limits", func(t *testing.T) {
+		limits, err := NewLengthLimits(2, 5)
+		require.NoError(t, err)
+		assert.False(t, limits.Allows(1))
+		assert.True(t, limits.Allows(2))
+		assert.True(t, limits.Allows(5))
+		assert.False(t, limits.Allows(6))
+	})
+
+	t.Run("zero max is unlimited", func(t *testing.T) {
+		limits, err := NewLengthLimits(1, 0)
+		require.NoError(t, err)
+		assert.False(t, limits.Allows(0))
+		assert.True(t, limits.Allows(1_000_000))
+	})
+
+	t.Run("invalid limits", func(t *testing.T) {
+		for _, tc := range []struct{ min, max int }{{-1, 3}, {4, 3}, {0, -1}} {
+			_, err := NewLengthLimits(tc.min, tc.max)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrInvalidLengthLimits))
+		}
+	})
+}
+
+func TestCheckLengths(t *testing.T) {
+	population := &Population[int]{Individuals: []Solution[int]{{Chromosome: []int{1, 2}}, {Chromosome: []int{1, 2, 3, 4}}}}
+
+	t.Run("accepts chromosomes within the limits", func(t *testing.T) {
+		assert.NoError(t, CheckLengths(population, LengthLimits{Min: 2, Max: 4}))
+		assert.NoError(t, CheckLengths(population, LengthLimits{Min: 1}))
+		assert.NoError(t, CheckLengths[int](nil, LengthLimits{Min: 1}))
+	})
+
+	t.Run("reports the first chromosome outside of the limits", func(t *testing.T) {
+		err := CheckLengths(population, LengthLimits{Min: 3})
+		var invalid *InvalidChromosomeError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, "chromosome 0 has 2 genes, outside of the supported lengths [3, unlimited]", invalid.Message)
+
+		err = CheckLengths(population, LengthLimits{Min: 2, Max: 3})
+		assert.ErrorContains(t, err, "chromosome 1 has 4 genes, outside of the supported lengths [2, 3]")
+	})
+}
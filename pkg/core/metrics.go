@@ -0,0 +1,228 @@
+// Package core provides data structures and interfaces for genetic algorithm solutions.
+package core
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// MeanFitness returns the mean fitness of the population.
+// If the population is empty, it returns an error.
+func (p *GenomePopulation[G]) MeanFitness() (float64, error) {
+	if p == nil || len(p.Individuals) == 0 {
+		return 0, ErrPopulationEmpty
+	}
+	sum := 0.0
+	for _, individual := range p.Individuals {
+		sum += individual.Fitness
+	}
+	return sum / float64(len(p.Individuals)), nil
+}
+
+// FitnessVariance returns the population variance of the fitness.
+// If the population is empty, it returns an error.
+func (p *GenomePopulation[G]) FitnessVariance() (float64, error) {
+	mean, err := p.MeanFitness()
+	if err != nil {
+		return 0, err
+	}
+	variance := 0.0
+	for _, individual := range p.Individuals {
+		variance += (individual.Fitness - mean) * (individual.Fitness - mean)
+	}
+	return variance / float64(len(p.Individuals)), nil
+}
+
+// FitnessQuantiles returns the fitness quantiles of the population for every requested
+// probability within [0, 1], interpolating linearly between order statistics. The quantile
+// for 0.5 is the median. The fitness values are sorted once for all probabilities.
+func (p *GenomePopulation[G]) FitnessQuantiles(probabilities ...float64) ([]float64, error) {
+	if p == nil || len(p.Individuals) == 0 {
+		return nil, ErrPopulationEmpty
+	}
+	for _, probability := range probabilities {
+		if probability < 0 || probability > 1 || math.IsNaN(probability) {
+			return nil, fmt.Errorf("quantile probability must be within [0, 1], but was %g", probability)
+		}
+	}
+
+	sorted := make([]float64, len(p.Individuals))
+	for i, individual := range p.Individuals {
+		sorted[i] = individual.Fitness
+	}
+	slices.Sort(sorted)
+
+	quantiles := make([]float64, len(probabilities))
+	for i, probability := range probabilities {
+		position := probability * float64(len(sorted)-1)
+		lower := int(math.Floor(position))
+		upper := min(lower+1, len(sorted)-1)
+		quantiles[i] = sorted[lower] + (position-float64(lower))*(sorted[upper]-sorted[lower])
+	}
+	return quantiles, nil
+}
+
+// UniqueGenotypes returns the number of distinct chromosomes in the population. Chromosomes are
+// bucketed by their hash and compared with Equal, so hash collisions do not affect the count.
+func (p *GenomePopulation[G]) UniqueGenotypes(genome Genome[G]) int {
+	if p == nil {
+		return 0
+	}
+	buckets := make(map[uint64][]int, len(p.Individuals))
+	unique := 0
+	for i, individual := range p.Individuals {
+		hash := genome.Hash(individual.Chromosome)
+		duplicate := false
+		for _, j := range buckets[hash] {
+			if genome.Equal(individual.Chromosome, p.Individuals[j].Chromosome) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			buckets[hash] = append(buckets[hash], i)
+			unique++
+		}
+	}
+	return unique
+}
+
+// MeanPairwiseDistance returns the mean distance between two distinct individuals of the
+// population. If the population has more than maxPairs pairs, the mean is estimated from
+// maxPairs randomly sampled pairs, which keeps the cost bounded for large populations.
+// A maxPairs of 0 or less always considers every pair.
+func (p *GenomePopulation[G]) MeanPairwiseDistance(distance func(a, b G) float64, maxPairs int) (float64, error) {
+	if p == nil || len(p.Individuals) == 0 {
+		return 0, ErrPopulationEmpty
+	}
+	n := len(p.Individuals)
+	if n == 1 {
+		return 0, nil
+	}
+
+	pairs := n * (n - 1) / 2
+	sum := 0.0
+	if maxPairs <= 0 || pairs <= maxPairs {
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				sum += distance(p.Individuals[i].Chromosome, p.Individuals[j].Chromosome)
+			}
+		}
+		return sum / float64(pairs), nil
+	}
+
+	for range maxPairs {
+		i := rand.Intn(n)
+		j := rand.Intn(n - 1)
+		if j >= i {
+			j++
+		}
+		sum += distance(p.Individuals[i].Chromosome, p.Individuals[j].Chromosome)
+	}
+	return sum / float64(maxPairs), nil
+}
+
+// AlleleFrequencies returns the relative frequency of every allele found at the given position
+// among the individuals whose chromosome is long enough to have that position.
+func AlleleFrequencies[T comparable](population *Population[T], position int) (map[T]float64, error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil, ErrPopulationEmpty
+	}
+	counts := make(map[T]int)
+	for _, individual := range population.Individuals {
+		if position < len(individual.Chromosome) {
+			counts[individual.Chromosome[position]]++
+		}
+	}
+	return relative(counts), nil
+}
+
+// GeneEntropy returns the Shannon entropy in bits of the alleles found at the given position.
+// It is 0 if every individual has the same allele and log2(k) if k alleles are equally frequent.
+func GeneEntropy[T comparable](population *Population[T], position int) (float64, error) {
+	frequencies, err := AlleleFrequencies(population, position)
+	if err != nil {
+		return 0, err
+	}
+	return entropy(frequencies), nil
+}
+
+// MeanGeneEntropy returns the mean GeneEntropy over all positions up to the length of the
+// longest chromosome. Positions are processed in a single pass over the population.
+func MeanGeneEntropy[T comparable](population *Population[T]) (float64, error) {
+	counts, err := alleleCounts(population)
+	if err != nil || len(counts) == 0 {
+		return 0, err
+	}
+	sum := 0.0
+	for _, position := range counts {
+		sum += entropy(relative(position))
+	}
+	return sum / float64(len(counts)), nil
+}
+
+// ConvergenceRatio returns the fraction of positions which have converged, i.e. whose most
+// frequent allele is carried by at least the given share of the individuals (De Jong uses 0.95).
+// It approaches 1 as the population loses its diversity.
+func ConvergenceRatio[T comparable](population *Population[T], threshold float64) (float64, error) {
+	if threshold <= 0 || threshold > 1 {
+		return 0, fmt.Errorf("convergence threshold must be within (0, 1], but was %g", threshold)
+	}
+	counts, err := alleleCounts(population)
+	if err != nil || len(counts) == 0 {
+		return 0, err
+	}
+	converged := 0
+	for _, position := range counts {
+		for _, frequency := range relative(position) {
+			if frequency >= threshold {
+				converged++
+				break
+			}
+		}
+	}
+	return float64(converged) / float64(len(counts)), nil
+}
+
+// alleleCounts counts the alleles of every position of the population.
+func alleleCounts[T comparable](population *Population[T]) ([]map[T]int, error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil, ErrPopulationEmpty
+	}
+	var counts []map[T]int
+	for _, individual := range population.Individuals {
+		for position, gene := range individual.Chromosome {
+			if position == len(counts) {
+				counts = append(counts, make(map[T]int))
+			}
+			counts[position][gene]++
+		}
+	}
+	return counts, nil
+}
+
+// relative turns allele counts into relative frequencies.
+func relative[T comparable](counts map[T]int) map[T]float64 {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	frequencies := make(map[T]float64, len(counts))
+	for allele, count := range counts {
+		frequencies[allele] = float64(count) / float64(total)
+	}
+	return frequencies
+}
+
+// entropy returns the Shannon entropy in bits of a distribution.
+func entropy[T comparable](frequencies map[T]float64) float64 {
+	h := 0.0
+	for _, frequency := range frequencies {
+		if frequency > 0 {
+			h -= frequency * math.Log2(frequency)
+		}
+	}
+	return h
+}
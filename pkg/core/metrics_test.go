@@ -0,0 +1,133 @@
+package core
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fitnessPopulation creates a population of empty chromosomes with the given fitness values.
+func fitnessPopulation(fitness ...float64) *Population[int] {
+	individuals := make([]Solution[int], len(fitness))
+	for i, f := range fitness {
+		individuals[i] = Solution[int]{Chromosome: []int{i}, Fitness: f}
+	}
+	return &Population[int]{Individuals: individuals}
+}
+
+// genePopulation creates a population holding the given chromosomes.
+func genePopulation(chromosomes ...[]int) *Population[int] {
+	individuals := make([]Solution[int], len(chromosomes))
+	for i, chromosome := range chromosomes {
+		individuals[i] = Solution[int]{Chromosome: chromosome}
+	}
+	return &Population[int]{Individuals: individuals}
+}
+
+func TestPopulation_FitnessMetrics(t *testing.T) {
+	population := fitnessPopulation(4, 1, 3, 2)
+
+	t.Run("mean and variance", func(t *testing.T) {
+		mean, err := population.MeanFitness()
+		require.NoError(t, err)
+		assert.Equal(t, 2.5, mean)
+		variance, err := population.FitnessVariance()
+		require.NoError(t, err)
+		assert.Equal(t, 1.25, variance)
+	})
+
+	t.Run("quantiles interpolate between order statistics", func(t *testing.T) {
+		quantiles, err := population.FitnessQuantiles(0, 0.25, 0.5, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 1.75, 2.5, 4}, quantiles)
+		assert.Equal(t, 4.0, population.Individuals[0].Fitness, "population must not be reordered")
+
+		_, err = population.FitnessQuantiles(1.5)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty population returns error", func(t *testing.T) {
+		empty := &Population[int]{}
+		_, err := empty.MeanFitness()
+		assert.ErrorIs(t, err, ErrPopulationEmpty)
+		_, err = empty.FitnessVariance()
+		assert.ErrorIs(t, err, ErrPopulationEmpty)
+		_, err = empty.FitnessQuantiles(0.5)
+		assert.ErrorIs(t, err, ErrPopulationEmpty)
+	})
+}
+
+func TestPopulation_GenotypeMetrics(t *testing.T) {
+	population := genePopulation([]int{0, 1}, []int{0, 1}, []int{0, 0}, []int{1, 0})
+
+	t.Run("unique genotypes", func(t *testing.T) {
+		assert.Equal(t, 3, population.UniqueGenotypes(NewSliceGenome[int]()))
+	})
+
+	t.Run("allele frequencies and entropy", func(t *testing.T) {
+		frequencies, err := AlleleFrequencies(population, 0)
+		require.NoError(t, err)
+		assert.Equal(t, map[int]float64{0: 0.75, 1: 0.25}, frequencies)
+
+		entropy, err := GeneEntropy(population, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, entropy)
+
+		mean, err := MeanGeneEntropy(population)
+		require.NoError(t, err)
+		expected := (-(0.75*math.Log2(0.75) + 0.25*math.Log2(0.25)) + 1) / 2
+		assert.InDelta(t, expected, mean, 1e-12)
+	})
+
+	t.Run("convergence ratio", func(t *testing.T) {
+		ratio, err := ConvergenceRatio(population, 0.75)
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, ratio)
+
+		converged := genePopulation([]int{1, 2}, []int{1, 2})
+		ratio, err = ConvergenceRatio(converged, 0.95)
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, ratio)
+
+		_, err = ConvergenceRatio(population, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("mean pairwise distance", func(t *testing.T) {
+		hamming := func(a, b []int) float64 {
+			d := 0.0
+			for i := range a {
+				if a[i] != b[i] {
+					d++
+				}
+			}
+			return d
+		}
+		exact, err := population.MeanPairwiseDistance(hamming, 0)
+		require.NoError(t, err)
+		// Pairs: 0, 1, 2, 1, 2, 1
+		assert.Equal(t, 7.0/6.0, exact)
+
+		sampled, err := population.MeanPairwiseDistance(hamming, 3)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, sampled, 0.0)
+		assert.LessOrEqual(t, sampled, 2.0)
+	})
+}
+
+// BenchmarkPopulationMetrics measures the per-generation cost of the metrics on 100k individuals.
+func BenchmarkPopulationMetrics(b *testing.B) {
+	factory := NewPopulationFactory[int]()
+	population := factory.CreateRandomPopulation(100_000, 20, NewSolutionFactory[int](), func() int { return rand.Intn(3) }, nil)
+	genome := NewSliceGenome[int]()
+	b.ResetTimer()
+	for range b.N {
+		_, _ = population.FitnessQuantiles(0.25, 0.5, 0.75)
+		_ = population.UniqueGenotypes(genome)
+		_, _ = MeanGeneEntropy(population)
+		_, _ = population.MeanPairwiseDistance(func(a, b []int) float64 { return 0 }, 10_000)
+	}
+}
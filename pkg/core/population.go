@@ -1,26 +1,30 @@
 // Package core provides data structures and interfaces for genetic algorithm solutions.
 package core
 
-import "cmp"
-
-// Population represents a collection of solutions (individuals) in a genetic algorithm.
-// It contains multiple Solution instances that form the current generation.
-type Population[T cmp.Ordered] struct {
-	// Individuals is a slice of Solution instances that make up the population.
+// GenomePopulation represents a collection of individuals with chromosomes of an arbitrary
+// representation G. It contains multiple Individual instances that form the current generation.
+type GenomePopulation[G any] struct {
+	// Individuals is a slice of Individual instances that make up the population.
 	// Each individual represents a potential solution to the optimization problem.
-	Individuals []Solution[T]
+	Individuals []Individual[G]
 }
 
-// BestSolution finds and returns the individual with the highest fitness in the population.
+// Population represents a collection of solutions (individuals) in a genetic algorithm
+// whose chromosomes are slices of genes.
+type Population[T any] = GenomePopulation[[]T]
+
+// BestSolution finds and returns the best individual in the population: the feasible individual
+// with the highest fitness or, if no individual is feasible, the one with the smallest violation.
+// Without constraints, every individual is feasible and the best one has the highest fitness.
 // If the population is empty, it returns an error.
-func (p *Population[T]) BestSolution() (*Solution[T], error) {
+func (p *GenomePopulation[G]) BestSolution() (*Individual[G], error) {
 	if p == nil || len(p.Individuals) == 0 {
 		return nil, ErrPopulationEmpty
 	}
 
 	best := p.Individuals[0]
 	for i := 1; i < len(p.Individuals); i++ {
-		if p.Individuals[i].Fitness > best.Fitness {
+		if p.Individuals[i].RanksAbove(&best) {
 			best = p.Individuals[i]
 		}
 	}
@@ -30,7 +34,7 @@ func (p *Population[T]) BestSolution() (*Solution[T], error) {
 
 // BestFitness returns the fitness of the best individual in the population.
 // If the population is empty, it returns an error.
-func (p *Population[T]) BestFitness() (float64, error) {
+func (p *GenomePopulation[G]) BestFitness() (float64, error) {
 	bestSolution, err := p.BestSolution()
 	if err != nil {
 		return 0, err
@@ -39,12 +43,12 @@ func (p *Population[T]) BestFitness() (float64, error) {
 }
 
 // PopulationFactory provides factory methods for creating Population instances.
-// It supports generic types that implement the cmp.Ordered interface.
-type PopulationFactory[T cmp.Ordered] struct{}
+// It supports slices of genes of any type.
+type PopulationFactory[T any] struct{}
 
 // NewPopulationFactory creates and returns a new PopulationFactory instance.
 // The factory can create populations of the specified generic type T.
-func NewPopulationFactory[T cmp.Ordered]() *PopulationFactory[T] {
+func NewPopulationFactory[T any]() *PopulationFactory[T] {
 	return &PopulationFactory[T]{}
 }
 
@@ -196,3 +196,36 @@ func TestPopulation_WithDifferentTypes(t *testing.T) {
 		}
 	})
 }
+
+func TestGenomePopulation_BestSolution(t *testing.T) {
+	t.Run("prefers feasible individuals", func(t *testing.T) {
+		population := &Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{1}, Fitness: 3},
+			{Chromosome: []int{2}, Fitness: 9, Violation: 1},
+			{Chromosome: []int{3}, Fitness: 5},
+		}}
+
+		best, err := population.BestSolution()
+		require.NoError(t, err)
+		assert.Equal(t, []int{3}, best.Chromosome)
+		fitness, err := population.BestFitness()
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, fitness)
+	})
+
+	t.Run("prefers the smallest violation without feasible individuals", func(t *testing.T) {
+		population := &Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{1}, Fitness: 9, Violation: 2},
+			{Chromosome: []int{2}, Fitness: 1, Violation: 0.5},
+		}}
+
+		best, err := population.BestSolution()
+		require.NoError(t, err)
+		assert.Equal(t, []int{2}, best.Chromosome)
+	})
+
+	t.Run("empty population returns error", func(t *testing.T) {
+		_, err := (&Population[int]{}).BestSolution()
+		assert.ErrorIs(t, err, ErrPopulationEmpty)
+	})
+}
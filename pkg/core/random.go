@@ -0,0 +1,13 @@
+package core
+
+import "math/rand"
+
+// IRandomized is implemented by components which can draw their random numbers from a given
+// generator instead of the top-level functions of math/rand, e.g. to make a seeded run
+// reproducible. Executors pass their generator to every operator implementing it. Operators
+// used by several workers at once draw from the generator concurrently, so it must then be safe
+// for concurrent use.
+type IRandomized interface {
+	// SetRand sets the generator the component draws from. A nil generator restores math/rand.
+	SetRand(generator *rand.Rand)
+}
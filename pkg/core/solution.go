@@ -0,0 +1,144 @@
+// Package core provides data structures and interfaces for genetic algorithm solutions.
+package core
+
+// Individual represents a single solution in an evolutionary algorithm whose chromosome
+// is of an arbitrary representation G, see Genome.
+type Individual[G any] struct {
+	// Chromosome represents the genetic material of the solution.
+	Chromosome G
+	// Fitness represents the quality or performance of the solution.
+	// Higher values typically indicate better solutions.
+	Fitness float64
+	// Violation measures how much the solution violates the constraints of the problem.
+	// A value of 0 means the solution is feasible, larger values mean larger violations.
+	Violation float64
+}
+
+// Feasible reports whether the individual satisfies all constraints, i.e. has no violation.
+func (s *Individual[G]) Feasible() bool {
+	return s.Violation <= 0
+}
+
+// RanksAbove reports whether the individual ranks strictly above other by Deb's feasibility rules:
+// a feasible individual ranks above an infeasible one, two feasible individuals are ranked by
+// fitness and two infeasible ones by violation. Without constraints, it compares the fitness.
+func (s *Individual[G]) RanksAbove(other *Individual[G]) bool {
+	switch {
+	case s.Feasible() && other.Feasible():
+		return s.Fitness > other.Fitness
+	case s.Feasible() != other.Feasible():
+		return s.Feasible()
+	default:
+		return s.Violation < other.Violation
+	}
+}
+
+// DeepCopy creates a deep copy of the individual without knowing its Genome.
+// Slice chromosomes are copied element by element, chromosomes implementing Cloner are
+// copied with their Clone method and any other chromosome is copied by assignment, so
+// other representations holding references, e.g. structs with slices, must use Clone.
+//
+// Returns:
+//   - A pointer to the newly created Individual
+func (s *Individual[G]) DeepCopy() *Individual[G] {
+	return &Individual[G]{
+		Chromosome: cloneChromosome(s.Chromosome),
+		Fitness:    s.Fitness,
+		Violation:  s.Violation,
+	}
+}
+
+// Clone creates a deep copy of the individual whose chromosome is copied by the genome
+// describing it. A nil genome falls back to DeepCopy.
+//
+// Parameters:
+//   - genome: The genome of the chromosome, or nil
+//
+// Returns:
+//   - A pointer to the newly created Individual
+func (s *Individual[G]) Clone(genome Genome[G]) *Individual[G] {
+	if genome == nil {
+		return s.DeepCopy()
+	}
+	return &Individual[G]{
+		Chromosome: genome.Clone(s.Chromosome),
+		Fitness:    s.Fitness,
+		Violation:  s.Violation,
+	}
+}
+
+// Solution represents a single solution in a genetic algorithm.
+// It contains a chromosome (genetic material), a slice of genes, and its associated fitness value.
+type Solution[T any] = Individual[[]T]
+
+// SolutionFactory provides factory methods for creating Solution instances.
+// It supports slices of genes of any type.
+type SolutionFactory[T any] struct{}
+
+// NewSolutionFactory creates and returns a new SolutionFactory instance.
+// The factory can create solutions of the specified generic type T.
+func NewSolutionFactory[T any]() *SolutionFactory[T] {
+	return &SolutionFactory[T]{}
+}
+
+// CreateSolution creates a new Solution with the provided chromosome.
+// The fitness is initialized to 0.0 and should be calculated separately.
+//
+// Parameters:
+//   - chromosome: The genetic material for the new solution
+//
+// Returns:
+//   - A pointer to the newly created Solution
+func (sf *SolutionFactory[T]) CreateSolution(chromosome []T) *Solution[T] {
+	return &Solution[T]{
+		Chromosome: chromosome,
+		Fitness:    0.0,
+	}
+}
+
+// CreateRandomSolution creates a new Solution with a randomly generated chromosome.
+// The chromosome is populated using the provided random generator function.
+//
+// Parameters:
+//   - length: The desired length of the chromosome
+//   - randomGen: A function that generates random values of type T
+//
+// Returns:
+//   - A pointer to the newly created Solution with random chromosome
+func (sf *SolutionFactory[T]) CreateRandomSolution(length int, randomGen func() T) *Solution[T] {
+	chromosome := make([]T, length)
+	for i := 0; i < length; i++ {
+		chromosome[i] = randomGen()
+	}
+	return sf.CreateSolution(chromosome)
+}
+
+// CreateRandomSolutionByPosition creates a new Solution with a randomly generated chromosome
+// whose genes may follow different distributions. The generator receives the position of
+// the gene it generates, which allows heterogeneous chromosomes described by a schema.
+//
+// Parameters:
+//   - length: The desired length of the chromosome
+//   - randomGen: A function that generates a random value of type T for the given position
+//
+// Returns:
+//   - A pointer to the newly created Solution with random chromosome
+func (sf *SolutionFactory[T]) CreateRandomSolutionByPosition(length int, randomGen func(position int) T) *Solution[T] {
+	chromosome := make([]T, length)
+	for i := 0; i < length; i++ {
+		chromosome[i] = randomGen(i)
+	}
+	return sf.CreateSolution(chromosome)
+}
+
+// CreateEmptySolution creates a new Solution with an empty chromosome.
+// This is useful for initializing solutions that will be populated later.
+//
+// Returns:
+//   - A pointer to the newly created Solution with empty chromosome
+func (sf *SolutionFactory[T]) CreateEmptySolution() *Solution[T] {
+	return &Solution[T]{
+		Chromosome: []T{},
+		Fitness:    0.0,
+	}
+}
@@ -0,0 +1,20 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolutionFactory_CreateRandomSolutionByPosition(t *testing.T) {
+	factory := NewSolutionFactory[int]()
+
+	solution := factory.CreateRandomSolutionByPosition(4, func(position int) int {
+		return position * 10
+	})
+
+	require.NotNil(t, solution)
+	assert.Equal(t, []int{0, 10, 20, 30}, solution.Chromosome)
+	assert.Equal(t, 0.0, solution.Fitness)
+}
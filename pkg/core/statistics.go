@@ -0,0 +1,181 @@
+// Package core provides data structures and interfaces for genetic algorithm solutions.
+package core
+
+import (
+	"math"
+	"time"
+)
+
+// GenerationStatistics summarizes the fitness of a single generation of an optimization run.
+type GenerationStatistics struct {
+	// Generation is the zero-based index of the generation.
+	Generation int
+	// Evaluations is the cumulative number of fitness evaluations performed up to this generation.
+	Evaluations int
+	// BestFitness is the fitness of the best individual within the generation, see
+	// GenomePopulation.BestSolution. Without constraints, it is the highest fitness.
+	BestFitness float64
+	// MeanFitness is the mean fitness of the generation.
+	MeanFitness float64
+	// WorstFitness is the lowest fitness within the generation.
+	WorstFitness float64
+	// StdDevFitness is the standard deviation of the fitness within the generation.
+	StdDevFitness float64
+	// Infeasible reports whether no individual of the generation is feasible, see Individual.Feasible.
+	Infeasible bool
+	// UniqueGenotypes is the number of distinct chromosomes within the generation,
+	// or 0 if the optimizer does not record it.
+	UniqueGenotypes int
+	// Diversity is the value of the diversity measure configured on the optimizer, if any.
+	Diversity float64
+	// Restart is the number of restarts performed before this generation.
+	Restart int
+	// BestEverFitness is the best fitness seen in this or any previous generation. The best fitness
+	// of a feasible generation always improves on that of infeasible ones and never the other way round.
+	BestEverFitness float64
+	// Elapsed is the time passed since the start of the run.
+	Elapsed time.Duration
+}
+
+// NewGenerationStatistics computes the fitness statistics of a population.
+// BestEverFitness and Elapsed are filled in by Statistics.Record.
+// If the population is empty, it returns an error.
+func NewGenerationStatistics[G any](population *GenomePopulation[G], generation, evaluations int) (GenerationStatistics, error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return GenerationStatistics{}, ErrPopulationEmpty
+	}
+
+	best, err := population.BestSolution()
+	if err != nil {
+		return GenerationStatistics{}, err
+	}
+	worst := math.Inf(1)
+	sum := 0.0
+	for _, individual := range population.Individuals {
+		worst = math.Min(worst, individual.Fitness)
+		sum += individual.Fitness
+	}
+	mean := sum / float64(len(population.Individuals))
+
+	variance := 0.0
+	for _, individual := range population.Individuals {
+		variance += (individual.Fitness - mean) * (individual.Fitness - mean)
+	}
+	variance /= float64(len(population.Individuals))
+
+	return GenerationStatistics{
+		Generation:    generation,
+		Evaluations:   evaluations,
+		BestFitness:   best.Fitness,
+		MeanFitness:   mean,
+		WorstFitness:  worst,
+		StdDevFitness: math.Sqrt(variance),
+		Infeasible:    !best.Feasible(),
+	}, nil
+}
+
+// Restart describes a restart of an optimization run, see Statistics.RecordRestart.
+type Restart struct {
+	// Generation is the generation after which the run was restarted.
+	Generation int
+	// Evaluations is the cumulative number of fitness evaluations performed before the restart.
+	Evaluations int
+	// BestFitness is the best fitness of the population that was discarded.
+	BestFitness float64
+	// PopulationSize is the size of the population after the restart.
+	PopulationSize int
+}
+
+// Statistics accumulates the statistics of every generation of an optimization run.
+// It is shared by all optimizers of the library so that termination criteria and
+// observers can work with any of them.
+type Statistics struct {
+	// History holds the statistics of every recorded generation in order.
+	History []GenerationStatistics
+	// Restarts holds every restart of the run in order.
+	Restarts []Restart
+
+	start               time.Time
+	bestEver            float64
+	bestEverInfeasible  bool
+	lastImprovement     int
+	improvementRecorded bool
+}
+
+// NewStatistics creates an empty Statistics whose clock starts now.
+func NewStatistics() *Statistics {
+	return &Statistics{start: time.Now()}
+}
+
+// Record appends the statistics of a generation, filling in the best fitness seen so far
+// and the elapsed time. It returns the completed statistics.
+func (s *Statistics) Record(generation GenerationStatistics) GenerationStatistics {
+	if s.improves(generation) {
+		s.bestEver, s.bestEverInfeasible = generation.BestFitness, generation.Infeasible
+		s.lastImprovement = len(s.History)
+		s.improvementRecorded = true
+	}
+	generation.BestEverFitness = s.bestEver
+	generation.Restart = len(s.Restarts)
+	generation.Elapsed = time.Since(s.start)
+	s.History = append(s.History, generation)
+	return generation
+}
+
+// RestoreStatistics recreates the statistics of a run from its recorded history and restarts,
+// e.g. when resuming from a checkpoint. The best-ever fitness and stagnation are derived from
+// the history, and the elapsed time continues from the last recorded generation.
+func RestoreStatistics(history []GenerationStatistics, restarts []Restart) *Statistics {
+	statistics := &Statistics{History: history, Restarts: restarts, start: time.Now()}
+	for i, generation := range history {
+		if statistics.improves(generation) {
+			statistics.bestEver, statistics.bestEverInfeasible = generation.BestFitness, generation.Infeasible
+			statistics.lastImprovement = i
+			statistics.improvementRecorded = true
+		}
+	}
+	if len(history) > 0 {
+		statistics.start = statistics.start.Add(-history[len(history)-1].Elapsed)
+	}
+	return statistics
+}
+
+// improves reports whether the best fitness of generation improves on the best fitness seen so far.
+func (s *Statistics) improves(generation GenerationStatistics) bool {
+	switch {
+	case !s.improvementRecorded:
+		return true
+	case generation.Infeasible != s.bestEverInfeasible:
+		return !generation.Infeasible
+	default:
+		return generation.BestFitness > s.bestEver
+	}
+}
+
+// RecordRestart appends a restart. Generations recorded afterwards count it in their Restart field.
+func (s *Statistics) RecordRestart(restart Restart) {
+	s.Restarts = append(s.Restarts, restart)
+}
+
+// Last returns the statistics of the most recently recorded generation.
+// The boolean is false if no generation has been recorded yet.
+func (s *Statistics) Last() (GenerationStatistics, bool) {
+	if s == nil || len(s.History) == 0 {
+		return GenerationStatistics{}, false
+	}
+	return s.History[len(s.History)-1], true
+}
+
+// Elapsed returns the time passed since the statistics were created.
+func (s *Statistics) Elapsed() time.Duration {
+	return time.Since(s.start)
+}
+
+// GenerationsWithoutImprovement returns the number of generations recorded since
+// the best fitness seen so far last improved.
+func (s *Statistics) GenerationsWithoutImprovement() int {
+	if s == nil || len(s.History) == 0 {
+		return 0
+	}
+	return len(s.History) - 1 - s.lastImprovement
+}
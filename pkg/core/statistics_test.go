@@ -0,0 +1,137 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGenerationStatistics(t *testing.T) {
+	t.Run("computes fitness summary of population", func(t *testing.T) {
+		population := &Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{1}, Fitness: 2},
+			{Chromosome: []int{2}, Fitness: 4},
+			{Chromosome: []int{3}, Fitness: 4},
+			{Chromosome: []int{4}, Fitness: 4},
+			{Chromosome: []int{5}, Fitness: 5},
+			{Chromosome: []int{6}, Fitness: 5},
+			{Chromosome: []int{7}, Fitness: 7},
+			{Chromosome: []int{8}, Fitness: 9},
+		}}
+
+		statistics, err := NewGenerationStatistics(population, 3, 40)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, statistics.Generation)
+		assert.Equal(t, 40, statistics.Evaluations)
+		assert.Equal(t, 9.0, statistics.BestFitness)
+		assert.Equal(t, 5.0, statistics.MeanFitness)
+		assert.Equal(t, 2.0, statistics.WorstFitness)
+		assert.InDelta(t, 2.0, statistics.StdDevFitness, 1e-12)
+	})
+
+	t.Run("best fitness is that of the best feasible individual", func(t *testing.T) {
+		population := &Population[int]{Individuals: []Solution[int]{
+			{Chromosome: []int{1}, Fitness: 9, Violation: 1},
+			{Chromosome: []int{2}, Fitness: 3},
+			{Chromosome: []int{3}, Fitness: 6},
+		}}
+
+		statistics, err := NewGenerationStatistics(population, 0, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 6.0, statistics.BestFitness)
+		assert.False(t, statistics.Infeasible)
+
+		population.Individuals[1].Violation, population.Individuals[2].Violation = 0.5, 2
+		statistics, err = NewGenerationStatistics(population, 0, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 3.0, statistics.BestFitness)
+		assert.True(t, statistics.Infeasible)
+	})
+
+	t.Run("empty population returns error", func(t *testing.T) {
+		_, err := NewGenerationStatistics(&Population[int]{}, 0, 0)
+		assert.ErrorIs(t, err, ErrPopulationEmpty)
+
+		_, err = NewGenerationStatistics[int](nil, 0, 0)
+		assert.ErrorIs(t, err, ErrPopulationEmpty)
+	})
+}
+
+func TestStatistics_Record(t *testing.T) {
+	t.Run("tracks best ever fitness and stagnation", func(t *testing.T) {
+		statistics := NewStatistics()
+		assert.Equal(t, 0, statistics.GenerationsWithoutImprovement())
+		_, ok := statistics.Last()
+		assert.False(t, ok)
+
+		recorded := statistics.Record(GenerationStatistics{Generation: 0, BestFitness: 1})
+		assert.Equal(t, 1.0, recorded.BestEverFitness)
+		assert.Equal(t, 0, statistics.GenerationsWithoutImprovement())
+
+		statistics.Record(GenerationStatistics{Generation: 1, BestFitness: 3})
+		statistics.Record(GenerationStatistics{Generation: 2, BestFitness: 2})
+		recorded = statistics.Record(GenerationStatistics{Generation: 3, BestFitness: 3})
+
+		assert.Equal(t, 3.0, recorded.BestEverFitness)
+		assert.Equal(t, 2, statistics.GenerationsWithoutImprovement())
+		assert.Len(t, statistics.History, 4)
+
+		last, ok := statistics.Last()
+		require.True(t, ok)
+		assert.Equal(t, recorded, last)
+	})
+
+	t.Run("negative fitness is recorded as best ever", func(t *testing.T) {
+		statistics := NewStatistics()
+		recorded := statistics.Record(GenerationStatistics{BestFitness: -5})
+		assert.Equal(t, -5.0, recorded.BestEverFitness)
+	})
+
+	t.Run("feasible generations improve on infeasible ones", func(t *testing.T) {
+		statistics := NewStatistics()
+		statistics.Record(GenerationStatistics{Generation: 0, BestFitness: 8, Infeasible: true})
+		recorded := statistics.Record(GenerationStatistics{Generation: 1, BestFitness: 2})
+		assert.Equal(t, 2.0, recorded.BestEverFitness)
+		assert.Equal(t, 0, statistics.GenerationsWithoutImprovement())
+
+		recorded = statistics.Record(GenerationStatistics{Generation: 2, BestFitness: 9, Infeasible: true})
+		assert.Equal(t, 2.0, recorded.BestEverFitness)
+		assert.Equal(t, 1, statistics.GenerationsWithoutImprovement())
+	})
+
+	t.Run("restarts are counted by later generations", func(t *testing.T) {
+		statistics := NewStatistics()
+		assert.Equal(t, 0, statistics.Record(GenerationStatistics{Generation: 0}).Restart)
+		statistics.RecordRestart(Restart{Generation: 0, PopulationSize: 20})
+		assert.Equal(t, 1, statistics.Record(GenerationStatistics{Generation: 1}).Restart)
+		assert.Equal(t, []Restart{{Generation: 0, PopulationSize: 20}}, statistics.Restarts)
+	})
+}
+
+func TestRestoreStatistics(t *testing.T) {
+	history := []GenerationStatistics{
+		{Generation: 0, BestFitness: 1, Elapsed: time.Second},
+		{Generation: 1, BestFitness: 4, Elapsed: 2 * time.Second},
+		{Generation: 2, BestFitness: 3, Elapsed: 3 * time.Second},
+	}
+	restarts := []Restart{{Generation: 1}}
+
+	statistics := RestoreStatistics(history, restarts)
+
+	assert.Equal(t, history, statistics.History)
+	assert.Equal(t, restarts, statistics.Restarts)
+	assert.Equal(t, 1, statistics.GenerationsWithoutImprovement())
+	assert.GreaterOrEqual(t, statistics.Elapsed(), 3*time.Second)
+
+	recorded := statistics.Record(GenerationStatistics{Generation: 3, BestFitness: 2})
+	assert.Equal(t, 4.0, recorded.BestEverFitness)
+	assert.Equal(t, 1, recorded.Restart)
+	assert.Equal(t, 2, statistics.GenerationsWithoutImprovement())
+
+	empty := RestoreStatistics(nil, nil)
+	assert.Equal(t, 0, empty.GenerationsWithoutImprovement())
+	assert.Equal(t, 5.0, empty.Record(GenerationStatistics{BestFitness: 5}).BestEverFitness)
+}
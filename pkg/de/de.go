@@ -0,0 +1,528 @@
+// Package de provides a differential evolution optimizer for continuous problems.
+package de
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// Strategy selects how DE builds the mutant vector of every target vector.
+// All strategies use binomial crossover between the target and the mutant.
+type Strategy int
+
+const (
+	// RandOneBin builds v = x_r1 + F·(x_r2 - x_r3) from three random individuals (DE/rand/1/bin).
+	RandOneBin Strategy = iota
+	// BestOneBin builds v = x_best + F·(x_r1 - x_r2) around the best individual (DE/best/1/bin).
+	BestOneBin
+	// CurrentToBestOne builds v = x_i + F·(x_best - x_i) + F·(x_r1 - x_r2) (DE/current-to-best/1/bin).
+	CurrentToBestOne
+	// CurrentToPBestOne builds v = x_i + F·(x_pbest - x_i) + F·(x_r1 - x̃_r2), where x_pbest is one of the
+	// 100p% best individuals and x̃_r2 is drawn from the population and the archive of replaced parents,
+	// as used by JADE and SHADE (DE/current-to-pbest/1/bin).
+	CurrentToPBestOne
+)
+
+// String returns the conventional name of the strategy.
+func (s Strategy) String() string {
+	switch s {
+	case RandOneBin:
+		return "rand/1/bin"
+	case BestOneBin:
+		return "best/1/bin"
+	case CurrentToBestOne:
+		return "current-to-best/1/bin"
+	case CurrentToPBestOne:
+		return "current-to-pbest/1/bin"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// Adaptation selects how the scale factor F and crossover rate CR are controlled.
+type Adaptation int
+
+const (
+	// NoAdaptation uses the constant Config.F and Config.CR for every individual.
+	NoAdaptation Adaptation = iota
+	// JADE samples F and CR per individual around means adapted from the successful values.
+	JADE
+	// SHADE samples F and CR per individual around a historical memory of successful means,
+	// weighted by the fitness improvement they produced.
+	SHADE
+)
+
+// String returns the name of the adaptation scheme.
+func (a Adaptation) String() string {
+	switch a {
+	case NoAdaptation:
+		return "none"
+	case JADE:
+		return "JADE"
+	case SHADE:
+		return "SHADE"
+	default:
+		return fmt.Sprintf("Adaptation(%d)", int(a))
+	}
+}
+
+// Config holds the parameters of a differential evolution run.
+type Config struct {
+	// Bounds delimit the search space; the initial population is sampled uniformly within them
+	// and trial vectors are repaired with BoundaryHandling.
+	Bounds *core.Bounds
+	// BoundaryHandling selects how trial vectors leaving Bounds are repaired.
+	BoundaryHandling core.BoundaryHandling
+	// PopulationSize is the number of individuals. 0 selects 10 times the dimension.
+	PopulationSize int
+	// Strategy selects the mutation strategy.
+	Strategy Strategy
+	// Adaptation selects the parameter control scheme.
+	Adaptation Adaptation
+	// F is the scale factor, or the initial mean of F under adaptation.
+	F float64
+	// CR is the crossover rate, or the initial mean of CR under adaptation.
+	CR float64
+	// P is the fraction of best individuals CurrentToPBestOne draws x_pbest from. 0 selects 0.1;
+	// SHADE samples it per individual from [2/NP, 0.2] instead.
+	P float64
+	// LearningRate is JADE's adaptation rate c of the means of F and CR. 0 selects 0.1.
+	LearningRate float64
+	// MemorySize is the number of SHADE memory slots H. 0 selects the population size.
+	MemorySize int
+	// MaxGenerations bounds the number of generations. 0 means unlimited.
+	MaxGenerations int
+	// MaxEvaluations bounds the number of fitness evaluations. 0 means unlimited.
+	MaxEvaluations int
+	// TargetFitness stops the optimization once a solution reaches the given fitness.
+	TargetFitness *float64
+	// NumWorkers limits the number of concurrent fitness evaluations. It must be positive or -1 for unlimited.
+	NumWorkers int
+}
+
+// DefaultConfig returns a DE/rand/1/bin configuration with F=0.5 and CR=0.9 within the given bounds.
+func DefaultConfig(bounds *core.Bounds) Config {
+	return Config{
+		Bounds:         bounds,
+		Strategy:       RandOneBin,
+		F:              0.5,
+		CR:             0.9,
+		MaxGenerations: 1000,
+		NumWorkers:     1,
+	}
+}
+
+// Optimizer implements differential evolution with the classic strategies and JADE/SHADE
+// parameter adaptation. It maximizes the fitness returned by the evaluator and evaluates
+// every generation of trial vectors in parallel using fitness.EvaluatePopulation.
+type Optimizer struct {
+	fitnessEvaluator fitness.IFitnessEvaluator[float64]
+	config           Config
+
+	population  *core.Population[float64]
+	archive     [][]float64
+	best        *core.Solution[float64]
+	evaluations int
+	generations int
+
+	// Adaptation state: JADE means, SHADE memories
+	meanF, meanCR       float64
+	memoryF, memoryCR   []float64
+	memoryIndex         int
+	successF, successCR []float64
+	improvements        []float64
+}
+
+// NewOptimizer creates a new differential evolution optimizer for the given evaluator and configuration.
+func NewOptimizer(fitnessEvaluator fitness.IFitnessEvaluator[float64], config Config) (*Optimizer, error) {
+	if fitnessEvaluator == nil {
+		return nil, NewDEError("invalid fitness evaluator", fmt.Errorf("fitness evaluator cannot be nil"))
+	}
+	if config.Bounds == nil || config.Bounds.Dimension() == 0 {
+		return nil, NewDEError("invalid bounds", core.ErrInvalidBounds)
+	}
+	if config.PopulationSize == 0 {
+		config.PopulationSize = 10 * config.Bounds.Dimension()
+	}
+	if config.PopulationSize < 4 {
+		return nil, NewDEError("invalid population size", fmt.Errorf("population size must be at least 4, but was %d", config.PopulationSize))
+	}
+	if config.F <= 0 || config.F > 2 {
+		return nil, NewDEError("invalid scale factor", fmt.Errorf("F must be in (0, 2], but was %g", config.F))
+	}
+	if config.CR < 0 || config.CR > 1 {
+		return nil, NewDEError("invalid crossover rate", fmt.Errorf("CR must be in [0, 1], but was %g", config.CR))
+	}
+	if config.P == 0 {
+		config.P = 0.1
+	}
+	if config.P < 0 || config.P > 1 {
+		return nil, NewDEError("invalid p", fmt.Errorf("p must be in (0, 1], but was %g", config.P))
+	}
+	if config.LearningRate == 0 {
+		config.LearningRate = 0.1
+	}
+	if config.MemorySize == 0 {
+		config.MemorySize = config.PopulationSize
+	}
+	if config.MaxGenerations < 0 || config.MaxEvaluations < 0 || config.MemorySize < 0 {
+		return nil, NewDEError("invalid configuration", fmt.Errorf("budgets and memory size cannot be negative"))
+	}
+	if config.NumWorkers == 0 || config.NumWorkers < -1 {
+		return nil, NewDEError("invalid number of workers", fmt.Errorf("number of workers must be positive or -1 for unlimited, but was %d", config.NumWorkers))
+	}
+	if config.MaxGenerations == 0 && config.MaxEvaluations == 0 && config.TargetFitness == nil {
+		return nil, NewDEError("invalid configuration", fmt.Errorf("at least one of max generations, max evaluations or target fitness must be set"))
+	}
+
+	return &Optimizer{
+		fitnessEvaluator: fitnessEvaluator,
+		config:           config,
+	}, nil
+}
+
+// BestSolution returns the best solution found so far, or nil before Optimize was called.
+func (o *Optimizer) BestSolution() *core.Solution[float64] {
+	return o.best
+}
+
+// Evaluations returns the number of fitness evaluations performed so far.
+func (o *Optimizer) Evaluations() int {
+	return o.evaluations
+}
+
+// Generations returns the number of generations performed so far.
+func (o *Optimizer) Generations() int {
+	return o.generations
+}
+
+// Optimize runs differential evolution until the generation or evaluation budget is exhausted
+// or the target fitness is reached, and returns the final population.
+func (o *Optimizer) Optimize(ctx context.Context) (*core.Population[float64], error) {
+	np := o.config.PopulationSize
+	o.reset()
+
+	individuals := make([]core.Solution[float64], np)
+	for i := range individuals {
+		individuals[i] = core.Solution[float64]{Chromosome: o.config.Bounds.Sample()}
+	}
+	o.population = &core.Population[float64]{Individuals: individuals}
+	if err := o.evaluate(ctx, o.population); err != nil {
+		return nil, err
+	}
+
+	for !o.done() {
+		if ctx.Err() != nil {
+			return nil, NewDEError("context cancelled", ctx.Err())
+		}
+		if err := o.step(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return o.population, nil
+}
+
+// reset clears the state of a previous run and initializes the adaptation state.
+func (o *Optimizer) reset() {
+	o.archive = o.archive[:0]
+	o.best = nil
+	o.evaluations, o.generations = 0, 0
+	o.meanF, o.meanCR = o.config.F, o.config.CR
+	o.memoryF = make([]float64, o.config.MemorySize)
+	o.memoryCR = make([]float64, o.config.MemorySize)
+	for k := range o.memoryF {
+		o.memoryF[k] = o.config.F
+		o.memoryCR[k] = o.config.CR
+	}
+	o.memoryIndex = 0
+}
+
+// done reports whether any stopping criterion is met.
+func (o *Optimizer) done() bool {
+	if o.config.MaxGenerations > 0 && o.generations >= o.config.MaxGenerations {
+		return true
+	}
+	if o.config.MaxEvaluations > 0 && o.evaluations >= o.config.MaxEvaluations {
+		return true
+	}
+	return o.config.TargetFitness != nil && o.best != nil && o.best.Fitness >= *o.config.TargetFitness
+}
+
+// step performs one generation: mutation, crossover, parallel evaluation and one-to-one selection.
+func (o *Optimizer) step(ctx context.Context) error {
+	np := o.config.PopulationSize
+	n := o.config.Bounds.Dimension()
+	parents := o.population.Individuals
+
+	ranking := make([]int, np)
+	for i := range ranking {
+		ranking[i] = i
+	}
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return parents[ranking[i]].Fitness > parents[ranking[j]].Fitness
+	})
+	bestIndex := ranking[0]
+
+	fs := make([]float64, np)
+	crs := make([]float64, np)
+	trials := make([]core.Solution[float64], np)
+	for i := 0; i < np; i++ {
+		fs[i], crs[i] = o.sampleParameters()
+		mutant := o.mutant(i, bestIndex, ranking, fs[i])
+
+		// Binomial crossover with at least one gene taken from the mutant
+		trial := make([]float64, n)
+		forced := rand.Intn(n)
+		for j := 0; j < n; j++ {
+			if j == forced || rand.Float64() < crs[i] {
+				trial[j] = mutant[j]
+			} else {
+				trial[j] = parents[i].Chromosome[j]
+			}
+		}
+		o.config.Bounds.Repair(trial, o.config.BoundaryHandling)
+		trials[i] = core.Solution[float64]{Chromosome: trial}
+	}
+
+	trialPopulation := &core.Population[float64]{Individuals: trials}
+	if err := o.evaluate(ctx, trialPopulation); err != nil {
+		return err
+	}
+
+	// One-to-one survivor selection, recording successful parameters
+	o.successF, o.successCR, o.improvements = o.successF[:0], o.successCR[:0], o.improvements[:0]
+	for i := 0; i < np; i++ {
+		if trials[i].Fitness < parents[i].Fitness {
+			continue
+		}
+		if trials[i].Fitness > parents[i].Fitness {
+			o.successF = append(o.successF, fs[i])
+			o.successCR = append(o.successCR, crs[i])
+			o.improvements = append(o.improvements, trials[i].Fitness-parents[i].Fitness)
+			o.archive = append(o.archive, parents[i].Chromosome)
+		}
+		parents[i] = trials[i]
+	}
+	for len(o.archive) > np {
+		k := rand.Intn(len(o.archive))
+		o.archive[k] = o.archive[len(o.archive)-1]
+		o.archive = o.archive[:len(o.archive)-1]
+	}
+	o.adapt()
+	o.generations++
+	return nil
+}
+
+// evaluate evaluates a population in parallel and tracks the evaluation count and best solution.
+func (o *Optimizer) evaluate(ctx context.Context, population *core.Population[float64]) error {
+	if err := fitness.EvaluatePopulation(ctx, o.fitnessEvaluator, population, o.config.NumWorkers); err != nil {
+		return NewDEError(fmt.Sprintf("failed to evaluate population at generation %d", o.generations), err)
+	}
+	o.evaluations += len(population.Individuals)
+	best, err := population.BestSolution()
+	if err != nil {
+		return err
+	}
+	if o.best == nil || best.Fitness > o.best.Fitness {
+		o.best = best.DeepCopy()
+	}
+	return nil
+}
+
+// mutant builds the mutant vector of the i-th individual according to the configured strategy.
+func (o *Optimizer) mutant(i, bestIndex int, ranking []int, f float64) []float64 {
+	parents := o.population.Individuals
+	np := len(parents)
+	n := len(parents[i].Chromosome)
+	mutant := make([]float64, n)
+
+	switch o.config.Strategy {
+	case BestOneBin:
+		r := distinctIndices(np, 2, i)
+		best, x1, x2 := parents[bestIndex].Chromosome, parents[r[0]].Chromosome, parents[r[1]].Chromosome
+		for j := 0; j < n; j++ {
+			mutant[j] = best[j] + f*(x1[j]-x2[j])
+		}
+	case CurrentToBestOne:
+		r := distinctIndices(np, 2, i)
+		x, best, x1, x2 := parents[i].Chromosome, parents[bestIndex].Chromosome, parents[r[0]].Chromosome, parents[r[1]].Chromosome
+		for j := 0; j < n; j++ {
+			mutant[j] = x[j] + f*(best[j]-x[j]) + f*(x1[j]-x2[j])
+		}
+	case CurrentToPBestOne:
+		p := o.config.P
+		if o.config.Adaptation == SHADE {
+			p = 2/float64(np) + rand.Float64()*(0.2-2/float64(np))
+		}
+		top := max(1, int(math.Round(p*float64(np))))
+		pbest := parents[ranking[rand.Intn(top)]].Chromosome
+		r1 := distinctIndices(np, 1, i)[0]
+		x, x1 := parents[i].Chromosome, parents[r1].Chromosome
+
+		// x̃_r2 is drawn from the union of the population and the archive
+		var x2 []float64
+		for {
+			r2 := rand.Intn(np + len(o.archive))
+			if r2 == i || r2 == r1 {
+				continue
+			}
+			if r2 < np {
+				x2 = parents[r2].Chromosome
+			} else {
+				x2 = o.archive[r2-np]
+			}
+			break
+		}
+		for j := 0; j < n; j++ {
+			mutant[j] = x[j] + f*(pbest[j]-x[j]) + f*(x1[j]-x2[j])
+		}
+	default:
+		r := distinctIndices(np, 3, i)
+		x0, x1, x2 := parents[r[0]].Chromosome, parents[r[1]].Chromosome, parents[r[2]].Chromosome
+		for j := 0; j < n; j++ {
+			mutant[j] = x0[j] + f*(x1[j]-x2[j])
+		}
+	}
+	return mutant
+}
+
+// sampleParameters returns the scale factor and crossover rate of a single trial vector.
+func (o *Optimizer) sampleParameters() (float64, float64) {
+	switch o.config.Adaptation {
+	case JADE:
+		return sampleF(o.meanF), sampleCR(o.meanCR)
+	case SHADE:
+		k := rand.Intn(len(o.memoryF))
+		return sampleF(o.memoryF[k]), sampleCR(o.memoryCR[k])
+	default:
+		return o.config.F, o.config.CR
+	}
+}
+
+// adapt updates the JADE means or a SHADE memory slot from the parameters of successful trials.
+func (o *Optimizer) adapt() {
+	if len(o.successF) == 0 {
+		return
+	}
+	switch o.config.Adaptation {
+	case JADE:
+		c := o.config.LearningRate
+		o.meanCR = (1-c)*o.meanCR + c*weightedMean(o.successCR, nil)
+		o.meanF = (1-c)*o.meanF + c*lehmerMean(o.successF, nil)
+	case SHADE:
+		o.memoryCR[o.memoryIndex] = weightedMean(o.successCR, o.improvements)
+		o.memoryF[o.memoryIndex] = lehmerMean(o.successF, o.improvements)
+		o.memoryIndex = (o.memoryIndex + 1) % len(o.memoryF)
+	}
+}
+
+// sampleF draws a scale factor from a Cauchy distribution around mean, regenerating non-positive
+// values and truncating at 1.
+func sampleF(mean float64) float64 {
+	for {
+		f := mean + 0.1*math.Tan(math.Pi*(rand.Float64()-0.5))
+		if f > 0 {
+			return math.Min(f, 1)
+		}
+	}
+}
+
+// sampleCR draws a crossover rate from a normal distribution around mean, clipped to [0, 1].
+func sampleCR(mean float64) float64 {
+	return math.Max(0, math.Min(1, mean+0.1*rand.NormFloat64()))
+}
+
+// weightedMean returns the arithmetic mean of values, weighted by weights when non-nil.
+func weightedMean(values, weights []float64) float64 {
+	sum, total := 0.0, 0.0
+	for k, v := range values {
+		w := 1.0
+		if weights != nil {
+			w = weights[k]
+		}
+		sum += w * v
+		total += w
+	}
+	if total == 0 {
+		return values[0]
+	}
+	return sum / total
+}
+
+// lehmerMean returns the Lehmer mean Σw·v²/Σw·v of values, weighted by weights when non-nil.
+func lehmerMean(values, weights []float64) float64 {
+	numerator, denominator := 0.0, 0.0
+	for k, v := range values {
+		w := 1.0
+		if weights != nil {
+			w = weights[k]
+		}
+		numerator += w * v * v
+		denominator += w * v
+	}
+	if denominator == 0 {
+		return values[0]
+	}
+	return numerator / denominator
+}
+
+// distinctIndices returns count distinct random indices in [0, n) which all differ from exclude.
+func distinctIndices(n, count, exclude int) []int {
+	indices := make([]int, 0, count)
+	for len(indices) < count {
+		candidate := rand.Intn(n)
+		if candidate == exclude {
+			continue
+		}
+		duplicate := false
+		for _, index := range indices {
+			if index == candidate {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			indices = append(indices, candidate)
+		}
+	}
+	return indices
+}
+
+// DEError represents an error that occurs during a differential evolution optimization.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type DEError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *DEError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *DEError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewDEError constructs a *DEError with the provided message and wrapped error.
+func NewDEError(message string, wrapped error) *DEError {
+	return &DEError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
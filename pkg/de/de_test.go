@@ -0,0 +1,213 @@
+package de
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// sphereEvaluator maximizes the negated sphere function, whose optimum 0 lies at the origin.
+type sphereEvaluator struct{}
+
+func (sphereEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	sum := 0.0
+	for _, x := range *chromosome {
+		sum += x * x
+	}
+	return -sum, nil
+}
+
+// rosenbrockEvaluator maximizes the negated Rosenbrock function, whose optimum 0 lies at (1, ..., 1).
+type rosenbrockEvaluator struct{}
+
+func (rosenbrockEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	x := *chromosome
+	sum := 0.0
+	for i := 0; i < len(x)-1; i++ {
+		sum += 100*(x[i+1]-x[i]*x[i])*(x[i+1]-x[i]*x[i]) + (1-x[i])*(1-x[i])
+	}
+	return -sum, nil
+}
+
+// failingEvaluator always fails.
+type failingEvaluator struct{}
+
+func (failingEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	return 0, core.ErrFitnessEvaluationFailed
+}
+
+// newBounds is a helper function creating uniform bounds, failing the test on error.
+func newBounds(t testing.TB, dimension int, lower, upper float64) *core.Bounds {
+	t.Helper()
+	bounds, err := core.NewUniformBounds(dimension, lower, upper)
+	require.NoError(t, err)
+	return bounds
+}
+
+func TestNewOptimizer(t *testing.T) {
+	t.Parallel()
+	valid := DefaultConfig(newBounds(t, 3, -5, 5))
+
+	testCases := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{"missing bounds", func(c *Config) { c.Bounds = nil }},
+		{"population too small", func(c *Config) { c.PopulationSize = 3 }},
+		{"non-positive F", func(c *Config) { c.F = 0 }},
+		{"CR above one", func(c *Config) { c.CR = 1.5 }},
+		{"p above one", func(c *Config) { c.P = 2 }},
+		{"negative budget", func(c *Config) { c.MaxEvaluations = -1 }},
+		{"no stopping criterion", func(c *Config) { c.MaxGenerations = 0 }},
+		{"zero workers", func(c *Config) { c.NumWorkers = 0 }},
+		{"negative workers", func(c *Config) { c.NumWorkers = -2 }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			config := valid
+			tc.modify(&config)
+			optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+			assert.Nil(t, optimizer)
+			var de *DEError
+			assert.ErrorAs(t, err, &de)
+		})
+	}
+
+	t.Run("defaults population size to ten times the dimension", func(t *testing.T) {
+		t.Parallel()
+		optimizer, err := NewOptimizer(sphereEvaluator{}, valid)
+		require.NoError(t, err)
+		assert.Equal(t, 30, optimizer.config.PopulationSize)
+	})
+}
+
+func TestOptimizer_Optimize(t *testing.T) {
+	strategies := []Strategy{RandOneBin, BestOneBin, CurrentToBestOne, CurrentToPBestOne}
+	for _, strategy := range strategies {
+		t.Run(strategy.String()+" converges on the sphere function", func(t *testing.T) {
+			config := DefaultConfig(newBounds(t, 5, -5, 5))
+			config.Strategy = strategy
+			config.MaxGenerations = 300
+
+			optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+			require.NoError(t, err)
+
+			population, err := optimizer.Optimize(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, population.Individuals, 50)
+			assert.Greater(t, optimizer.BestSolution().Fitness, -1e-6)
+			assert.Equal(t, 300, optimizer.Generations())
+			assert.Equal(t, 50*301, optimizer.Evaluations())
+		})
+	}
+
+	for _, adaptation := range []Adaptation{JADE, SHADE} {
+		t.Run(adaptation.String()+" solves the Rosenbrock function", func(t *testing.T) {
+			config := DefaultConfig(newBounds(t, 4, -5, 5))
+			config.Strategy = CurrentToPBestOne
+			config.Adaptation = adaptation
+			config.MaxGenerations = 0
+			config.MaxEvaluations = 200000
+			target := -1e-6
+			config.TargetFitness = &target
+
+			optimizer, err := NewOptimizer(rosenbrockEvaluator{}, config)
+			require.NoError(t, err)
+
+			_, err = optimizer.Optimize(context.Background())
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, optimizer.BestSolution().Fitness, target)
+			assert.Less(t, optimizer.Evaluations(), 200000)
+		})
+	}
+
+	t.Run("keeps every individual within bounds", func(t *testing.T) {
+		bounds := newBounds(t, 3, 1, 2)
+		config := DefaultConfig(bounds)
+		config.MaxGenerations = 150
+		config.F = 1.5
+		config.BoundaryHandling = core.BoundaryReflect
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+
+		population, err := optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		for _, individual := range population.Individuals {
+			assert.True(t, bounds.Contains(individual.Chromosome))
+		}
+		// The optimum within [1, 2]³ lies at the lower corner
+		assert.InDelta(t, -3, optimizer.BestSolution().Fitness, 0.1)
+	})
+
+	t.Run("respects the evaluation budget", func(t *testing.T) {
+		config := DefaultConfig(newBounds(t, 2, -1, 1))
+		config.PopulationSize = 10
+		config.MaxGenerations = 0
+		config.MaxEvaluations = 35
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+
+		_, err = optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 40, optimizer.Evaluations())
+	})
+
+	t.Run("propagates evaluation errors", func(t *testing.T) {
+		optimizer, err := NewOptimizer(failingEvaluator{}, DefaultConfig(newBounds(t, 2, -1, 1)))
+		require.NoError(t, err)
+
+		population, err := optimizer.Optimize(context.Background())
+		assert.Nil(t, population)
+		assert.ErrorIs(t, err, core.ErrFitnessEvaluationFailed)
+		var de *DEError
+		assert.ErrorAs(t, err, &de)
+	})
+
+	t.Run("stops on cancelled context", func(t *testing.T) {
+		optimizer, err := NewOptimizer(sphereEvaluator{}, DefaultConfig(newBounds(t, 2, -1, 1)))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = optimizer.Optimize(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestMeans(t *testing.T) {
+	t.Parallel()
+	assert.InDelta(t, 2.0, weightedMean([]float64{1, 3}, nil), 1e-12)
+	assert.InDelta(t, 2.5, weightedMean([]float64{1, 3}, []float64{1, 3}), 1e-12)
+	assert.InDelta(t, 10.0/4.0, lehmerMean([]float64{1, 3}, nil), 1e-12)
+}
+
+func TestDistinctIndices(t *testing.T) {
+	t.Parallel()
+	for i := 0; i < 100; i++ {
+		indices := distinctIndices(4, 3, 2)
+		assert.Len(t, indices, 3)
+		assert.NotContains(t, indices, 2)
+		assert.ElementsMatch(t, []int{0, 1, 3}, indices)
+	}
+}
+
+// BenchmarkOptimizer_Optimize benchmarks 100 generations of DE/rand/1/bin on a 10-dimensional sphere.
+func BenchmarkOptimizer_Optimize(b *testing.B) {
+	config := DefaultConfig(newBounds(b, 10, -5, 5))
+	config.MaxGenerations = 100
+
+	optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = optimizer.Optimize(context.Background())
+	}
+}
@@ -0,0 +1,163 @@
+// Package constraint provides constraint handling for genetic algorithms: evaluators measuring
+// constraint violation, repair operators applied before evaluation, penalty functions and
+// selectors ranking individuals by feasibility.
+package constraint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// Constraint measures how much a chromosome violates a single constraint. It returns 0 if the
+// constraint is satisfied and a positive value growing with the size of the violation otherwise.
+type Constraint[G any] func(chromosome *G) float64
+
+// LessEqual returns the violation of the constraint value <= limit.
+func LessEqual(value, limit float64) float64 {
+	return math.Max(0, value-limit)
+}
+
+// GreaterEqual returns the violation of the constraint value >= limit.
+func GreaterEqual(value, limit float64) float64 {
+	return math.Max(0, limit-value)
+}
+
+// Equal returns the violation of the constraint value == target, treating deviations of at most
+// tolerance as satisfied.
+func Equal(value, target, tolerance float64) float64 {
+	return math.Max(0, math.Abs(value-target)-tolerance)
+}
+
+// ConstrainedEvaluator combines an objective with a set of constraints. It implements
+// fitness.IConstrainedEvaluator, the violation being the sum of the violations of all constraints.
+type ConstrainedEvaluator[G any] struct {
+	// Objective calculates the fitness, ignoring the constraints.
+	Objective fitness.IGenomeEvaluator[G]
+	// Constraints are the constraints a feasible chromosome satisfies.
+	Constraints []Constraint[G]
+}
+
+// NewConstrainedEvaluator creates a ConstrainedEvaluator for the given objective and constraints.
+func NewConstrainedEvaluator[G any](objective fitness.IGenomeEvaluator[G], constraints ...Constraint[G]) (*ConstrainedEvaluator[G], error) {
+	if objective == nil {
+		return nil, NewConstraintError("invalid objective", errors.New("objective cannot be nil"))
+	}
+	return &ConstrainedEvaluator[G]{Objective: objective, Constraints: constraints}, nil
+}
+
+// Evaluate implements fitness.IGenomeEvaluator and returns the fitness of the objective.
+func (c *ConstrainedEvaluator[G]) Evaluate(ctx context.Context, chromosome *G) (float64, error) {
+	return c.Objective.Evaluate(ctx, chromosome)
+}
+
+// EvaluateConstrained implements fitness.IConstrainedEvaluator.
+func (c *ConstrainedEvaluator[G]) EvaluateConstrained(ctx context.Context, chromosome *G) (float64, float64, error) {
+	value, err := c.Objective.Evaluate(ctx, chromosome)
+	if err != nil {
+		return 0, 0, err
+	}
+	violation := 0.0
+	for _, constraint := range c.Constraints {
+		violation += constraint(chromosome)
+	}
+	return value, violation, nil
+}
+
+// IRepairer defines the interface for repair operators, which turn infeasible chromosomes into
+// feasible (or less infeasible) ones.
+type IRepairer[G any] interface {
+	// Repair modifies the given chromosome in place to reduce its constraint violation.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeout control
+	//   - chromosome: The genetic material to repair
+	//
+	// Returns:
+	//   - error: Any error that occurred during repair
+	Repair(ctx context.Context, chromosome *G) error
+}
+
+// RepairFunc adapts a function to the IRepairer interface.
+type RepairFunc[G any] func(ctx context.Context, chromosome *G) error
+
+// Repair implements IRepairer.
+func (f RepairFunc[G]) Repair(ctx context.Context, chromosome *G) error {
+	return f(ctx, chromosome)
+}
+
+// RepairingEvaluator repairs every chromosome before evaluating it. The repaired chromosome
+// replaces the original one in the population, so repairs are inherited by the offspring.
+type RepairingEvaluator[G any] struct {
+	// Repairer repairs chromosomes before evaluation.
+	Repairer IRepairer[G]
+	// Evaluator evaluates the repaired chromosomes. If it implements fitness.IConstrainedEvaluator,
+	// the violation remaining after the repair is reported as well.
+	Evaluator fitness.IGenomeEvaluator[G]
+}
+
+// NewRepairingEvaluator creates a RepairingEvaluator.
+func NewRepairingEvaluator[G any](repairer IRepairer[G], evaluator fitness.IGenomeEvaluator[G]) (*RepairingEvaluator[G], error) {
+	if repairer == nil {
+		return nil, NewConstraintError("invalid repairer", errors.New("repairer cannot be nil"))
+	}
+	if evaluator == nil {
+		return nil, NewConstraintError("invalid evaluator", errors.New("evaluator cannot be nil"))
+	}
+	return &RepairingEvaluator[G]{Repairer: repairer, Evaluator: evaluator}, nil
+}
+
+// Evaluate implements fitness.IGenomeEvaluator.
+func (r *RepairingEvaluator[G]) Evaluate(ctx context.Context, chromosome *G) (float64, error) {
+	value, _, err := r.EvaluateConstrained(ctx, chromosome)
+	return value, err
+}
+
+// EvaluateConstrained implements fitness.IConstrainedEvaluator. The violation is 0 unless the
+// wrapped evaluator measures constraint violations itself.
+func (r *RepairingEvaluator[G]) EvaluateConstrained(ctx context.Context, chromosome *G) (float64, float64, error) {
+	if err := r.Repairer.Repair(ctx, chromosome); err != nil {
+		return 0, 0, NewConstraintError("failed to repair chromosome", err)
+	}
+	if constrained, ok := r.Evaluator.(fitness.IConstrainedEvaluator[G]); ok {
+		return constrained.EvaluateConstrained(ctx, chromosome)
+	}
+	value, err := r.Evaluator.Evaluate(ctx, chromosome)
+	return value, 0, err
+}
+
+// ConstraintError represents an error that occurs while handling constraints.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type ConstraintError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *ConstraintError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *ConstraintError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewConstraintError constructs a *ConstraintError with the provided message and wrapped error.
+func NewConstraintError(message string, wrapped error) *ConstraintError {
+	return &ConstraintError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
@@ -0,0 +1,114 @@
+package constraint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// sumOf returns the sum of the genes of a chromosome.
+func sumOf(chromosome *[]int) float64 {
+	sum := 0.0
+	for _, gene := range *chromosome {
+		sum += float64(gene)
+	}
+	return sum
+}
+
+func TestViolationHelpers(t *testing.T) {
+	assert.Equal(t, 0.0, LessEqual(3, 5))
+	assert.Equal(t, 2.0, LessEqual(7, 5))
+	assert.Equal(t, 0.0, GreaterEqual(7, 5))
+	assert.Equal(t, 2.0, GreaterEqual(3, 5))
+	assert.Equal(t, 0.0, Equal(5.05, 5, 0.1))
+	assert.InDelta(t, 0.9, Equal(4, 5, 0.1), 1e-12)
+}
+
+func TestConstrainedEvaluator(t *testing.T) {
+	capacity := func(chromosome *[]int) float64 { return LessEqual(sumOf(chromosome), 10) }
+	positive := func(chromosome *[]int) float64 {
+		violation := 0.0
+		for _, gene := range *chromosome {
+			violation += GreaterEqual(float64(gene), 0)
+		}
+		return violation
+	}
+	evaluator, err := NewConstrainedEvaluator(fitness.IGenomeEvaluator[[]int](fitness.NewSimpleSumFitnessEvaluator[int]()), capacity, positive)
+	require.NoError(t, err)
+
+	t.Run("sums the violations of all constraints", func(t *testing.T) {
+		chromosome := []int{8, 6, -1}
+		value, violation, err := evaluator.EvaluateConstrained(context.Background(), &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, 13.0, value)
+		assert.Equal(t, 4.0, violation)
+	})
+
+	t.Run("population evaluation stores the violation", func(t *testing.T) {
+		population := &core.Population[int]{Individuals: []core.Solution[int]{
+			{Chromosome: []int{1, 2}},
+			{Chromosome: []int{9, 9}},
+		}}
+		require.NoError(t, fitness.EvaluatePopulation[[]int](context.Background(), evaluator, population, 2))
+		assert.True(t, population.Individuals[0].Feasible())
+		assert.False(t, population.Individuals[1].Feasible())
+		assert.Equal(t, 8.0, population.Individuals[1].Violation)
+	})
+
+	t.Run("nil objective returns error", func(t *testing.T) {
+		_, err := NewConstrainedEvaluator[[]int](nil)
+		var ce *ConstraintError
+		assert.True(t, errors.As(err, &ce))
+	})
+}
+
+func TestRepairingEvaluator(t *testing.T) {
+	// Clamps every gene to [0, 5]
+	clamp := RepairFunc[[]int](func(ctx context.Context, chromosome *[]int) error {
+		for i, gene := range *chromosome {
+			(*chromosome)[i] = min(max(gene, 0), 5)
+		}
+		return nil
+	})
+
+	t.Run("repairs chromosomes in place before evaluation", func(t *testing.T) {
+		evaluator, err := NewRepairingEvaluator[[]int](clamp, fitness.NewSimpleSumFitnessEvaluator[int]())
+		require.NoError(t, err)
+		chromosome := []int{-3, 9, 2}
+		value, violation, err := evaluator.EvaluateConstrained(context.Background(), &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 5, 2}, chromosome)
+		assert.Equal(t, 7.0, value)
+		assert.Equal(t, 0.0, violation)
+	})
+
+	t.Run("reports the remaining violation of constrained evaluators", func(t *testing.T) {
+		constrained, err := NewConstrainedEvaluator[[]int](fitness.NewSimpleSumFitnessEvaluator[int](),
+			func(chromosome *[]int) float64 { return LessEqual(sumOf(chromosome), 8) })
+		require.NoError(t, err)
+		evaluator, err := NewRepairingEvaluator[[]int](clamp, constrained)
+		require.NoError(t, err)
+		chromosome := []int{9, 9}
+		_, violation, err := evaluator.EvaluateConstrained(context.Background(), &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, violation)
+	})
+
+	t.Run("repair errors are wrapped", func(t *testing.T) {
+		failure := errors.New("cannot repair")
+		evaluator, err := NewRepairingEvaluator[[]int](RepairFunc[[]int](func(context.Context, *[]int) error { return failure }),
+			fitness.NewSimpleSumFitnessEvaluator[int]())
+		require.NoError(t, err)
+		chromosome := []int{1}
+		_, err = evaluator.Evaluate(context.Background(), &chromosome)
+		var ce *ConstraintError
+		assert.True(t, errors.As(err, &ce))
+		assert.True(t, errors.Is(err, failure))
+	})
+}
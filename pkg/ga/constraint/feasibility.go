@@ -0,0 +1,175 @@
+package constraint
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+// FeasibilityBetter compares two individuals by Deb's feasibility rules and reports whether a is
+// strictly better than b: a feasible individual beats an infeasible one, two feasible individuals
+// are compared by fitness and two infeasible individuals by violation.
+func FeasibilityBetter[G any](a, b *core.Individual[G]) bool {
+	return a.RanksAbove(b)
+}
+
+// FeasibilityTournamentSelector performs tournament selection using Deb's feasibility rules, see
+// FeasibilityBetter. It needs no penalty coefficient, and the best individuals by the same rules
+// are carried over as elites.
+type FeasibilityTournamentSelector[G any] struct {
+	TournamentSize int
+	NumElites      int
+
+	genome core.Genome[G]
+}
+
+// NewFeasibilityTournamentSelector creates a FeasibilityTournamentSelector with the specified
+// tournament size and number of elites.
+func NewFeasibilityTournamentSelector[G any](tournamentSize int, numElites int) (*FeasibilityTournamentSelector[G], error) {
+	if err := validateTournament(tournamentSize, numElites); err != nil {
+		return nil, err
+	}
+	return &FeasibilityTournamentSelector[G]{TournamentSize: tournamentSize, NumElites: numElites}, nil
+}
+
+// SetGenome implements selection.IGenomeAware.
+func (f *FeasibilityTournamentSelector[G]) SetGenome(genome core.Genome[G]) {
+	f.genome = genome
+}
+
+// Select implements selection.IGenomeSelector.
+func (f *FeasibilityTournamentSelector[G]) Select(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil, selection.NewSelectionError("cannot perform selection on nil or empty population", core.ErrPopulationEmpty)
+	}
+	individuals := population.Individuals
+	ranking := make([]int, len(individuals))
+	for i := range ranking {
+		ranking[i] = i
+	}
+	slices.SortStableFunc(ranking, func(a, b int) int {
+		switch {
+		case FeasibilityBetter(&individuals[a], &individuals[b]):
+			return -1
+		case FeasibilityBetter(&individuals[b], &individuals[a]):
+			return 1
+		}
+		return 0
+	})
+	return rankTournament(individuals, ranking, f.TournamentSize, f.NumElites, f.genome)
+}
+
+// StochasticRanking ranks individuals by stochastic ranking (Runarsson and Yao) and returns their
+// indices, best first. It bubble-sorts the individuals for at most sweeps passes, comparing
+// adjacent individuals by fitness if both are feasible or with probability pf, and by violation
+// otherwise. pf balances the objective against the constraints; values below 0.5 favor feasibility.
+func StochasticRanking[G any](individuals []core.Individual[G], pf float64, sweeps int) []int {
+	ranking := make([]int, len(individuals))
+	for i := range ranking {
+		ranking[i] = i
+	}
+	for sweep := 0; sweep < sweeps; sweep++ {
+		swapped := false
+		for j := 0; j+1 < len(ranking); j++ {
+			a, b := &individuals[ranking[j]], &individuals[ranking[j+1]]
+			var swap bool
+			if (a.Feasible() && b.Feasible()) || rand.Float64() < pf {
+				swap = a.Fitness < b.Fitness
+			} else {
+				swap = a.Violation > b.Violation
+			}
+			if swap {
+				ranking[j], ranking[j+1] = ranking[j+1], ranking[j]
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+	return ranking
+}
+
+// StochasticRankingSelector ranks the population by StochasticRanking and performs tournament
+// selection on the ranks. The best ranked individuals are carried over as elites.
+type StochasticRankingSelector[G any] struct {
+	// Pf is the probability of comparing infeasible individuals by fitness.
+	Pf float64
+	// Sweeps is the maximum number of sweeps of the ranking. 0 uses the population size.
+	Sweeps         int
+	TournamentSize int
+	NumElites      int
+
+	genome core.Genome[G]
+}
+
+// NewStochasticRankingSelector creates a StochasticRankingSelector. A common choice of pf is 0.45.
+func NewStochasticRankingSelector[G any](pf float64, tournamentSize int, numElites int) (*StochasticRankingSelector[G], error) {
+	if pf < 0 || pf > 1 {
+		return nil, NewConstraintError("invalid comparison probability", fmt.Errorf("comparison probability must be within [0, 1], but was %g", pf))
+	}
+	if err := validateTournament(tournamentSize, numElites); err != nil {
+		return nil, err
+	}
+	return &StochasticRankingSelector[G]{Pf: pf, TournamentSize: tournamentSize, NumElites: numElites}, nil
+}
+
+// SetGenome implements selection.IGenomeAware.
+func (s *StochasticRankingSelector[G]) SetGenome(genome core.Genome[G]) {
+	s.genome = genome
+}
+
+// Select implements selection.IGenomeSelector.
+func (s *StochasticRankingSelector[G]) Select(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil, selection.NewSelectionError("cannot perform selection on nil or empty population", core.ErrPopulationEmpty)
+	}
+	sweeps := s.Sweeps
+	if sweeps == 0 {
+		sweeps = len(population.Individuals)
+	}
+	ranking := StochasticRanking(population.Individuals, s.Pf, sweeps)
+	return rankTournament(population.Individuals, ranking, s.TournamentSize, s.NumElites, s.genome)
+}
+
+// validateTournament checks the parameters of a tournament.
+func validateTournament(tournamentSize, numElites int) error {
+	if tournamentSize <= 0 {
+		return selection.NewSelectionError("invalid tournament size", fmt.Errorf("tournament size must be positive, but was %d", tournamentSize))
+	}
+	if numElites < 0 {
+		return selection.NewSelectionError("invalid number of elites", fmt.Errorf("number of elites cannot be negative, but was %d", numElites))
+	}
+	return nil
+}
+
+// rankTournament selects as many individuals as there are in the population given their ranking,
+// best first. The first numElites ranked individuals are copied as elites, the others are chosen
+// by tournaments among the remaining individuals won by the better ranked competitor. Selected
+// individuals are copied with genome, see core.Individual.Clone.
+func rankTournament[G any](individuals []core.Individual[G], ranking []int, tournamentSize, numElites int, genome core.Genome[G]) (*core.GenomePopulation[G], error) {
+	populationSize := len(individuals)
+	if numElites >= populationSize {
+		return nil, selection.NewSelectionError(
+			fmt.Sprintf("number of elites (%d) is greater than or equal to population size (%d)", numElites, populationSize), nil)
+	}
+
+	offspring := make([]core.Individual[G], 0, populationSize)
+	for _, index := range ranking[:numElites] {
+		offspring = append(offspring, *individuals[index].Clone(genome))
+	}
+
+	// Positions in the pool are ranks, so the smaller position wins a tournament
+	pool := ranking[numElites:]
+	for len(offspring) < populationSize {
+		winner := rand.Intn(len(pool))
+		for j := 1; j < tournamentSize; j++ {
+			winner = min(winner, rand.Intn(len(pool)))
+		}
+		offspring = append(offspring, *individuals[pool[winner]].Clone(genome))
+	}
+	return &core.GenomePopulation[G]{Individuals: offspring}, nil
+}
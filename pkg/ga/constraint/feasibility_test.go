@@ -0,0 +1,150 @@
+package constraint
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+func TestFeasibilityBetter(t *testing.T) {
+	feasibleHigh := &core.Solution[int]{Fitness: 10}
+	feasibleLow := &core.Solution[int]{Fitness: 1}
+	infeasibleSmall := &core.Solution[int]{Fitness: 100, Violation: 1}
+	infeasibleLarge := &core.Solution[int]{Fitness: 200, Violation: 5}
+
+	assert.True(t, FeasibilityBetter(feasibleHigh, feasibleLow))
+	assert.True(t, FeasibilityBetter(feasibleLow, infeasibleSmall))
+	assert.False(t, FeasibilityBetter(infeasibleSmall, feasibleLow))
+	assert.True(t, FeasibilityBetter(infeasibleSmall, infeasibleLarge))
+	assert.False(t, FeasibilityBetter(feasibleHigh, feasibleHigh))
+}
+
+func TestFeasibilityTournamentSelector(t *testing.T) {
+	population := &core.Population[int]{Individuals: []core.Solution[int]{
+		{Chromosome: []int{1}, Fitness: 100, Violation: 2},
+		{Chromosome: []int{2}, Fitness: 1},
+		{Chromosome: []int{3}, Fitness: 50, Violation: 1},
+		{Chromosome: []int{4}, Fitness: 3},
+	}}
+
+	t.Run("elites follow the feasibility rules", func(t *testing.T) {
+		selector, err := NewFeasibilityTournamentSelector[[]int](2, 3)
+		require.NoError(t, err)
+		selected, err := selector.Select(population)
+		require.NoError(t, err)
+		require.Len(t, selected.Individuals, 4)
+		assert.Equal(t, []int{4}, selected.Individuals[0].Chromosome)
+		assert.Equal(t, []int{2}, selected.Individuals[1].Chromosome)
+		assert.Equal(t, []int{3}, selected.Individuals[2].Chromosome)
+		assert.Equal(t, []int{1}, selected.Individuals[3].Chromosome)
+	})
+
+	t.Run("tournaments of the population size select the best", func(t *testing.T) {
+		selector, err := NewFeasibilityTournamentSelector[[]int](100, 0)
+		require.NoError(t, err)
+		selected, err := selector.Select(population)
+		require.NoError(t, err)
+		for _, individual := range selected.Individuals {
+			assert.Equal(t, []int{4}, individual.Chromosome)
+		}
+	})
+
+	t.Run("invalid parameters return error", func(t *testing.T) {
+		_, err := NewFeasibilityTournamentSelector[[]int](0, 0)
+		assert.Error(t, err)
+		selector, err := NewFeasibilityTournamentSelector[[]int](2, 4)
+		require.NoError(t, err)
+		_, err = selector.Select(population)
+		assert.Error(t, err)
+	})
+}
+
+func TestStochasticRanking(t *testing.T) {
+	individuals := []core.Solution[int]{
+		{Fitness: 100, Violation: 3},
+		{Fitness: 1},
+		{Fitness: 50, Violation: 1},
+		{Fitness: 3},
+	}
+
+	t.Run("pf of 0 ranks by feasibility rules", func(t *testing.T) {
+		assert.Equal(t, []int{3, 1, 2, 0}, StochasticRanking(individuals, 0, len(individuals)))
+	})
+
+	t.Run("pf of 1 ranks by fitness", func(t *testing.T) {
+		assert.Equal(t, []int{0, 2, 3, 1}, StochasticRanking(individuals, 1, len(individuals)))
+	})
+
+	t.Run("selector keeps the best ranked individual as elite", func(t *testing.T) {
+		selector, err := NewStochasticRankingSelector[[]int](0, 2, 1)
+		require.NoError(t, err)
+		selected, err := selector.Select(&core.Population[int]{Individuals: individuals})
+		require.NoError(t, err)
+		assert.Equal(t, 3.0, selected.Individuals[0].Fitness)
+
+		_, err = NewStochasticRankingSelector[[]int](1.5, 2, 1)
+		assert.Error(t, err)
+	})
+}
+
+// TestConstrainedOptimization maximizes the number of ones in a bit vector whose number of
+// ones is limited, with every constraint handling technique of the package.
+func TestConstrainedOptimization(t *testing.T) {
+	const length, limit = 20, 8
+	evaluator, err := NewConstrainedEvaluator[[]int](fitness.NewSimpleSumFitnessEvaluator[int](),
+		func(chromosome *[]int) float64 { return LessEqual(sumOf(chromosome), limit) })
+	require.NoError(t, err)
+
+	penalized := func(penalty IPenalty, err error) selection.ISelector[int] {
+		require.NoError(t, err)
+		tournament, err := selection.NewTournamentSelector[int](2, 0)
+		require.NoError(t, err)
+		selector, err := NewPenaltySelector[[]int](tournament, penalty)
+		require.NoError(t, err)
+		return selector
+	}
+	feasibilityRules, err := NewFeasibilityTournamentSelector[[]int](2, 0)
+	require.NoError(t, err)
+	stochasticRanking, err := NewStochasticRankingSelector[[]int](0.45, 2, 0)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		selector selection.ISelector[int]
+	}{
+		{"static penalty", penalized(NewStaticPenalty(2, 1))},
+		{"dynamic penalty", penalized(NewDynamicPenalty(0.5, 2, 2))},
+		{"adaptive penalty", penalized(NewAdaptivePenalty(4, 5, 1.1, 2))},
+		{"feasibility rules", feasibilityRules},
+		{"stochastic ranking", stochasticRanking},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			population := core.NewPopulationFactory[int]().CreateRandomPopulation(40, length, core.NewSolutionFactory[int](),
+				func() int { return rand.Intn(2) }, nil)
+			exec := executor.NewGeneticAlgorithmExecutor[int](population, evaluator, mutation.NewSimpleSwapMutator[int](0.5),
+				tc.selector, crossover.NewSinglePointCrossover[int](), 30)
+			final, err := exec.Loop(context.Background(), 30)
+			require.NoError(t, err)
+
+			bestFeasible := 0.0
+			for _, individual := range final.Individuals {
+				if individual.Feasible() {
+					bestFeasible = max(bestFeasible, individual.Fitness)
+				}
+			}
+			assert.Equal(t, float64(limit), bestFeasible, "the best feasible individual should reach the limit")
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package constraint
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+// IPenalty defines the interface for penalty functions, which turn a constrained problem into an
+// unconstrained one by subtracting a penalty growing with the violation from the fitness.
+type IPenalty interface {
+	// Penalty returns the amount subtracted from the fitness of an individual.
+	//
+	// Parameters:
+	//   - violation: The constraint violation of the individual
+	//
+	// Returns:
+	//   - float64: The non-negative penalty, 0 for feasible individuals
+	Penalty(violation float64) float64
+	// Update adapts the penalty once per generation, before the population is penalized.
+	//
+	// Parameters:
+	//   - generation: The number of the generation, starting at 0
+	//   - bestFeasible: Whether the best individual of the generation under the current penalty is feasible
+	Update(generation int, bestFeasible bool)
+}
+
+// StaticPenalty penalizes an individual by Coefficient * violation^Exponent.
+type StaticPenalty struct {
+	Coefficient float64
+	Exponent    float64
+}
+
+// NewStaticPenalty creates a StaticPenalty. An exponent of 1 penalizes linearly, 2 quadratically.
+func NewStaticPenalty(coefficient, exponent float64) (*StaticPenalty, error) {
+	if coefficient < 0 {
+		return nil, NewConstraintError("invalid penalty coefficient", fmt.Errorf("penalty coefficient cannot be negative, but was %g", coefficient))
+	}
+	if exponent <= 0 {
+		return nil, NewConstraintError("invalid penalty exponent", fmt.Errorf("penalty exponent must be positive, but was %g", exponent))
+	}
+	return &StaticPenalty{Coefficient: coefficient, Exponent: exponent}, nil
+}
+
+// Penalty implements IPenalty.
+func (s *StaticPenalty) Penalty(violation float64) float64 {
+	if violation <= 0 {
+		return 0
+	}
+	return s.Coefficient * math.Pow(violation, s.Exponent)
+}
+
+// Update implements IPenalty. Static penalties do not change.
+func (s *StaticPenalty) Update(generation int, bestFeasible bool) {}
+
+// DynamicPenalty penalizes an individual by (C * t)^Alpha * violation^Beta, where t is the
+// generation counted from 1 (Joines and Houck). Infeasible individuals are tolerated early in
+// the run and increasingly punished later on.
+type DynamicPenalty struct {
+	C     float64
+	Alpha float64
+	Beta  float64
+
+	generation int
+}
+
+// NewDynamicPenalty creates a DynamicPenalty. Common choices are c = 0.5 and alpha = beta = 2.
+func NewDynamicPenalty(c, alpha, beta float64) (*DynamicPenalty, error) {
+	if c <= 0 || alpha < 0 || beta <= 0 {
+		return nil, NewConstraintError("invalid dynamic penalty", fmt.Errorf("c and beta must be positive and alpha non-negative, but were c=%g, alpha=%g, beta=%g", c, alpha, beta))
+	}
+	return &DynamicPenalty{C: c, Alpha: alpha, Beta: beta}, nil
+}
+
+// Penalty implements IPenalty.
+func (d *DynamicPenalty) Penalty(violation float64) float64 {
+	if violation <= 0 {
+		return 0
+	}
+	return math.Pow(d.C*float64(d.generation+1), d.Alpha) * math.Pow(violation, d.Beta)
+}
+
+// Update implements IPenalty and records the generation.
+func (d *DynamicPenalty) Update(generation int, bestFeasible bool) {
+	d.generation = generation
+}
+
+// AdaptivePenalty penalizes an individual by Lambda * violation, adapting Lambda to the search
+// (Bean and Hadj-Alouane): if the best individual was feasible in each of the last Window
+// generations, Lambda is divided by Decrease; if it was infeasible in each of them, Lambda is
+// multiplied by Increase.
+type AdaptivePenalty struct {
+	Lambda   float64
+	Window   int
+	Decrease float64
+	Increase float64
+
+	history []bool
+}
+
+// NewAdaptivePenalty creates an AdaptivePenalty starting with the given Lambda.
+// decrease and increase must be greater than 1 and should differ to avoid cycling.
+func NewAdaptivePenalty(lambda float64, window int, decrease, increase float64) (*AdaptivePenalty, error) {
+	if lambda <= 0 {
+		return nil, NewConstraintError("invalid penalty coefficient", fmt.Errorf("penalty coefficient must be positive, but was %g", lambda))
+	}
+	if window < 1 {
+		return nil, NewConstraintError("invalid window", fmt.Errorf("window must be positive, but was %d", window))
+	}
+	if decrease <= 1 || increase <= 1 {
+		return nil, NewConstraintError("invalid adaptation factors", fmt.Errorf("adaptation factors must be greater than 1, but were %g and %g", decrease, increase))
+	}
+	return &AdaptivePenalty{Lambda: lambda, Window: window, Decrease: decrease, Increase: increase}, nil
+}
+
+// Penalty implements IPenalty.
+func (a *AdaptivePenalty) Penalty(violation float64) float64 {
+	if violation <= 0 {
+		return 0
+	}
+	return a.Lambda * violation
+}
+
+// Update implements IPenalty and adapts Lambda to the feasibility of the recent best individuals.
+func (a *AdaptivePenalty) Update(generation int, bestFeasible bool) {
+	a.history = append(a.history, bestFeasible)
+	if len(a.history) > a.Window {
+		a.history = a.history[1:]
+	}
+	if len(a.history) < a.Window {
+		return
+	}
+
+	feasible := 0
+	for _, f := range a.history {
+		if f {
+			feasible++
+		}
+	}
+	switch feasible {
+	case a.Window:
+		a.Lambda /= a.Decrease
+	case 0:
+		a.Lambda *= a.Increase
+	}
+}
+
+// PenaltySelector lets any selector handle constraints by penalizing the fitness of infeasible
+// individuals before selection. The selected individuals carry their unpenalized fitness.
+type PenaltySelector[G any] struct {
+	// Selector selects individuals by penalized fitness.
+	Selector selection.IGenomeSelector[G]
+	// Penalty calculates the penalty of infeasible individuals.
+	Penalty IPenalty
+
+	generation int
+}
+
+// NewPenaltySelector creates a PenaltySelector wrapping the given selector.
+func NewPenaltySelector[G any](selector selection.IGenomeSelector[G], penalty IPenalty) (*PenaltySelector[G], error) {
+	if selector == nil {
+		return nil, NewConstraintError("invalid selector", errors.New("selector cannot be nil"))
+	}
+	if penalty == nil {
+		return nil, NewConstraintError("invalid penalty", errors.New("penalty cannot be nil"))
+	}
+	return &PenaltySelector[G]{Selector: selector, Penalty: penalty}, nil
+}
+
+// Select implements selection.IGenomeSelector. Every call counts as one generation: the penalty
+// is updated first, then the population is penalized and passed to the wrapped selector.
+func (p *PenaltySelector[G]) Select(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil, selection.NewSelectionError("cannot perform selection on nil or empty population", core.ErrPopulationEmpty)
+	}
+
+	best := 0
+	for i, individual := range population.Individuals {
+		if p.penalized(individual) > p.penalized(population.Individuals[best]) {
+			best = i
+		}
+	}
+	p.Penalty.Update(p.generation, population.Individuals[best].Feasible())
+	p.generation++
+
+	// The copies share chromosomes with the population, the wrapped selector copies them
+	penalized := &core.GenomePopulation[G]{Individuals: make([]core.Individual[G], len(population.Individuals))}
+	for i, individual := range population.Individuals {
+		individual.Fitness = p.penalized(individual)
+		penalized.Individuals[i] = individual
+	}
+
+	selected, err := p.Selector.Select(penalized)
+	if err != nil {
+		return nil, err
+	}
+	for i := range selected.Individuals {
+		selected.Individuals[i].Fitness += p.Penalty.Penalty(selected.Individuals[i].Violation)
+	}
+	return selected, nil
+}
+
+// penalized returns the fitness of the individual reduced by its penalty.
+func (p *PenaltySelector[G]) penalized(individual core.Individual[G]) float64 {
+	return individual.Fitness - p.Penalty.Penalty(individual.Violation)
+}
@@ -0,0 +1,100 @@
+package constraint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+func TestStaticPenalty(t *testing.T) {
+	penalty, err := NewStaticPenalty(10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, penalty.Penalty(0))
+	assert.Equal(t, 40.0, penalty.Penalty(2))
+
+	_, err = NewStaticPenalty(-1, 1)
+	assert.Error(t, err)
+	_, err = NewStaticPenalty(1, 0)
+	assert.Error(t, err)
+}
+
+func TestDynamicPenalty(t *testing.T) {
+	penalty, err := NewDynamicPenalty(0.5, 2, 2)
+	require.NoError(t, err)
+
+	penalty.Update(0, false)
+	assert.Equal(t, 0.25*4, penalty.Penalty(2))
+	penalty.Update(9, false)
+	assert.Equal(t, 25.0*4, penalty.Penalty(2))
+	assert.Equal(t, 0.0, penalty.Penalty(0))
+
+	_, err = NewDynamicPenalty(0, 2, 2)
+	assert.Error(t, err)
+}
+
+func TestAdaptivePenalty(t *testing.T) {
+	penalty, err := NewAdaptivePenalty(8, 2, 2, 4)
+	require.NoError(t, err)
+
+	penalty.Update(0, true)
+	assert.Equal(t, 8.0, penalty.Lambda, "window not yet filled")
+	penalty.Update(1, true)
+	assert.Equal(t, 4.0, penalty.Lambda, "decreased after feasible bests")
+	penalty.Update(2, false)
+	assert.Equal(t, 4.0, penalty.Lambda, "unchanged after mixed bests")
+	penalty.Update(3, false)
+	assert.Equal(t, 16.0, penalty.Lambda, "increased after infeasible bests")
+	assert.Equal(t, 32.0, penalty.Penalty(2))
+
+	_, err = NewAdaptivePenalty(1, 0, 2, 2)
+	assert.Error(t, err)
+	_, err = NewAdaptivePenalty(1, 2, 1, 2)
+	assert.Error(t, err)
+}
+
+func TestPenaltySelector(t *testing.T) {
+	penalty, err := NewStaticPenalty(100, 1)
+	require.NoError(t, err)
+	tournament, err := selection.NewGenomeTournamentSelector[[]int](2, 1)
+	require.NoError(t, err)
+	selector, err := NewPenaltySelector[[]int](tournament, penalty)
+	require.NoError(t, err)
+
+	population := &core.Population[int]{Individuals: []core.Solution[int]{
+		{Chromosome: []int{1}, Fitness: 50, Violation: 1},
+		{Chromosome: []int{2}, Fitness: 10},
+		{Chromosome: []int{3}, Fitness: 5},
+	}}
+
+	t.Run("elite is the best penalized individual and fitness is restored", func(t *testing.T) {
+		selected, err := selector.Select(population)
+		require.NoError(t, err)
+		require.Len(t, selected.Individuals, 3)
+		assert.Equal(t, []int{2}, selected.Individuals[0].Chromosome)
+		for _, individual := range selected.Individuals {
+			switch individual.Chromosome[0] {
+			case 1:
+				assert.Equal(t, 50.0, individual.Fitness)
+			case 2:
+				assert.Equal(t, 10.0, individual.Fitness)
+			}
+		}
+		assert.Equal(t, 50.0, population.Individuals[0].Fitness, "population must not be modified")
+	})
+
+	t.Run("empty population returns error", func(t *testing.T) {
+		_, err := selector.Select(&core.Population[int]{})
+		assert.ErrorIs(t, err, core.ErrPopulationEmpty)
+	})
+
+	t.Run("nil arguments return error", func(t *testing.T) {
+		_, err := NewPenaltySelector[[]int](nil, penalty)
+		assert.Error(t, err)
+		_, err = NewPenaltySelector[[]int](tournament, nil)
+		assert.Error(t, err)
+	})
+}
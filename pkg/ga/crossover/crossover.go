@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"math/rand"
 
-	"github.com/tomhoffer/darwinium/internal/core"
+	"github.com/tomhoffer/darwinium/internal/random"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
-// ICrossover defines the interface for chromosome crossover in genetic algorithms.
-type ICrossover[T any] interface {
+// IGenomeCrossover defines the interface for crossover of chromosomes of an arbitrary
+// representation G, see core.Genome.
+type IGenomeCrossover[G any] interface {
 	// Crossover performs crossover on two parent chromosomes to produce offspring.
 	//
 	// Parameters:
@@ -17,25 +19,36 @@ type ICrossover[T any] interface {
 	//   - parent2: The second parent chromosome.
 	//
 	// Returns:
-	//   - []T: The first offspring chromosome.
-	//   - []T: The second offspring chromosome.
+	//   - G: The first offspring chromosome.
+	//   - G: The second offspring chromosome.
 	//   - error: Any error that occurred during crossover.
-	Crossover(parent1, parent2 []T) ([]T, []T, error)
+	Crossover(parent1, parent2 G) (G, G, error)
 }
 
+// ICrossover defines the interface for chromosome crossover in genetic algorithms
+// whose chromosomes are slices of genes.
+type ICrossover[T any] = IGenomeCrossover[[]T]
+
 // SinglePointCrossover implements the single-point crossover method.
 // In single-point crossover, a point on both parent chromosome strings is
 // picked randomly and designated a 'crossover point'. Genes to the right
 // of that point are swapped between the two parent chromosomes.
 // This results in two offspring, each carrying some genetic material
 // from both parents.
-type SinglePointCrossover[T any] struct{}
+type SinglePointCrossover[T any] struct {
+	rng *random.Rand
+}
 
 // NewSinglePointCrossover creates and returns a new SinglePointCrossover instance.
 func NewSinglePointCrossover[T any]() *SinglePointCrossover[T] {
 	return &SinglePointCrossover[T]{}
 }
 
+// SetRand implements core.IRandomized.
+func (s *SinglePointCrossover[T]) SetRand(generator *rand.Rand) {
+	s.rng = random.New(generator)
+}
+
 // Crossover performs a single-point crossover on two parent chromosomes.
 func (s SinglePointCrossover[T]) Crossover(parent1, parent2 []T) ([]T, []T, error) {
 	if parent1 == nil || len(parent1) == 0 || parent2 == nil || len(parent2) == 0 {
@@ -56,7 +69,7 @@ func (s SinglePointCrossover[T]) Crossover(parent1, parent2 []T) ([]T, []T, erro
 	}
 
 	// Crossover_point is between 1 and parent1Len-1 inclusive.
-	crossoverPoint := rand.Intn(parent1Len-1) + 1
+	crossoverPoint := s.rng.Intn(parent1Len-1) + 1
 
 	offspring1 := make([]T, parent1Len)
 	copy(offspring1[:crossoverPoint], parent1[:crossoverPoint])
@@ -69,6 +82,19 @@ func (s SinglePointCrossover[T]) Crossover(parent1, parent2 []T) ([]T, []T, erro
 	return offspring1, offspring2, nil
 }
 
+// CheckCompatibility checks that the chromosomes of the population are non-empty and of equal length,
+// as required by Crossover.
+func (s SinglePointCrossover[T]) CheckCompatibility(population *core.Population[T]) error {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil
+	}
+	length := len(population.Individuals[0].Chromosome)
+	if err := core.CheckLengths(population, core.LengthLimits{Min: max(length, 1), Max: max(length, 1)}); err != nil {
+		return NewCrossoverError("single-point crossover requires non-empty chromosomes of equal length", err)
+	}
+	return nil
+}
+
 // CrossoverError represents an error that occurs during a crossover process.
 // Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
 type CrossoverError struct {
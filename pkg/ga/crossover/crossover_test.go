@@ -6,7 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/tomhoffer/darwinium/internal/core"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
 // TestSinglePointCrossover_Int tests the SinglePointCrossover with integer chromosomes.
@@ -114,3 +114,24 @@ func TestSinglePointCrossover_String(t *testing.T) {
 		assert.NotEqual(t, parent2, offspring2)
 	})
 }
+
+func TestSinglePointCrossover_CheckCompatibility(t *testing.T) {
+	crossover := NewSinglePointCrossover[int]()
+	t.Run("accepts chromosomes of equal length", func(t *testing.T) {
+		population := &core.Population[int]{Individuals: []core.Solution[int]{{Chromosome: []int{1, 2}}, {Chromosome: []int{3, 4}}}}
+		assert.NoError(t, crossover.CheckCompatibility(population))
+	})
+
+	t.Run("rejects empty chromosomes and chromosomes of different lengths", func(t *testing.T) {
+		for _, chromosomes := range [][][]int{{{}, {}}, {{1, 2}, {3}}} {
+			population := &core.Population[int]{}
+			for _, chromosome := range chromosomes {
+				population.Individuals = append(population.Individuals, core.Solution[int]{Chromosome: chromosome})
+			}
+			err := crossover.CheckCompatibility(population)
+			var crossoverErr *CrossoverError
+			require.ErrorAs(t, err, &crossoverErr)
+			assert.ErrorAs(t, err, new(*core.InvalidChromosomeError))
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package crossover
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/tomhoffer/darwinium/internal/random"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// OrderCrossover implements the order crossover (OX) for permutations, e.g. tours of a
+// traveling salesman. Each offspring inherits a random segment of one parent at the same
+// positions and receives the remaining genes in the order they appear in the other parent,
+// starting after the segment. Offspring of two permutations of the same genes are permutations
+// of these genes, too.
+type OrderCrossover[T comparable] struct {
+	rng *random.Rand
+}
+
+// NewOrderCrossover creates and returns a new OrderCrossover instance.
+func NewOrderCrossover[T comparable]() *OrderCrossover[T] {
+	return &OrderCrossover[T]{}
+}
+
+// SetRand implements core.IRandomized.
+func (o *OrderCrossover[T]) SetRand(generator *rand.Rand) {
+	o.rng = random.New(generator)
+}
+
+// Crossover performs an order crossover on two parent permutations of the same genes.
+func (o OrderCrossover[T]) Crossover(parent1, parent2 []T) ([]T, []T, error) {
+	if len(parent1) == 0 || len(parent2) == 0 {
+		return nil, nil, NewCrossoverError("cannot perform crossover", core.NewInvalidChromosomeError("parent chromosomes cannot be empty", nil))
+	}
+	if len(parent1) != len(parent2) {
+		return nil, nil, NewCrossoverError("cannot perform crossover", core.NewInvalidChromosomeError("parent chromosomes must be of the same length", nil))
+	}
+
+	n := len(parent1)
+	start := o.rng.Intn(n)
+	end := start + 1 + o.rng.Intn(n-start)
+	offspring1, ok1 := orderOffspring(parent1, parent2, start, end)
+	offspring2, ok2 := orderOffspring(parent2, parent1, start, end)
+	if !ok1 || !ok2 {
+		return nil, nil, NewCrossoverError("cannot perform crossover", core.NewInvalidChromosomeError("parent chromosomes must be permutations of the same genes", nil))
+	}
+	return offspring1, offspring2, nil
+}
+
+// orderOffspring is a helper function copying donor[start:end] and filling the other positions,
+// starting at end and wrapping around, with the genes of other which are not yet used.
+// It reports false if the parents are not permutations of the same genes.
+func orderOffspring[T comparable](donor, other []T, start, end int) ([]T, bool) {
+	n := len(donor)
+	offspring := make([]T, n)
+	copy(offspring[start:end], donor[start:end])
+
+	// Counting the inherited genes supports repeated genes, as in permutations of a multiset.
+	inherited := make(map[T]int, end-start)
+	for _, gene := range donor[start:end] {
+		inherited[gene]++
+	}
+	free := n - (end - start)
+	filled := 0
+	for k := range n {
+		gene := other[(end+k)%n]
+		if inherited[gene] > 0 {
+			inherited[gene]--
+			continue
+		}
+		if filled == free {
+			return nil, false
+		}
+		offspring[(end+filled)%n] = gene
+		filled++
+	}
+	return offspring, filled == free
+}
+
+// CheckCompatibility checks that the chromosomes of the population are non-empty permutations of the
+// same genes, as required by Crossover.
+func (o OrderCrossover[T]) CheckCompatibility(population *core.Population[T]) error {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil
+	}
+	first := population.Individuals[0].Chromosome
+	counts := make(map[T]int, len(first))
+	for _, gene := range first {
+		counts[gene]++
+	}
+	for i, individual := range population.Individuals {
+		chromosome := individual.Chromosome
+		if len(chromosome) == 0 || len(chromosome) != len(first) || !isPermutation(chromosome, counts) {
+			return NewCrossoverError("order crossover requires permutations of the same genes",
+				core.NewInvalidChromosomeError(fmt.Sprintf("chromosome %d is not a permutation of the genes of chromosome 0", i), nil))
+		}
+	}
+	return nil
+}
+
+// isPermutation is a helper function reporting whether chromosome contains every gene exactly as often as counts.
+func isPermutation[T comparable](chromosome []T, counts map[T]int) bool {
+	remaining := make(map[T]int, len(counts))
+	for gene, count := range counts {
+		remaining[gene] = count
+	}
+	for _, gene := range chromosome {
+		if remaining[gene] == 0 {
+			return false
+		}
+		remaining[gene]--
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package crossover
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+func TestOrderCrossover(t *testing.T) {
+	crossover := NewOrderCrossover[int]()
+
+	t.Run("offspring are permutations", func(t *testing.T) {
+		for range 200 {
+			parent1 := rand.Perm(9)
+			parent2 := rand.Perm(9)
+			offspring1, offspring2, err := crossover.Crossover(parent1, parent2)
+			require.NoError(t, err)
+			for _, offspring := range [][]int{offspring1, offspring2} {
+				sorted := slices.Clone(offspring)
+				slices.Sort(sorted)
+				assert.Equal(t, sequence(0, 9), sorted)
+			}
+		}
+	})
+
+	t.Run("keeps a segment and the relative order of the other parent", func(t *testing.T) {
+		parent1 := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+		parent2 := []int{9, 3, 7, 8, 2, 6, 5, 1, 4}
+		offspring, ok := orderOffspring(parent1, parent2, 3, 7)
+		require.True(t, ok)
+		assert.Equal(t, []int{3, 8, 2, 4, 5, 6, 7, 1, 9}, offspring)
+	})
+
+	t.Run("supports repeated genes", func(t *testing.T) {
+		parent1 := []int{1, 1, 2, 2, 3}
+		parent2 := []int{2, 3, 1, 2, 1}
+		for range 50 {
+			offspring1, offspring2, err := crossover.Crossover(parent1, parent2)
+			require.NoError(t, err)
+			for _, offspring := range [][]int{offspring1, offspring2} {
+				sorted := slices.Clone(offspring)
+				slices.Sort(sorted)
+				assert.Equal(t, []int{1, 1, 2, 2, 3}, sorted)
+			}
+		}
+	})
+
+	t.Run("rejects invalid parents", func(t *testing.T) {
+		for _, parents := range [][2][]int{{{}, {}}, {{1, 2}, {1}}, {{1, 2, 3}, {4, 5, 6}}} {
+			_, _, err := crossover.Crossover(parents[0], parents[1])
+			var crossoverErr *CrossoverError
+			require.ErrorAs(t, err, &crossoverErr)
+			assert.ErrorAs(t, err, new(*core.InvalidChromosomeError))
+		}
+	})
+}
+
+func TestOrderCrossover_CheckCompatibility(t *testing.T) {
+	crossover := NewOrderCrossover[int]()
+	population := func(chromosomes ...[]int) *core.Population[int] {
+		population := &core.Population[int]{}
+		for _, chromosome := range chromosomes {
+			population.Individuals = append(population.Individuals, core.Solution[int]{Chromosome: chromosome})
+		}
+		return population
+	}
+
+	assert.NoError(t, crossover.CheckCompatibility(population([]int{0, 1, 2}, []int{2, 0, 1})))
+	assert.NoError(t, crossover.CheckCompatibility(nil))
+	for _, invalid := range [][][]int{{{}, {}}, {{0, 1, 2}, {0, 1}}, {{0, 1, 2}, {0, 1, 1}}} {
+		err := crossover.CheckCompatibility(population(invalid...))
+		var crossoverErr *CrossoverError
+		require.ErrorAs(t, err, &crossoverErr)
+		assert.ErrorContains(t, err, "permutations")
+	}
+}
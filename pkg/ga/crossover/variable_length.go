@@ -0,0 +1,123 @@
+package crossover
+
+import (
+	"math/rand"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// cutPointAttempts is the number of random cut points tried before variable-length crossovers
+// give up on satisfying the length limits and return copies of the parents.
+const cutPointAttempts = 10
+
+// CutAndSpliceCrossover cuts each parent at an independently chosen point and exchanges the
+// tails, so the offspring lengths generally differ from the parents' lengths.
+// Cut points are chosen such that both offspring satisfy Limits.
+type CutAndSpliceCrossover[T any] struct {
+	Limits core.LengthLimits
+}
+
+// NewCutAndSpliceCrossover creates a CutAndSpliceCrossover producing offspring within the given limits.
+func NewCutAndSpliceCrossover[T any](limits core.LengthLimits) (*CutAndSpliceCrossover[T], error) {
+	if err := limits.Validate(); err != nil {
+		return nil, NewCrossoverError("invalid length limits", err)
+	}
+	return &CutAndSpliceCrossover[T]{Limits: limits}, nil
+}
+
+// Crossover performs a cut-and-splice crossover on two parent chromosomes of possibly different lengths.
+// The first offspring is parent1[:c1] + parent2[c2:] and the second is parent2[:c2] + parent1[c1:].
+// If no pair of cut points satisfies the limits, copies of the parents are returned.
+func (c *CutAndSpliceCrossover[T]) Crossover(parent1, parent2 []T) ([]T, []T, error) {
+	len1, len2 := len(parent1), len(parent2)
+	limits := c.Limits
+
+	start := rand.Intn(len1 + 1)
+	for k := 0; k <= len1; k++ {
+		cut1 := (start + k) % (len1 + 1)
+
+		// The offspring have lengths cut1+len2-cut2 and cut2+len1-cut1
+		lower := max(0, limits.Min-len1+cut1)
+		upper := min(len2, cut1+len2-limits.Min)
+		if limits.Max > 0 {
+			lower = max(lower, cut1+len2-limits.Max)
+			upper = min(upper, limits.Max-len1+cut1)
+		}
+		if lower > upper {
+			continue
+		}
+		cut2 := lower + rand.Intn(upper-lower+1)
+
+		offspring1 := make([]T, 0, cut1+len2-cut2)
+		offspring1 = append(append(offspring1, parent1[:cut1]...), parent2[cut2:]...)
+		offspring2 := make([]T, 0, cut2+len1-cut1)
+		offspring2 = append(append(offspring2, parent2[:cut2]...), parent1[cut1:]...)
+		return offspring1, offspring2, nil
+	}
+	return append([]T{}, parent1...), append([]T{}, parent2...), nil
+}
+
+// MessyCrossover exchanges a segment of each parent. The segments are chosen independently
+// and may differ in length and position, so the offspring lengths generally differ from the
+// parents' lengths. Segments are chosen such that both offspring satisfy Limits.
+type MessyCrossover[T any] struct {
+	Limits core.LengthLimits
+}
+
+// NewMessyCrossover creates a MessyCrossover producing offspring within the given limits.
+func NewMessyCrossover[T any](limits core.LengthLimits) (*MessyCrossover[T], error) {
+	if err := limits.Validate(); err != nil {
+		return nil, NewCrossoverError("invalid length limits", err)
+	}
+	return &MessyCrossover[T]{Limits: limits}, nil
+}
+
+// Crossover performs a messy crossover on two parent chromosomes of possibly different lengths.
+// If no pair of segments satisfying the limits is found, copies of the parents are returned.
+func (m *MessyCrossover[T]) Crossover(parent1, parent2 []T) ([]T, []T, error) {
+	len1, len2 := len(parent1), len(parent2)
+	limits := m.Limits
+
+	for attempt := 0; attempt < cutPointAttempts; attempt++ {
+		start1 := rand.Intn(len1 + 1)
+		end1 := start1 + rand.Intn(len1-start1+1)
+		segment1 := end1 - start1
+
+		// The offspring have lengths len1-segment1+segment2 and len2-segment2+segment1
+		lower := max(0, limits.Min-len1+segment1)
+		upper := min(len2, len2+segment1-limits.Min)
+		if limits.Max > 0 {
+			lower = max(lower, len2+segment1-limits.Max)
+			upper = min(upper, limits.Max-len1+segment1)
+		}
+		if lower > upper {
+			continue
+		}
+		segment2 := lower + rand.Intn(upper-lower+1)
+		start2 := rand.Intn(len2 - segment2 + 1)
+		end2 := start2 + segment2
+
+		offspring1 := make([]T, 0, len1-segment1+segment2)
+		offspring1 = append(append(append(offspring1, parent1[:start1]...), parent2[start2:end2]...), parent1[end1:]...)
+		offspring2 := make([]T, 0, len2-segment2+segment1)
+		offspring2 = append(append(append(offspring2, parent2[:start2]...), parent1[start1:end1]...), parent2[end2:]...)
+		return offspring1, offspring2, nil
+	}
+	return append([]T{}, parent1...), append([]T{}, parent2...), nil
+}
+
+// CheckCompatibility checks that the chromosomes of the population satisfy the length limits.
+func (c *CutAndSpliceCrossover[T]) CheckCompatibility(population *core.Population[T]) error {
+	if err := core.CheckLengths(population, c.Limits); err != nil {
+		return NewCrossoverError("cut-and-splice crossover requires chromosomes within its length limits", err)
+	}
+	return nil
+}
+
+// CheckCompatibility checks that the chromosomes of the population satisfy the length limits.
+func (m *MessyCrossover[T]) CheckCompatibility(population *core.Population[T]) error {
+	if err := core.CheckLengths(population, m.Limits); err != nil {
+		return NewCrossoverError("messy crossover requires chromosomes within its length limits", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package crossover
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// sequence returns the genes [from, from+n).
+func sequence(from, n int) []int {
+	genes := make([]int, n)
+	for i := range genes {
+		genes[i] = from + i
+	}
+	return genes
+}
+
+func TestCutAndSpliceCrossover(t *testing.T) {
+	t.Run("offspring respect the length limits and conserve genes", func(t *testing.T) {
+		limits := core.LengthLimits{Min: 3, Max: 8}
+		cx, err := NewCutAndSpliceCrossover[int](limits)
+		require.NoError(t, err)
+
+		parent1, parent2 := sequence(0, 4), sequence(100, 7)
+		lengths := map[int]bool{}
+		for range 500 {
+			offspring1, offspring2, err := cx.Crossover(parent1, parent2)
+			require.NoError(t, err)
+			assert.True(t, limits.Allows(len(offspring1)), "length %d", len(offspring1))
+			assert.True(t, limits.Allows(len(offspring2)), "length %d", len(offspring2))
+			assert.Equal(t, len(parent1)+len(parent2), len(offspring1)+len(offspring2))
+
+			genes := slices.Concat(offspring1, offspring2)
+			slices.Sort(genes)
+			assert.Equal(t, slices.Concat(parent1, parent2), genes)
+			lengths[len(offspring1)] = true
+		}
+		assert.Greater(t, len(lengths), 2, "offspring lengths should vary")
+	})
+
+	t.Run("parents are not modified", func(t *testing.T) {
+		cx, err := NewCutAndSpliceCrossover[int](core.LengthLimits{})
+		require.NoError(t, err)
+		parent1, parent2 := sequence(0, 5), sequence(10, 2)
+		offspring1, _, err := cx.Crossover(parent1, parent2)
+		require.NoError(t, err)
+		if len(offspring1) > 0 {
+			offspring1[0] = -1
+		}
+		assert.Equal(t, sequence(0, 5), parent1)
+		assert.Equal(t, sequence(10, 2), parent2)
+	})
+
+	t.Run("invalid limits return error", func(t *testing.T) {
+		_, err := NewCutAndSpliceCrossover[int](core.LengthLimits{Min: 5, Max: 2})
+		require.Error(t, err)
+		var ce *CrossoverError
+		assert.True(t, errors.As(err, &ce))
+		assert.True(t, errors.Is(err, core.ErrInvalidLengthLimits))
+	})
+}
+
+func TestMessyCrossover(t *testing.T) {
+	t.Run("offspring respect the length limits and conserve genes", func(t *testing.T) {
+		limits := core.LengthLimits{Min: 2, Max: 6}
+		cx, err := NewMessyCrossover[int](limits)
+		require.NoError(t, err)
+
+		parent1, parent2 := sequence(0, 3), sequence(100, 6)
+		lengths := map[int]bool{}
+		for range 500 {
+			offspring1, offspring2, err := cx.Crossover(parent1, parent2)
+			require.NoError(t, err)
+			assert.True(t, limits.Allows(len(offspring1)), "length %d", len(offspring1))
+			assert.True(t, limits.Allows(len(offspring2)), "length %d", len(offspring2))
+
+			genes := slices.Concat(offspring1, offspring2)
+			slices.Sort(genes)
+			assert.Equal(t, slices.Concat(parent1, parent2), genes)
+			lengths[len(offspring1)] = true
+		}
+		assert.Greater(t, len(lengths), 2, "offspring lengths should vary")
+	})
+
+	t.Run("invalid limits return error", func(t *testing.T) {
+		_, err := NewMessyCrossover[int](core.LengthLimits{Min: -1})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, core.ErrInvalidLengthLimits))
+	})
+}
@@ -0,0 +1,201 @@
+// Package distance provides distance metrics between chromosomes, used by niching and
+// diversity measures to tell how similar two individuals are.
+package distance
+
+import (
+	"math"
+)
+
+// IMetric defines the interface for distance metrics between chromosomes of an arbitrary
+// representation G, see core.Genome.
+type IMetric[G any] interface {
+	// Distance returns the distance between two chromosomes.
+	//
+	// Parameters:
+	//   - a, b: The chromosomes to compare
+	//
+	// Returns:
+	//   - float64: The non-negative distance, 0 for equal chromosomes
+	Distance(a, b G) float64
+}
+
+// MetricFunc adapts a function to the IMetric interface.
+type MetricFunc[G any] func(a, b G) float64
+
+// Distance implements IMetric.
+func (f MetricFunc[G]) Distance(a, b G) float64 {
+	return f(a, b)
+}
+
+// Number is the set of gene types Euclidean and Manhattan distances are defined on.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64
+}
+
+// Hamming counts the positions at which two chromosomes differ. Positions present in only
+// one of the chromosomes count as differences.
+type Hamming[T comparable] struct{}
+
+// Distance implements IMetric.
+func (Hamming[T]) Distance(a, b []T) float64 {
+	n := min(len(a), len(b))
+	distance := max(len(a), len(b)) - n
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			distance++
+		}
+	}
+	return float64(distance)
+}
+
+// Euclidean is the Euclidean distance between chromosomes of numeric genes.
+// Chromosomes of different lengths are infinitely distant.
+type Euclidean[T Number] struct{}
+
+// Distance implements IMetric.
+func (Euclidean[T]) Distance(a, b []T) float64 {
+	if len(a) != len(b) {
+		return math.Inf(1)
+	}
+	sum := 0.0
+	for i := range a {
+		d := float64(a[i]) - float64(b[i])
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// Manhattan is the sum of absolute gene differences between chromosomes of numeric genes.
+// Chromosomes of different lengths are infinitely distant.
+type Manhattan[T Number] struct{}
+
+// Distance implements IMetric.
+func (Manhattan[T]) Distance(a, b []T) float64 {
+	if len(a) != len(b) {
+		return math.Inf(1)
+	}
+	sum := 0.0
+	for i := range a {
+		sum += math.Abs(float64(a[i]) - float64(b[i]))
+	}
+	return sum
+}
+
+// positions maps every gene of a permutation to its position. It reports false if b is not a
+// permutation of the genes of a.
+func positions[T comparable](a, b []T) (map[T]int, bool) {
+	if len(a) != len(b) {
+		return nil, false
+	}
+	position := make(map[T]int, len(a))
+	for i, gene := range a {
+		position[gene] = i
+	}
+	if len(position) != len(a) {
+		return nil, false
+	}
+	seen := make(map[T]struct{}, len(b))
+	for _, gene := range b {
+		if _, ok := position[gene]; !ok {
+			return nil, false
+		}
+		seen[gene] = struct{}{}
+	}
+	return position, len(seen) == len(b)
+}
+
+// KendallTau counts the pairs of genes two permutations order differently, which equals the
+// minimal number of adjacent swaps turning one permutation into the other.
+// Chromosomes which are not permutations of each other are infinitely distant.
+type KendallTau[T comparable] struct{}
+
+// Distance implements IMetric. It runs in O(n log n).
+func (KendallTau[T]) Distance(a, b []T) float64 {
+	position, ok := positions(a, b)
+	if !ok {
+		return math.Inf(1)
+	}
+	// The distance is the number of inversions of b relabelled by the positions in a
+	sequence := make([]int, len(b))
+	for i, gene := range b {
+		sequence[i] = position[gene]
+	}
+	return float64(countInversions(sequence, make([]int, len(sequence))))
+}
+
+// countInversions sorts values by merge sort and returns its number of inversions.
+func countInversions(values, buffer []int) int {
+	if len(values) < 2 {
+		return 0
+	}
+	mid := len(values) / 2
+	inversions := countInversions(values[:mid], buffer[:mid]) + countInversions(values[mid:], buffer[mid:])
+
+	merged := buffer[:0]
+	i, j := 0, mid
+	for i < mid && j < len(values) {
+		if values[i] <= values[j] {
+			merged = append(merged, values[i])
+			i++
+		} else {
+			merged = append(merged, values[j])
+			inversions += mid - i
+			j++
+		}
+	}
+	merged = append(append(merged, values[i:mid]...), values[j:]...)
+	copy(values, merged)
+	return inversions
+}
+
+// Deviation sums how far every gene moved between two permutations, |position in a - position in b|.
+// Chromosomes which are not permutations of each other are infinitely distant.
+type Deviation[T comparable] struct{}
+
+// Distance implements IMetric.
+func (Deviation[T]) Distance(a, b []T) float64 {
+	position, ok := positions(a, b)
+	if !ok {
+		return math.Inf(1)
+	}
+	sum := 0
+	for i, gene := range b {
+		sum += abs(position[gene] - i)
+	}
+	return float64(sum)
+}
+
+// Adjacency counts the edges of the cyclic tour a which are missing in the cyclic tour b,
+// ignoring their direction. It suits routing problems where only neighboring genes matter.
+// Chromosomes which are not permutations of each other are infinitely distant.
+type Adjacency[T comparable] struct{}
+
+// Distance implements IMetric.
+func (Adjacency[T]) Distance(a, b []T) float64 {
+	if _, ok := positions(a, b); !ok {
+		return math.Inf(1)
+	}
+	n := len(b)
+	if n < 3 {
+		return 0
+	}
+	neighbors := make(map[T][2]T, n)
+	for i, gene := range b {
+		neighbors[gene] = [2]T{b[(i+n-1)%n], b[(i+1)%n]}
+	}
+	missing := 0
+	for i, gene := range a {
+		next := a[(i+1)%n]
+		if adjacent := neighbors[gene]; adjacent[0] != next && adjacent[1] != next {
+			missing++
+		}
+	}
+	return float64(missing)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
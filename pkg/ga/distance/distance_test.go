@@ -0,0 +1,77 @@
+package distance
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHamming(t *testing.T) {
+	metric := Hamming[int]{}
+	assert.Equal(t, 0.0, metric.Distance([]int{1, 2, 3}, []int{1, 2, 3}))
+	assert.Equal(t, 2.0, metric.Distance([]int{1, 2, 3}, []int{1, 5, 6}))
+	assert.Equal(t, 3.0, metric.Distance([]int{1, 2, 3}, []int{1, 5, 3, 4, 4}))
+}
+
+func TestEuclideanAndManhattan(t *testing.T) {
+	assert.Equal(t, 5.0, Euclidean[float64]{}.Distance([]float64{0, 0}, []float64{3, 4}))
+	assert.Equal(t, 7.0, Manhattan[int]{}.Distance([]int{0, 0}, []int{3, -4}))
+	assert.True(t, math.IsInf(Euclidean[int]{}.Distance([]int{1}, []int{1, 2}), 1))
+}
+
+func TestPermutationDistances(t *testing.T) {
+	identity := []int{0, 1, 2, 3, 4}
+	reversed := []int{4, 3, 2, 1, 0}
+	shifted := []int{1, 2, 3, 4, 0}
+
+	t.Run("kendall tau counts discordant pairs", func(t *testing.T) {
+		metric := KendallTau[int]{}
+		assert.Equal(t, 0.0, metric.Distance(identity, identity))
+		assert.Equal(t, 10.0, metric.Distance(identity, reversed))
+		assert.Equal(t, 1.0, metric.Distance(identity, []int{1, 0, 2, 3, 4}))
+
+		// Matches the quadratic definition on random permutations
+		for range 20 {
+			a, b := rand.Perm(30), rand.Perm(30)
+			expected := 0
+			position := map[int]int{}
+			for i, gene := range b {
+				position[gene] = i
+			}
+			for i := range a {
+				for j := i + 1; j < len(a); j++ {
+					if position[a[i]] > position[a[j]] {
+						expected++
+					}
+				}
+			}
+			assert.Equal(t, float64(expected), metric.Distance(a, b))
+		}
+	})
+
+	t.Run("deviation sums displacements", func(t *testing.T) {
+		assert.Equal(t, 12.0, Deviation[int]{}.Distance(identity, reversed))
+		assert.Equal(t, 8.0, Deviation[int]{}.Distance(identity, shifted))
+	})
+
+	t.Run("adjacency ignores rotation and direction", func(t *testing.T) {
+		metric := Adjacency[int]{}
+		assert.Equal(t, 0.0, metric.Distance(identity, shifted))
+		assert.Equal(t, 0.0, metric.Distance(identity, reversed))
+		assert.Equal(t, 2.0, metric.Distance(identity, []int{0, 2, 1, 3, 4}))
+	})
+
+	t.Run("non-permutations are infinitely distant", func(t *testing.T) {
+		for _, metric := range []IMetric[[]int]{KendallTau[int]{}, Deviation[int]{}, Adjacency[int]{}} {
+			assert.True(t, math.IsInf(metric.Distance(identity, []int{0, 1, 2, 3, 3}), 1))
+			assert.True(t, math.IsInf(metric.Distance(identity, []int{0, 1}), 1))
+		}
+	})
+}
+
+func TestMetricFunc(t *testing.T) {
+	metric := MetricFunc[float64](func(a, b float64) float64 { return math.Abs(a - b) })
+	assert.Equal(t, 2.5, metric.Distance(1, 3.5))
+}
@@ -0,0 +1,365 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/observer"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+// Defaults of the components configured by NewBuilder and NewGenomeBuilder.
+const (
+	DefaultGenerations    = 100
+	DefaultTournamentSize = 5
+	DefaultElites         = 1
+)
+
+// ErrInvalidConfiguration is wrapped by the errors of Builder.Build.
+var ErrInvalidConfiguration = errors.New("invalid executor configuration")
+
+// ExecutorError represents an error that occurs while building or running an executor.
+type ExecutorError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *ExecutorError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *ExecutorError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewExecutorError constructs a *ExecutorError with the provided message and wrapped error.
+func NewExecutorError(message string, wrapped error) *ExecutorError {
+	return &ExecutorError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// IElitist is implemented by selectors and replacers which carry a number of the best individuals
+// over to the next generation unchanged. The builder checks that fewer elites than individuals are kept.
+type IElitist interface {
+	// Elites returns the number of individuals carried over unchanged.
+	Elites() int
+}
+
+// ICompatibilityChecker is implemented by operators which only support some chromosomes,
+// e.g. crossovers requiring parents of equal length. The builder checks the initial population
+// against every operator implementing it.
+type ICompatibilityChecker[G any] interface {
+	// CheckCompatibility checks whether the operator can be applied to the chromosomes of a population.
+	//
+	// Parameters:
+	//   - population: the initial population of the executor
+	//
+	// Returns:
+	//   - error: nil if the operator supports every chromosome, a description of the first unsupported one otherwise
+	CheckCompatibility(population *core.GenomePopulation[G]) error
+}
+
+// Builder assembles and validates a GenomeExecutor. Every component has a setter returning the
+// builder, so calls can be chained; problems are collected and reported together by Build,
+// before generation 0 rather than deep inside Loop.
+type Builder[G any] struct {
+	genome             core.Genome[G]
+	population         *core.GenomePopulation[G]
+	populationSize     int
+	seed               func() G
+	evaluator          fitness.IGenomeEvaluator[G]
+	selector           selection.IGenomeSelector[G]
+	crossover          crossover.IGenomeCrossover[G]
+	mutator            mutation.IGenomeMutator[G]
+	generations        int
+	workers            int
+	replacer           replacement.IGenomeReplacer[G]
+	offspringSize      int
+	criterion          termination.ITerminationCriterion
+	observers          []observer.IGenomeObserver[G]
+	diversityMeasure   func(population *core.GenomePopulation[G]) float64
+	hallOfFame         *core.HallOfFame[G]
+	localSearcher      localsearch.ILocalSearcher[G]
+	localSearchOptions localsearch.Options
+	restartPolicy      *RestartPolicy[G]
+}
+
+// NewBuilder creates a builder of executors for chromosomes which are slices of genes.
+// It defaults to tournament selection of size DefaultTournamentSize keeping DefaultElites elites,
+// single-point crossover, swap mutation, DefaultGenerations generations and a single worker.
+// The population and the fitness evaluator must be set.
+func NewBuilder[T comparable]() *Builder[[]T] {
+	builder := NewGenomeBuilder(core.NewSliceGenome[T]())
+	builder.crossover = crossover.NewSinglePointCrossover[T]()
+	builder.mutator = mutation.NewSimpleSwapMutator[T]()
+	return builder
+}
+
+// NewGenomeBuilder creates a builder of executors for chromosomes of the representation described
+// by genome. It defaults to tournament selection of size DefaultTournamentSize keeping DefaultElites
+// elites, DefaultGenerations generations and a single worker. The population, the fitness evaluator,
+// the crossover and the mutator must be set.
+func NewGenomeBuilder[G any](genome core.Genome[G]) *Builder[G] {
+	selector, _ := selection.NewGenomeTournamentSelector[G](DefaultTournamentSize, DefaultElites)
+	return &Builder[G]{
+		genome:      genome,
+		selector:    selector,
+		generations: DefaultGenerations,
+		workers:     1,
+	}
+}
+
+// WithPopulation sets the initial population.
+func (b *Builder[G]) WithPopulation(population *core.GenomePopulation[G]) *Builder[G] {
+	b.population = population
+	return b
+}
+
+// WithRandomPopulation lets Build create an initial population of size individuals whose
+// chromosomes are created by seed, e.g. SliceSeed(length, randomGene).
+func (b *Builder[G]) WithRandomPopulation(size int, seed func() G) *Builder[G] {
+	b.populationSize = size
+	b.seed = seed
+	return b
+}
+
+// WithEvaluator sets the fitness evaluator.
+func (b *Builder[G]) WithEvaluator(evaluator fitness.IGenomeEvaluator[G]) *Builder[G] {
+	b.evaluator = evaluator
+	return b
+}
+
+// WithSelector sets the selection operator.
+func (b *Builder[G]) WithSelector(selector selection.IGenomeSelector[G]) *Builder[G] {
+	b.selector = selector
+	return b
+}
+
+// WithCrossover sets the crossover operator.
+func (b *Builder[G]) WithCrossover(crossover crossover.IGenomeCrossover[G]) *Builder[G] {
+	b.crossover = crossover
+	return b
+}
+
+// WithMutator sets the mutation operator.
+func (b *Builder[G]) WithMutator(mutator mutation.IGenomeMutator[G]) *Builder[G] {
+	b.mutator = mutator
+	return b
+}
+
+// WithGenerations sets the number of generations run by GenomeExecutor.Run.
+func (b *Builder[G]) WithGenerations(generations int) *Builder[G] {
+	b.generations = generations
+	return b
+}
+
+// WithWorkers sets the number of workers evaluating and mutating individuals in parallel, -1 for no limit.
+func (b *Builder[G]) WithWorkers(workers int) *Builder[G] {
+	b.workers = workers
+	return b
+}
+
+// WithReplacer sets the survivor selection strategy and the number of offspring bred every
+// generation, 0 for as many offspring as there are parents. See GenomeExecutor.SetReplacer.
+func (b *Builder[G]) WithReplacer(replacer replacement.IGenomeReplacer[G], offspringSize int) *Builder[G] {
+	b.replacer = replacer
+	b.offspringSize = offspringSize
+	return b
+}
+
+// WithTerminationCriterion sets a criterion stopping the run early, see GenomeExecutor.SetTerminationCriterion.
+func (b *Builder[G]) WithTerminationCriterion(criterion termination.ITerminationCriterion) *Builder[G] {
+	b.criterion = criterion
+	return b
+}
+
+// WithObserver adds an observer notified after every evaluated generation.
+func (b *Builder[G]) WithObserver(obs observer.IGenomeObserver[G]) *Builder[G] {
+	b.observers = append(b.observers, obs)
+	return b
+}
+
+// WithDiversityMeasure sets the diversity measure recorded in the statistics, see GenomeExecutor.SetDiversityMeasure.
+func (b *Builder[G]) WithDiversityMeasure(measure func(population *core.GenomePopulation[G]) float64) *Builder[G] {
+	b.diversityMeasure = measure
+	return b
+}
+
+// WithHallOfFame sets the hall of fame updated with every evaluated generation.
+func (b *Builder[G]) WithHallOfFame(hallOfFame *core.HallOfFame[G]) *Builder[G] {
+	b.hallOfFame = hallOfFame
+	return b
+}
+
+// WithLocalSearch sets the local search of a memetic algorithm, see GenomeExecutor.SetLocalSearch.
+func (b *Builder[G]) WithLocalSearch(searcher localsearch.ILocalSearcher[G], options localsearch.Options) *Builder[G] {
+	b.localSearcher = searcher
+	b.localSearchOptions = options
+	return b
+}
+
+// WithRestartPolicy sets the restart policy, see GenomeExecutor.SetRestartPolicy.
+func (b *Builder[G]) WithRestartPolicy(policy RestartPolicy[G]) *Builder[G] {
+	b.restartPolicy = &policy
+	return b
+}
+
+// Build validates the configuration and creates the executor. It returns an *ExecutorError
+// wrapping ErrInvalidConfiguration and a description of every problem found, e.g. missing
+// components, more elites than individuals or chromosomes which the operators do not support.
+func (b *Builder[G]) Build() (*GenomeExecutor[G], error) {
+	var problems []error
+	problem := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if b.genome == nil {
+		problem("genome is required")
+	}
+	population := b.population
+	switch {
+	case population != nil && b.seed != nil:
+		problem("population: set either an initial population or a random population, not both")
+	case population != nil && len(population.Individuals) == 0:
+		problem("population: initial population is empty")
+	case population == nil && b.seed == nil:
+		problem("population: an initial population or a random population is required")
+	case population == nil && b.populationSize < 1:
+		problem("population: size must be at least 1, but was %d", b.populationSize)
+	case population == nil:
+		population = &core.GenomePopulation[G]{Individuals: make([]core.Individual[G], b.populationSize)}
+		for i := range population.Individuals {
+			population.Individuals[i].Chromosome = b.seed()
+		}
+	}
+	if b.evaluator == nil {
+		problem("fitness evaluator is required")
+	}
+	if b.selector == nil {
+		problem("selector is required")
+	}
+	if b.crossover == nil {
+		problem("crossover is required")
+	}
+	if b.mutator == nil {
+		problem("mutator is required")
+	}
+	if b.generations < 1 {
+		problem("generations: must be at least 1, but was %d", b.generations)
+	}
+	if b.workers < 1 && b.workers != -1 {
+		problem("workers: must be at least 1 or -1 for no limit, but was %d", b.workers)
+	}
+	if b.offspringSize < 0 {
+		problem("offspring size: must not be negative, but was %d", b.offspringSize)
+	}
+	if b.offspringSize > 0 && b.replacer == nil {
+		problem("offspring size: requires a replacer")
+	}
+	if b.localSearcher != nil {
+		if err := b.localSearchOptions.Validate(); err != nil {
+			problem("local search: %w", err)
+		}
+	}
+	if b.restartPolicy != nil {
+		if err := b.restartPolicy.validate(); err != nil {
+			problem("restart policy: %w", err)
+		}
+	}
+
+	if population != nil && len(population.Individuals) > 0 {
+		problems = append(problems, b.checkPopulation(population)...)
+	}
+	if len(problems) > 0 {
+		return nil, NewExecutorError("cannot build executor", fmt.Errorf("%w:\n%w", ErrInvalidConfiguration, errors.Join(problems...)))
+	}
+
+	executor := NewGenomeExecutor(b.genome, population, b.evaluator, b.mutator, b.selector, b.crossover, b.generations, b.workers)
+	executor.SetReplacer(b.replacer)
+	executor.SetOffspringSize(b.offspringSize)
+	executor.SetTerminationCriterion(b.criterion)
+	for _, obs := range b.observers {
+		executor.AddObserver(obs)
+	}
+	executor.SetDiversityMeasure(b.diversityMeasure)
+	executor.SetHallOfFame(b.hallOfFame)
+	// Both were validated above
+	_ = executor.SetLocalSearch(b.localSearcher, b.localSearchOptions)
+	if b.restartPolicy != nil {
+		_ = executor.SetRestartPolicy(*b.restartPolicy)
+	}
+	return executor, nil
+}
+
+// checkPopulation is a helper function checking the population against the elites and survivor
+// counts of the operators and against operators supporting only some chromosomes.
+func (b *Builder[G]) checkPopulation(population *core.GenomePopulation[G]) []error {
+	var problems []error
+	size := len(population.Individuals)
+	offspringSize := b.offspringSize
+	if offspringSize == 0 {
+		offspringSize = size
+	}
+
+	components := []struct {
+		name     string
+		operator any
+	}{
+		{"fitness evaluator", b.evaluator},
+		{"selector", b.selector},
+		{"crossover", b.crossover},
+		{"mutator", b.mutator},
+		{"replacer", b.replacer},
+	}
+	for _, component := range components {
+		if elitist, ok := component.operator.(IElitist); ok && elitist.Elites() >= size {
+			problems = append(problems, fmt.Errorf("%s: number of elites (%d) must be smaller than the population size (%d)", component.name, elitist.Elites(), size))
+		}
+		if checker, ok := component.operator.(ICompatibilityChecker[G]); ok {
+			if err := checker.CheckCompatibility(population); err != nil {
+				problems = append(problems, fmt.Errorf("%s: incompatible with the initial population: %w", component.name, err))
+			}
+		}
+	}
+
+	switch replacer := b.replacer.(type) {
+	case *replacement.GenomeGenerationalReplacement[G]:
+		if offspringSize < size-replacer.NumElites {
+			problems = append(problems, fmt.Errorf("replacer: %d offspring cannot fill a population of %d with %d elites", offspringSize, size, replacer.NumElites))
+		}
+	case *replacement.GenomeCommaReplacement[G]:
+		mu := replacer.Mu
+		if mu == 0 {
+			mu = size
+		}
+		if mu > offspringSize {
+			problems = append(problems, fmt.Errorf("replacer: (mu,lambda) replacement needs at least as many offspring (%d) as survivors (%d)", offspringSize, mu))
+		}
+	case *replacement.GenomePlusReplacement[G]:
+		if replacer.Mu > size+offspringSize {
+			problems = append(problems, fmt.Errorf("replacer: mu (%d) exceeds the number of parents and offspring (%d)", replacer.Mu, size+offspringSize))
+		}
+	}
+
+	if b.restartPolicy != nil && b.restartPolicy.KeepElites >= size {
+		problems = append(problems, fmt.Errorf("restart policy: number of kept elites (%d) must be smaller than the population size (%d)", b.restartPolicy.KeepElites, size))
+	}
+	return problems
+}
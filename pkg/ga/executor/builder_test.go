@@ -0,0 +1,217 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/observer"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+// randomGene is a helper function drawing genes from [0, 10).
+func randomGene() int {
+	return rand.Intn(10)
+}
+
+// buildErrors is a helper function building the executor and returning the messages of its problems.
+func buildErrors(t *testing.T, builder *Builder[[]int]) []string {
+	t.Helper()
+	executor, err := builder.Build()
+	require.Error(t, err)
+	assert.Nil(t, executor)
+	assert.ErrorIs(t, err, ErrInvalidConfiguration)
+	var executorErr *ExecutorError
+	require.ErrorAs(t, err, &executorErr)
+
+	// The configuration error wraps the sentinel together with the joined problems
+	var wrapper interface{ Unwrap() []error }
+	require.True(t, errors.As(err, &wrapper))
+	wrapped := wrapper.Unwrap()
+	require.Len(t, wrapped, 2)
+	var messages []string
+	for _, problem := range wrapped[1].(interface{ Unwrap() []error }).Unwrap() {
+		messages = append(messages, problem.Error())
+	}
+	return messages
+}
+
+func TestBuilder(t *testing.T) {
+	t.Run("builds a runnable executor from defaults", func(t *testing.T) {
+		executor, err := NewBuilder[int]().
+			WithRandomPopulation(20, SliceSeed(8, randomGene)).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()).
+			WithGenerations(5).
+			Build()
+		require.NoError(t, err)
+		assert.Equal(t, 5, executor.Generations())
+
+		population, err := executor.Run(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, population.Individuals, 20)
+		assert.Equal(t, 120, executor.Evaluations())
+		last, ok := executor.Statistics().Last()
+		require.True(t, ok)
+		assert.Equal(t, 5, last.Generation)
+	})
+
+	t.Run("wires every component", func(t *testing.T) {
+		selector, err := selection.NewTournamentSelector[int](2, 0)
+		require.NoError(t, err)
+		replacer, err := replacement.NewPlusReplacement[int](0)
+		require.NoError(t, err)
+		hallOfFame, err := core.NewHallOfFame(core.NewSliceGenome[int](), 3)
+		require.NoError(t, err)
+		generations := 0
+
+		executor, err := NewBuilder[int]().
+			WithPopulation(createTestPopulation([][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {1, 1, 1}})).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()).
+			WithSelector(selector).
+			WithCrossover(crossover.NewSinglePointCrossover[int]()).
+			WithMutator(mutation.NewSimpleSwapMutator[int](0.5)).
+			WithReplacer(replacer, 2).
+			WithTerminationCriterion(termination.NewMaxEvaluations(12)).
+			WithObserver(observer.FuncObserver[int](func(core.GenerationStatistics, *core.Population[int]) { generations++ })).
+			WithDiversityMeasure(func(*core.Population[int]) float64 { return 1 }).
+			WithHallOfFame(hallOfFame).
+			WithLocalSearch(localsearch.NewSwapHillClimber[int](), localsearch.Options{Probability: 0.5, Budget: 1}).
+			WithRestartPolicy(RestartPolicy[[]int]{Trigger: termination.NewStagnation(100), Seed: SliceSeed(3, randomGene)}).
+			WithWorkers(-1).
+			WithGenerations(50).
+			Build()
+		require.NoError(t, err)
+
+		_, err = executor.Run(context.Background())
+		require.NoError(t, err)
+		assert.Less(t, generations, 50)
+		assert.NotEmpty(t, hallOfFame.Entries)
+		last, _ := executor.Statistics().Last()
+		assert.Equal(t, 1.0, last.Diversity)
+		assert.Same(t, hallOfFame, executor.HallOfFame())
+	})
+
+	t.Run("requires a population and an evaluator", func(t *testing.T) {
+		messages := buildErrors(t, NewBuilder[int]())
+		assert.Equal(t, []string{
+			"population: an initial population or a random population is required",
+			"fitness evaluator is required",
+		}, messages)
+	})
+
+	t.Run("genome builders require representation specific operators", func(t *testing.T) {
+		_, err := NewGenomeBuilder(core.NewSliceGenome[int]()).
+			WithRandomPopulation(10, SliceSeed(4, randomGene)).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()).
+			Build()
+		assert.ErrorContains(t, err, "crossover is required")
+		assert.ErrorContains(t, err, "mutator is required")
+	})
+
+	t.Run("reports every invalid setting", func(t *testing.T) {
+		messages := buildErrors(t, NewBuilder[int]().
+			WithPopulation(createTestPopulation([][]int{{1, 2}})).
+			WithRandomPopulation(5, SliceSeed(2, randomGene)).
+			WithSelector(nil).
+			WithGenerations(0).
+			WithWorkers(0).
+			WithReplacer(nil, 3).
+			WithLocalSearch(nil, localsearch.Options{}).
+			WithRestartPolicy(RestartPolicy[[]int]{}))
+		assert.ElementsMatch(t, []string{
+			"population: set either an initial population or a random population, not both",
+			"fitness evaluator is required",
+			"selector is required",
+			"generations: must be at least 1, but was 0",
+			"workers: must be at least 1 or -1 for no limit, but was 0",
+			"offspring size: requires a replacer",
+			"restart policy: restart trigger cannot be nil",
+		}, messages)
+	})
+
+	t.Run("rejects empty populations", func(t *testing.T) {
+		messages := buildErrors(t, NewBuilder[int]().
+			WithPopulation(&core.Population[int]{}).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()))
+		assert.Equal(t, []string{"population: initial population is empty"}, messages)
+
+		messages = buildErrors(t, NewBuilder[int]().
+			WithRandomPopulation(0, SliceSeed(2, randomGene)).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()))
+		assert.Equal(t, []string{"population: size must be at least 1, but was 0"}, messages)
+	})
+
+	t.Run("requires fewer elites than individuals", func(t *testing.T) {
+		selector, err := selection.NewTournamentSelector[int](2, 3)
+		require.NoError(t, err)
+		replacer, err := replacement.NewGenerationalReplacement[int](3)
+		require.NoError(t, err)
+		messages := buildErrors(t, NewBuilder[int]().
+			WithRandomPopulation(3, SliceSeed(4, randomGene)).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()).
+			WithSelector(selector).
+			WithReplacer(replacer, 0).
+			WithRestartPolicy(RestartPolicy[[]int]{Trigger: termination.NewStagnation(5), Seed: SliceSeed(4, randomGene), KeepElites: 3}))
+		assert.ElementsMatch(t, []string{
+			"selector: number of elites (3) must be smaller than the population size (3)",
+			"replacer: number of elites (3) must be smaller than the population size (3)",
+			"restart policy: number of kept elites (3) must be smaller than the population size (3)",
+		}, messages)
+	})
+
+	t.Run("requires enough offspring for the replacer", func(t *testing.T) {
+		generational, err := replacement.NewGenerationalReplacement[int](1)
+		require.NoError(t, err)
+		comma, err := replacement.NewCommaReplacement[int](0)
+		require.NoError(t, err)
+		plus, err := replacement.NewPlusReplacement[int](20)
+		require.NoError(t, err)
+
+		testCases := []struct {
+			replacer replacement.IReplacer[int]
+			message  string
+		}{
+			{generational, "replacer: 5 offspring cannot fill a population of 10 with 1 elites"},
+			{comma, "replacer: (mu,lambda) replacement needs at least as many offspring (5) as survivors (10)"},
+			{plus, "replacer: mu (20) exceeds the number of parents and offspring (15)"},
+		}
+		for _, tc := range testCases {
+			messages := buildErrors(t, NewBuilder[int]().
+				WithRandomPopulation(10, SliceSeed(4, randomGene)).
+				WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()).
+				WithReplacer(tc.replacer, 5))
+			assert.Equal(t, []string{tc.message}, messages)
+		}
+	})
+
+	t.Run("checks chromosome lengths against the operators", func(t *testing.T) {
+		messages := buildErrors(t, NewBuilder[int]().
+			WithPopulation(createTestPopulation([][]int{{1, 2, 3}, {4}})).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()))
+		require.Len(t, messages, 2)
+		assert.Contains(t, messages[0], "crossover: incompatible with the initial population: single-point crossover requires non-empty chromosomes of equal length")
+		assert.Contains(t, messages[1], "mutator: incompatible with the initial population: swap mutation requires chromosomes of at least 2 genes")
+		assert.Contains(t, messages[1], "chromosome 1 has 1 genes")
+
+		limits, err := core.NewLengthLimits(2, 4)
+		require.NoError(t, err)
+		cutAndSplice, err := crossover.NewCutAndSpliceCrossover[int](limits)
+		require.NoError(t, err)
+		_, err = NewBuilder[int]().
+			WithPopulation(createTestPopulation([][]int{{1, 2, 3}, {4, 5}})).
+			WithEvaluator(fitness.NewSimpleSumFitnessEvaluator[int]()).
+			WithCrossover(cutAndSplice).
+			Build()
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,584 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	progressbar "github.com/schollz/progressbar/v3"
+	"github.com/tomhoffer/darwinium/internal/random"
+	"github.com/tomhoffer/darwinium/internal/utils"
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/observer"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+	"golang.org/x/sync/errgroup"
+)
+
+// GenomeExecutor runs a genetic algorithm on chromosomes of an arbitrary representation G.
+// The genome describes how chromosomes are cloned and compared, while the evaluator and
+// the variation operators define the problem.
+type GenomeExecutor[G any] struct {
+	genome           core.Genome[G]
+	population       *core.GenomePopulation[G]
+	fitnessEvaluator fitness.IGenomeEvaluator[G]
+	mutator          mutation.IGenomeMutator[G]
+	selector         selection.IGenomeSelector[G]
+	crossover        crossover.IGenomeCrossover[G]
+	replacer         replacement.IGenomeReplacer[G]
+	offspringSize    int
+	generations      int
+	numWorkers       int
+
+	terminationCriterion termination.ITerminationCriterion
+	observers            []observer.IGenomeObserver[G]
+	diversityMeasure     func(population *core.GenomePopulation[G]) float64
+	hallOfFame           *core.HallOfFame[G]
+	localSearcher        localsearch.ILocalSearcher[G]
+	localSearchOptions   localsearch.Options
+	restartPolicy        *RestartPolicy[G]
+	restartStatistics    *core.Statistics
+	statistics           *core.Statistics
+	evaluations          int
+	quiet                bool
+	generator            *rand.Rand
+	rng                  *random.Rand
+}
+
+// GeneticAlgorithmExecutor runs a genetic algorithm on chromosomes which are slices of genes.
+type GeneticAlgorithmExecutor[T comparable] = GenomeExecutor[[]T]
+
+// NewGeneticAlgorithmExecutor creates an executor for chromosomes which are slices of genes.
+// It performs no validation; NewBuilder offers defaults for every component and reports
+// misconfiguration before the first generation.
+func NewGeneticAlgorithmExecutor[T comparable](population *core.Population[T], fitnessEvaluator fitness.IFitnessEvaluator[T], mutator mutation.IMutator[T], selector selection.ISelector[T], crossover crossover.ICrossover[T], generations int, numWorkers ...int) *GeneticAlgorithmExecutor[T] {
+	return NewGenomeExecutor(core.NewSliceGenome[T](), population, fitnessEvaluator, mutator, selector, crossover, generations, numWorkers...)
+}
+
+// NewGenomeExecutor creates an executor for chromosomes of the representation described by genome.
+// It performs no validation, see NewGenomeBuilder. If numWorkers is omitted, fitness evaluation and mutation run on a single worker.
+// Selectors implementing selection.IGenomeAware are given the genome to copy the individuals they select.
+func NewGenomeExecutor[G any](genome core.Genome[G], population *core.GenomePopulation[G], fitnessEvaluator fitness.IGenomeEvaluator[G], mutator mutation.IGenomeMutator[G], selector selection.IGenomeSelector[G], crossover crossover.IGenomeCrossover[G], generations int, numWorkers ...int) *GenomeExecutor[G] {
+	// Default to 1 worker if not specified
+	workerCount := 1
+	if len(numWorkers) > 0 {
+		workerCount = numWorkers[0]
+	}
+	if aware, ok := selector.(selection.IGenomeAware[G]); ok {
+		aware.SetGenome(genome)
+	}
+
+	return &GenomeExecutor[G]{
+		genome:           genome,
+		population:       population,
+		fitnessEvaluator: fitnessEvaluator,
+		mutator:          mutator,
+		selector:         selector,
+		crossover:        crossover,
+		generations:      generations,
+		numWorkers:       workerCount,
+	}
+}
+
+// SetProgress enables or disables the progress bar and the messages printed when a run starts
+// and finishes. Progress is shown by default, except in tests.
+func (e *GenomeExecutor[G]) SetProgress(show bool) {
+	e.quiet = !show
+}
+
+// SetRand implements core.IRandomized. The executor draws its own random numbers from generator
+// and passes it at the start of every run to the operators implementing core.IRandomized, so a
+// seeded generator makes runs on a single worker reproducible. Operators draw from it on every
+// worker, so it must be safe for concurrent use with more than one worker.
+func (e *GenomeExecutor[G]) SetRand(generator *rand.Rand) {
+	e.generator = generator
+	e.rng = random.New(generator)
+}
+
+// shareRand is a helper function passing the generator of the executor, if any, to its operators.
+func (e *GenomeExecutor[G]) shareRand() {
+	if e.generator == nil {
+		return
+	}
+	for _, operator := range []any{e.fitnessEvaluator, e.mutator, e.selector, e.crossover, e.replacer, e.localSearcher} {
+		if randomized, ok := operator.(core.IRandomized); ok {
+			randomized.SetRand(e.generator)
+		}
+	}
+}
+
+// Genome returns the genome describing the chromosomes of the executor.
+func (e *GenomeExecutor[G]) Genome() core.Genome[G] {
+	return e.genome
+}
+
+// SetReplacer configures the survivor selection strategy used by Loop.
+// When a replacer is set, parents are kept until the offspring are evaluated and the
+// replacer decides which individuals survive. Without a replacer, Loop replaces the
+// whole population with offspring every generation.
+func (e *GenomeExecutor[G]) SetReplacer(replacer replacement.IGenomeReplacer[G]) {
+	e.replacer = replacer
+}
+
+// SetOffspringSize configures the number of offspring (λ) bred every generation when a
+// replacer is set. A value of 0 (the default) breeds as many offspring as there are parents.
+func (e *GenomeExecutor[G]) SetOffspringSize(offspringSize int) {
+	e.offspringSize = offspringSize
+}
+
+// SetTerminationCriterion configures a criterion which stops Loop before the requested
+// number of generations has been run. The criterion is checked after every evaluated generation.
+func (e *GenomeExecutor[G]) SetTerminationCriterion(criterion termination.ITerminationCriterion) {
+	e.terminationCriterion = criterion
+}
+
+// AddObserver registers an observer notified after every evaluated generation of Loop.
+func (e *GenomeExecutor[G]) AddObserver(obs observer.IGenomeObserver[G]) {
+	e.observers = append(e.observers, obs)
+}
+
+// SetDiversityMeasure configures a measure of the diversity of the population, such as
+// core.MeanGeneEntropy or a sampled MeanPairwiseDistance, which is recorded as the Diversity of
+// the statistics of every generation. The number of unique genotypes is always recorded.
+func (e *GenomeExecutor[G]) SetDiversityMeasure(measure func(population *core.GenomePopulation[G]) float64) {
+	e.diversityMeasure = measure
+}
+
+// SetHallOfFame configures a hall of fame which is updated with every evaluated generation of
+// Loop, so the best solutions survive even if they are lost from the population. The hall of
+// fame is not cleared between runs, so one hall of fame can span several runs.
+func (e *GenomeExecutor[G]) SetHallOfFame(hallOfFame *core.HallOfFame[G]) {
+	e.hallOfFame = hallOfFame
+}
+
+// HallOfFame returns the configured hall of fame, or nil if none was set.
+func (e *GenomeExecutor[G]) HallOfFame() *core.HallOfFame[G] {
+	return e.hallOfFame
+}
+
+// SetLocalSearch turns the genetic algorithm into a memetic algorithm: after every evaluation of
+// a population, i.e. after variation, each individual is refined by the local searcher with
+// the configured probability. Local search evaluations count towards Evaluations.
+// A nil searcher disables local search. It returns an error if the options are invalid.
+func (e *GenomeExecutor[G]) SetLocalSearch(searcher localsearch.ILocalSearcher[G], options localsearch.Options) error {
+	if searcher != nil {
+		if err := options.Validate(); err != nil {
+			return err
+		}
+	}
+	e.localSearcher = searcher
+	e.localSearchOptions = options
+	return nil
+}
+
+// Statistics returns the statistics recorded by the last call to Loop, or nil before Loop was called.
+func (e *GenomeExecutor[G]) Statistics() *core.Statistics {
+	return e.statistics
+}
+
+// Evaluations returns the number of fitness evaluations performed by the executor so far.
+func (e *GenomeExecutor[G]) Evaluations() int {
+	return e.evaluations
+}
+
+func (e *GenomeExecutor[G]) RefreshFitness(ctx context.Context) error {
+	return e.evaluatePopulation(ctx, e.population)
+}
+
+// evaluatePopulation evaluates the fitness of every individual of the given population in
+// parallel and applies the local search, if configured.
+func (e *GenomeExecutor[G]) evaluatePopulation(ctx context.Context, population *core.GenomePopulation[G]) error {
+	if err := fitness.EvaluatePopulation(ctx, e.fitnessEvaluator, population, e.numWorkers); err != nil {
+		return err
+	}
+	e.evaluations += len(population.Individuals)
+	if e.localSearcher != nil {
+		return e.improvePopulation(ctx, population)
+	}
+	return nil
+}
+
+// improvePopulation refines randomly chosen individuals of the evaluated population in parallel
+// with the local searcher. Lamarckian search replaces the chromosome by the improved one, while
+// Baldwinian search only assigns the improved fitness.
+func (e *GenomeExecutor[G]) improvePopulation(ctx context.Context, population *core.GenomePopulation[G]) error {
+	g, gCtx := errgroup.WithContext(ctx)
+	if e.numWorkers != -1 {
+		g.SetLimit(e.numWorkers)
+	}
+
+	options := e.localSearchOptions
+	evaluations := make([]int, len(population.Individuals))
+	for i := range population.Individuals {
+		if e.rng.Float64() >= options.Probability {
+			continue
+		}
+		individual := &population.Individuals[i]
+		g.Go(func() error {
+			chromosome := &individual.Chromosome
+			if options.Mode == localsearch.Baldwinian {
+				clone := e.genome.Clone(individual.Chromosome)
+				chromosome = &clone
+			}
+			improved, used, err := e.localSearcher.Search(gCtx, chromosome, individual.Fitness, e.fitnessEvaluator, options.Budget)
+			if err != nil {
+				return err
+			}
+			individual.Fitness = improved
+			evaluations[i] = used
+			return nil
+		})
+	}
+
+	err := g.Wait()
+	for _, used := range evaluations {
+		e.evaluations += used
+	}
+	if err != nil {
+		return localsearch.NewLocalSearchError("failed to perform local search", err)
+	}
+	return nil
+}
+
+// recordGeneration records the statistics of the current, evaluated population, updates the
+// hall of fame, notifies the observers and reports whether the termination criterion is met.
+func (e *GenomeExecutor[G]) recordGeneration(generation int) (bool, error) {
+	generationStatistics, err := core.NewGenerationStatistics(e.population, generation, e.evaluations)
+	if err != nil {
+		return false, err
+	}
+	generationStatistics.UniqueGenotypes = e.population.UniqueGenotypes(e.genome)
+	if e.diversityMeasure != nil {
+		generationStatistics.Diversity = e.diversityMeasure(e.population)
+	}
+	generationStatistics = e.statistics.Record(generationStatistics)
+	if e.hallOfFame != nil {
+		e.hallOfFame.Update(e.population, generation)
+	}
+	for _, obs := range e.observers {
+		obs.OnGeneration(generationStatistics, e.population)
+	}
+	return e.terminationCriterion != nil && e.terminationCriterion.ShouldTerminate(e.statistics), nil
+}
+
+func (e *GenomeExecutor[G]) PerformMutation(ctx context.Context) error {
+	return e.mutatePopulation(ctx, e.population)
+}
+
+// mutatePopulation mutates every individual of the given population in parallel.
+func (e *GenomeExecutor[G]) mutatePopulation(ctx context.Context, population *core.GenomePopulation[G]) error {
+	if population == nil || population.Individuals == nil || len(population.Individuals) == 0 {
+		return core.ErrPopulationEmpty
+	}
+
+	// Run mutation in goroutines with limited concurrency
+	g, gCtx := errgroup.WithContext(ctx)
+
+	if e.numWorkers != -1 {
+		g.SetLimit(e.numWorkers)
+	}
+
+	for i := range population.Individuals {
+		individualIndex := i // explicit capture
+		g.Go(func() error {
+			err := e.mutator.Mutate(gCtx, &population.Individuals[individualIndex].Chromosome)
+			if err != nil {
+				return err
+			}
+			return nil
+		})
+	}
+
+	// Wait for all goroutines to finish
+	if err := g.Wait(); err != nil {
+		return fitness.NewFitnessEvaluationError("failed to evaluate fitness", err)
+	}
+	return nil
+}
+
+func (e *GenomeExecutor[G]) PerformSelection() (*core.GenomePopulation[G], error) {
+	if e.population == nil || e.population.Individuals == nil || len(e.population.Individuals) == 0 {
+		return nil, core.ErrPopulationEmpty
+	}
+	newPopulation, err := e.selector.Select(e.population)
+	if err != nil {
+		return nil, err
+	}
+	return newPopulation, nil
+}
+
+func (e *GenomeExecutor[G]) PerformCrossover() (*core.GenomePopulation[G], error) {
+	return e.crossoverPopulation(e.population)
+}
+
+// crossoverPopulation shuffles the given mating pool in place and recombines consecutive pairs.
+func (e *GenomeExecutor[G]) crossoverPopulation(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if population == nil || population.Individuals == nil || len(population.Individuals) == 0 {
+		return nil, crossover.NewCrossoverError("cannot perform crossover on empty population", core.ErrPopulationEmpty)
+	}
+
+	individuals := population.Individuals
+	e.rng.Shuffle(len(individuals), func(i, j int) {
+		individuals[i], individuals[j] = individuals[j], individuals[i]
+	})
+
+	offspringPopulation := &core.GenomePopulation[G]{
+		Individuals: make([]core.Individual[G], 0, len(individuals)),
+	}
+
+	for i := 0; i < len(individuals); i += 2 {
+		if i+1 >= len(individuals) {
+			// The unpaired individual may share its chromosome with other members of the mating pool
+			leftover := core.Individual[G]{Chromosome: e.genome.Clone(individuals[i].Chromosome), Fitness: individuals[i].Fitness, Violation: individuals[i].Violation}
+			offspringPopulation.Individuals = append(offspringPopulation.Individuals, leftover)
+			break
+		}
+
+		parent1 := individuals[i]
+		parent2 := individuals[i+1]
+
+		offspringChr1, offspringChr2, err := e.crossover.Crossover(parent1.Chromosome, parent2.Chromosome)
+		if err != nil {
+			return nil, err
+		}
+
+		offspringPopulation.Individuals = append(offspringPopulation.Individuals, core.Individual[G]{Chromosome: offspringChr1}, core.Individual[G]{Chromosome: offspringChr2})
+	}
+
+	return offspringPopulation, nil
+}
+
+// PerformBreeding produces an evaluated offspring population from the current population.
+// Parents are selected repeatedly until the mating pool holds the configured number of
+// offspring, which are then recombined, mutated and evaluated. The current population is left untouched.
+func (e *GenomeExecutor[G]) PerformBreeding(ctx context.Context) (*core.GenomePopulation[G], error) {
+	if e.population == nil || e.population.Individuals == nil || len(e.population.Individuals) == 0 {
+		return nil, core.ErrPopulationEmpty
+	}
+
+	offspringSize := e.offspringSize
+	if offspringSize <= 0 {
+		offspringSize = len(e.population.Individuals)
+	}
+
+	matingPool := make([]core.Individual[G], 0, offspringSize)
+	for len(matingPool) < offspringSize {
+		selectedPopulation, err := e.PerformSelection()
+		if err != nil {
+			return nil, err
+		}
+		if selectedPopulation == nil || len(selectedPopulation.Individuals) == 0 {
+			return nil, core.ErrPopulationEmpty
+		}
+		matingPool = append(matingPool, selectedPopulation.Individuals...)
+	}
+
+	offspringPopulation, err := e.crossoverPopulation(&core.GenomePopulation[G]{Individuals: matingPool[:offspringSize]})
+	if err != nil {
+		return nil, err
+	}
+	if err := e.mutatePopulation(ctx, offspringPopulation); err != nil {
+		return nil, err
+	}
+	if err := e.evaluatePopulation(ctx, offspringPopulation); err != nil {
+		return nil, err
+	}
+	return offspringPopulation, nil
+}
+
+// Run runs the genetic algorithm for the number of generations the executor was created with, see Loop.
+func (e *GenomeExecutor[G]) Run(ctx context.Context) (*core.GenomePopulation[G], error) {
+	return e.Loop(ctx, e.generations)
+}
+
+// Generations returns the number of generations the executor was created with.
+func (e *GenomeExecutor[G]) Generations() int {
+	return e.generations
+}
+
+// Loop runs the genetic algorithm for the specified number of generations.
+// It performs fitness evaluation, selection, crossover, and mutation in each generation.
+// If a replacer is configured, survivors are chosen among parents and offspring, see loopWithReplacement.
+// After every evaluated generation, statistics are recorded, observers are notified and the
+// termination criterion, if any, may end the run early.
+// The method returns the final population and any error that occurred during execution.
+func (e *GenomeExecutor[G]) Loop(ctx context.Context, generations int) (*core.GenomePopulation[G], error) {
+	e.statistics = core.NewStatistics()
+	e.restartStatistics = core.NewStatistics()
+	e.evaluations = 0
+	return e.loop(ctx, 0, generations)
+}
+
+// Resume continues a run from a checkpoint. The population of the executor must be the population
+// of the last generation recorded in statistics, e.g. saved by an observer. That generation is
+// evaluated and recorded again, then the run continues until the given total number of generations.
+// Statistics and evaluations continue from the checkpoint, while restart triggers start afresh.
+func (e *GenomeExecutor[G]) Resume(ctx context.Context, statistics *core.Statistics, generations int) (*core.GenomePopulation[G], error) {
+	last, ok := statistics.Last()
+	if !ok {
+		return nil, fmt.Errorf("cannot resume a run without recorded generations")
+	}
+	history := statistics.History[:len(statistics.History)-1]
+	e.statistics = core.RestoreStatistics(slices.Clone(history), slices.Clone(statistics.Restarts))
+	e.restartStatistics = core.NewStatistics()
+	e.evaluations = 0
+	if len(history) > 0 {
+		e.evaluations = history[len(history)-1].Evaluations
+	}
+	return e.loop(ctx, last.Generation, generations)
+}
+
+// loop runs the generations from start up to, excluding, generations with the current statistics.
+func (e *GenomeExecutor[G]) loop(ctx context.Context, start, generations int) (*core.GenomePopulation[G], error) {
+	e.shareRand()
+	if e.replacer != nil {
+		return e.loopWithReplacement(ctx, start, generations)
+	}
+
+	// Progress is only shown outside of tests and unless disabled
+	quiet := e.quiet || utils.IsTestEnvironment()
+
+	var bar *progressbar.ProgressBar
+	if !quiet {
+		bar = progressbar.Default(int64(generations - start))
+		fmt.Println("Starting genetic algorithm...")
+	}
+
+	for i := start; i < generations; i++ {
+		if !quiet && bar != nil {
+			if err := bar.Add(1); err != nil {
+				return nil, err
+			}
+		}
+
+		// a. Refresh fitness for the current population
+		if err := e.RefreshFitness(ctx); err != nil {
+			return nil, fmt.Errorf("failed to refresh fitness at generation %d: %w", i, err)
+		}
+
+		// b. Find and print the best fitness in the current generation
+		if _, err := e.population.BestFitness(); err != nil {
+			return nil, fmt.Errorf("failed to get best fitness at generation %d: %w", i, err)
+		}
+
+		// c. Record statistics, notify observers and check the termination criterion
+		terminate, err := e.recordGeneration(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record statistics at generation %d: %w", i, err)
+		}
+		if terminate {
+			finish(quiet)
+			return e.population, nil
+		}
+
+		// d. Restart a stagnated run; the new population is evaluated by the next refresh
+		restarted, err := e.restartIfStagnated(ctx, i, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restart at generation %d: %w", i, err)
+		}
+		if restarted {
+			continue
+		}
+
+		// e. Perform selection
+		selectedPopulation, err := e.PerformSelection()
+		if err != nil {
+			return nil, fmt.Errorf("failed to perform selection at generation %d: %w", i, err)
+		}
+		e.population = selectedPopulation
+
+		// f. Perform crossover
+		offspringPopulation, err := e.PerformCrossover()
+		if err != nil {
+			return nil, fmt.Errorf("failed to perform crossover at generation %d: %w", i, err)
+		}
+		e.population = offspringPopulation
+
+		// g. Perform mutation
+		if err := e.PerformMutation(ctx); err != nil {
+			return nil, fmt.Errorf("failed to perform mutation at generation %d: %w", i, err)
+		}
+	}
+	// h. Re-evaluate fitness for the new population (after crossover + mutation)
+	if err := e.RefreshFitness(ctx); err != nil {
+		return nil, fmt.Errorf("failed to refresh fitness: %w", err)
+	}
+	if _, err := e.recordGeneration(generations); err != nil {
+		return nil, fmt.Errorf("failed to record statistics: %w", err)
+	}
+
+	finish(quiet)
+	return e.population, nil
+}
+
+// loopWithReplacement runs the genetic algorithm using the configured survivor selection strategy.
+// The parents are evaluated once upfront; every generation then breeds and evaluates offspring
+// and lets the replacer build the next generation from parents and offspring.
+func (e *GenomeExecutor[G]) loopWithReplacement(ctx context.Context, start, generations int) (*core.GenomePopulation[G], error) {
+	quiet := e.quiet || utils.IsTestEnvironment()
+
+	var bar *progressbar.ProgressBar
+	if !quiet {
+		bar = progressbar.Default(int64(generations - start))
+		fmt.Println("Starting genetic algorithm...")
+	}
+
+	if err := e.RefreshFitness(ctx); err != nil {
+		return nil, fmt.Errorf("failed to refresh fitness: %w", err)
+	}
+
+	for i := start; i < generations; i++ {
+		terminate, err := e.recordGeneration(i)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record statistics at generation %d: %w", i, err)
+		}
+		if terminate {
+			finish(quiet)
+			return e.population, nil
+		}
+
+		if !quiet && bar != nil {
+			if err := bar.Add(1); err != nil {
+				return nil, err
+			}
+		}
+
+		restarted, err := e.restartIfStagnated(ctx, i, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restart at generation %d: %w", i, err)
+		}
+		if restarted {
+			continue
+		}
+
+		offspringPopulation, err := e.PerformBreeding(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to breed offspring at generation %d: %w", i, err)
+		}
+
+		survivors, err := e.replacer.Replace(e.population, offspringPopulation)
+		if err != nil {
+			return nil, fmt.Errorf("failed to perform replacement at generation %d: %w", i, err)
+		}
+		e.population = survivors
+	}
+	if _, err := e.recordGeneration(generations); err != nil {
+		return nil, fmt.Errorf("failed to record statistics: %w", err)
+	}
+
+	finish(quiet)
+	return e.population, nil
+}
+
+// finish prints the closing message of a run unless progress output is disabled.
+func finish(quiet bool) {
+	if !quiet {
+		fmt.Println("\nFinished genetic algorithm!")
+	}
+}
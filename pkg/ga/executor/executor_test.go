@@ -5,15 +5,20 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/tomhoffer/darwinium/internal/core"
-	"github.com/tomhoffer/darwinium/internal/ga/crossover"
-	"github.com/tomhoffer/darwinium/internal/ga/fitness"
-	"github.com/tomhoffer/darwinium/internal/ga/mutation"
-	"github.com/tomhoffer/darwinium/internal/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/observer"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
 )
 
 // Mock fitness evaluator for testing
@@ -1149,3 +1154,479 @@ func BenchmarkExecutor_PerformMutation_DefaultWorkers(b *testing.B) {
 func BenchmarkExecutor_PerformMutation_UnlimitedWorkers(b *testing.B) {
 	runMutationBenchmark(b, -1)
 }
+
+func TestGeneticAlgorithmExecutor_PerformBreeding(t *testing.T) {
+	t.Run("breeds the configured number of evaluated offspring", func(t *testing.T) {
+		population := createTestPopulation([][]int{{1, 2}, {3, 4}})
+		mockFitness := &mockFitnessEvaluator[int]{fitnessValues: []float64{1, 2, 3, 4, 5}, errorOnIndex: -1}
+		mockSelector := &mockSelector[int]{populationToReturn: createTestPopulation([][]int{{5, 6}, {7, 8}}), errorOnIndex: -1}
+
+		executor := NewGeneticAlgorithmExecutor(population, mockFitness, &mockMutator[int]{errorOnIndex: -1}, mockSelector, &mockCrossover[int]{}, 1)
+		executor.SetOffspringSize(5)
+
+		offspring, err := executor.PerformBreeding(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, offspring.Individuals, 5)
+		assert.Equal(t, 3, mockSelector.callCount, "Selector should be called until the mating pool is full")
+		assert.Equal(t, 5, mockFitness.callCount, "Every offspring should be evaluated")
+		assert.Equal(t, []int{1, 2}, executor.population.Individuals[0].Chromosome, "Parents should be left untouched")
+	})
+
+	t.Run("propagates selection errors", func(t *testing.T) {
+		population := createTestPopulation([][]int{{1, 2}, {3, 4}})
+		mockSelector := &mockSelector[int]{errorOnIndex: 0}
+
+		executor := NewGeneticAlgorithmExecutor(population, &mockFitnessEvaluator[int]{errorOnIndex: -1}, &mockMutator[int]{errorOnIndex: -1}, mockSelector, &mockCrossover[int]{}, 1)
+
+		_, err := executor.PerformBreeding(context.Background())
+		assert.ErrorIs(t, err, errMockSelection)
+	})
+
+	t.Run("returns ErrPopulationEmpty for empty population", func(t *testing.T) {
+		executor := NewGeneticAlgorithmExecutor(&core.Population[int]{}, &mockFitnessEvaluator[int]{}, &mockMutator[int]{}, &mockSelector[int]{}, &mockCrossover[int]{}, 1)
+
+		_, err := executor.PerformBreeding(context.Background())
+		assert.ErrorIs(t, err, core.ErrPopulationEmpty)
+	})
+}
+
+func TestGeneticAlgorithmExecutor_LoopWithReplacement(t *testing.T) {
+	t.Run("plus replacement never loses the best individual", func(t *testing.T) {
+		population := createBenchmarkPopulation(20, 10)
+		fitnessEvaluator := fitness.NewSimpleSumFitnessEvaluator[int]()
+		selector, err := selection.NewTournamentSelector[int](2, 0)
+		require.NoError(t, err)
+		replacer, err := replacement.NewPlusReplacement[int](0)
+		require.NoError(t, err)
+
+		executor := NewGeneticAlgorithmExecutor(population, fitnessEvaluator, mutation.NewSimpleSwapMutator[int](0.5), selector, crossover.NewSinglePointCrossover[int](), 5)
+		executor.SetReplacer(replacer)
+		require.NoError(t, executor.RefreshFitness(context.Background()))
+		initialBest, err := population.BestFitness()
+		require.NoError(t, err)
+
+		finalPopulation, err := executor.Loop(context.Background(), 5)
+		require.NoError(t, err)
+		assert.Len(t, finalPopulation.Individuals, 20)
+
+		finalBest, err := finalPopulation.BestFitness()
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, finalBest, initialBest)
+	})
+
+	t.Run("comma replacement keeps mu best offspring", func(t *testing.T) {
+		population := createTestPopulation([][]int{{1, 2}, {3, 4}})
+		// 2 parents evaluated upfront, then 4 offspring per generation
+		mockFitness := &mockFitnessEvaluator[int]{fitnessValues: []float64{100, 200, 1, 4, 3, 2}, errorOnIndex: -1}
+		mockSelector := &mockSelector[int]{populationToReturn: createTestPopulation([][]int{{5, 6}, {7, 8}}), errorOnIndex: -1}
+		replacer, err := replacement.NewCommaReplacement[int](0)
+		require.NoError(t, err)
+
+		executor := NewGeneticAlgorithmExecutor(population, mockFitness, &mockMutator[int]{errorOnIndex: -1}, mockSelector, &mockCrossover[int]{}, 1)
+		executor.SetReplacer(replacer)
+		executor.SetOffspringSize(4)
+
+		finalPopulation, err := executor.Loop(context.Background(), 1)
+		require.NoError(t, err)
+		require.Len(t, finalPopulation.Individuals, 2)
+		assert.Equal(t, 4.0, finalPopulation.Individuals[0].Fitness)
+		assert.Equal(t, 3.0, finalPopulation.Individuals[1].Fitness)
+	})
+
+	t.Run("propagates replacement errors", func(t *testing.T) {
+		population := createTestPopulation([][]int{{1, 2}, {3, 4}})
+		mockSelector := &mockSelector[int]{populationToReturn: createTestPopulation([][]int{{5, 6}, {7, 8}}), errorOnIndex: -1}
+		replacer, err := replacement.NewCommaReplacement[int](3)
+		require.NoError(t, err)
+
+		executor := NewGeneticAlgorithmExecutor(population, &mockFitnessEvaluator[int]{errorOnIndex: -1}, &mockMutator[int]{errorOnIndex: -1}, mockSelector, &mockCrossover[int]{}, 1)
+		executor.SetReplacer(replacer)
+
+		finalPopulation, err := executor.Loop(context.Background(), 1)
+		require.Error(t, err)
+		assert.Nil(t, finalPopulation)
+		var re *replacement.ReplacementError
+		assert.ErrorAs(t, err, &re)
+	})
+}
+
+func TestGeneticAlgorithmExecutor_TerminationAndObservers(t *testing.T) {
+	t.Run("observers are notified after every evaluated generation", func(t *testing.T) {
+		population := createBenchmarkPopulation(10, 5)
+		selector, err := selection.NewTournamentSelector[int](2, 1)
+		require.NoError(t, err)
+		executor := NewGeneticAlgorithmExecutor(population, fitness.NewSimpleSumFitnessEvaluator[int](), mutation.NewSimpleSwapMutator[int](), selector, crossover.NewSinglePointCrossover[int](), 4)
+		history := observer.NewHistoryObserver[int]()
+		executor.AddObserver(history)
+
+		_, err = executor.Loop(context.Background(), 4)
+		require.NoError(t, err)
+
+		// One record per generation plus one for the final refresh
+		require.Len(t, history.History(), 5)
+		assert.Equal(t, 4, history.History()[4].Generation)
+		assert.Equal(t, 50, executor.Evaluations())
+		assert.Equal(t, history.History(), executor.Statistics().History)
+	})
+
+	t.Run("diversity metrics are recorded and can stop the loop", func(t *testing.T) {
+		population := createBenchmarkPopulation(10, 5)
+		selector, err := selection.NewTournamentSelector[int](10, 0)
+		require.NoError(t, err)
+		executor := NewGeneticAlgorithmExecutor(population, fitness.NewSimpleSumFitnessEvaluator[int](), mutation.NewSimpleSwapMutator[int](0), selector, crossover.NewSinglePointCrossover[int](), 100)
+		executor.SetDiversityMeasure(func(population *core.Population[int]) float64 {
+			entropy, _ := core.MeanGeneEntropy(population)
+			return entropy
+		})
+		executor.SetTerminationCriterion(termination.NewMinUniqueGenotypes(2))
+
+		_, err = executor.Loop(context.Background(), 100)
+		require.NoError(t, err)
+		first := executor.Statistics().History[0]
+		assert.Equal(t, 10, first.UniqueGenotypes)
+		assert.Greater(t, first.Diversity, 0.0)
+
+		// Tournaments over the whole population without mutation converge onto a single genotype
+		last, _ := executor.Statistics().Last()
+		assert.Equal(t, 1, last.UniqueGenotypes)
+		assert.Equal(t, 0.0, last.Diversity)
+	})
+
+	t.Run("hall of fame keeps the best solutions of the run", func(t *testing.T) {
+		population := createBenchmarkPopulation(10, 5)
+		selector, err := selection.NewTournamentSelector[int](2, 0)
+		require.NoError(t, err)
+		executor := NewGeneticAlgorithmExecutor(population, fitness.NewSimpleSumFitnessEvaluator[int](), mutation.NewSimpleSwapMutator[int](), selector, crossover.NewSinglePointCrossover[int](), 10)
+		hallOfFame, err := core.NewHallOfFame[[]int](executor.Genome(), 3)
+		require.NoError(t, err)
+		executor.SetHallOfFame(hallOfFame)
+
+		_, err = executor.Loop(context.Background(), 10)
+		require.NoError(t, err)
+
+		best, ok := executor.HallOfFame().Best()
+		require.True(t, ok)
+		last, _ := executor.Statistics().Last()
+		assert.Equal(t, last.BestEverFitness, best.Individual.Fitness)
+		entries := hallOfFame.Entries()
+		require.Len(t, entries, 3)
+		assert.GreaterOrEqual(t, entries[0].Individual.Fitness, entries[1].Individual.Fitness)
+		assert.False(t, executor.Genome().Equal(entries[0].Individual.Chromosome, entries[1].Individual.Chromosome))
+	})
+
+	t.Run("termination criterion stops the loop early", func(t *testing.T) {
+		population := createBenchmarkPopulation(10, 5)
+		selector, err := selection.NewTournamentSelector[int](2, 1)
+		require.NoError(t, err)
+		executor := NewGeneticAlgorithmExecutor(population, fitness.NewSimpleSumFitnessEvaluator[int](), mutation.NewSimpleSwapMutator[int](), selector, crossover.NewSinglePointCrossover[int](), 100)
+		executor.SetTerminationCriterion(termination.NewMaxGenerations(3))
+
+		finalPopulation, err := executor.Loop(context.Background(), 100)
+		require.NoError(t, err)
+		assert.Len(t, finalPopulation.Individuals, 10)
+		assert.Len(t, executor.Statistics().History, 3)
+		assert.Equal(t, 30, executor.Evaluations())
+	})
+
+	t.Run("termination criterion stops the loop with replacement early", func(t *testing.T) {
+		population := createBenchmarkPopulation(10, 5)
+		selector, err := selection.NewTournamentSelector[int](2, 0)
+		require.NoError(t, err)
+		replacer, err := replacement.NewPlusReplacement[int](0)
+		require.NoError(t, err)
+		executor := NewGeneticAlgorithmExecutor(population, fitness.NewSimpleSumFitnessEvaluator[int](), mutation.NewSimpleSwapMutator[int](), selector, crossover.NewSinglePointCrossover[int](), 100)
+		executor.SetReplacer(replacer)
+		executor.SetTerminationCriterion(termination.NewMaxEvaluations(25))
+
+		_, err = executor.Loop(context.Background(), 100)
+		require.NoError(t, err)
+		assert.Equal(t, 30, executor.Evaluations())
+		assert.Len(t, executor.Statistics().History, 3)
+	})
+}
+
+// gridPoint is a chromosome type which is not a slice, used to test executors over custom genomes.
+type gridPoint struct {
+	X, Y int
+}
+
+// gridGenome implements core.Genome for gridPoint.
+type gridGenome struct{}
+
+func (gridGenome) Clone(p gridPoint) gridPoint { return p }
+func (gridGenome) Len(p gridPoint) int         { return 2 }
+func (gridGenome) Equal(a, b gridPoint) bool   { return a == b }
+func (gridGenome) Hash(p gridPoint) uint64     { return uint64(p.X)<<32 | uint64(uint32(p.Y)) }
+
+// gridEvaluator rewards points close to (3, -2).
+type gridEvaluator struct{}
+
+func (gridEvaluator) Evaluate(ctx context.Context, p *gridPoint) (float64, error) {
+	dx, dy := float64(p.X-3), float64(p.Y+2)
+	return -(dx*dx + dy*dy), nil
+}
+
+// gridMutator moves a point by one step in a random direction.
+type gridMutator struct{}
+
+func (gridMutator) Mutate(ctx context.Context, p *gridPoint) error {
+	switch rand.Intn(4) {
+	case 0:
+		p.X++
+	case 1:
+		p.X--
+	case 2:
+		p.Y++
+	default:
+		p.Y--
+	}
+	return nil
+}
+
+// gridCrossover exchanges the Y coordinates of two points.
+type gridCrossover struct{}
+
+func (gridCrossover) Crossover(p1, p2 gridPoint) (gridPoint, gridPoint, error) {
+	return gridPoint{p1.X, p2.Y}, gridPoint{p2.X, p1.Y}, nil
+}
+
+// route is a chromosome type holding a slice, which only its genome copies deeply.
+type route struct {
+	Stops []int
+}
+
+// routeGenome implements core.Genome for route.
+type routeGenome struct{}
+
+func (routeGenome) Clone(r route) route   { return route{Stops: slices.Clone(r.Stops)} }
+func (routeGenome) Len(r route) int       { return len(r.Stops) }
+func (routeGenome) Equal(a, b route) bool { return slices.Equal(a.Stops, b.Stops) }
+func (routeGenome) Hash(r route) uint64   { return core.NewSliceGenome[int]().Hash(r.Stops) }
+
+func TestGenomeExecutor(t *testing.T) {
+	newPopulation := func() *core.GenomePopulation[gridPoint] {
+		individuals := make([]core.Individual[gridPoint], 20)
+		for i := range individuals {
+			individuals[i] = core.Individual[gridPoint]{Chromosome: gridPoint{rand.Intn(21) - 10, rand.Intn(21) - 10}}
+		}
+		return &core.GenomePopulation[gridPoint]{Individuals: individuals}
+	}
+
+	t.Run("evolves non-slice chromosomes", func(t *testing.T) {
+		selector, err := selection.NewGenomeTournamentSelector[gridPoint](3, 1)
+		require.NoError(t, err)
+		executor := NewGenomeExecutor[gridPoint](gridGenome{}, newPopulation(), gridEvaluator{}, gridMutator{}, selector, gridCrossover{}, 60)
+		assert.Equal(t, gridGenome{}, executor.Genome())
+
+		finalPopulation, err := executor.Loop(context.Background(), 60)
+		require.NoError(t, err)
+		assert.Len(t, finalPopulation.Individuals, 20)
+		last, ok := executor.Statistics().Last()
+		require.True(t, ok)
+		assert.Equal(t, 0.0, last.BestEverFitness)
+	})
+
+	t.Run("evolves non-slice chromosomes with replacement and observers", func(t *testing.T) {
+		selector, err := selection.NewGenomeTournamentSelector[gridPoint](2, 0)
+		require.NoError(t, err)
+		replacer, err := replacement.NewGenomePlusReplacement[gridPoint](0)
+		require.NoError(t, err)
+		executor := NewGenomeExecutor[gridPoint](gridGenome{}, newPopulation(), gridEvaluator{}, gridMutator{}, selector, gridCrossover{}, 40, -1)
+		executor.SetReplacer(replacer)
+		history := observer.NewGenomeHistoryObserver[gridPoint]()
+		executor.AddObserver(history)
+
+		finalPopulation, err := executor.Loop(context.Background(), 40)
+		require.NoError(t, err)
+		assert.Len(t, finalPopulation.Individuals, 20)
+		assert.Len(t, history.History(), 41)
+		assert.Equal(t, 0.0, history.History()[40].BestFitness)
+	})
+
+	t.Run("selected individuals are copied with the genome", func(t *testing.T) {
+		population := &core.GenomePopulation[route]{Individuals: []core.Individual[route]{
+			{Chromosome: route{Stops: []int{0, 1}}, Fitness: 1},
+			{Chromosome: route{Stops: []int{1, 0}}, Fitness: 2},
+		}}
+		selector, err := selection.NewGenomeTournamentSelector[route](2, 1)
+		require.NoError(t, err)
+		executor := NewGenomeExecutor[route](routeGenome{}, population, nil, nil, selector, nil, 1)
+
+		selected, err := executor.PerformSelection()
+		require.NoError(t, err)
+		for _, individual := range selected.Individuals {
+			individual.Chromosome.Stops[0] = -1
+		}
+		assert.Equal(t, []int{0, 1}, population.Individuals[0].Chromosome.Stops)
+		assert.Equal(t, []int{1, 0}, population.Individuals[1].Chromosome.Stops)
+	})
+}
+
+// misplacedEvaluator evaluates a permutation by the negative number of genes not at their own index.
+type misplacedEvaluator struct{}
+
+func (misplacedEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	count := 0
+	for i, gene := range *chromosome {
+		if gene != i {
+			count++
+		}
+	}
+	return -float64(count), nil
+}
+
+func TestGeneticAlgorithmExecutor_LocalSearch(t *testing.T) {
+	newExecutor := func(t *testing.T) *GeneticAlgorithmExecutor[int] {
+		individuals := make([]core.Solution[int], 10)
+		for i := range individuals {
+			individuals[i] = core.Solution[int]{Chromosome: rand.Perm(8)}
+		}
+		selector, err := selection.NewTournamentSelector[int](2, 0)
+		require.NoError(t, err)
+		return NewGeneticAlgorithmExecutor(&core.Population[int]{Individuals: individuals}, misplacedEvaluator{},
+			mutation.NewSimpleSwapMutator[int](1), selector, noCrossover{}, 3, 4)
+	}
+
+	t.Run("lamarckian search writes improvements back", func(t *testing.T) {
+		executor := newExecutor(t)
+		require.NoError(t, executor.SetLocalSearch(localsearch.NewSwapHillClimber[int](), localsearch.Options{Probability: 1, Mode: localsearch.Lamarckian, Budget: 10_000}))
+
+		final, err := executor.Loop(context.Background(), 3)
+		require.NoError(t, err)
+		for _, individual := range final.Individuals {
+			assert.Equal(t, 0.0, individual.Fitness)
+			assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, individual.Chromosome)
+		}
+		assert.Greater(t, executor.Evaluations(), 40, "local search evaluations are counted")
+	})
+
+	t.Run("baldwinian search only assigns the improved fitness", func(t *testing.T) {
+		executor := newExecutor(t)
+		require.NoError(t, executor.SetLocalSearch(localsearch.NewSwapHillClimber[int](), localsearch.Options{Probability: 1, Mode: localsearch.Baldwinian, Budget: 10_000}))
+
+		final, err := executor.Loop(context.Background(), 3)
+		require.NoError(t, err)
+		unsorted := 0
+		for _, individual := range final.Individuals {
+			assert.Equal(t, 0.0, individual.Fitness)
+			if value, _ := (misplacedEvaluator{}).Evaluate(context.Background(), &individual.Chromosome); value < 0 {
+				unsorted++
+			}
+		}
+		assert.Greater(t, unsorted, 0, "chromosomes keep their genes")
+	})
+
+	t.Run("invalid options return error", func(t *testing.T) {
+		executor := newExecutor(t)
+		err := executor.SetLocalSearch(localsearch.NewSwapHillClimber[int](), localsearch.Options{Probability: 1, Budget: 0})
+		var lse *localsearch.LocalSearchError
+		assert.True(t, errors.As(err, &lse))
+		assert.NoError(t, executor.SetLocalSearch(nil, localsearch.Options{}))
+	})
+}
+
+func TestGeneticAlgorithmExecutor_SetRand(t *testing.T) {
+	run := func(t *testing.T, seed int64) []core.Solution[int] {
+		generator := rand.New(rand.NewSource(seed))
+		individuals := make([]core.Solution[int], 10)
+		for i := range individuals {
+			individuals[i] = core.Solution[int]{Chromosome: generator.Perm(8)}
+		}
+		selector, err := selection.NewTournamentSelector[int](2, 0)
+		require.NoError(t, err)
+		executor := NewGeneticAlgorithmExecutor(&core.Population[int]{Individuals: individuals}, misplacedEvaluator{},
+			mutation.NewSimpleSwapMutator[int](0.5), selector, crossover.NewOrderCrossover[int](), 2, 1)
+		executor.SetRand(generator)
+
+		final, err := executor.Loop(context.Background(), 5)
+		require.NoError(t, err)
+		return final.Individuals
+	}
+
+	t.Run("runs with the same seed are reproducible", func(t *testing.T) {
+		assert.Equal(t, run(t, 1), run(t, 1))
+		assert.NotEqual(t, run(t, 1), run(t, 2))
+	})
+
+	t.Run("concurrent runs do not interfere", func(t *testing.T) {
+		expected := run(t, 3)
+		results := make([][]core.Solution[int], 4)
+		done := make(chan int)
+		for i := range results {
+			go func() {
+				results[i] = run(t, 3)
+				done <- i
+			}()
+		}
+		for range results {
+			<-done
+		}
+		for _, result := range results {
+			assert.Equal(t, expected, result)
+		}
+	})
+}
+
+// noCrossover returns copies of the parents, which keeps permutations valid.
+type noCrossover struct{}
+
+func (noCrossover) Crossover(parent1, parent2 []int) ([]int, []int, error) {
+	return append([]int{}, parent1...), append([]int{}, parent2...), nil
+}
+
+func TestGeneticAlgorithmExecutor_Resume(t *testing.T) {
+	newExecutor := func(t *testing.T, population *core.Population[int]) *GeneticAlgorithmExecutor[int] {
+		selector, err := selection.NewTournamentSelector[int](2, 1)
+		require.NoError(t, err)
+		return NewGeneticAlgorithmExecutor(population, fitness.NewSimpleSumFitnessEvaluator[int](), mutation.NewSimpleSwapMutator[int](), selector, crossover.NewSinglePointCrossover[int](), 10)
+	}
+	for _, withReplacement := range []bool{false, true} {
+		t.Run(map[bool]string{false: "continues from a checkpointed generation", true: "continues with replacement"}[withReplacement], func(t *testing.T) {
+			var checkpoint *core.Population[int]
+			first := newExecutor(t, createBenchmarkPopulation(10, 5))
+			if withReplacement {
+				replacer, err := replacement.NewPlusReplacement[int](0)
+				require.NoError(t, err)
+				first.SetReplacer(replacer)
+			}
+			first.AddObserver(observer.FuncObserver[int](func(statistics core.GenerationStatistics, population *core.Population[int]) {
+				if statistics.Generation == 3 {
+					checkpoint = &core.Population[int]{}
+					for _, individual := range population.Individuals {
+						checkpoint.Individuals = append(checkpoint.Individuals, *individual.DeepCopy())
+					}
+				}
+			}))
+			_, err := first.Loop(context.Background(), 4)
+			require.NoError(t, err)
+			require.NotNil(t, checkpoint)
+
+			resumed := newExecutor(t, checkpoint)
+			if withReplacement {
+				replacer, err := replacement.NewPlusReplacement[int](0)
+				require.NoError(t, err)
+				resumed.SetReplacer(replacer)
+			}
+			history := first.Statistics().History[:4]
+			final, err := resumed.Resume(context.Background(), &core.Statistics{History: history}, 10)
+			require.NoError(t, err)
+			assert.Len(t, final.Individuals, 10)
+
+			statistics := resumed.Statistics()
+			require.Len(t, statistics.History, 11)
+			for i, generation := range statistics.History {
+				assert.Equal(t, i, generation.Generation)
+			}
+			assert.Equal(t, history[:3], statistics.History[:3])
+			assert.Equal(t, history[3].BestFitness, statistics.History[3].BestFitness)
+			assert.Greater(t, resumed.Evaluations(), history[3].Evaluations)
+			assert.GreaterOrEqual(t, statistics.History[10].BestEverFitness, history[3].BestEverFitness)
+		})
+	}
+
+	t.Run("requires recorded generations", func(t *testing.T) {
+		executor := newExecutor(t, createBenchmarkPopulation(10, 5))
+		_, err := executor.Resume(context.Background(), core.NewStatistics(), 10)
+		assert.Error(t, err)
+	})
+}
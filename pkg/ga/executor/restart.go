@@ -0,0 +1,156 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+// RestartPolicy configures automatic restarts of a stagnating run, see SetRestartPolicy.
+type RestartPolicy[G any] struct {
+	// Trigger decides when the population has stagnated, e.g. termination.NewStagnation(20) or
+	// termination.NewMinUniqueGenotypes(2). It only sees the generations since the last restart.
+	Trigger termination.ITerminationCriterion
+	// Seed creates the chromosomes of the reinitialized individuals.
+	Seed func() G
+	// KeepElites is the number of best individuals of the stagnated population kept after a restart.
+	KeepElites int
+	// KeepHallOfFame reinserts the entries of the hall of fame, if one is set, after a restart.
+	KeepHallOfFame bool
+	// PopulationGrowth multiplies the population size at every restart, as in IPOP.
+	// Values of 0 and 1 keep the population size.
+	PopulationGrowth float64
+	// MaxRestarts limits the number of restarts. 0 allows an unlimited number.
+	MaxRestarts int
+}
+
+// SliceSeed returns a seeding function creating chromosomes of the given length whose genes
+// are drawn from randomGene, for use as RestartPolicy.Seed.
+func SliceSeed[T any](length int, randomGene func() T) func() []T {
+	return func() []T {
+		chromosome := make([]T, length)
+		for i := range chromosome {
+			chromosome[i] = randomGene()
+		}
+		return chromosome
+	}
+}
+
+// validate checks that the policy is consistent.
+func (p RestartPolicy[G]) validate() error {
+	switch {
+	case p.Trigger == nil:
+		return errors.New("restart trigger cannot be nil")
+	case p.Seed == nil:
+		return errors.New("seeding function cannot be nil")
+	case p.KeepElites < 0:
+		return fmt.Errorf("number of kept elites cannot be negative, but was %d", p.KeepElites)
+	case p.PopulationGrowth < 0 || (p.PopulationGrowth > 0 && p.PopulationGrowth < 1):
+		return fmt.Errorf("population growth must be 0 or at least 1, but was %g", p.PopulationGrowth)
+	case p.MaxRestarts < 0:
+		return fmt.Errorf("maximum number of restarts cannot be negative, but was %d", p.MaxRestarts)
+	}
+	return nil
+}
+
+// SetRestartPolicy lets Loop restart the run whenever the trigger of the policy reports that the
+// population stagnated: the best individuals and the hall of fame are kept as configured, the
+// rest of the population is reinitialized with the seeding function and the population may grow.
+// Every restart is recorded in the statistics. It returns an error if the policy is invalid.
+func (e *GenomeExecutor[G]) SetRestartPolicy(policy RestartPolicy[G]) error {
+	if err := policy.validate(); err != nil {
+		return fmt.Errorf("invalid restart policy: %w", err)
+	}
+	e.restartPolicy = &policy
+	return nil
+}
+
+// Restarts returns the number of restarts performed by the last call to Loop.
+func (e *GenomeExecutor[G]) Restarts() int {
+	if e.statistics == nil {
+		return 0
+	}
+	return len(e.statistics.Restarts)
+}
+
+// shouldRestart records the generation in the statistics of the current restart and reports
+// whether the restart policy asks for a restart.
+func (e *GenomeExecutor[G]) shouldRestart(generation core.GenerationStatistics) bool {
+	if e.restartPolicy == nil {
+		return false
+	}
+	e.restartStatistics.Record(generation)
+	if e.restartPolicy.MaxRestarts > 0 && e.Restarts() >= e.restartPolicy.MaxRestarts {
+		return false
+	}
+	return e.restartPolicy.Trigger.ShouldTerminate(e.restartStatistics)
+}
+
+// restart replaces the population by a reinitialized one after the given generation. The new
+// individuals are not evaluated.
+func (e *GenomeExecutor[G]) restart(generation int) error {
+	policy := e.restartPolicy
+	best, err := e.population.BestFitness()
+	if err != nil {
+		return err
+	}
+
+	size := len(e.population.Individuals)
+	if policy.PopulationGrowth > 1 {
+		size = int(math.Round(float64(size) * policy.PopulationGrowth))
+	}
+
+	individuals := make([]core.Individual[G], 0, size)
+	if policy.KeepHallOfFame && e.hallOfFame != nil {
+		for _, entry := range e.hallOfFame.Entries() {
+			if len(individuals) < size {
+				individuals = append(individuals, entry.Individual)
+			}
+		}
+	}
+	if policy.KeepElites > 0 {
+		sorted := append([]core.Individual[G]{}, e.population.Individuals...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].RanksAbove(&sorted[j]) })
+		for _, elite := range sorted[:min(policy.KeepElites, len(sorted))] {
+			if len(individuals) < size {
+				individuals = append(individuals, core.Individual[G]{Chromosome: e.genome.Clone(elite.Chromosome)})
+			}
+		}
+	}
+	for len(individuals) < size {
+		individuals = append(individuals, core.Individual[G]{Chromosome: policy.Seed()})
+	}
+
+	e.population = &core.GenomePopulation[G]{Individuals: individuals}
+	e.statistics.RecordRestart(core.Restart{
+		Generation:     generation,
+		Evaluations:    e.evaluations,
+		BestFitness:    best,
+		PopulationSize: size,
+	})
+	e.restartStatistics = core.NewStatistics()
+	return nil
+}
+
+// restartIfStagnated restarts the run if the restart policy asks for it and reports whether it did.
+// With evaluate set, the new population is evaluated right away.
+func (e *GenomeExecutor[G]) restartIfStagnated(ctx context.Context, generation int, evaluate bool) (bool, error) {
+	last, _ := e.statistics.Last()
+	if !e.shouldRestart(last) {
+		return false, nil
+	}
+	if err := e.restart(generation); err != nil {
+		return false, err
+	}
+	if evaluate {
+		if err := e.RefreshFitness(ctx); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
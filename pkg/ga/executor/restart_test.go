@@ -0,0 +1,88 @@
+package executor
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+func TestGeneticAlgorithmExecutor_Restarts(t *testing.T) {
+	newExecutor := func(t *testing.T) *GeneticAlgorithmExecutor[int] {
+		selector, err := selection.NewTournamentSelector[int](10, 1)
+		require.NoError(t, err)
+		// Without mutation the population converges and stagnates quickly
+		return NewGeneticAlgorithmExecutor(createBenchmarkPopulation(10, 5), fitness.NewSimpleSumFitnessEvaluator[int](),
+			mutation.NewSimpleSwapMutator[int](0), selector, crossover.NewSinglePointCrossover[int](), 60)
+	}
+	seed := SliceSeed(5, func() int { return rand.Intn(100) })
+
+	t.Run("restarts grow the population and are recorded", func(t *testing.T) {
+		executor := newExecutor(t)
+		require.NoError(t, executor.SetRestartPolicy(RestartPolicy[[]int]{
+			Trigger:          termination.NewStagnation(3),
+			Seed:             seed,
+			KeepElites:       1,
+			PopulationGrowth: 2,
+			MaxRestarts:      2,
+		}))
+
+		final, err := executor.Loop(context.Background(), 60)
+		require.NoError(t, err)
+		require.Equal(t, 2, executor.Restarts())
+
+		restarts := executor.Statistics().Restarts
+		assert.Equal(t, 20, restarts[0].PopulationSize)
+		assert.Equal(t, 40, restarts[1].PopulationSize)
+		assert.Less(t, restarts[0].Generation, restarts[1].Generation)
+		assert.Len(t, final.Individuals, 40)
+
+		last, _ := executor.Statistics().Last()
+		assert.Equal(t, 2, last.Restart)
+		assert.GreaterOrEqual(t, last.BestFitness, restarts[0].BestFitness, "the kept elite preserves the best fitness")
+	})
+
+	t.Run("hall of fame is reinserted with replacement", func(t *testing.T) {
+		executor := newExecutor(t)
+		replacer, err := replacement.NewPlusReplacement[int](0)
+		require.NoError(t, err)
+		executor.SetReplacer(replacer)
+		hallOfFame, err := core.NewHallOfFame[[]int](executor.Genome(), 2)
+		require.NoError(t, err)
+		executor.SetHallOfFame(hallOfFame)
+		require.NoError(t, executor.SetRestartPolicy(RestartPolicy[[]int]{
+			Trigger:        termination.NewStagnation(3),
+			Seed:           seed,
+			KeepHallOfFame: true,
+			MaxRestarts:    1,
+		}))
+
+		final, err := executor.Loop(context.Background(), 60)
+		require.NoError(t, err)
+		require.Equal(t, 1, executor.Restarts())
+		assert.Len(t, final.Individuals, 10)
+
+		best, ok := hallOfFame.Best()
+		require.True(t, ok)
+		finalBest, err := final.BestFitness()
+		require.NoError(t, err)
+		assert.Equal(t, best.Individual.Fitness, finalBest)
+	})
+
+	t.Run("invalid policies return error", func(t *testing.T) {
+		executor := newExecutor(t)
+		assert.Error(t, executor.SetRestartPolicy(RestartPolicy[[]int]{Seed: seed}))
+		assert.Error(t, executor.SetRestartPolicy(RestartPolicy[[]int]{Trigger: termination.NewStagnation(3)}))
+		assert.Error(t, executor.SetRestartPolicy(RestartPolicy[[]int]{Trigger: termination.NewStagnation(3), Seed: seed, PopulationGrowth: 0.5}))
+		assert.Error(t, executor.SetRestartPolicy(RestartPolicy[[]int]{Trigger: termination.NewStagnation(3), Seed: seed, KeepElites: -1}))
+	})
+}
@@ -6,13 +6,14 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/tomhoffer/darwinium/internal/core"
 	"github.com/tomhoffer/darwinium/internal/utils"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
-// IFitnessEvaluator defines the interface for fitness evaluation in genetic algorithms.
+// IGenomeEvaluator defines the interface for fitness evaluation of chromosomes of an
+// arbitrary representation G, see core.Genome.
 // Implementations must provide a method to evaluate the fitness of a chromosome.
-type IFitnessEvaluator[T cmp.Ordered] interface {
+type IGenomeEvaluator[G any] interface {
 	// Evaluate calculates the fitness value of a given chromosome.
 	// Higher fitness values typically indicate better solutions.
 	//
@@ -23,9 +24,31 @@ type IFitnessEvaluator[T cmp.Ordered] interface {
 	// Returns:
 	//   - fitness: The calculated fitness value
 	//   - error: Any error that occurred during evaluation
-	Evaluate(ctx context.Context, chromosome *[]T) (float64, error)
+	Evaluate(ctx context.Context, chromosome *G) (float64, error)
 }
 
+// IConstrainedEvaluator is implemented by evaluators of constrained problems. Besides the
+// fitness it measures how much a chromosome violates the constraints of the problem.
+// EvaluatePopulation stores the violation in the individual's Violation field.
+type IConstrainedEvaluator[G any] interface {
+	IGenomeEvaluator[G]
+	// EvaluateConstrained calculates the fitness and the constraint violation of a given chromosome.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeout
+	//   - chromosome: The genetic material to evaluate
+	//
+	// Returns:
+	//   - fitness: The calculated fitness value, ignoring the constraints
+	//   - violation: The total constraint violation, 0 if the chromosome is feasible
+	//   - error: Any error that occurred during evaluation
+	EvaluateConstrained(ctx context.Context, chromosome *G) (float64, float64, error)
+}
+
+// IFitnessEvaluator defines the interface for fitness evaluation in genetic algorithms
+// whose chromosomes are slices of genes.
+type IFitnessEvaluator[T any] = IGenomeEvaluator[[]T]
+
 // SimpleSumFitnessEvaluator implements a basic fitness evaluator that calculates
 // fitness as the sum of all values in the chromosome. This is a simple example
 // implementation that can be used for testing or as a baseline.
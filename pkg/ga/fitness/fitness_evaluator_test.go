@@ -10,8 +10,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/tomhoffer/darwinium/internal/core"
 	"github.com/tomhoffer/darwinium/internal/utils"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
 func TestSimpleSumFitnessEvaluator_Int(t *testing.T) {
@@ -0,0 +1,53 @@
+package fitness
+
+import (
+	"context"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"golang.org/x/sync/errgroup"
+)
+
+// EvaluatePopulation evaluates the fitness of every individual of a population in parallel
+// and stores the result in the individual's Fitness field. If the evaluator implements
+// IConstrainedEvaluator, the constraint violation is stored in the Violation field as well. At most numWorkers evaluations run
+// concurrently; a value of -1 removes the limit. The first evaluation error cancels the
+// remaining evaluations and is returned wrapped in a FitnessEvaluationError.
+func EvaluatePopulation[G any](ctx context.Context, evaluator IGenomeEvaluator[G], population *core.GenomePopulation[G], numWorkers int) error {
+	if population == nil || population.Individuals == nil || len(population.Individuals) == 0 {
+		return core.ErrPopulationEmpty
+	}
+
+	// Run fitness evaluation in goroutines with limited concurrency
+	g, gCtx := errgroup.WithContext(ctx)
+
+	if numWorkers != -1 {
+		g.SetLimit(numWorkers)
+	}
+
+	constrained, isConstrained := evaluator.(IConstrainedEvaluator[G])
+	for i := range population.Individuals {
+		individual := &population.Individuals[i]
+		g.Go(func() error {
+			if isConstrained {
+				fitness, violation, err := constrained.EvaluateConstrained(gCtx, &individual.Chromosome)
+				if err != nil {
+					return err
+				}
+				individual.Fitness, individual.Violation = fitness, violation
+				return nil
+			}
+			fitness, err := evaluator.Evaluate(gCtx, &individual.Chromosome)
+			if err != nil {
+				return err
+			}
+			individual.Fitness = fitness
+			return nil
+		})
+	}
+
+	// Wait for all goroutines to finish
+	if err := g.Wait(); err != nil {
+		return NewFitnessEvaluationError("failed to evaluate fitness", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package fitness
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+func TestEvaluatePopulation(t *testing.T) {
+	evaluator := NewSimpleSumFitnessEvaluator[int]()
+
+	t.Run("evaluates every individual with limited and unlimited workers", func(t *testing.T) {
+		for _, numWorkers := range []int{1, 4, -1} {
+			population := &core.Population[int]{Individuals: []core.Solution[int]{
+				{Chromosome: []int{1, 2, 3}},
+				{Chromosome: []int{4, 5, 6}},
+				{Chromosome: []int{-1, -1}},
+			}}
+
+			err := EvaluatePopulation[[]int](context.Background(), evaluator, population, numWorkers)
+			require.NoError(t, err)
+			assert.Equal(t, 6.0, population.Individuals[0].Fitness)
+			assert.Equal(t, 15.0, population.Individuals[1].Fitness)
+			assert.Equal(t, -2.0, population.Individuals[2].Fitness)
+		}
+	})
+
+	t.Run("wraps evaluation errors", func(t *testing.T) {
+		population := &core.Population[int]{Individuals: []core.Solution[int]{
+			{Chromosome: []int{1}},
+			{Chromosome: []int{}},
+		}}
+
+		err := EvaluatePopulation[[]int](context.Background(), evaluator, population, 1)
+		var fe *FitnessEvaluationError
+		assert.ErrorAs(t, err, &fe)
+		var ice *core.InvalidChromosomeError
+		assert.ErrorAs(t, err, &ice)
+	})
+
+	t.Run("stores the violation of constrained evaluators", func(t *testing.T) {
+		population := &core.Population[int]{Individuals: []core.Solution[int]{
+			{Chromosome: []int{1, 2}},
+			{Chromosome: []int{4, 5}},
+		}}
+
+		err := EvaluatePopulation[[]int](context.Background(), capacityEvaluator{capacity: 5}, population, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 3.0, population.Individuals[0].Fitness)
+		assert.Equal(t, 0.0, population.Individuals[0].Violation)
+		assert.Equal(t, 9.0, population.Individuals[1].Fitness)
+		assert.Equal(t, 4.0, population.Individuals[1].Violation)
+	})
+
+	t.Run("returns ErrPopulationEmpty for nil or empty population", func(t *testing.T) {
+		assert.ErrorIs(t, EvaluatePopulation[[]int](context.Background(), evaluator, nil, 1), core.ErrPopulationEmpty)
+		assert.ErrorIs(t, EvaluatePopulation[[]int](context.Background(), evaluator, &core.Population[int]{}, 1), core.ErrPopulationEmpty)
+	})
+}
+
+// capacityEvaluator maximizes the sum of the genes subject to the sum not exceeding capacity.
+type capacityEvaluator struct {
+	capacity float64
+}
+
+func (c capacityEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	fitness, _, err := c.EvaluateConstrained(ctx, chromosome)
+	return fitness, err
+}
+
+func (c capacityEvaluator) EvaluateConstrained(ctx context.Context, chromosome *[]int) (float64, float64, error) {
+	sum := 0.0
+	for _, gene := range *chromosome {
+		sum += float64(gene)
+	}
+	return sum, max(0, sum-c.capacity), nil
+}
@@ -0,0 +1,136 @@
+// Package localsearch provides local search operators which refine individuals between the
+// generations of a genetic algorithm, turning it into a memetic algorithm.
+package localsearch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// ILocalSearcher defines the interface for local search operators on chromosomes of an
+// arbitrary representation G, see core.Genome.
+// Implementations improve the chromosome in place and must not exceed the evaluation budget.
+type ILocalSearcher[G any] interface {
+	// Search improves the given chromosome in place.
+	//
+	// Parameters:
+	//   - ctx: Context for cancellation and timeout control
+	//   - chromosome: The genetic material to improve
+	//   - fitness: The fitness of the chromosome before the search
+	//   - evaluator: The evaluator used to assess candidate chromosomes
+	//   - budget: The maximum number of evaluations the search may perform
+	//
+	// Returns:
+	//   - float64: The fitness of the improved chromosome
+	//   - int: The number of evaluations performed
+	//   - error: Any error that occurred during the search
+	Search(ctx context.Context, chromosome *G, fitness float64, evaluator fitness.IGenomeEvaluator[G], budget int) (float64, int, error)
+}
+
+// Mode selects how the result of a local search is inherited.
+type Mode int
+
+const (
+	// Lamarckian writes the improved chromosome back into the individual, so the improvement is inherited.
+	Lamarckian Mode = iota
+	// Baldwinian only assigns the improved fitness to the individual and keeps its chromosome,
+	// so the search guides selection without reducing the diversity of the population.
+	Baldwinian
+)
+
+// String returns the name of the mode.
+func (m Mode) String() string {
+	switch m {
+	case Lamarckian:
+		return "lamarckian"
+	case Baldwinian:
+		return "baldwinian"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// Options configures how an optimizer applies local search.
+type Options struct {
+	// Probability is the probability with which every evaluated individual is refined.
+	Probability float64
+	// Mode selects whether improvements are inherited.
+	Mode Mode
+	// Budget is the maximum number of evaluations of a single search.
+	Budget int
+}
+
+// Validate checks that the options are consistent.
+func (o Options) Validate() error {
+	if o.Probability < 0 || o.Probability > 1 {
+		return NewLocalSearchError("invalid local search probability", fmt.Errorf("probability must be within [0, 1], but was %g", o.Probability))
+	}
+	if o.Mode != Lamarckian && o.Mode != Baldwinian {
+		return NewLocalSearchError("invalid local search mode", fmt.Errorf("unknown mode %v", o.Mode))
+	}
+	if o.Budget < 1 {
+		return NewLocalSearchError("invalid local search budget", fmt.Errorf("budget must be positive, but was %d", o.Budget))
+	}
+	return nil
+}
+
+// evaluationBudget evaluates candidate chromosomes until a number of evaluations is used up.
+type evaluationBudget[G any] struct {
+	ctx       context.Context
+	evaluator fitness.IGenomeEvaluator[G]
+	remaining int
+	used      int
+}
+
+// evaluate returns the fitness of the chromosome. The boolean is false if the budget is used up,
+// in which case the chromosome was not evaluated.
+func (b *evaluationBudget[G]) evaluate(chromosome *G) (float64, bool, error) {
+	if b.remaining <= 0 {
+		return 0, false, nil
+	}
+	if err := b.ctx.Err(); err != nil {
+		return 0, false, NewLocalSearchError("context cancelled", err)
+	}
+	b.remaining--
+	b.used++
+	value, err := b.evaluator.Evaluate(b.ctx, chromosome)
+	if err != nil {
+		return 0, false, NewLocalSearchError("failed to evaluate candidate", err)
+	}
+	return value, true, nil
+}
+
+// LocalSearchError represents an error that occurs during a local search.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type LocalSearchError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *LocalSearchError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *LocalSearchError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewLocalSearchError constructs a *LocalSearchError with the provided message and wrapped error.
+func NewLocalSearchError(message string, wrapped error) *LocalSearchError {
+	return &LocalSearchError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
@@ -0,0 +1,164 @@
+package localsearch
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// circleTour evaluates a tour through points evenly spaced on a unit circle by its negative
+// length. Visiting the points in circular order is optimal.
+type circleTour struct{}
+
+func (circleTour) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	tour := *chromosome
+	n := float64(len(tour))
+	length := 0.0
+	for i := range tour {
+		a := 2 * math.Pi * float64(tour[i]) / n
+		b := 2 * math.Pi * float64(tour[(i+1)%len(tour)]) / n
+		length += math.Hypot(math.Cos(a)-math.Cos(b), math.Sin(a)-math.Sin(b))
+	}
+	return -length, nil
+}
+
+// misplaced evaluates a permutation by the negative number of genes not at their own index.
+type misplaced struct{}
+
+func (misplaced) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	count := 0
+	for i, gene := range *chromosome {
+		if gene != i {
+			count++
+		}
+	}
+	return -float64(count), nil
+}
+
+// shiftedSphere evaluates a point by its negative squared distance to (1, -2, 3).
+type shiftedSphere struct{}
+
+func (shiftedSphere) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	target := []float64{1, -2, 3}
+	sum := 0.0
+	for i, x := range *chromosome {
+		sum += (x - target[i]) * (x - target[i])
+	}
+	return -sum, nil
+}
+
+// failing always returns an error.
+type failing struct{}
+
+func (failing) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	return 0, errors.New("evaluation failed")
+}
+
+func TestPermutationHillClimbers(t *testing.T) {
+	t.Run("2-opt finds the optimal tour on a circle", func(t *testing.T) {
+		tour := rand.Perm(12)
+		start, _ := circleTour{}.Evaluate(context.Background(), &tour)
+		value, used, err := NewTwoOptHillClimber[int]().Search(context.Background(), &tour, start, circleTour{}, 100_000)
+		require.NoError(t, err)
+		assert.InDelta(t, -12*2*math.Sin(math.Pi/12), value, 1e-9)
+		assert.Greater(t, used, 0)
+
+		check, _ := circleTour{}.Evaluate(context.Background(), &tour)
+		assert.Equal(t, value, check, "returned fitness must match the chromosome")
+	})
+
+	t.Run("swap climbing sorts a permutation", func(t *testing.T) {
+		permutation := rand.Perm(10)
+		start, _ := misplaced{}.Evaluate(context.Background(), &permutation)
+		value, _, err := NewSwapHillClimber[int]().Search(context.Background(), &permutation, start, misplaced{}, 100_000)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, value)
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, permutation)
+	})
+
+	t.Run("budget is respected and the chromosome stays consistent", func(t *testing.T) {
+		permutation := []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+		start, _ := misplaced{}.Evaluate(context.Background(), &permutation)
+		value, used, err := NewSwapHillClimber[int]().Search(context.Background(), &permutation, start, misplaced{}, 7)
+		require.NoError(t, err)
+		assert.Equal(t, 7, used)
+		check, _ := misplaced{}.Evaluate(context.Background(), &permutation)
+		assert.Equal(t, value, check)
+	})
+
+	t.Run("evaluation errors are wrapped", func(t *testing.T) {
+		permutation := []int{1, 0}
+		_, _, err := NewSwapHillClimber[int]().Search(context.Background(), &permutation, 0, failing{}, 10)
+		var lse *LocalSearchError
+		assert.True(t, errors.As(err, &lse))
+		assert.Equal(t, []int{1, 0}, permutation)
+	})
+}
+
+func TestNumericSearches(t *testing.T) {
+	coordinate, err := NewCoordinateSearch(1, 1e-6, 0.5, nil)
+	require.NoError(t, err)
+	pattern, err := NewPatternSearch(1, 1e-6, 0.5, nil)
+	require.NoError(t, err)
+
+	for name, searcher := range map[string]ILocalSearcher[[]float64]{"coordinate search": coordinate, "pattern search": pattern} {
+		t.Run(name+" converges on a sphere", func(t *testing.T) {
+			point := []float64{-4.3, 7.1, 0.2}
+			start, _ := shiftedSphere{}.Evaluate(context.Background(), &point)
+			value, used, err := searcher.Search(context.Background(), &point, start, shiftedSphere{}, 10_000)
+			require.NoError(t, err)
+			assert.Greater(t, value, -1e-9)
+			assert.InDeltaSlice(t, []float64{1, -2, 3}, point, 1e-4)
+			assert.LessOrEqual(t, used, 10_000)
+
+			check, _ := shiftedSphere{}.Evaluate(context.Background(), &point)
+			assert.Equal(t, value, check, "returned fitness must match the chromosome")
+		})
+
+		t.Run(name+" respects the budget", func(t *testing.T) {
+			point := []float64{-4.3, 7.1, 0.2}
+			start, _ := shiftedSphere{}.Evaluate(context.Background(), &point)
+			value, used, err := searcher.Search(context.Background(), &point, start, shiftedSphere{}, 5)
+			require.NoError(t, err)
+			assert.Equal(t, 5, used)
+			check, _ := shiftedSphere{}.Evaluate(context.Background(), &point)
+			assert.Equal(t, value, check)
+		})
+	}
+
+	t.Run("bounds are respected", func(t *testing.T) {
+		bounds, err := core.NewBounds([]float64{-1, -1, -1}, []float64{0, 0, 0})
+		require.NoError(t, err)
+		search, err := NewCoordinateSearch(1, 1e-6, 0.5, bounds)
+		require.NoError(t, err)
+		point := []float64{-1, -1, -1}
+		start, _ := shiftedSphere{}.Evaluate(context.Background(), &point)
+		_, _, err = search.Search(context.Background(), &point, start, shiftedSphere{}, 1000)
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0, -1, 0}, point, 1e-9)
+	})
+
+	t.Run("invalid parameters return error", func(t *testing.T) {
+		_, err := NewCoordinateSearch(0, 1e-6, 0.5, nil)
+		assert.Error(t, err)
+		_, err = NewPatternSearch(1, 2, 0.5, nil)
+		assert.Error(t, err)
+		_, err = NewPatternSearch(1, 0.1, 1, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestOptions_Validate(t *testing.T) {
+	assert.NoError(t, Options{Probability: 0.5, Mode: Baldwinian, Budget: 10}.Validate())
+	assert.Error(t, Options{Probability: 1.5, Budget: 10}.Validate())
+	assert.Error(t, Options{Probability: 1, Mode: Mode(7), Budget: 10}.Validate())
+	assert.Error(t, Options{Probability: 1, Budget: 0}.Validate())
+	assert.Equal(t, "lamarckian", Lamarckian.String())
+}
@@ -0,0 +1,161 @@
+package localsearch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// stepSchedule holds the step sizes shared by the numeric searches.
+type stepSchedule struct {
+	// Step is the initial step size.
+	Step float64
+	// MinStep ends the search once the step size falls below it.
+	MinStep float64
+	// Shrink is the factor within (0, 1) applied to the step size when no move improves.
+	Shrink float64
+	// Bounds, if set, keeps candidates within the search space by clipping.
+	Bounds *core.Bounds
+}
+
+// validate checks that the schedule is consistent.
+func (s stepSchedule) validate() error {
+	if s.Step <= 0 || s.MinStep <= 0 || s.MinStep > s.Step {
+		return NewLocalSearchError("invalid step sizes", fmt.Errorf("step sizes must satisfy 0 < min step <= step, but were %g and %g", s.MinStep, s.Step))
+	}
+	if s.Shrink <= 0 || s.Shrink >= 1 {
+		return NewLocalSearchError("invalid shrink factor", fmt.Errorf("shrink factor must be within (0, 1), but was %g", s.Shrink))
+	}
+	return nil
+}
+
+// explore tries a step in both directions along every coordinate and keeps every improving move.
+// It returns the resulting fitness, whether the budget allows further evaluations and any error.
+func (s stepSchedule) explore(b *evaluationBudget[[]float64], chromosome *[]float64, current, step float64) (float64, bool, error) {
+	x := *chromosome
+	for i := range x {
+		old := x[i]
+		for _, direction := range []float64{1, -1} {
+			x[i] = old + direction*step
+			if s.Bounds != nil {
+				s.Bounds.Repair(x, core.BoundaryClip)
+			}
+			if x[i] == old {
+				continue
+			}
+			value, ok, err := b.evaluate(chromosome)
+			if err != nil || !ok {
+				x[i] = old
+				return current, false, err
+			}
+			if value > current {
+				current = value
+				break
+			}
+			x[i] = old
+		}
+	}
+	return current, true, nil
+}
+
+// CoordinateSearch improves numeric chromosomes by moving one gene at a time by plus or minus
+// the step size, shrinking the step whenever no coordinate move improves the fitness.
+type CoordinateSearch struct {
+	stepSchedule
+}
+
+// NewCoordinateSearch creates a CoordinateSearch. bounds may be nil for an unbounded search space.
+func NewCoordinateSearch(step, minStep, shrink float64, bounds *core.Bounds) (*CoordinateSearch, error) {
+	schedule := stepSchedule{Step: step, MinStep: minStep, Shrink: shrink, Bounds: bounds}
+	if err := schedule.validate(); err != nil {
+		return nil, err
+	}
+	return &CoordinateSearch{stepSchedule: schedule}, nil
+}
+
+// Search implements ILocalSearcher.
+func (c *CoordinateSearch) Search(ctx context.Context, chromosome *[]float64, fitness float64, evaluator fitness.IGenomeEvaluator[[]float64], budget int) (float64, int, error) {
+	b := &evaluationBudget[[]float64]{ctx: ctx, evaluator: evaluator, remaining: budget}
+	current := fitness
+	for step := c.Step; step >= c.MinStep; {
+		value, ok, err := c.explore(b, chromosome, current, step)
+		if err != nil || !ok {
+			return value, b.used, err
+		}
+		if value <= current {
+			step *= c.Shrink
+		}
+		current = value
+	}
+	return current, b.used, nil
+}
+
+// PatternSearch implements the Hooke-Jeeves pattern search for numeric chromosomes. After a
+// successful exploratory move around the base point it jumps further in the same direction,
+// which accelerates the search along ridges; otherwise the step size shrinks.
+type PatternSearch struct {
+	stepSchedule
+}
+
+// NewPatternSearch creates a PatternSearch. bounds may be nil for an unbounded search space.
+func NewPatternSearch(step, minStep, shrink float64, bounds *core.Bounds) (*PatternSearch, error) {
+	schedule := stepSchedule{Step: step, MinStep: minStep, Shrink: shrink, Bounds: bounds}
+	if err := schedule.validate(); err != nil {
+		return nil, err
+	}
+	return &PatternSearch{stepSchedule: schedule}, nil
+}
+
+// Search implements ILocalSearcher.
+func (p *PatternSearch) Search(ctx context.Context, chromosome *[]float64, fitness float64, evaluator fitness.IGenomeEvaluator[[]float64], budget int) (float64, int, error) {
+	b := &evaluationBudget[[]float64]{ctx: ctx, evaluator: evaluator, remaining: budget}
+	base, baseFitness := *chromosome, fitness
+	defer func() { *chromosome = base }()
+
+	for step := p.Step; step >= p.MinStep; {
+		candidate := slices.Clone(base)
+		candidateFitness, ok, err := p.explore(b, &candidate, baseFitness, step)
+		if err != nil || !ok {
+			if candidateFitness > baseFitness {
+				base, baseFitness = candidate, candidateFitness
+			}
+			return baseFitness, b.used, err
+		}
+		if candidateFitness <= baseFitness {
+			step *= p.Shrink
+			continue
+		}
+
+		// Pattern moves: keep jumping along the direction of improvement while it pays off
+		for candidateFitness > baseFitness {
+			pattern := make([]float64, len(candidate))
+			for i := range pattern {
+				pattern[i] = 2*candidate[i] - base[i]
+			}
+			base, baseFitness = candidate, candidateFitness
+			if p.Bounds != nil {
+				p.Bounds.Repair(pattern, core.BoundaryClip)
+			}
+
+			patternFitness, ok, err := b.evaluate(&pattern)
+			if err != nil || !ok {
+				return baseFitness, b.used, err
+			}
+			candidate = pattern
+			candidateFitness, ok, err = p.explore(b, &candidate, patternFitness, step)
+			if err != nil {
+				return baseFitness, b.used, err
+			}
+			if !ok {
+				if candidateFitness > baseFitness {
+					base, baseFitness = candidate, candidateFitness
+				}
+				return baseFitness, b.used, nil
+			}
+		}
+	}
+	return baseFitness, b.used, nil
+}
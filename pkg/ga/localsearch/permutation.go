@@ -0,0 +1,76 @@
+package localsearch
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// SwapHillClimber is a first-improvement hill climber in the swap neighborhood: it exchanges two
+// genes, keeps the change if the fitness improves and stops at a local optimum or when the
+// budget is used up. Swaps preserve permutations.
+type SwapHillClimber[T any] struct{}
+
+// NewSwapHillClimber creates a SwapHillClimber.
+func NewSwapHillClimber[T any]() *SwapHillClimber[T] {
+	return &SwapHillClimber[T]{}
+}
+
+// Search implements ILocalSearcher.
+func (s *SwapHillClimber[T]) Search(ctx context.Context, chromosome *[]T, fitness float64, evaluator fitness.IGenomeEvaluator[[]T], budget int) (float64, int, error) {
+	swap := func(genes []T, i, j int) { genes[i], genes[j] = genes[j], genes[i] }
+	return climb(ctx, chromosome, fitness, evaluator, budget, swap, swap)
+}
+
+// TwoOptHillClimber is a first-improvement hill climber in the 2-opt neighborhood: it reverses a
+// segment of the chromosome, which for a tour replaces two edges by two others. It keeps the
+// change if the fitness improves and stops at a local optimum or when the budget is used up.
+type TwoOptHillClimber[T any] struct{}
+
+// NewTwoOptHillClimber creates a TwoOptHillClimber.
+func NewTwoOptHillClimber[T any]() *TwoOptHillClimber[T] {
+	return &TwoOptHillClimber[T]{}
+}
+
+// Search implements ILocalSearcher.
+func (t *TwoOptHillClimber[T]) Search(ctx context.Context, chromosome *[]T, fitness float64, evaluator fitness.IGenomeEvaluator[[]T], budget int) (float64, int, error) {
+	reverse := func(genes []T, i, j int) { slices.Reverse(genes[i : j+1]) }
+	return climb(ctx, chromosome, fitness, evaluator, budget, reverse, reverse)
+}
+
+// climb runs a first-improvement hill climber over the moves (i, j) with i < j. Scans start at a
+// random position and continue after every improvement until a whole scan finds none.
+func climb[T any](ctx context.Context, chromosome *[]T, current float64, evaluator fitness.IGenomeEvaluator[[]T], limit int,
+	apply, undo func(genes []T, i, j int)) (float64, int, error) {
+	genes := *chromosome
+	n := len(genes)
+	b := &evaluationBudget[[]T]{ctx: ctx, evaluator: evaluator, remaining: limit}
+	if n < 2 {
+		return current, 0, nil
+	}
+
+	for improved := true; improved; {
+		improved = false
+		start := rand.Intn(n)
+		for offset := 0; offset < n; offset++ {
+			i := (start + offset) % n
+			for j := i + 1; j < n; j++ {
+				apply(genes, i, j)
+				value, ok, err := b.evaluate(chromosome)
+				if err != nil || !ok {
+					undo(genes, i, j)
+					return current, b.used, err
+				}
+				if value > current {
+					current = value
+					improved = true
+				} else {
+					undo(genes, i, j)
+				}
+			}
+		}
+	}
+	return current, b.used, nil
+}
@@ -2,17 +2,18 @@
 package mutation
 
 import (
-	"cmp"
 	"context"
 	"fmt"
 	"math/rand"
 
-	"github.com/tomhoffer/darwinium/internal/core"
+	"github.com/tomhoffer/darwinium/internal/random"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
-// IMutator defines the interface for chromosome mutation in genetic algorithms.
+// IGenomeMutator defines the interface for mutation of chromosomes of an arbitrary
+// representation G, see core.Genome.
 // Implementations should modify the provided chromosome in place.
-type IMutator[T cmp.Ordered] interface {
+type IGenomeMutator[G any] interface {
 	// Mutate applies a mutation to the given chromosome in place.
 	//
 	// Parameters:
@@ -21,18 +22,23 @@ type IMutator[T cmp.Ordered] interface {
 	//
 	// Returns:
 	//   - error: Any error that occurred during mutation
-	Mutate(ctx context.Context, chromosome *[]T) error
+	Mutate(ctx context.Context, chromosome *G) error
 }
 
+// IMutator defines the interface for chromosome mutation in genetic algorithms
+// whose chromosomes are slices of genes.
+type IMutator[T any] = IGenomeMutator[[]T]
+
 // SimpleSwapMutator performs a simple mutation by swapping two distinct genes
-// at randomly selected positions. This operation is valid for any ordered type.
-type SimpleSwapMutator[T cmp.Ordered] struct {
+// at randomly selected positions. This operation is valid for any gene type.
+type SimpleSwapMutator[T any] struct {
 	mutationRate float64
+	rng          *random.Rand
 }
 
 // NewSimpleSwapMutator creates and returns a new SimpleSwapMutator instance.
 // If no mutationRate is provided, it defaults to 0.1 (10%).
-func NewSimpleSwapMutator[T cmp.Ordered](mutationRate ...float64) *SimpleSwapMutator[T] {
+func NewSimpleSwapMutator[T any](mutationRate ...float64) *SimpleSwapMutator[T] {
 	defaultRate := 0.01
 	if len(mutationRate) > 0 {
 		defaultRate = mutationRate[0]
@@ -41,6 +47,11 @@ func NewSimpleSwapMutator[T cmp.Ordered](mutationRate ...float64) *SimpleSwapMut
 	return &SimpleSwapMutator[T]{mutationRate: defaultRate}
 }
 
+// SetRand implements core.IRandomized.
+func (s *SimpleSwapMutator[T]) SetRand(generator *rand.Rand) {
+	s.rng = random.New(generator)
+}
+
 // Mutate swaps two distinct positions in the chromosome. The mutation is performed in place.
 // Returns a MutationError wrapping an InvalidChromosomeError if the chromosome is empty or too short.
 func (s SimpleSwapMutator[T]) Mutate(ctx context.Context, chromosome *[]T) error {
@@ -51,7 +62,7 @@ func (s SimpleSwapMutator[T]) Mutate(ctx context.Context, chromosome *[]T) error
 	if len(*chromosome) < 2 {
 		return NewMutationError("cannot mutate chromosome", core.NewInvalidChromosomeError("chromosome must contain at least 2 genes", nil))
 	}
-	if rand.Float64() > s.mutationRate {
+	if s.rng.Float64() > s.mutationRate {
 		return nil
 	}
 
@@ -61,8 +72,8 @@ func (s SimpleSwapMutator[T]) Mutate(ctx context.Context, chromosome *[]T) error
 	}
 
 	n := len(*chromosome)
-	firstPosition := rand.Intn(n)
-	secondPosition := rand.Intn(n - 1)
+	firstPosition := s.rng.Intn(n)
+	secondPosition := s.rng.Intn(n - 1)
 
 	if firstPosition == secondPosition {
 		secondPosition++
@@ -74,6 +85,35 @@ func (s SimpleSwapMutator[T]) Mutate(ctx context.Context, chromosome *[]T) error
 	return nil
 }
 
+// CheckCompatibility checks that the chromosomes of the population contain at least 2 genes, as required by Mutate.
+func (s SimpleSwapMutator[T]) CheckCompatibility(population *core.Population[T]) error {
+	if err := core.CheckLengths(population, core.LengthLimits{Min: 2}); err != nil {
+		return NewMutationError("swap mutation requires chromosomes of at least 2 genes", err)
+	}
+	return nil
+}
+
+// ChainMutator applies several mutators one after another. It allows combining, for example,
+// insertion and deletion mutations so that chromosomes can both grow and shrink.
+type ChainMutator[G any] struct {
+	mutators []IGenomeMutator[G]
+}
+
+// NewChainMutator creates a ChainMutator applying the given mutators in order.
+func NewChainMutator[G any](mutators ...IGenomeMutator[G]) *ChainMutator[G] {
+	return &ChainMutator[G]{mutators: mutators}
+}
+
+// Mutate applies every mutator in order and stops at the first error.
+func (c *ChainMutator[G]) Mutate(ctx context.Context, chromosome *G) error {
+	for _, mutator := range c.mutators {
+		if err := mutator.Mutate(ctx, chromosome); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // MutationError represents an error that occurs during a mutation process.
 // Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
 type MutationError struct {
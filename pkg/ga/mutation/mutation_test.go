@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
 // TestSimpleSwapMutator_Int tests the SimpleSwapMutator with integer chromosomes.
@@ -248,3 +249,15 @@ func TestSimpleSwapMutator_ContextCancellation(t *testing.T) {
 		assert.Equal(t, original, chromosome)
 	})
 }
+
+func TestSimpleSwapMutator_CheckCompatibility(t *testing.T) {
+	mutator := NewSimpleSwapMutator[int]()
+	population := &core.Population[int]{Individuals: []core.Solution[int]{{Chromosome: []int{1, 2}}, {Chromosome: []int{3, 4, 5}}}}
+	assert.NoError(t, mutator.CheckCompatibility(population))
+
+	population.Individuals = append(population.Individuals, core.Solution[int]{Chromosome: []int{6}})
+	err := mutator.CheckCompatibility(population)
+	var mutationErr *MutationError
+	require.ErrorAs(t, err, &mutationErr)
+	assert.ErrorContains(t, err, "chromosome 2 has 1 genes")
+}
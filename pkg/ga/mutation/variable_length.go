@@ -0,0 +1,169 @@
+package mutation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// validateRate checks that a mutation rate is a probability.
+func validateRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return NewMutationError("invalid mutation rate", fmt.Errorf("mutation rate must be within [0, 1], but was %g", rate))
+	}
+	return nil
+}
+
+// InsertionMutator inserts a new random gene at a random position, growing the chromosome by one.
+// Chromosomes that already have the maximum length of Limits are left unchanged.
+type InsertionMutator[T any] struct {
+	// RandomGene creates the gene to insert.
+	RandomGene func() T
+	// Rate is the probability of applying the mutation.
+	Rate float64
+	// Limits constrains the length of mutated chromosomes.
+	Limits core.LengthLimits
+}
+
+// NewInsertionMutator creates an InsertionMutator drawing inserted genes from randomGene.
+func NewInsertionMutator[T any](randomGene func() T, rate float64, limits core.LengthLimits) (*InsertionMutator[T], error) {
+	if randomGene == nil {
+		return nil, NewMutationError("invalid gene generator", errors.New("gene generator cannot be nil"))
+	}
+	if err := validateRate(rate); err != nil {
+		return nil, err
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, NewMutationError("invalid length limits", err)
+	}
+	return &InsertionMutator[T]{RandomGene: randomGene, Rate: rate, Limits: limits}, nil
+}
+
+// Mutate implements IMutator. The chromosome may be empty.
+func (m *InsertionMutator[T]) Mutate(ctx context.Context, chromosome *[]T) error {
+	if chromosome == nil {
+		return NewMutationError("cannot mutate chromosome", core.NewInvalidChromosomeError("nil chromosome found", nil))
+	}
+	if ctx.Err() != nil {
+		return NewMutationError("context cancelled", ctx.Err())
+	}
+	if rand.Float64() >= m.Rate || !m.Limits.Allows(len(*chromosome)+1) {
+		return nil
+	}
+	position := rand.Intn(len(*chromosome) + 1)
+	*chromosome = slices.Insert(*chromosome, position, m.RandomGene())
+	return nil
+}
+
+// DeletionMutator removes the gene at a random position, shrinking the chromosome by one.
+// Chromosomes that already have the minimum length of Limits are left unchanged.
+type DeletionMutator[T any] struct {
+	// Rate is the probability of applying the mutation.
+	Rate float64
+	// Limits constrains the length of mutated chromosomes.
+	Limits core.LengthLimits
+}
+
+// NewDeletionMutator creates a DeletionMutator.
+func NewDeletionMutator[T any](rate float64, limits core.LengthLimits) (*DeletionMutator[T], error) {
+	if err := validateRate(rate); err != nil {
+		return nil, err
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, NewMutationError("invalid length limits", err)
+	}
+	return &DeletionMutator[T]{Rate: rate, Limits: limits}, nil
+}
+
+// Mutate implements IMutator.
+func (m *DeletionMutator[T]) Mutate(ctx context.Context, chromosome *[]T) error {
+	if chromosome == nil {
+		return NewMutationError("cannot mutate chromosome", core.NewInvalidChromosomeError("nil chromosome found", nil))
+	}
+	if ctx.Err() != nil {
+		return NewMutationError("context cancelled", ctx.Err())
+	}
+	n := len(*chromosome)
+	if n == 0 || rand.Float64() >= m.Rate || !m.Limits.Allows(n-1) {
+		return nil
+	}
+	position := rand.Intn(n)
+	*chromosome = slices.Delete(*chromosome, position, position+1)
+	return nil
+}
+
+// DuplicationMutator copies a random segment of the chromosome and inserts the copy right after
+// the segment. The segment is shortened if necessary so the chromosome does not exceed the
+// maximum length of Limits.
+type DuplicationMutator[T any] struct {
+	// Rate is the probability of applying the mutation.
+	Rate float64
+	// Limits constrains the length of mutated chromosomes.
+	Limits core.LengthLimits
+}
+
+// NewDuplicationMutator creates a DuplicationMutator.
+func NewDuplicationMutator[T any](rate float64, limits core.LengthLimits) (*DuplicationMutator[T], error) {
+	if err := validateRate(rate); err != nil {
+		return nil, err
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, NewMutationError("invalid length limits", err)
+	}
+	return &DuplicationMutator[T]{Rate: rate, Limits: limits}, nil
+}
+
+// Mutate implements IMutator.
+func (m *DuplicationMutator[T]) Mutate(ctx context.Context, chromosome *[]T) error {
+	if chromosome == nil {
+		return NewMutationError("cannot mutate chromosome", core.NewInvalidChromosomeError("nil chromosome found", nil))
+	}
+	if ctx.Err() != nil {
+		return NewMutationError("context cancelled", ctx.Err())
+	}
+	n := len(*chromosome)
+	if n == 0 || rand.Float64() >= m.Rate {
+		return nil
+	}
+
+	maxSegment := n
+	if m.Limits.Max > 0 {
+		maxSegment = min(maxSegment, m.Limits.Max-n)
+	}
+	if maxSegment < 1 {
+		return nil
+	}
+	start := rand.Intn(n)
+	end := start + 1 + rand.Intn(min(maxSegment, n-start))
+	segment := slices.Clone((*chromosome)[start:end])
+	*chromosome = slices.Insert(*chromosome, end, segment...)
+	return nil
+}
+
+// CheckCompatibility checks that the chromosomes of the population satisfy the length limits.
+func (m *InsertionMutator[T]) CheckCompatibility(population *core.Population[T]) error {
+	if err := core.CheckLengths(population, m.Limits); err != nil {
+		return NewMutationError("insertion mutation requires chromosomes within its length limits", err)
+	}
+	return nil
+}
+
+// CheckCompatibility checks that the chromosomes of the population satisfy the length limits.
+func (m *DeletionMutator[T]) CheckCompatibility(population *core.Population[T]) error {
+	if err := core.CheckLengths(population, m.Limits); err != nil {
+		return NewMutationError("deletion mutation requires chromosomes within its length limits", err)
+	}
+	return nil
+}
+
+// CheckCompatibility checks that the chromosomes of the population satisfy the length limits.
+func (m *DuplicationMutator[T]) CheckCompatibility(population *core.Population[T]) error {
+	if err := core.CheckLengths(population, m.Limits); err != nil {
+		return NewMutationError("duplication mutation requires chromosomes within its length limits", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package mutation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+func TestInsertionMutator(t *testing.T) {
+	limits := core.LengthLimits{Min: 0, Max: 4}
+	mut, err := NewInsertionMutator(func() int { return 7 }, 1.0, limits)
+	require.NoError(t, err)
+
+	t.Run("inserts a gene", func(t *testing.T) {
+		chromosome := []int{1, 2}
+		require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+		assert.Len(t, chromosome, 3)
+		assert.Contains(t, chromosome, 7)
+	})
+
+	t.Run("grows an empty chromosome", func(t *testing.T) {
+		chromosome := []int{}
+		require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+		assert.Equal(t, []int{7}, chromosome)
+	})
+
+	t.Run("respects the maximum length", func(t *testing.T) {
+		chromosome := []int{1, 2, 3, 4}
+		require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+		assert.Equal(t, []int{1, 2, 3, 4}, chromosome)
+	})
+
+	t.Run("invalid arguments return error", func(t *testing.T) {
+		_, err := NewInsertionMutator[int](nil, 1.0, limits)
+		assert.Error(t, err)
+		_, err = NewInsertionMutator(func() int { return 0 }, 1.5, limits)
+		assert.Error(t, err)
+		_, err = NewInsertionMutator(func() int { return 0 }, 0.5, core.LengthLimits{Min: 3, Max: 1})
+		assert.True(t, errors.Is(err, core.ErrInvalidLengthLimits))
+	})
+}
+
+func TestDeletionMutator(t *testing.T) {
+	mut, err := NewDeletionMutator[int](1.0, core.LengthLimits{Min: 2})
+	require.NoError(t, err)
+
+	t.Run("deletes a gene", func(t *testing.T) {
+		chromosome := []int{1, 2, 3}
+		require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+		assert.Len(t, chromosome, 2)
+	})
+
+	t.Run("respects the minimum length", func(t *testing.T) {
+		chromosome := []int{1, 2}
+		require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+		assert.Equal(t, []int{1, 2}, chromosome)
+	})
+
+	t.Run("cancelled context returns error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		chromosome := []int{1, 2, 3}
+		err := mut.Mutate(ctx, &chromosome)
+		var me *MutationError
+		require.True(t, errors.As(err, &me))
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+func TestDuplicationMutator(t *testing.T) {
+	t.Run("duplicates a segment next to itself", func(t *testing.T) {
+		mut, err := NewDuplicationMutator[int](1.0, core.LengthLimits{})
+		require.NoError(t, err)
+		for range 100 {
+			chromosome := []int{1, 2, 3, 4, 5}
+			require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+			require.Greater(t, len(chromosome), 5)
+
+			// Removing the duplicated copy restores the original chromosome
+			n := len(chromosome) - 5
+			found := false
+			for start := 0; start+2*n <= len(chromosome); start++ {
+				if assert.ObjectsAreEqual(chromosome[start:start+n], chromosome[start+n:start+2*n]) &&
+					assert.ObjectsAreEqual([]int{1, 2, 3, 4, 5}, append(append([]int{}, chromosome[:start+n]...), chromosome[start+2*n:]...)) {
+					found = true
+				}
+			}
+			assert.True(t, found, "chromosome %v is not a duplication", chromosome)
+		}
+	})
+
+	t.Run("respects the maximum length", func(t *testing.T) {
+		mut, err := NewDuplicationMutator[int](1.0, core.LengthLimits{Max: 6})
+		require.NoError(t, err)
+		for range 50 {
+			chromosome := []int{1, 2, 3, 4, 5}
+			require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+			assert.Len(t, chromosome, 6)
+		}
+		chromosome := []int{1, 2, 3, 4, 5, 6}
+		require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+		assert.Len(t, chromosome, 6)
+	})
+}
+
+func TestChainMutator(t *testing.T) {
+	limits := core.LengthLimits{Min: 1, Max: 10}
+	insertion, err := NewInsertionMutator(func() int { return 0 }, 1.0, limits)
+	require.NoError(t, err)
+	duplication, err := NewDuplicationMutator[int](1.0, limits)
+	require.NoError(t, err)
+
+	chromosome := []int{1}
+	mut := NewChainMutator[[]int](insertion, duplication)
+	require.NoError(t, mut.Mutate(context.Background(), &chromosome))
+	assert.GreaterOrEqual(t, len(chromosome), 3)
+	assert.True(t, limits.Allows(len(chromosome)))
+}
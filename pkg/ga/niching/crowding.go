@@ -0,0 +1,106 @@
+package niching
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/distance"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+)
+
+// DeterministicCrowdingReplacement lets every offspring compete with the most similar individual
+// of the population, replacing it if the offspring is at least as fit. New individuals thus only
+// displace individuals of their own niche. The replacer does not know which parents produced an
+// offspring; with low-disruption variation operators the most similar individual is usually a parent.
+type DeterministicCrowdingReplacement[G any] struct {
+	Metric distance.IMetric[G]
+}
+
+// NewDeterministicCrowdingReplacement creates a DeterministicCrowdingReplacement.
+func NewDeterministicCrowdingReplacement[G any](metric distance.IMetric[G]) (*DeterministicCrowdingReplacement[G], error) {
+	if metric == nil {
+		return nil, NewNichingError("invalid metric", errors.New("metric cannot be nil"))
+	}
+	return &DeterministicCrowdingReplacement[G]{Metric: metric}, nil
+}
+
+// Replace implements replacement.IGenomeReplacer. The size of the population is preserved.
+func (d *DeterministicCrowdingReplacement[G]) Replace(parents, offspring *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if err := validatePopulations(parents, offspring); err != nil {
+		return nil, err
+	}
+	survivors := append([]core.Individual[G]{}, parents.Individuals...)
+	for _, child := range offspring.Individuals {
+		compete(survivors, child, d.Metric, all(len(survivors)))
+	}
+	return &core.GenomePopulation[G]{Individuals: survivors}, nil
+}
+
+// RestrictedTournamentReplacement implements restricted tournament selection (Harik): every
+// offspring competes with the most similar of WindowSize randomly chosen individuals of the
+// population and replaces it if the offspring is at least as fit.
+type RestrictedTournamentReplacement[G any] struct {
+	Metric     distance.IMetric[G]
+	WindowSize int
+}
+
+// NewRestrictedTournamentReplacement creates a RestrictedTournamentReplacement. Window sizes
+// larger than the population use the whole population.
+func NewRestrictedTournamentReplacement[G any](metric distance.IMetric[G], windowSize int) (*RestrictedTournamentReplacement[G], error) {
+	if metric == nil {
+		return nil, NewNichingError("invalid metric", errors.New("metric cannot be nil"))
+	}
+	if windowSize < 1 {
+		return nil, NewNichingError("invalid window size", fmt.Errorf("window size must be positive, but was %d", windowSize))
+	}
+	return &RestrictedTournamentReplacement[G]{Metric: metric, WindowSize: windowSize}, nil
+}
+
+// Replace implements replacement.IGenomeReplacer. The size of the population is preserved.
+func (r *RestrictedTournamentReplacement[G]) Replace(parents, offspring *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if err := validatePopulations(parents, offspring); err != nil {
+		return nil, err
+	}
+	survivors := append([]core.Individual[G]{}, parents.Individuals...)
+	window := min(r.WindowSize, len(survivors))
+	for _, child := range offspring.Individuals {
+		compete(survivors, child, r.Metric, rand.Perm(len(survivors))[:window])
+	}
+	return &core.GenomePopulation[G]{Individuals: survivors}, nil
+}
+
+// compete replaces the candidate most similar to child if child is at least as fit.
+func compete[G any](survivors []core.Individual[G], child core.Individual[G], metric distance.IMetric[G], candidates []int) {
+	closest := candidates[0]
+	closestDistance := metric.Distance(child.Chromosome, survivors[closest].Chromosome)
+	for _, candidate := range candidates[1:] {
+		if d := metric.Distance(child.Chromosome, survivors[candidate].Chromosome); d < closestDistance {
+			closest, closestDistance = candidate, d
+		}
+	}
+	if child.Fitness >= survivors[closest].Fitness {
+		survivors[closest] = child
+	}
+}
+
+// all returns the indices [0, n).
+func all(n int) []int {
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	return indices
+}
+
+// validatePopulations checks that both populations taking part in a replacement are non-empty.
+func validatePopulations[G any](parents, offspring *core.GenomePopulation[G]) error {
+	if parents == nil || len(parents.Individuals) == 0 {
+		return replacement.NewReplacementError("cannot perform replacement with nil or empty parent population", core.ErrPopulationEmpty)
+	}
+	if offspring == nil || len(offspring.Individuals) == 0 {
+		return replacement.NewReplacementError("cannot perform replacement with nil or empty offspring population", core.ErrPopulationEmpty)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package niching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/distance"
+)
+
+func TestDeterministicCrowdingReplacement(t *testing.T) {
+	replacer, err := NewDeterministicCrowdingReplacement[[]float64](distance.Euclidean[float64]{})
+	require.NoError(t, err)
+
+	t.Run("offspring replace their most similar individual if fitter", func(t *testing.T) {
+		parents := population([]float64{0, 5, 10}, []float64{1, 1, 1})
+		offspring := population([]float64{4.5, 9}, []float64{2, 0})
+		survivors, err := replacer.Replace(parents, offspring)
+		require.NoError(t, err)
+		points := []float64{}
+		for _, individual := range survivors.Individuals {
+			points = append(points, individual.Chromosome[0])
+		}
+		assert.Equal(t, []float64{0, 4.5, 10}, points)
+		assert.Equal(t, []float64{5}, parents.Individuals[1].Chromosome, "parents must not be modified")
+	})
+
+	t.Run("empty populations return error", func(t *testing.T) {
+		_, err := replacer.Replace(&core.Population[float64]{}, population([]float64{1}, []float64{1}))
+		assert.ErrorIs(t, err, core.ErrPopulationEmpty)
+		_, err = replacer.Replace(population([]float64{1}, []float64{1}), nil)
+		assert.ErrorIs(t, err, core.ErrPopulationEmpty)
+	})
+}
+
+func TestRestrictedTournamentReplacement(t *testing.T) {
+	t.Run("window of the population size behaves like deterministic crowding", func(t *testing.T) {
+		replacer, err := NewRestrictedTournamentReplacement[[]float64](distance.Euclidean[float64]{}, 10)
+		require.NoError(t, err)
+		parents := population([]float64{0, 5, 10}, []float64{1, 1, 1})
+		survivors, err := replacer.Replace(parents, population([]float64{9.5}, []float64{3}))
+		require.NoError(t, err)
+		require.Len(t, survivors.Individuals, 3)
+		assert.Equal(t, []float64{9.5}, survivors.Individuals[2].Chromosome)
+	})
+
+	t.Run("invalid window returns error", func(t *testing.T) {
+		_, err := NewRestrictedTournamentReplacement[[]float64](distance.Euclidean[float64]{}, 0)
+		assert.Error(t, err)
+		_, err = NewRestrictedTournamentReplacement[[]float64](nil, 3)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,239 @@
+// Package niching provides diversity preservation for genetic algorithms: fitness sharing,
+// clearing, crowding replacement and species identification. Niching keeps subpopulations
+// around several optima of multimodal problems instead of converging onto a single one.
+package niching
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/distance"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+// validateNiche checks the parameters shared by the niching methods.
+func validateNiche[G any](metric distance.IMetric[G], radius float64) error {
+	if metric == nil {
+		return NewNichingError("invalid metric", errors.New("metric cannot be nil"))
+	}
+	if radius <= 0 {
+		return NewNichingError("invalid niche radius", fmt.Errorf("niche radius must be positive, but was %g", radius))
+	}
+	return nil
+}
+
+// SharedFitness returns the shared fitness of every individual: its fitness divided by its niche
+// count, the sum of 1 - (d/radius)^alpha over all individuals at a distance d below radius,
+// itself included. Individuals in crowded niches thus share their fitness with their neighbors.
+// Negative fitness values are multiplied by the niche count instead, so crowding never helps.
+func SharedFitness[G any](individuals []core.Individual[G], metric distance.IMetric[G], radius, alpha float64) []float64 {
+	nicheCounts := make([]float64, len(individuals))
+	for i := range individuals {
+		nicheCounts[i]++
+		for j := i + 1; j < len(individuals); j++ {
+			d := metric.Distance(individuals[i].Chromosome, individuals[j].Chromosome)
+			if d < radius {
+				sh := 1 - math.Pow(d/radius, alpha)
+				nicheCounts[i] += sh
+				nicheCounts[j] += sh
+			}
+		}
+	}
+
+	shared := make([]float64, len(individuals))
+	for i, individual := range individuals {
+		if individual.Fitness >= 0 {
+			shared[i] = individual.Fitness / nicheCounts[i]
+		} else {
+			shared[i] = individual.Fitness * nicheCounts[i]
+		}
+	}
+	return shared
+}
+
+// SharingSelector applies fitness sharing before delegating to a wrapped selector, such as a
+// TournamentSelector. The selected individuals carry their shared fitness until they are evaluated again.
+type SharingSelector[G any] struct {
+	// Selector selects individuals by shared fitness.
+	Selector selection.IGenomeSelector[G]
+	// Metric measures the distance between individuals.
+	Metric distance.IMetric[G]
+	// Radius is the sharing radius: individuals farther apart do not share fitness.
+	Radius float64
+	// Alpha shapes the sharing function, 1 decreases the sharing linearly with the distance.
+	Alpha float64
+}
+
+// NewSharingSelector creates a SharingSelector.
+func NewSharingSelector[G any](selector selection.IGenomeSelector[G], metric distance.IMetric[G], radius, alpha float64) (*SharingSelector[G], error) {
+	if selector == nil {
+		return nil, NewNichingError("invalid selector", errors.New("selector cannot be nil"))
+	}
+	if err := validateNiche(metric, radius); err != nil {
+		return nil, err
+	}
+	if alpha <= 0 {
+		return nil, NewNichingError("invalid sharing exponent", fmt.Errorf("sharing exponent must be positive, but was %g", alpha))
+	}
+	return &SharingSelector[G]{Selector: selector, Metric: metric, Radius: radius, Alpha: alpha}, nil
+}
+
+// SetGenome implements selection.IGenomeAware by passing the genome on to the wrapped selector.
+func (s *SharingSelector[G]) SetGenome(genome core.Genome[G]) {
+	if aware, ok := s.Selector.(selection.IGenomeAware[G]); ok {
+		aware.SetGenome(genome)
+	}
+}
+
+// Select implements selection.IGenomeSelector.
+func (s *SharingSelector[G]) Select(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil, selection.NewSelectionError("cannot perform selection on nil or empty population", core.ErrPopulationEmpty)
+	}
+	return s.Selector.Select(withFitness(population, SharedFitness(population.Individuals, s.Metric, s.Radius, s.Alpha)))
+}
+
+// Species is a group of similar individuals identified by Speciate.
+type Species struct {
+	// Seed is the index of the best individual of the species.
+	Seed int
+	// Members holds the indices of the individuals of the species, best first. The seed is the first member.
+	Members []int
+}
+
+// Speciate partitions a population into species. Individuals are visited from best to worst;
+// each joins the species of the first seed within radius or otherwise becomes the seed of a new
+// species. Species are returned in the order of their seeds.
+func Speciate[G any](individuals []core.Individual[G], metric distance.IMetric[G], radius float64) []Species {
+	order := make([]int, len(individuals))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return individuals[order[a]].Fitness > individuals[order[b]].Fitness
+	})
+
+	var species []Species
+	for _, index := range order {
+		joined := false
+		for s := range species {
+			if metric.Distance(individuals[species[s].Seed].Chromosome, individuals[index].Chromosome) < radius {
+				species[s].Members = append(species[s].Members, index)
+				joined = true
+				break
+			}
+		}
+		if !joined {
+			species = append(species, Species{Seed: index, Members: []int{index}})
+		}
+	}
+	return species
+}
+
+// ClearedFitness returns the fitness of every individual after clearing: within every species
+// found by Speciate, the capacity best individuals keep their fitness while the fitness of the
+// others is cleared to negative infinity.
+func ClearedFitness[G any](individuals []core.Individual[G], metric distance.IMetric[G], radius float64, capacity int) []float64 {
+	cleared := make([]float64, len(individuals))
+	for _, species := range Speciate(individuals, metric, radius) {
+		for rank, index := range species.Members {
+			if rank < capacity {
+				cleared[index] = individuals[index].Fitness
+			} else {
+				cleared[index] = math.Inf(-1)
+			}
+		}
+	}
+	return cleared
+}
+
+// ClearingSelector applies clearing before delegating to a wrapped selector, such as a
+// TournamentSelector. Only the Capacity best individuals of every niche of the given Radius
+// can win a selection unless a tournament consists of cleared individuals only.
+// The selected individuals carry their cleared fitness until they are evaluated again.
+type ClearingSelector[G any] struct {
+	// Selector selects individuals by cleared fitness.
+	Selector selection.IGenomeSelector[G]
+	// Metric measures the distance between individuals.
+	Metric distance.IMetric[G]
+	// Radius is the clearing radius.
+	Radius float64
+	// Capacity is the number of individuals of every niche keeping their fitness.
+	Capacity int
+}
+
+// NewClearingSelector creates a ClearingSelector.
+func NewClearingSelector[G any](selector selection.IGenomeSelector[G], metric distance.IMetric[G], radius float64, capacity int) (*ClearingSelector[G], error) {
+	if selector == nil {
+		return nil, NewNichingError("invalid selector", errors.New("selector cannot be nil"))
+	}
+	if err := validateNiche(metric, radius); err != nil {
+		return nil, err
+	}
+	if capacity < 1 {
+		return nil, NewNichingError("invalid niche capacity", fmt.Errorf("niche capacity must be positive, but was %d", capacity))
+	}
+	return &ClearingSelector[G]{Selector: selector, Metric: metric, Radius: radius, Capacity: capacity}, nil
+}
+
+// SetGenome implements selection.IGenomeAware by passing the genome on to the wrapped selector.
+func (c *ClearingSelector[G]) SetGenome(genome core.Genome[G]) {
+	if aware, ok := c.Selector.(selection.IGenomeAware[G]); ok {
+		aware.SetGenome(genome)
+	}
+}
+
+// Select implements selection.IGenomeSelector.
+func (c *ClearingSelector[G]) Select(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if population == nil || len(population.Individuals) == 0 {
+		return nil, selection.NewSelectionError("cannot perform selection on nil or empty population", core.ErrPopulationEmpty)
+	}
+	return c.Selector.Select(withFitness(population, ClearedFitness(population.Individuals, c.Metric, c.Radius, c.Capacity)))
+}
+
+// withFitness returns a copy of the population with the given fitness values. The copy shares
+// its chromosomes with the population.
+func withFitness[G any](population *core.GenomePopulation[G], fitness []float64) *core.GenomePopulation[G] {
+	individuals := make([]core.Individual[G], len(population.Individuals))
+	for i, individual := range population.Individuals {
+		individual.Fitness = fitness[i]
+		individuals[i] = individual
+	}
+	return &core.GenomePopulation[G]{Individuals: individuals}
+}
+
+// NichingError represents an error that occurs while configuring a niching method.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type NichingError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *NichingError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *NichingError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewNichingError constructs a *NichingError with the provided message and wrapped error.
+func NewNichingError(message string, wrapped error) *NichingError {
+	return &NichingError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
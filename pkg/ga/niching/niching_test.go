@@ -0,0 +1,182 @@
+package niching
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/distance"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+// population creates a population of one-dimensional points with the given fitness values.
+func population(points []float64, fitness []float64) *core.Population[float64] {
+	individuals := make([]core.Solution[float64], len(points))
+	for i := range points {
+		individuals[i] = core.Solution[float64]{Chromosome: []float64{points[i]}, Fitness: fitness[i]}
+	}
+	return &core.Population[float64]{Individuals: individuals}
+}
+
+func TestSharedFitness(t *testing.T) {
+	pop := population([]float64{0, 0, 0, 10}, []float64{6, 6, 6, 4})
+	shared := SharedFitness(pop.Individuals, distance.Euclidean[float64]{}, 1, 1)
+	assert.Equal(t, []float64{2, 2, 2, 4}, shared)
+
+	t.Run("partial sharing within the radius", func(t *testing.T) {
+		pop := population([]float64{0, 0.5}, []float64{3, -3})
+		shared := SharedFitness(pop.Individuals, distance.Euclidean[float64]{}, 1, 1)
+		assert.Equal(t, []float64{2, -4.5}, shared)
+	})
+}
+
+func TestSpeciateAndClearing(t *testing.T) {
+	pop := population([]float64{0, 0.1, 5, 0.2, 5.1}, []float64{1, 3, 2, 2, 1})
+	metric := distance.Euclidean[float64]{}
+
+	species := Speciate(pop.Individuals, metric, 0.5)
+	require.Len(t, species, 2)
+	assert.Equal(t, Species{Seed: 1, Members: []int{1, 3, 0}}, species[0])
+	assert.Equal(t, Species{Seed: 2, Members: []int{2, 4}}, species[1])
+
+	cleared := ClearedFitness(pop.Individuals, metric, 0.5, 1)
+	assert.Equal(t, []float64{math.Inf(-1), 3, 2, math.Inf(-1), math.Inf(-1)}, cleared)
+}
+
+func TestSelectors(t *testing.T) {
+	tournament, err := selection.NewTournamentSelector[float64](2, 0)
+	require.NoError(t, err)
+	metric := distance.Euclidean[float64]{}
+
+	t.Run("clearing only selects niche winners", func(t *testing.T) {
+		selector, err := NewClearingSelector[[]float64](tournament, metric, 0.5, 1)
+		require.NoError(t, err)
+		pop := population([]float64{0, 0.1, 5, 0.2}, []float64{1, 3, 2, 2})
+		for range 20 {
+			selected, err := selector.Select(pop)
+			require.NoError(t, err)
+			require.Len(t, selected.Individuals, 4)
+			for _, individual := range selected.Individuals {
+				if !math.IsInf(individual.Fitness, -1) {
+					assert.Contains(t, []float64{0.1, 5}, individual.Chromosome[0])
+				}
+			}
+		}
+		assert.Equal(t, 1.0, pop.Individuals[0].Fitness, "population must not be modified")
+	})
+
+	t.Run("invalid parameters return error", func(t *testing.T) {
+		var ne *NichingError
+		_, err := NewSharingSelector[[]float64](tournament, metric, 0, 1)
+		assert.True(t, errors.As(err, &ne))
+		_, err = NewSharingSelector[[]float64](tournament, nil, 1, 1)
+		assert.True(t, errors.As(err, &ne))
+		_, err = NewSharingSelector[[]float64](tournament, metric, 1, 0)
+		assert.True(t, errors.As(err, &ne))
+		_, err = NewClearingSelector[[]float64](nil, metric, 1, 1)
+		assert.True(t, errors.As(err, &ne))
+		_, err = NewClearingSelector[[]float64](tournament, metric, 1, 0)
+		assert.True(t, errors.As(err, &ne))
+	})
+
+	t.Run("empty population returns error", func(t *testing.T) {
+		selector, err := NewSharingSelector[[]float64](tournament, metric, 1, 1)
+		require.NoError(t, err)
+		_, err = selector.Select(&core.Population[float64]{})
+		assert.ErrorIs(t, err, core.ErrPopulationEmpty)
+	})
+}
+
+// peaks is a multimodal function on [0, 1] with five equal peaks at 0.1, 0.3, 0.5, 0.7 and 0.9.
+type peaks struct{}
+
+func (peaks) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	return math.Pow(math.Sin(5*math.Pi*(*chromosome)[0]), 6), nil
+}
+
+// gaussianMutator adds gaussian noise to every gene, keeping it within [0, 1].
+type gaussianMutator struct{}
+
+func (gaussianMutator) Mutate(ctx context.Context, chromosome *[]float64) error {
+	for i := range *chromosome {
+		(*chromosome)[i] = math.Min(1, math.Max(0, (*chromosome)[i]+rand.NormFloat64()*0.02))
+	}
+	return nil
+}
+
+// cloningCrossover returns the parents unchanged, which leaves the search to mutation.
+type cloningCrossover struct{}
+
+func (cloningCrossover) Crossover(parent1, parent2 []float64) ([]float64, []float64, error) {
+	return append([]float64{}, parent1...), append([]float64{}, parent2...), nil
+}
+
+var _ crossover.ICrossover[float64] = cloningCrossover{}
+
+// peaksFound counts the peaks of the peaks function with at least two individuals nearby.
+func peaksFound(population *core.Population[float64]) int {
+	found := 0
+	for _, peak := range []float64{0.1, 0.3, 0.5, 0.7, 0.9} {
+		near := 0
+		for _, individual := range population.Individuals {
+			if math.Abs(individual.Chromosome[0]-peak) < 0.05 {
+				near++
+			}
+		}
+		if near >= 2 {
+			found++
+		}
+	}
+	return found
+}
+
+// TestMultimodalOptimization checks that every niching method maintains several optima.
+func TestMultimodalOptimization(t *testing.T) {
+	metric := distance.Euclidean[float64]{}
+	tournament, err := selection.NewTournamentSelector[float64](2, 0)
+	require.NoError(t, err)
+
+	sharing, err := NewSharingSelector[[]float64](tournament, metric, 0.1, 1)
+	require.NoError(t, err)
+	clearing, err := NewClearingSelector[[]float64](tournament, metric, 0.1, 10)
+	require.NoError(t, err)
+	crowding, err := NewDeterministicCrowdingReplacement[[]float64](metric)
+	require.NoError(t, err)
+	rts, err := NewRestrictedTournamentReplacement[[]float64](metric, 20)
+	require.NoError(t, err)
+	randomSelector, err := selection.NewTournamentSelector[float64](1, 0)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		selector selection.ISelector[float64]
+		replacer replacement.IReplacer[float64]
+	}{
+		{"fitness sharing", sharing, nil},
+		{"clearing", clearing, nil},
+		{"deterministic crowding", randomSelector, crowding},
+		{"restricted tournament", randomSelector, rts},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			population := core.NewPopulationFactory[float64]().CreateRandomPopulation(100, 1, core.NewSolutionFactory[float64](), rand.Float64, nil)
+			exec := executor.NewGeneticAlgorithmExecutor[float64](population, peaks{}, gaussianMutator{}, tc.selector, cloningCrossover{}, 60)
+			if tc.replacer != nil {
+				exec.SetReplacer(tc.replacer)
+			}
+			final, err := exec.Loop(context.Background(), 60)
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, peaksFound(final), 4)
+		})
+	}
+}
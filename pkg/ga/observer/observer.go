@@ -0,0 +1,105 @@
+// Package observer provides hooks for monitoring optimization runs generation by generation.
+package observer
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// IGenomeObserver defines the interface for components notified after every generation of a
+// run over chromosomes of an arbitrary representation G, see core.Genome.
+// Observers must not modify the population they receive.
+type IGenomeObserver[G any] interface {
+	// OnGeneration is called after a generation has been evaluated and recorded.
+	//
+	// Parameters:
+	//   - statistics: The statistics of the generation
+	//   - population: The evaluated population of the generation
+	OnGeneration(statistics core.GenerationStatistics, population *core.GenomePopulation[G])
+}
+
+// IObserver defines the interface for components notified after every generation of a run
+// whose chromosomes are slices of genes.
+type IObserver[T any] = IGenomeObserver[[]T]
+
+// GenomeFuncObserver adapts an ordinary function to the IGenomeObserver interface.
+type GenomeFuncObserver[G any] func(statistics core.GenerationStatistics, population *core.GenomePopulation[G])
+
+// FuncObserver adapts an ordinary function to the IObserver interface.
+type FuncObserver[T any] = GenomeFuncObserver[[]T]
+
+// OnGeneration calls f(statistics, population).
+func (f GenomeFuncObserver[G]) OnGeneration(statistics core.GenerationStatistics, population *core.GenomePopulation[G]) {
+	f(statistics, population)
+}
+
+// GenomeLoggingObserver writes a one-line summary of every generation to a writer.
+type GenomeLoggingObserver[G any] struct {
+	writer io.Writer
+	every  int
+}
+
+// LoggingObserver is the GenomeLoggingObserver of runs whose chromosomes are slices of genes.
+type LoggingObserver[T any] = GenomeLoggingObserver[[]T]
+
+// NewLoggingObserver creates a LoggingObserver writing every n-th generation to writer.
+// Values of every below 1 log every generation.
+func NewLoggingObserver[T any](writer io.Writer, every int) *LoggingObserver[T] {
+	return NewGenomeLoggingObserver[[]T](writer, every)
+}
+
+// NewGenomeLoggingObserver creates a GenomeLoggingObserver writing every n-th generation to writer.
+// Values of every below 1 log every generation.
+func NewGenomeLoggingObserver[G any](writer io.Writer, every int) *GenomeLoggingObserver[G] {
+	if every < 1 {
+		every = 1
+	}
+	return &GenomeLoggingObserver[G]{writer: writer, every: every}
+}
+
+// OnGeneration implements IGenomeObserver.
+func (l *GenomeLoggingObserver[G]) OnGeneration(statistics core.GenerationStatistics, population *core.GenomePopulation[G]) {
+	if statistics.Generation%l.every != 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(l.writer, "generation %d: best=%.6g mean=%.6g worst=%.6g std=%.6g best-ever=%.6g unique=%d diversity=%.6g evaluations=%d elapsed=%s\n",
+		statistics.Generation, statistics.BestFitness, statistics.MeanFitness, statistics.WorstFitness,
+		statistics.StdDevFitness, statistics.BestEverFitness, statistics.UniqueGenotypes, statistics.Diversity,
+		statistics.Evaluations, statistics.Elapsed)
+}
+
+// GenomeHistoryObserver collects the statistics of every generation. It is safe for concurrent use.
+type GenomeHistoryObserver[G any] struct {
+	mu      sync.Mutex
+	history []core.GenerationStatistics
+}
+
+// HistoryObserver is the GenomeHistoryObserver of runs whose chromosomes are slices of genes.
+type HistoryObserver[T any] = GenomeHistoryObserver[[]T]
+
+// NewHistoryObserver creates an empty HistoryObserver.
+func NewHistoryObserver[T any]() *HistoryObserver[T] {
+	return NewGenomeHistoryObserver[[]T]()
+}
+
+// NewGenomeHistoryObserver creates an empty GenomeHistoryObserver.
+func NewGenomeHistoryObserver[G any]() *GenomeHistoryObserver[G] {
+	return &GenomeHistoryObserver[G]{}
+}
+
+// OnGeneration implements IGenomeObserver.
+func (h *GenomeHistoryObserver[G]) OnGeneration(statistics core.GenerationStatistics, population *core.GenomePopulation[G]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.history = append(h.history, statistics)
+}
+
+// History returns a copy of the statistics collected so far.
+func (h *GenomeHistoryObserver[G]) History() []core.GenerationStatistics {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]core.GenerationStatistics{}, h.history...)
+}
@@ -0,0 +1,71 @@
+package observer
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+func TestFuncObserver(t *testing.T) {
+	var received []int
+	obs := FuncObserver[int](func(statistics core.GenerationStatistics, population *core.Population[int]) {
+		received = append(received, statistics.Generation)
+	})
+
+	obs.OnGeneration(core.GenerationStatistics{Generation: 4}, &core.Population[int]{})
+
+	assert.Equal(t, []int{4}, received)
+}
+
+func TestLoggingObserver(t *testing.T) {
+	t.Run("logs every n-th generation", func(t *testing.T) {
+		var buffer bytes.Buffer
+		obs := NewLoggingObserver[int](&buffer, 2)
+
+		for i := 0; i < 5; i++ {
+			obs.OnGeneration(core.GenerationStatistics{Generation: i, BestFitness: float64(i)}, &core.Population[int]{})
+		}
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "generation 0: best=0"))
+		assert.True(t, strings.HasPrefix(lines[2], "generation 4: best=4"))
+	})
+
+	t.Run("non-positive interval logs every generation", func(t *testing.T) {
+		var buffer bytes.Buffer
+		obs := NewLoggingObserver[int](&buffer, 0)
+
+		for i := 0; i < 3; i++ {
+			obs.OnGeneration(core.GenerationStatistics{Generation: i}, &core.Population[int]{})
+		}
+
+		assert.Equal(t, 3, strings.Count(buffer.String(), "\n"))
+	})
+}
+
+func TestHistoryObserver(t *testing.T) {
+	obs := NewHistoryObserver[int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(generation int) {
+			defer wg.Done()
+			obs.OnGeneration(core.GenerationStatistics{Generation: generation}, &core.Population[int]{})
+		}(i)
+	}
+	wg.Wait()
+
+	history := obs.History()
+	assert.Len(t, history, 10)
+
+	// The returned history is a copy
+	history[0].Generation = -1
+	assert.NotEqual(t, -1, obs.History()[0].Generation)
+}
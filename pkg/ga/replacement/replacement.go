@@ -0,0 +1,232 @@
+// Package replacement provides survivor selection strategies for genetic algorithms.
+package replacement
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// IGenomeReplacer defines the interface for survivor selection on populations of chromosomes
+// of an arbitrary representation G, see core.Genome.
+// While a selector picks the parents that take part in variation, a replacer
+// decides which individuals of the parent and offspring populations survive
+// into the next generation.
+type IGenomeReplacer[G any] interface {
+	// Replace builds the next generation from the evaluated parent and offspring populations.
+	//
+	// Parameters:
+	//   - parents: The current (evaluated) population
+	//   - offspring: The evaluated offspring produced from the parents
+	//
+	// Returns:
+	//   - *core.GenomePopulation[G]: The population of survivors
+	//   - error: Any error that occurred during replacement
+	Replace(parents, offspring *core.GenomePopulation[G]) (*core.GenomePopulation[G], error)
+}
+
+// IReplacer defines the interface for survivor selection in genetic algorithms
+// whose chromosomes are slices of genes.
+type IReplacer[T any] = IGenomeReplacer[[]T]
+
+// GenomeGenerationalReplacement replaces the whole parent population with offspring,
+// preserving the NumElites best parents in place of the worst offspring.
+// The size of the next generation always equals the size of the parent population.
+type GenomeGenerationalReplacement[G any] struct {
+	NumElites int
+}
+
+// GenerationalReplacement is the generational replacement of populations whose chromosomes are slices of genes.
+type GenerationalReplacement[T any] = GenomeGenerationalReplacement[[]T]
+
+// NewGenerationalReplacement creates a new GenerationalReplacement preserving numElites parents.
+func NewGenerationalReplacement[T any](numElites int) (*GenerationalReplacement[T], error) {
+	return NewGenomeGenerationalReplacement[[]T](numElites)
+}
+
+// NewGenomeGenerationalReplacement creates a new GenomeGenerationalReplacement preserving numElites parents.
+func NewGenomeGenerationalReplacement[G any](numElites int) (*GenomeGenerationalReplacement[G], error) {
+	if numElites < 0 {
+		return nil, NewReplacementError("invalid number of elites", fmt.Errorf("number of elites cannot be negative, but was %d", numElites))
+	}
+	return &GenomeGenerationalReplacement[G]{NumElites: numElites}, nil
+}
+
+// Elites returns the number of best parents preserved in the next generation.
+func (g *GenomeGenerationalReplacement[G]) Elites() int {
+	return g.NumElites
+}
+
+// Replace returns the best len(parents)-NumElites offspring together with the NumElites best parents.
+func (g *GenomeGenerationalReplacement[G]) Replace(parents, offspring *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if err := validatePopulations(parents, offspring); err != nil {
+		return nil, err
+	}
+
+	mu := len(parents.Individuals)
+	if g.NumElites >= mu {
+		return nil, NewReplacementError(
+			fmt.Sprintf("number of elites (%d) is greater than or equal to population size (%d)", g.NumElites, mu), nil)
+	}
+	if len(offspring.Individuals) < mu-g.NumElites {
+		return nil, NewReplacementError(
+			fmt.Sprintf("not enough offspring (%d) to fill the population of size %d with %d elites", len(offspring.Individuals), mu, g.NumElites), nil)
+	}
+
+	survivors := make([]core.Individual[G], 0, mu)
+	survivors = append(survivors, bestN(parents.Individuals, g.NumElites)...)
+	if len(offspring.Individuals) == mu-g.NumElites {
+		survivors = append(survivors, offspring.Individuals...)
+	} else {
+		survivors = append(survivors, bestN(offspring.Individuals, mu-g.NumElites)...)
+	}
+
+	return &core.GenomePopulation[G]{Individuals: survivors}, nil
+}
+
+// GenomePlusReplacement implements the (μ+λ) survivor selection scheme, where parents
+// compete with their offspring and the Mu best individuals of the union survive.
+// A Mu of 0 keeps the size of the parent population.
+type GenomePlusReplacement[G any] struct {
+	Mu int
+}
+
+// PlusReplacement is the (μ+λ) replacement of populations whose chromosomes are slices of genes.
+type PlusReplacement[T any] = GenomePlusReplacement[[]T]
+
+// NewPlusReplacement creates a new (μ+λ) replacement keeping mu survivors.
+// Pass 0 to keep the size of the parent population.
+func NewPlusReplacement[T any](mu int) (*PlusReplacement[T], error) {
+	return NewGenomePlusReplacement[[]T](mu)
+}
+
+// NewGenomePlusReplacement creates a new (μ+λ) replacement keeping mu survivors.
+// Pass 0 to keep the size of the parent population.
+func NewGenomePlusReplacement[G any](mu int) (*GenomePlusReplacement[G], error) {
+	if mu < 0 {
+		return nil, NewReplacementError("invalid number of survivors", fmt.Errorf("mu cannot be negative, but was %d", mu))
+	}
+	return &GenomePlusReplacement[G]{Mu: mu}, nil
+}
+
+// Replace returns the Mu best individuals from the union of parents and offspring.
+func (p *GenomePlusReplacement[G]) Replace(parents, offspring *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if err := validatePopulations(parents, offspring); err != nil {
+		return nil, err
+	}
+
+	mu := p.Mu
+	if mu == 0 {
+		mu = len(parents.Individuals)
+	}
+
+	union := make([]core.Individual[G], 0, len(parents.Individuals)+len(offspring.Individuals))
+	union = append(union, parents.Individuals...)
+	union = append(union, offspring.Individuals...)
+	if mu > len(union) {
+		return nil, NewReplacementError(
+			fmt.Sprintf("mu (%d) is greater than the number of parents and offspring (%d)", mu, len(union)), nil)
+	}
+
+	return &core.GenomePopulation[G]{Individuals: bestN(union, mu)}, nil
+}
+
+// GenomeCommaReplacement implements the (μ,λ) survivor selection scheme, where parents
+// are discarded and only the Mu best offspring survive. It requires λ >= μ.
+// A Mu of 0 keeps the size of the parent population.
+type GenomeCommaReplacement[G any] struct {
+	Mu int
+}
+
+// CommaReplacement is the (μ,λ) replacement of populations whose chromosomes are slices of genes.
+type CommaReplacement[T any] = GenomeCommaReplacement[[]T]
+
+// NewCommaReplacement creates a new (μ,λ) replacement keeping mu survivors.
+// Pass 0 to keep the size of the parent population.
+func NewCommaReplacement[T any](mu int) (*CommaReplacement[T], error) {
+	return NewGenomeCommaReplacement[[]T](mu)
+}
+
+// NewGenomeCommaReplacement creates a new (μ,λ) replacement keeping mu survivors.
+// Pass 0 to keep the size of the parent population.
+func NewGenomeCommaReplacement[G any](mu int) (*GenomeCommaReplacement[G], error) {
+	if mu < 0 {
+		return nil, NewReplacementError("invalid number of survivors", fmt.Errorf("mu cannot be negative, but was %d", mu))
+	}
+	return &GenomeCommaReplacement[G]{Mu: mu}, nil
+}
+
+// Replace returns the Mu best offspring. The parents never survive.
+func (c *GenomeCommaReplacement[G]) Replace(parents, offspring *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
+	if err := validatePopulations(parents, offspring); err != nil {
+		return nil, err
+	}
+
+	mu := c.Mu
+	if mu == 0 {
+		mu = len(parents.Individuals)
+	}
+	if mu > len(offspring.Individuals) {
+		return nil, NewReplacementError(
+			fmt.Sprintf("mu (%d) is greater than the number of offspring (%d)", mu, len(offspring.Individuals)), nil)
+	}
+
+	return &core.GenomePopulation[G]{Individuals: bestN(offspring.Individuals, mu)}, nil
+}
+
+// validatePopulations checks that both populations taking part in a replacement are non-empty.
+func validatePopulations[G any](parents, offspring *core.GenomePopulation[G]) error {
+	if parents == nil || len(parents.Individuals) == 0 {
+		return NewReplacementError("cannot perform replacement with nil or empty parent population", core.ErrPopulationEmpty)
+	}
+	if offspring == nil || len(offspring.Individuals) == 0 {
+		return NewReplacementError("cannot perform replacement with nil or empty offspring population", core.ErrPopulationEmpty)
+	}
+	return nil
+}
+
+// bestN returns the n best individuals, best first, see core.Individual.RanksAbove.
+// Without constraints, these are the n fittest individuals.
+// The input slice is left untouched.
+func bestN[G any](individuals []core.Individual[G], n int) []core.Individual[G] {
+	sorted := make([]core.Individual[G], len(individuals))
+	copy(sorted, individuals)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].RanksAbove(&sorted[j])
+	})
+	return sorted[:n]
+}
+
+// ReplacementError represents an error that occurs during survivor selection.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type ReplacementError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *ReplacementError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *ReplacementError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewReplacementError constructs a *ReplacementError with the provided message and wrapped error.
+func NewReplacementError(message string, wrapped error) *ReplacementError {
+	return &ReplacementError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
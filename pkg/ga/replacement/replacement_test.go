@@ -0,0 +1,195 @@
+package replacement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// createPopulation is a helper function creating a population with the given fitness values.
+// Each individual carries its fitness as the single gene, which makes the survivors easy to identify.
+func createPopulation(fitness ...float64) *core.Population[int] {
+	individuals := make([]core.Solution[int], len(fitness))
+	for i, f := range fitness {
+		individuals[i] = core.Solution[int]{Chromosome: []int{int(f)}, Fitness: f}
+	}
+	return &core.Population[int]{Individuals: individuals}
+}
+
+// fitnessOf returns the fitness values of all individuals of a population.
+func fitnessOf(population *core.Population[int]) []float64 {
+	values := make([]float64, len(population.Individuals))
+	for i, individual := range population.Individuals {
+		values[i] = individual.Fitness
+	}
+	return values
+}
+
+func TestConstructors_RejectNegativeArguments(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewGenerationalReplacement[int](-1)
+	var re *ReplacementError
+	assert.ErrorAs(t, err, &re)
+
+	_, err = NewPlusReplacement[int](-1)
+	assert.ErrorAs(t, err, &re)
+
+	_, err = NewCommaReplacement[int](-1)
+	assert.ErrorAs(t, err, &re)
+}
+
+func TestReplacers_EmptyPopulations(t *testing.T) {
+	t.Parallel()
+	generational, err := NewGenerationalReplacement[int](0)
+	require.NoError(t, err)
+	plus, err := NewPlusReplacement[int](0)
+	require.NoError(t, err)
+	comma, err := NewCommaReplacement[int](0)
+	require.NoError(t, err)
+
+	replacers := map[string]IReplacer[int]{
+		"generational": generational,
+		"plus":         plus,
+		"comma":        comma,
+	}
+
+	for name, replacer := range replacers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := replacer.Replace(nil, createPopulation(1))
+			assert.ErrorIs(t, err, core.ErrPopulationEmpty)
+
+			_, err = replacer.Replace(createPopulation(1), createPopulation())
+			assert.ErrorIs(t, err, core.ErrPopulationEmpty)
+		})
+	}
+}
+
+func TestGenerationalReplacement_Replace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("offspring replace parents without elitism", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewGenerationalReplacement[int](0)
+		require.NoError(t, err)
+
+		survivors, err := replacer.Replace(createPopulation(10, 20, 30), createPopulation(1, 2, 3))
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 2, 3}, fitnessOf(survivors))
+	})
+
+	t.Run("elites replace the worst offspring", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewGenerationalReplacement[int](1)
+		require.NoError(t, err)
+
+		survivors, err := replacer.Replace(createPopulation(10, 30, 20), createPopulation(1, 3, 2))
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []float64{30, 3, 2}, fitnessOf(survivors))
+	})
+
+	t.Run("too many elites return error", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewGenerationalReplacement[int](3)
+		require.NoError(t, err)
+
+		_, err = replacer.Replace(createPopulation(1, 2, 3), createPopulation(1, 2, 3))
+		var re *ReplacementError
+		assert.ErrorAs(t, err, &re)
+	})
+
+	t.Run("not enough offspring return error", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewGenerationalReplacement[int](0)
+		require.NoError(t, err)
+
+		_, err = replacer.Replace(createPopulation(1, 2, 3), createPopulation(1))
+		var re *ReplacementError
+		assert.ErrorAs(t, err, &re)
+	})
+}
+
+func TestPlusReplacement_Replace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parents compete with offspring", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewPlusReplacement[int](0)
+		require.NoError(t, err)
+
+		survivors, err := replacer.Replace(createPopulation(10, 1, 5), createPopulation(2, 8, 3, 9))
+		require.NoError(t, err)
+		assert.Equal(t, []float64{10, 9, 8}, fitnessOf(survivors))
+	})
+
+	t.Run("explicit mu changes population size", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewPlusReplacement[int](2)
+		require.NoError(t, err)
+
+		survivors, err := replacer.Replace(createPopulation(10, 1, 5), createPopulation(2, 8))
+		require.NoError(t, err)
+		assert.Equal(t, []float64{10, 8}, fitnessOf(survivors))
+	})
+
+	t.Run("feasible individuals beat fitter infeasible ones", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewPlusReplacement[int](0)
+		require.NoError(t, err)
+
+		parents, offspring := createPopulation(10, 1, 5), createPopulation(20, 8)
+		offspring.Individuals[0].Violation = 1
+		parents.Individuals[0].Violation = 2
+		survivors, err := replacer.Replace(parents, offspring)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{8, 5, 1}, fitnessOf(survivors))
+	})
+
+	t.Run("mu larger than union returns error", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewPlusReplacement[int](10)
+		require.NoError(t, err)
+
+		_, err = replacer.Replace(createPopulation(1), createPopulation(2))
+		var re *ReplacementError
+		assert.ErrorAs(t, err, &re)
+	})
+}
+
+func TestCommaReplacement_Replace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only offspring survive", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewCommaReplacement[int](0)
+		require.NoError(t, err)
+
+		survivors, err := replacer.Replace(createPopulation(100, 200), createPopulation(1, 4, 3, 2))
+		require.NoError(t, err)
+		assert.Equal(t, []float64{4, 3}, fitnessOf(survivors))
+	})
+
+	t.Run("fewer offspring than mu return error", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewCommaReplacement[int](0)
+		require.NoError(t, err)
+
+		_, err = replacer.Replace(createPopulation(1, 2, 3), createPopulation(1, 2))
+		var re *ReplacementError
+		assert.ErrorAs(t, err, &re)
+	})
+
+	t.Run("input populations are not reordered", func(t *testing.T) {
+		t.Parallel()
+		replacer, err := NewCommaReplacement[int](1)
+		require.NoError(t, err)
+
+		offspring := createPopulation(1, 3, 2)
+		_, err = replacer.Replace(createPopulation(5), offspring)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 3, 2}, fitnessOf(offspring))
+	})
+}
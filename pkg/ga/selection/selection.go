@@ -1,12 +1,12 @@
 package selection
 
 import (
-	"cmp"
 	"fmt"
 	"math/rand"
 	"sort"
 
-	"github.com/tomhoffer/darwinium/internal/core"
+	"github.com/tomhoffer/darwinium/internal/random"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
 // SelectionError represents an error that occurs during a selection process.
@@ -39,39 +39,80 @@ func NewSelectionError(message string, wrapped error) *SelectionError {
 	}
 }
 
-// ISelector defines the interface for selection operators in genetic algorithms.
-type ISelector[T cmp.Ordered] interface {
-	Select(population *core.Population[T]) (*core.Population[T], error)
+// IGenomeSelector defines the interface for selection operators on populations of
+// chromosomes of an arbitrary representation G, see core.Genome.
+type IGenomeSelector[G any] interface {
+	Select(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error)
 }
 
-// TournamentSelector performs selection using a tournament method.
+// ISelector defines the interface for selection operators in genetic algorithms
+// whose chromosomes are slices of genes.
+type ISelector[T any] = IGenomeSelector[[]T]
+
+// IGenomeAware is implemented by selectors which copy the individuals they select. Executors
+// pass them their genome, so that chromosomes are copied with core.Genome.Clone; without a
+// genome, selectors fall back to core.Individual.DeepCopy.
+type IGenomeAware[G any] interface {
+	// SetGenome sets the genome used to copy chromosomes.
+	SetGenome(genome core.Genome[G])
+}
+
+// GenomeTournamentSelector performs selection using a tournament method on populations of
+// chromosomes of an arbitrary representation G.
 // It includes support for elitism, where the best individuals from the
 // current generation are carried over to the next.
-type TournamentSelector[T cmp.Ordered] struct {
+type GenomeTournamentSelector[G any] struct {
 	TournamentSize int
 	NumElites      int
+
+	genome core.Genome[G]
+	rng    *random.Rand
 }
 
+// TournamentSelector performs tournament selection on populations whose chromosomes are slices of genes.
+type TournamentSelector[T any] = GenomeTournamentSelector[[]T]
+
 // NewTournamentSelector creates a new TournamentSelector with the specified
 // tournament size and number of elites.
-func NewTournamentSelector[T cmp.Ordered](tournamentSize int, numElites int) (*TournamentSelector[T], error) {
+func NewTournamentSelector[T any](tournamentSize int, numElites int) (*TournamentSelector[T], error) {
+	return NewGenomeTournamentSelector[[]T](tournamentSize, numElites)
+}
+
+// NewGenomeTournamentSelector creates a new GenomeTournamentSelector with the specified
+// tournament size and number of elites.
+func NewGenomeTournamentSelector[G any](tournamentSize int, numElites int) (*GenomeTournamentSelector[G], error) {
 	if tournamentSize <= 0 {
 		return nil, NewSelectionError("invalid tournament size", fmt.Errorf("tournament size must be positive, but was %d", tournamentSize))
 	}
 	if numElites < 0 {
 		return nil, NewSelectionError("invalid number of elites", fmt.Errorf("number of elites cannot be negative, but was %d", numElites))
 	}
-	return &TournamentSelector[T]{
+	return &GenomeTournamentSelector[G]{
 		TournamentSize: tournamentSize,
 		NumElites:      numElites,
 	}, nil
 }
 
+// Elites returns the number of best individuals carried over to the next generation.
+func (ts *GenomeTournamentSelector[G]) Elites() int {
+	return ts.NumElites
+}
+
+// SetGenome implements IGenomeAware.
+func (ts *GenomeTournamentSelector[G]) SetGenome(genome core.Genome[G]) {
+	ts.genome = genome
+}
+
+// SetRand implements core.IRandomized.
+func (ts *GenomeTournamentSelector[G]) SetRand(generator *rand.Rand) {
+	ts.rng = random.New(generator)
+}
+
 // Select performs tournament selection on a population. It creates a new
 // population of the same size, composed of individuals selected through
 // a series of tournaments. If elitism is enabled, the fittest individuals
 // are preserved and passed directly to the next generation.
-func (ts *TournamentSelector[T]) Select(population *core.Population[T]) (*core.Population[T], error) {
+func (ts *GenomeTournamentSelector[G]) Select(population *core.GenomePopulation[G]) (*core.GenomePopulation[G], error) {
 	if population == nil || len(population.Individuals) == 0 {
 		return nil, NewSelectionError("cannot perform selection on nil or empty population", core.ErrPopulationEmpty)
 	}
@@ -82,18 +123,18 @@ func (ts *TournamentSelector[T]) Select(population *core.Population[T]) (*core.P
 			fmt.Sprintf("number of elites (%d) is greater than or equal to population size (%d)", ts.NumElites, populationSize), nil)
 	}
 
-	offspring := make([]core.Solution[T], 0, populationSize)
-	var selectionPool []core.Solution[T]
+	offspring := make([]core.Individual[G], 0, populationSize)
+	var selectionPool []core.Individual[G]
 
 	if ts.NumElites > 0 {
-		sortedIndividuals := make([]core.Solution[T], populationSize)
+		sortedIndividuals := make([]core.Individual[G], populationSize)
 		copy(sortedIndividuals, population.Individuals)
 		sort.Slice(sortedIndividuals, func(i, j int) bool {
 			return sortedIndividuals[i].Fitness > sortedIndividuals[j].Fitness
 		})
 
 		for i := 0; i < ts.NumElites; i++ {
-			offspring = append(offspring, *sortedIndividuals[i].DeepCopy())
+			offspring = append(offspring, *sortedIndividuals[i].Clone(ts.genome))
 		}
 		selectionPool = sortedIndividuals[ts.NumElites:]
 	} else {
@@ -104,15 +145,15 @@ func (ts *TournamentSelector[T]) Select(population *core.Population[T]) (*core.P
 	selectionPoolSize := len(selectionPool)
 
 	for i := 0; i < numToSelect; i++ {
-		winnerIndex := rand.Intn(selectionPoolSize)
+		winnerIndex := ts.rng.Intn(selectionPoolSize)
 		for j := 1; j < ts.TournamentSize; j++ {
-			competitorIndex := rand.Intn(selectionPoolSize)
+			competitorIndex := ts.rng.Intn(selectionPoolSize)
 			if selectionPool[competitorIndex].Fitness > selectionPool[winnerIndex].Fitness {
 				winnerIndex = competitorIndex
 			}
 		}
-		offspring = append(offspring, *selectionPool[winnerIndex].DeepCopy())
+		offspring = append(offspring, *selectionPool[winnerIndex].Clone(ts.genome))
 	}
 
-	return &core.Population[T]{Individuals: offspring}, nil
+	return &core.GenomePopulation[G]{Individuals: offspring}, nil
 }
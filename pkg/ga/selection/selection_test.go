@@ -8,7 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/tomhoffer/darwinium/internal/core"
+	"github.com/tomhoffer/darwinium/pkg/core"
 )
 
 //
@@ -0,0 +1,175 @@
+// Package termination provides stopping criteria for optimization runs.
+package termination
+
+import (
+	"time"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// ITerminationCriterion defines the interface for stopping criteria of optimization runs.
+// Criteria only look at the run statistics, so the same criterion works with every optimizer.
+type ITerminationCriterion interface {
+	// ShouldTerminate reports whether the run should stop after the last recorded generation.
+	//
+	// Parameters:
+	//   - statistics: The statistics of every generation recorded so far
+	//
+	// Returns:
+	//   - bool: true if the run should stop
+	ShouldTerminate(statistics *core.Statistics) bool
+}
+
+// MaxGenerations stops a run once the given number of generations has been recorded.
+type MaxGenerations struct {
+	Generations int
+}
+
+// NewMaxGenerations creates a criterion stopping after the given number of generations.
+func NewMaxGenerations(generations int) *MaxGenerations {
+	return &MaxGenerations{Generations: generations}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (m *MaxGenerations) ShouldTerminate(statistics *core.Statistics) bool {
+	return len(statistics.History) >= m.Generations
+}
+
+// MaxEvaluations stops a run once the given number of fitness evaluations has been performed.
+type MaxEvaluations struct {
+	Evaluations int
+}
+
+// NewMaxEvaluations creates a criterion stopping after the given number of fitness evaluations.
+func NewMaxEvaluations(evaluations int) *MaxEvaluations {
+	return &MaxEvaluations{Evaluations: evaluations}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (m *MaxEvaluations) ShouldTerminate(statistics *core.Statistics) bool {
+	last, ok := statistics.Last()
+	return ok && last.Evaluations >= m.Evaluations
+}
+
+// TargetFitness stops a run once a feasible solution reaches the given fitness.
+type TargetFitness struct {
+	Fitness float64
+}
+
+// NewTargetFitness creates a criterion stopping once the best fitness reaches the target.
+func NewTargetFitness(fitness float64) *TargetFitness {
+	return &TargetFitness{Fitness: fitness}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (t *TargetFitness) ShouldTerminate(statistics *core.Statistics) bool {
+	last, ok := statistics.Last()
+	return ok && !last.Infeasible && last.BestEverFitness >= t.Fitness
+}
+
+// Stagnation stops a run once the best fitness has not improved for the given number of generations.
+type Stagnation struct {
+	Generations int
+}
+
+// NewStagnation creates a criterion stopping after the given number of generations without improvement.
+func NewStagnation(generations int) *Stagnation {
+	return &Stagnation{Generations: generations}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (s *Stagnation) ShouldTerminate(statistics *core.Statistics) bool {
+	return statistics.GenerationsWithoutImprovement() >= s.Generations
+}
+
+// MinUniqueGenotypes stops a run once the population holds fewer than the given number of
+// distinct chromosomes. Generations whose number of unique genotypes was not recorded are ignored.
+type MinUniqueGenotypes struct {
+	Count int
+}
+
+// NewMinUniqueGenotypes creates a criterion stopping when fewer than count distinct chromosomes remain.
+func NewMinUniqueGenotypes(count int) *MinUniqueGenotypes {
+	return &MinUniqueGenotypes{Count: count}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (m *MinUniqueGenotypes) ShouldTerminate(statistics *core.Statistics) bool {
+	last, ok := statistics.Last()
+	return ok && last.UniqueGenotypes > 0 && last.UniqueGenotypes < m.Count
+}
+
+// MinDiversity stops a run once the recorded diversity of the population drops below the given
+// threshold. It requires a diversity measure to be configured on the optimizer.
+type MinDiversity struct {
+	Threshold float64
+}
+
+// NewMinDiversity creates a criterion stopping when the diversity drops below threshold.
+func NewMinDiversity(threshold float64) *MinDiversity {
+	return &MinDiversity{Threshold: threshold}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (m *MinDiversity) ShouldTerminate(statistics *core.Statistics) bool {
+	last, ok := statistics.Last()
+	return ok && last.Diversity < m.Threshold
+}
+
+// Timeout stops a run once the given wall-clock duration has passed since its start.
+type Timeout struct {
+	Duration time.Duration
+}
+
+// NewTimeout creates a criterion stopping after the given duration.
+func NewTimeout(duration time.Duration) *Timeout {
+	return &Timeout{Duration: duration}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (t *Timeout) ShouldTerminate(statistics *core.Statistics) bool {
+	return statistics.Elapsed() >= t.Duration
+}
+
+// AnyOf stops a run as soon as any of its criteria is met.
+type AnyOf struct {
+	Criteria []ITerminationCriterion
+}
+
+// Any creates a criterion combining the given criteria with a logical OR.
+func Any(criteria ...ITerminationCriterion) *AnyOf {
+	return &AnyOf{Criteria: criteria}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (a *AnyOf) ShouldTerminate(statistics *core.Statistics) bool {
+	for _, criterion := range a.Criteria {
+		if criterion.ShouldTerminate(statistics) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllOf stops a run once all of its criteria are met.
+type AllOf struct {
+	Criteria []ITerminationCriterion
+}
+
+// All creates a criterion combining the given criteria with a logical AND.
+func All(criteria ...ITerminationCriterion) *AllOf {
+	return &AllOf{Criteria: criteria}
+}
+
+// ShouldTerminate implements ITerminationCriterion.
+func (a *AllOf) ShouldTerminate(statistics *core.Statistics) bool {
+	if len(a.Criteria) == 0 {
+		return false
+	}
+	for _, criterion := range a.Criteria {
+		if !criterion.ShouldTerminate(statistics) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package termination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+// recordAll is a helper function recording a generation for every given best fitness.
+func recordAll(bestFitness ...float64) *core.Statistics {
+	statistics := core.NewStatistics()
+	for i, fitness := range bestFitness {
+		statistics.Record(core.GenerationStatistics{Generation: i, Evaluations: 10 * (i + 1), BestFitness: fitness})
+	}
+	return statistics
+}
+
+// recordInfeasible is a helper function recording a single generation without feasible individuals.
+func recordInfeasible(bestFitness float64) *core.Statistics {
+	statistics := core.NewStatistics()
+	statistics.Record(core.GenerationStatistics{BestFitness: bestFitness, Infeasible: true})
+	return statistics
+}
+
+// recordDiversity is a helper function recording a single generation with the given diversity metrics.
+func recordDiversity(uniqueGenotypes int, diversity float64) *core.Statistics {
+	statistics := core.NewStatistics()
+	statistics.Record(core.GenerationStatistics{UniqueGenotypes: uniqueGenotypes, Diversity: diversity})
+	return statistics
+}
+
+// constant is a criterion always returning the same answer.
+type constant bool
+
+func (c constant) ShouldTerminate(statistics *core.Statistics) bool {
+	return bool(c)
+}
+
+func TestCriteria(t *testing.T) {
+	testCases := []struct {
+		name       string
+		criterion  ITerminationCriterion
+		statistics *core.Statistics
+		expected   bool
+	}{
+		{"max generations not reached", NewMaxGenerations(3), recordAll(1, 2), false},
+		{"max generations reached", NewMaxGenerations(3), recordAll(1, 2, 3), true},
+		{"max evaluations not reached", NewMaxEvaluations(25), recordAll(1, 2), false},
+		{"max evaluations reached", NewMaxEvaluations(25), recordAll(1, 2, 3), true},
+		{"max evaluations without history", NewMaxEvaluations(0), core.NewStatistics(), false},
+		{"target fitness not reached", NewTargetFitness(5), recordAll(1, 4, 2), false},
+		{"unique genotypes above minimum", NewMinUniqueGenotypes(3), recordDiversity(3, 0), false},
+		{"unique genotypes below minimum", NewMinUniqueGenotypes(3), recordDiversity(2, 0), true},
+		{"unique genotypes not recorded", NewMinUniqueGenotypes(3), recordDiversity(0, 0), false},
+		{"diversity above minimum", NewMinDiversity(0.5), recordDiversity(10, 0.7), false},
+		{"diversity below minimum", NewMinDiversity(0.5), recordDiversity(10, 0.2), true},
+		{"target fitness reached in earlier generation", NewTargetFitness(4), recordAll(1, 4, 2), true},
+		{"target fitness reached by infeasible generation", NewTargetFitness(4), recordInfeasible(5), false},
+		{"stagnation not reached", NewStagnation(3), recordAll(1, 2, 2, 2), false},
+		{"stagnation reached", NewStagnation(3), recordAll(1, 2, 2, 2, 1), true},
+		{"timeout not reached", NewTimeout(time.Hour), recordAll(1), false},
+		{"any of with one met", Any(constant(false), constant(true)), recordAll(1), true},
+		{"any of with none met", Any(constant(false), constant(false)), recordAll(1), false},
+		{"all of with one unmet", All(constant(true), constant(false)), recordAll(1), false},
+		{"all of with all met", All(constant(true), constant(true)), recordAll(1), true},
+		{"all of without criteria", All(), recordAll(1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.criterion.ShouldTerminate(tc.statistics))
+		})
+	}
+
+	t.Run("timeout reached", func(t *testing.T) {
+		statistics := core.NewStatistics()
+		time.Sleep(5 * time.Millisecond)
+		assert.True(t, NewTimeout(time.Millisecond).ShouldTerminate(statistics))
+	})
+}
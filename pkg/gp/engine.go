@@ -0,0 +1,349 @@
+package gp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+// IEvaluator defines the interface for fitness evaluators of expression trees.
+// As everywhere in the library, higher fitness is better. Implementations must be safe for
+// concurrent use and must not modify the tree.
+type IEvaluator interface {
+	Evaluate(ctx context.Context, tree *Node) (float64, error)
+}
+
+// SymbolicRegression evaluates float64 valued trees by their negated mean squared error
+// on a set of samples.
+type SymbolicRegression struct {
+	// Inputs holds the variable bindings of every sample.
+	Inputs []map[string]any
+	// Targets holds the expected output of every sample.
+	Targets []float64
+}
+
+// NewSymbolicRegression creates a SymbolicRegression evaluator for the given samples.
+// It returns an error if there are no samples or inputs and targets differ in length.
+func NewSymbolicRegression(inputs []map[string]any, targets []float64) (*SymbolicRegression, error) {
+	if len(inputs) == 0 || len(inputs) != len(targets) {
+		return nil, NewGPError("invalid samples", fmt.Errorf("expected the same positive number of inputs and targets, but got %d and %d", len(inputs), len(targets)))
+	}
+	return &SymbolicRegression{Inputs: inputs, Targets: targets}, nil
+}
+
+// Evaluate implements IEvaluator. Trees producing non-finite errors get the lowest finite fitness.
+func (s *SymbolicRegression) Evaluate(ctx context.Context, tree *Node) (float64, error) {
+	sum := 0.0
+	for i, env := range s.Inputs {
+		output, err := tree.EvaluateFloat(env)
+		if err != nil {
+			return 0, err
+		}
+		sum += (output - s.Targets[i]) * (output - s.Targets[i])
+	}
+	mse := sum / float64(len(s.Inputs))
+	if math.IsNaN(mse) || math.IsInf(mse, 0) {
+		return -math.MaxFloat64, nil
+	}
+	return -mse, nil
+}
+
+// treeEvaluator adapts an IEvaluator to fitness.IFitnessEvaluator over index chromosomes,
+// so that trees can be evaluated in parallel with fitness.EvaluatePopulation.
+type treeEvaluator struct {
+	evaluator IEvaluator
+	trees     []*Node
+}
+
+// Evaluate implements fitness.IFitnessEvaluator for a chromosome holding a single tree index.
+func (t *treeEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	return t.evaluator.Evaluate(ctx, t.trees[(*chromosome)[0]])
+}
+
+// Individual is an evaluated expression tree.
+type Individual struct {
+	Tree    *Node
+	Fitness float64
+}
+
+// Config holds the parameters of a genetic programming run.
+type Config struct {
+	// Set provides the primitives trees are built from.
+	Set *PrimitiveSet
+	// RootType is the type of the value produced by every tree.
+	RootType Type
+	// PopulationSize is the number of trees per generation.
+	PopulationSize int
+	// MinInitDepth and MaxInitDepth delimit the depths of the ramped half-and-half initialization.
+	MinInitDepth, MaxInitDepth int
+	// MaxDepth limits the depth of trees created by the default operators. 0 disables the limit.
+	MaxDepth int
+	// Generations is the maximum number of generations.
+	Generations int
+	// CrossoverRate is the probability of recombining a pair of parents.
+	CrossoverRate float64
+	// MutationRate is the probability of mutating an offspring with one of the Mutators.
+	MutationRate float64
+	// Crossover recombines parents. Nil selects a SubtreeCrossover limited to MaxDepth.
+	Crossover ICrossover
+	// Mutators hold the mutation operators, one of which is chosen uniformly for every mutation.
+	// Nil selects subtree, point and hoist mutation.
+	Mutators []IMutator
+	// Selector selects parents. It operates on a population whose chromosomes hold the index of
+	// a tree and whose fitness is the parsimony adjusted fitness. Nil selects a tournament of 7.
+	Selector selection.ISelector[int]
+	// NumElites is the number of best trees copied unchanged into the next generation.
+	NumElites int
+	// ParsimonyCoefficient is subtracted from the fitness used for selection once per node,
+	// favoring smaller trees to control bloat. Reported fitness values are never adjusted.
+	ParsimonyCoefficient float64
+	// NumWorkers limits the number of concurrent fitness evaluations. It must be positive or -1 for unlimited.
+	NumWorkers int
+}
+
+// DefaultConfig returns a configuration evolving 500 trees of the given root type for
+// 50 generations with depths limited to 17, as proposed by Koza.
+func DefaultConfig(set *PrimitiveSet, rootType Type) Config {
+	return Config{
+		Set:            set,
+		RootType:       rootType,
+		PopulationSize: 500,
+		MinInitDepth:   2,
+		MaxInitDepth:   6,
+		MaxDepth:       17,
+		Generations:    50,
+		CrossoverRate:  0.9,
+		MutationRate:   0.1,
+		NumElites:      1,
+		NumWorkers:     1,
+	}
+}
+
+// Engine evolves expression trees. Selection is delegated to a selection.ISelector and
+// evaluation to fitness.EvaluatePopulation, so it shares the selection operators and the
+// parallel evaluation of the genetic algorithm executor.
+type Engine struct {
+	evaluator IEvaluator
+	config    Config
+
+	terminationCriterion termination.ITerminationCriterion
+	statistics           *core.Statistics
+
+	best        *Individual
+	evaluations int
+}
+
+// NewEngine creates a new genetic programming engine for the given evaluator and configuration.
+func NewEngine(evaluator IEvaluator, config Config) (*Engine, error) {
+	if evaluator == nil {
+		return nil, NewGPError("invalid evaluator", fmt.Errorf("evaluator cannot be nil"))
+	}
+	if config.Set == nil || config.RootType == "" {
+		return nil, NewGPError("invalid primitive set", fmt.Errorf("primitive set and root type must be set"))
+	}
+	if config.PopulationSize < 2 {
+		return nil, NewGPError("invalid population size", fmt.Errorf("population size must be at least 2, but was %d", config.PopulationSize))
+	}
+	if config.Generations <= 0 {
+		return nil, NewGPError("invalid number of generations", fmt.Errorf("generations must be positive, but was %d", config.Generations))
+	}
+	if config.CrossoverRate < 0 || config.CrossoverRate > 1 || config.MutationRate < 0 || config.MutationRate > 1 {
+		return nil, NewGPError("invalid rates", fmt.Errorf("crossover and mutation rates must be within [0, 1], but were %g and %g", config.CrossoverRate, config.MutationRate))
+	}
+	if config.NumElites < 0 || config.NumElites >= config.PopulationSize {
+		return nil, NewGPError("invalid number of elites", fmt.Errorf("number of elites must be within [0, %d), but was %d", config.PopulationSize, config.NumElites))
+	}
+	if config.ParsimonyCoefficient < 0 {
+		return nil, NewGPError("invalid parsimony coefficient", fmt.Errorf("parsimony coefficient cannot be negative, but was %g", config.ParsimonyCoefficient))
+	}
+	if config.MinInitDepth < 0 || config.MaxInitDepth < config.MinInitDepth {
+		return nil, NewGPError("invalid initialization depths", fmt.Errorf("depths must satisfy 0 <= min <= max, but were %d and %d", config.MinInitDepth, config.MaxInitDepth))
+	}
+	if config.NumWorkers == 0 || config.NumWorkers < -1 {
+		return nil, NewGPError("invalid number of workers", fmt.Errorf("number of workers must be positive or -1 for unlimited, but was %d", config.NumWorkers))
+	}
+
+	if config.Crossover == nil {
+		c, err := NewSubtreeCrossover(config.MaxDepth)
+		if err != nil {
+			return nil, err
+		}
+		config.Crossover = c
+	}
+	if config.Mutators == nil {
+		subtree, err := NewSubtreeMutation(config.Set, 4, config.MaxDepth)
+		if err != nil {
+			return nil, err
+		}
+		point, err := NewPointMutation(config.Set, 0.1)
+		if err != nil {
+			return nil, err
+		}
+		config.Mutators = []IMutator{subtree, point, NewHoistMutation()}
+	}
+	if config.Selector == nil {
+		s, err := selection.NewTournamentSelector[int](7, 0)
+		if err != nil {
+			return nil, err
+		}
+		config.Selector = s
+	}
+
+	return &Engine{evaluator: evaluator, config: config}, nil
+}
+
+// SetTerminationCriterion configures a criterion which stops Optimize before Generations.
+// The criterion is checked after every evaluated generation.
+func (e *Engine) SetTerminationCriterion(criterion termination.ITerminationCriterion) {
+	e.terminationCriterion = criterion
+}
+
+// Statistics returns the statistics recorded by the last call to Optimize, or nil before Optimize was called.
+// The recorded fitness values are not adjusted for parsimony.
+func (e *Engine) Statistics() *core.Statistics {
+	return e.statistics
+}
+
+// BestSolution returns the best tree found so far, or nil before Optimize was called.
+// Among trees of equal fitness, the smallest one is kept.
+func (e *Engine) BestSolution() *Individual {
+	return e.best
+}
+
+// Evaluations returns the number of fitness evaluations performed so far.
+func (e *Engine) Evaluations() int {
+	return e.evaluations
+}
+
+// Optimize evolves the trees until Generations is reached or the termination criterion is met,
+// and returns the last evaluated generation.
+func (e *Engine) Optimize(ctx context.Context) ([]Individual, error) {
+	e.statistics = core.NewStatistics()
+	e.evaluations = 0
+	e.best = nil
+
+	trees, err := RampedHalfAndHalf(e.config.Set, e.config.RootType, e.config.PopulationSize, e.config.MinInitDepth, e.config.MaxInitDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	for generation := 0; ; generation++ {
+		if ctx.Err() != nil {
+			return nil, NewGPError("context cancelled", ctx.Err())
+		}
+		population, err := e.evaluate(ctx, trees)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate generation %d: %w", generation, err)
+		}
+
+		generationStatistics, err := core.NewGenerationStatistics(population, generation, e.evaluations)
+		if err != nil {
+			return nil, err
+		}
+		e.statistics.Record(generationStatistics)
+		if generation+1 >= e.config.Generations || (e.terminationCriterion != nil && e.terminationCriterion.ShouldTerminate(e.statistics)) {
+			individuals := make([]Individual, len(trees))
+			for i, tree := range trees {
+				individuals[i] = Individual{Tree: tree, Fitness: population.Individuals[i].Fitness}
+			}
+			return individuals, nil
+		}
+
+		trees, err = e.breed(trees, population)
+		if err != nil {
+			return nil, fmt.Errorf("failed to breed generation %d: %w", generation+1, err)
+		}
+	}
+}
+
+// evaluate evaluates the trees in parallel and returns an index population holding their fitness.
+func (e *Engine) evaluate(ctx context.Context, trees []*Node) (*core.Population[int], error) {
+	population := indexPopulation(len(trees))
+	if err := fitness.EvaluatePopulation(ctx, &treeEvaluator{evaluator: e.evaluator, trees: trees}, population, e.config.NumWorkers); err != nil {
+		return nil, err
+	}
+	e.evaluations += len(trees)
+
+	for i, individual := range population.Individuals {
+		if e.best == nil || individual.Fitness > e.best.Fitness ||
+			(individual.Fitness == e.best.Fitness && trees[i].Size() < e.best.Tree.Size()) {
+			e.best = &Individual{Tree: trees[i].Clone(), Fitness: individual.Fitness}
+		}
+	}
+	return population, nil
+}
+
+// breed creates the next generation from the evaluated trees.
+func (e *Engine) breed(trees []*Node, population *core.Population[int]) ([]*Node, error) {
+	size := e.config.PopulationSize
+	adjusted := indexPopulation(len(trees))
+	for i := range adjusted.Individuals {
+		adjusted.Individuals[i].Fitness = population.Individuals[i].Fitness - e.config.ParsimonyCoefficient*float64(trees[i].Size())
+	}
+
+	next := make([]*Node, 0, size)
+	if e.config.NumElites > 0 {
+		ranked := make([]int, len(trees))
+		for i := range ranked {
+			ranked[i] = i
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return adjusted.Individuals[ranked[i]].Fitness > adjusted.Individuals[ranked[j]].Fitness
+		})
+		for _, i := range ranked[:e.config.NumElites] {
+			next = append(next, trees[i].Clone())
+		}
+	}
+
+	selected, err := e.config.Selector.Select(adjusted)
+	if err != nil {
+		return nil, err
+	}
+	parents := selected.Individuals
+	if len(parents) == 0 {
+		return nil, NewGPError("selection returned no parents", core.ErrPopulationEmpty)
+	}
+
+	for i := 0; len(next) < size; i += 2 {
+		parent1 := trees[parents[i%len(parents)].Chromosome[0]]
+		parent2 := trees[parents[(i+1)%len(parents)].Chromosome[0]]
+
+		var child1, child2 *Node
+		if rand.Float64() < e.config.CrossoverRate {
+			if child1, child2, err = e.config.Crossover.Crossover(parent1, parent2); err != nil {
+				return nil, err
+			}
+		} else {
+			child1, child2 = parent1.Clone(), parent2.Clone()
+		}
+
+		for _, child := range []*Node{child1, child2} {
+			if len(next) == size {
+				break
+			}
+			if len(e.config.Mutators) > 0 && rand.Float64() < e.config.MutationRate {
+				if child, err = e.config.Mutators[rand.Intn(len(e.config.Mutators))].Mutate(child); err != nil {
+					return nil, err
+				}
+			}
+			next = append(next, child)
+		}
+	}
+	return next, nil
+}
+
+// indexPopulation creates a population whose i-th chromosome holds the index i.
+func indexPopulation(size int) *core.Population[int] {
+	individuals := make([]core.Solution[int], size)
+	for i := range individuals {
+		individuals[i] = core.Solution[int]{Chromosome: []int{i}}
+	}
+	return &core.Population[int]{Individuals: individuals}
+}
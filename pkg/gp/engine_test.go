@@ -0,0 +1,185 @@
+package gp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+// newQuadraticRegression is a helper function creating samples of x^2 + x on [-1, 1].
+func newQuadraticRegression(t testing.TB) *SymbolicRegression {
+	t.Helper()
+	var inputs []map[string]any
+	var targets []float64
+	for i := -10; i <= 10; i++ {
+		x := float64(i) / 10
+		inputs = append(inputs, map[string]any{"x": x})
+		targets = append(targets, x*x+x)
+	}
+	evaluator, err := NewSymbolicRegression(inputs, targets)
+	require.NoError(t, err)
+	return evaluator
+}
+
+// failingEvaluator always fails.
+type failingEvaluator struct{}
+
+func (failingEvaluator) Evaluate(ctx context.Context, tree *Node) (float64, error) {
+	return 0, errors.New("evaluation failed")
+}
+
+func TestNewEngine(t *testing.T) {
+	valid := DefaultConfig(NewArithmeticSet("x"), TypeFloat)
+
+	testCases := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{"missing set", func(c *Config) { c.Set = nil }},
+		{"missing root type", func(c *Config) { c.RootType = "" }},
+		{"population too small", func(c *Config) { c.PopulationSize = 1 }},
+		{"no generations", func(c *Config) { c.Generations = 0 }},
+		{"invalid crossover rate", func(c *Config) { c.CrossoverRate = 2 }},
+		{"too many elites", func(c *Config) { c.NumElites = c.PopulationSize }},
+		{"negative parsimony", func(c *Config) { c.ParsimonyCoefficient = -1 }},
+		{"invalid initialization depths", func(c *Config) { c.MinInitDepth = 7 }},
+		{"zero workers", func(c *Config) { c.NumWorkers = 0 }},
+		{"negative workers", func(c *Config) { c.NumWorkers = -2 }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid
+			tc.modify(&config)
+			engine, err := NewEngine(newQuadraticRegression(t), config)
+			assert.Nil(t, engine)
+			var ge *GPError
+			assert.ErrorAs(t, err, &ge)
+		})
+	}
+
+	t.Run("nil evaluator", func(t *testing.T) {
+		_, err := NewEngine(nil, valid)
+		var ge *GPError
+		assert.ErrorAs(t, err, &ge)
+	})
+}
+
+func TestNewSymbolicRegression(t *testing.T) {
+	_, err := NewSymbolicRegression([]map[string]any{{"x": 1.0}}, nil)
+	var ge *GPError
+	assert.ErrorAs(t, err, &ge)
+}
+
+func TestEngine_Optimize(t *testing.T) {
+	t.Run("symbolic regression finds x^2 + x", func(t *testing.T) {
+		config := DefaultConfig(NewArithmeticSet("x"), TypeFloat)
+		config.PopulationSize = 300
+		config.Generations = 50
+		config.NumWorkers = -1
+
+		engine, err := NewEngine(newQuadraticRegression(t), config)
+		require.NoError(t, err)
+
+		population, err := engine.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, population, 300)
+		assert.Greater(t, engine.BestSolution().Fitness, -1e-2, engine.BestSolution().Tree.String())
+		assert.LessOrEqual(t, engine.BestSolution().Tree.Depth(), 17)
+	})
+
+	t.Run("parsimony pressure favors smaller trees", func(t *testing.T) {
+		set := NewArithmeticSet("x")
+		x := NewVariable("x", TypeFloat)
+		// Both trees have the same raw fitness, so only the parsimony adjusted fitness can rank them
+		trees := []*Node{
+			{Function: function(set, TypeFloat, "add"), Children: []*Node{{Terminal: x}, {Terminal: x}}},
+			{Terminal: x},
+		}
+		elite := func(parsimony float64) string {
+			config := DefaultConfig(set, TypeFloat)
+			config.PopulationSize = 2
+			config.CrossoverRate, config.MutationRate = 0, 0
+			config.ParsimonyCoefficient = parsimony
+			engine, err := NewEngine(newQuadraticRegression(t), config)
+			require.NoError(t, err)
+			next, err := engine.breed(trees, indexPopulation(len(trees)))
+			require.NoError(t, err)
+			require.Len(t, next, 2)
+			return next[0].String()
+		}
+		assert.Equal(t, "(add x x)", elite(0))
+		assert.Equal(t, "x", elite(0.1))
+	})
+
+	t.Run("typed trees evolve with custom selector", func(t *testing.T) {
+		config := DefaultConfig(newTypedSet(t), TypeFloat)
+		config.PopulationSize = 50
+		config.Generations = 5
+		selector, err := selection.NewTournamentSelector[int](3, 2)
+		require.NoError(t, err)
+		config.Selector = selector
+
+		engine, err := NewEngine(newQuadraticRegression(t), config)
+		require.NoError(t, err)
+		population, err := engine.Optimize(context.Background())
+		require.NoError(t, err)
+		for _, individual := range population {
+			checkTypes(t, individual.Tree)
+		}
+	})
+
+	t.Run("stops on termination criterion", func(t *testing.T) {
+		config := DefaultConfig(NewArithmeticSet("x"), TypeFloat)
+		config.PopulationSize = 20
+		engine, err := NewEngine(newQuadraticRegression(t), config)
+		require.NoError(t, err)
+		engine.SetTerminationCriterion(termination.NewMaxGenerations(3))
+
+		_, err = engine.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, engine.Statistics().History, 3)
+		assert.Equal(t, 60, engine.Evaluations())
+	})
+
+	t.Run("propagates evaluation errors", func(t *testing.T) {
+		config := DefaultConfig(NewArithmeticSet("x"), TypeFloat)
+		config.PopulationSize = 10
+		engine, err := NewEngine(failingEvaluator{}, config)
+		require.NoError(t, err)
+
+		population, err := engine.Optimize(context.Background())
+		assert.Nil(t, population)
+		assert.ErrorContains(t, err, "evaluation failed")
+	})
+
+	t.Run("stops on cancelled context", func(t *testing.T) {
+		engine, err := NewEngine(newQuadraticRegression(t), DefaultConfig(NewArithmeticSet("x"), TypeFloat))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = engine.Optimize(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+// BenchmarkEngine_Optimize benchmarks 10 generations of 200 trees on a symbolic regression problem.
+func BenchmarkEngine_Optimize(b *testing.B) {
+	config := DefaultConfig(NewArithmeticSet("x"), TypeFloat)
+	config.PopulationSize = 200
+	config.Generations = 10
+
+	engine, err := NewEngine(newQuadraticRegression(b), config)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = engine.Optimize(context.Background())
+	}
+}
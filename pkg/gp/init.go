@@ -0,0 +1,71 @@
+package gp
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Full creates a random tree of the given type whose leaves all lie at maxDepth, unless no
+// function returns the required type at some position, in which case a leaf is placed earlier.
+// It returns an error if a leaf of some required type cannot be created.
+func Full(set *PrimitiveSet, t Type, maxDepth int) (*Node, error) {
+	return generate(set, t, maxDepth, true)
+}
+
+// Grow creates a random tree of the given type with depth at most maxDepth, choosing
+// uniformly among all functions and terminals of the required type at every position.
+// It returns an error if a leaf of some required type cannot be created.
+func Grow(set *PrimitiveSet, t Type, maxDepth int) (*Node, error) {
+	return generate(set, t, maxDepth, false)
+}
+
+// generate implements Full and Grow.
+func generate(set *PrimitiveSet, t Type, depth int, full bool) (*Node, error) {
+	functions := set.Functions(t)
+	terminals := set.Terminals(t)
+
+	useTerminal := depth <= 0 || len(functions) == 0
+	if !useTerminal && !full && len(terminals) > 0 {
+		useTerminal = rand.Intn(len(functions)+len(terminals)) >= len(functions)
+	}
+
+	if useTerminal {
+		if len(terminals) == 0 {
+			return nil, NewGPError("cannot create tree", fmt.Errorf("no terminal of type %s", t))
+		}
+		return newLeaf(terminals[rand.Intn(len(terminals))]), nil
+	}
+
+	function := functions[rand.Intn(len(functions))]
+	node := &Node{Function: function, Children: make([]*Node, len(function.ArgTypes))}
+	for i, argType := range function.ArgTypes {
+		child, err := generate(set, argType, depth-1, full)
+		if err != nil {
+			return nil, err
+		}
+		node.Children[i] = child
+	}
+	return node, nil
+}
+
+// RampedHalfAndHalf creates n random trees of the given type. Maximum depths are spread
+// evenly over [minDepth, maxDepth] and, for every depth, half of the trees are created
+// with Full and half with Grow.
+func RampedHalfAndHalf(set *PrimitiveSet, t Type, n, minDepth, maxDepth int) ([]*Node, error) {
+	if minDepth < 0 || maxDepth < minDepth {
+		return nil, NewGPError("invalid initialization depths", fmt.Errorf("depths must satisfy 0 <= min <= max, but were %d and %d", minDepth, maxDepth))
+	}
+
+	depths := maxDepth - minDepth + 1
+	trees := make([]*Node, n)
+	for i := 0; i < n; i++ {
+		depth := minDepth + i%depths
+		full := (i/depths)%2 == 0
+		tree, err := generate(set, t, depth, full)
+		if err != nil {
+			return nil, err
+		}
+		trees[i] = tree
+	}
+	return trees, nil
+}
@@ -0,0 +1,193 @@
+package gp
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// ICrossover defines the interface for crossover operators on expression trees.
+// Implementations never modify the parents.
+type ICrossover interface {
+	Crossover(parent1, parent2 *Node) (*Node, *Node, error)
+}
+
+// IMutator defines the interface for mutation operators on expression trees.
+// Implementations never modify the tree and return the root of the mutated copy.
+type IMutator interface {
+	Mutate(tree *Node) (*Node, error)
+}
+
+// choosePosition picks a random position among the given ones, picking an internal node with
+// probability internalProbability if there is any (Koza's 90/10 rule).
+func choosePosition(positions []position, internalProbability float64) position {
+	internal := make([]position, 0, len(positions))
+	for _, p := range positions {
+		if !p.node.IsLeaf() {
+			internal = append(internal, p)
+		}
+	}
+	if len(internal) > 0 && rand.Float64() < internalProbability {
+		return internal[rand.Intn(len(internal))]
+	}
+	return positions[rand.Intn(len(positions))]
+}
+
+// positionsOfType returns the positions whose node produces the given type.
+func positionsOfType(positions []position, t Type) []position {
+	return slices.DeleteFunc(slices.Clone(positions), func(p position) bool {
+		return p.node.Type() != t
+	})
+}
+
+// SubtreeCrossover swaps randomly chosen subtrees of the same type between two parents.
+type SubtreeCrossover struct {
+	// MaxDepth limits the depth of the offspring. An offspring exceeding it is replaced by a
+	// copy of its parent. 0 disables the limit.
+	MaxDepth int
+	// InternalProbability is the probability of choosing an internal node as a crossover point.
+	InternalProbability float64
+}
+
+// NewSubtreeCrossover creates a SubtreeCrossover with the given depth limit, choosing
+// internal nodes as crossover points with probability 0.9.
+func NewSubtreeCrossover(maxDepth int) (*SubtreeCrossover, error) {
+	if maxDepth < 0 {
+		return nil, NewGPError("invalid maximum depth", fmt.Errorf("maximum depth cannot be negative, but was %d", maxDepth))
+	}
+	return &SubtreeCrossover{MaxDepth: maxDepth, InternalProbability: 0.9}, nil
+}
+
+// Crossover implements ICrossover. If the second parent has no subtree of the type chosen in
+// the first parent, copies of the parents are returned.
+func (c *SubtreeCrossover) Crossover(parent1, parent2 *Node) (*Node, *Node, error) {
+	if parent1 == nil || parent2 == nil {
+		return nil, nil, NewGPError("cannot perform crossover", fmt.Errorf("parents cannot be nil"))
+	}
+	child1, child2 := parent1.Clone(), parent2.Clone()
+
+	point1 := choosePosition(child1.positions(), c.InternalProbability)
+	candidates := positionsOfType(child2.positions(), point1.node.Type())
+	if len(candidates) == 0 {
+		return child1, child2, nil
+	}
+	point2 := choosePosition(candidates, c.InternalProbability)
+
+	child1 = replace(child1, point1, point2.node)
+	child2 = replace(child2, point2, point1.node)
+
+	if c.MaxDepth > 0 && child1.Depth() > c.MaxDepth {
+		child1 = parent1.Clone()
+	}
+	if c.MaxDepth > 0 && child2.Depth() > c.MaxDepth {
+		child2 = parent2.Clone()
+	}
+	return child1, child2, nil
+}
+
+// SubtreeMutation replaces a randomly chosen subtree with a new random subtree of the same type.
+type SubtreeMutation struct {
+	// Set provides the primitives of the new subtree.
+	Set *PrimitiveSet
+	// MaxSubtreeDepth is the maximum depth of the new subtree created with Grow.
+	MaxSubtreeDepth int
+	// MaxDepth limits the depth of the mutated tree. A mutant exceeding it is replaced by a
+	// copy of the original. 0 disables the limit.
+	MaxDepth int
+}
+
+// NewSubtreeMutation creates a SubtreeMutation growing subtrees of at most maxSubtreeDepth
+// and limiting mutants to maxDepth.
+func NewSubtreeMutation(set *PrimitiveSet, maxSubtreeDepth, maxDepth int) (*SubtreeMutation, error) {
+	if set == nil {
+		return nil, NewGPError("invalid primitive set", fmt.Errorf("primitive set cannot be nil"))
+	}
+	if maxSubtreeDepth < 0 || maxDepth < 0 {
+		return nil, NewGPError("invalid depth", fmt.Errorf("depths cannot be negative, but were %d and %d", maxSubtreeDepth, maxDepth))
+	}
+	return &SubtreeMutation{Set: set, MaxSubtreeDepth: maxSubtreeDepth, MaxDepth: maxDepth}, nil
+}
+
+// Mutate implements IMutator.
+func (m *SubtreeMutation) Mutate(tree *Node) (*Node, error) {
+	mutant := tree.Clone()
+	positions := mutant.positions()
+	point := positions[rand.Intn(len(positions))]
+
+	subtree, err := Grow(m.Set, point.node.Type(), m.MaxSubtreeDepth)
+	if err != nil {
+		return nil, err
+	}
+	mutant = replace(mutant, point, subtree)
+
+	if m.MaxDepth > 0 && mutant.Depth() > m.MaxDepth {
+		return tree.Clone(), nil
+	}
+	return mutant, nil
+}
+
+// PointMutation replaces every node with probability Rate by a random primitive of the same
+// signature: terminals by terminals of the same type, and functions by functions with the
+// same argument and return types. The shape of the tree is preserved.
+type PointMutation struct {
+	// Set provides the replacement primitives.
+	Set *PrimitiveSet
+	// Rate is the per-node probability of replacement.
+	Rate float64
+}
+
+// NewPointMutation creates a PointMutation replacing every node with the given probability.
+func NewPointMutation(set *PrimitiveSet, rate float64) (*PointMutation, error) {
+	if set == nil {
+		return nil, NewGPError("invalid primitive set", fmt.Errorf("primitive set cannot be nil"))
+	}
+	if rate < 0 || rate > 1 {
+		return nil, NewGPError("invalid mutation rate", fmt.Errorf("mutation rate must be within [0, 1], but was %g", rate))
+	}
+	return &PointMutation{Set: set, Rate: rate}, nil
+}
+
+// Mutate implements IMutator.
+func (m *PointMutation) Mutate(tree *Node) (*Node, error) {
+	mutant := tree.Clone()
+	for _, p := range mutant.positions() {
+		if rand.Float64() >= m.Rate {
+			continue
+		}
+		if p.node.IsLeaf() {
+			terminals := m.Set.Terminals(p.node.Type())
+			if len(terminals) == 0 {
+				continue
+			}
+			leaf := newLeaf(terminals[rand.Intn(len(terminals))])
+			p.node.Terminal, p.node.Value = leaf.Terminal, leaf.Value
+			continue
+		}
+		compatible := slices.DeleteFunc(slices.Clone(m.Set.Functions(p.node.Type())), func(f *Function) bool {
+			return !slices.Equal(f.ArgTypes, p.node.Function.ArgTypes)
+		})
+		if len(compatible) > 0 {
+			p.node.Function = compatible[rand.Intn(len(compatible))]
+		}
+	}
+	return mutant, nil
+}
+
+// HoistMutation replaces the tree with a randomly chosen proper subtree of the same type as
+// the root, which always reduces the size of the tree and counteracts bloat.
+type HoistMutation struct{}
+
+// NewHoistMutation creates a HoistMutation.
+func NewHoistMutation() *HoistMutation {
+	return &HoistMutation{}
+}
+
+// Mutate implements IMutator. Trees without a proper subtree of the root's type are copied unchanged.
+func (m *HoistMutation) Mutate(tree *Node) (*Node, error) {
+	mutant := tree.Clone()
+	candidates := positionsOfType(mutant.positions()[1:], mutant.Type())
+	if len(candidates) == 0 {
+		return mutant, nil
+	}
+	return candidates[rand.Intn(len(candidates))].node, nil
+}
@@ -0,0 +1,122 @@
+package gp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubtreeCrossover(t *testing.T) {
+	set := newTypedSet(t)
+
+	t.Run("offspring are well typed and parents unchanged", func(t *testing.T) {
+		crossover, err := NewSubtreeCrossover(0)
+		require.NoError(t, err)
+		for i := 0; i < 50; i++ {
+			parent1, err := Full(set, TypeFloat, 3)
+			require.NoError(t, err)
+			parent2, err := Grow(set, TypeFloat, 3)
+			require.NoError(t, err)
+			before1, before2 := parent1.String(), parent2.String()
+
+			child1, child2, err := crossover.Crossover(parent1, parent2)
+			require.NoError(t, err)
+			checkTypes(t, child1)
+			checkTypes(t, child2)
+			assert.Equal(t, TypeFloat, child1.Type())
+			assert.Equal(t, TypeFloat, child2.Type())
+			assert.Equal(t, parent1.Size()+parent2.Size(), child1.Size()+child2.Size())
+			assert.Equal(t, before1, parent1.String())
+			assert.Equal(t, before2, parent2.String())
+		}
+	})
+
+	t.Run("depth limit is enforced", func(t *testing.T) {
+		crossover, err := NewSubtreeCrossover(3)
+		require.NoError(t, err)
+		for i := 0; i < 50; i++ {
+			parent1, err := Full(set, TypeFloat, 3)
+			require.NoError(t, err)
+			parent2, err := Full(set, TypeFloat, 3)
+			require.NoError(t, err)
+
+			child1, child2, err := crossover.Crossover(parent1, parent2)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, child1.Depth(), 3)
+			assert.LessOrEqual(t, child2.Depth(), 3)
+		}
+	})
+
+	t.Run("invalid arguments return error", func(t *testing.T) {
+		_, err := NewSubtreeCrossover(-1)
+		var ge *GPError
+		assert.ErrorAs(t, err, &ge)
+
+		crossover, err := NewSubtreeCrossover(0)
+		require.NoError(t, err)
+		_, _, err = crossover.Crossover(nil, nil)
+		assert.ErrorAs(t, err, &ge)
+	})
+}
+
+func TestMutators(t *testing.T) {
+	set := newTypedSet(t)
+	subtree, err := NewSubtreeMutation(set, 3, 6)
+	require.NoError(t, err)
+	point, err := NewPointMutation(set, 1)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		mutator IMutator
+	}{
+		{"subtree", subtree},
+		{"point", point},
+		{"hoist", NewHoistMutation()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+" mutation keeps trees well typed", func(t *testing.T) {
+			for i := 0; i < 50; i++ {
+				tree, err := Full(set, TypeFloat, 4)
+				require.NoError(t, err)
+				before := tree.String()
+
+				mutant, err := tc.mutator.Mutate(tree)
+				require.NoError(t, err)
+				checkTypes(t, mutant)
+				assert.Equal(t, TypeFloat, mutant.Type())
+				assert.LessOrEqual(t, mutant.Depth(), 6)
+				assert.Equal(t, before, tree.String())
+			}
+		})
+	}
+
+	t.Run("point mutation preserves shape", func(t *testing.T) {
+		tree, err := Full(set, TypeFloat, 4)
+		require.NoError(t, err)
+		mutant, err := point.Mutate(tree)
+		require.NoError(t, err)
+		assert.Equal(t, tree.Size(), mutant.Size())
+		assert.Equal(t, tree.Depth(), mutant.Depth())
+	})
+
+	t.Run("hoist mutation shrinks trees", func(t *testing.T) {
+		tree, err := Full(set, TypeFloat, 4)
+		require.NoError(t, err)
+		mutant, err := NewHoistMutation().Mutate(tree)
+		require.NoError(t, err)
+		assert.Less(t, mutant.Size(), tree.Size())
+	})
+
+	t.Run("invalid arguments return error", func(t *testing.T) {
+		var ge *GPError
+		_, err := NewSubtreeMutation(nil, 2, 2)
+		assert.ErrorAs(t, err, &ge)
+		_, err = NewSubtreeMutation(set, -1, 2)
+		assert.ErrorAs(t, err, &ge)
+		_, err = NewPointMutation(set, 1.5)
+		assert.ErrorAs(t, err, &ge)
+	})
+}
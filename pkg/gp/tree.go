@@ -0,0 +1,354 @@
+// Package gp provides genetic programming with strongly typed expression trees.
+package gp
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+// Type names the type of the value produced by a node. Nodes may only be plugged into
+// argument positions expecting the same type.
+type Type string
+
+const (
+	// TypeFloat is the type of float64 valued nodes.
+	TypeFloat Type = "float64"
+	// TypeBool is the type of bool valued nodes.
+	TypeBool Type = "bool"
+)
+
+// Function is an internal node primitive taking typed arguments.
+type Function struct {
+	// Name identifies the function in the printed form of a tree.
+	Name string
+	// ArgTypes holds the type of every argument. Its length is the arity of the function.
+	ArgTypes []Type
+	// ReturnType is the type of the value returned by Apply.
+	ReturnType Type
+	// Apply computes the value of the function from the values of its arguments.
+	Apply func(args []any) (any, error)
+}
+
+// Terminal is a leaf primitive: a variable read from the environment, a constant, or an
+// ephemeral random constant whose value is drawn once when the leaf is created.
+type Terminal struct {
+	// Name identifies the terminal. Variables are looked up in the environment by name.
+	Name string
+	// Type is the type of the value produced by the terminal.
+	Type Type
+	// Variable marks the terminal as an input variable.
+	Variable bool
+	// Value is the value of a constant terminal.
+	Value any
+	// Generate draws the value of an ephemeral random constant. Nil for other terminals.
+	Generate func() any
+}
+
+// NewVariable creates a terminal reading the input variable with the given name.
+func NewVariable(name string, t Type) *Terminal {
+	return &Terminal{Name: name, Type: t, Variable: true}
+}
+
+// NewConstant creates a terminal always producing the given value.
+func NewConstant(name string, t Type, value any) *Terminal {
+	return &Terminal{Name: name, Type: t, Value: value}
+}
+
+// NewEphemeralConstant creates an ephemeral random constant terminal whose value is drawn
+// by generate whenever a leaf is created.
+func NewEphemeralConstant(name string, t Type, generate func() any) *Terminal {
+	return &Terminal{Name: name, Type: t, Generate: generate}
+}
+
+// NewUniformConstant creates a float64 ephemeral random constant drawn uniformly from [lower, upper).
+func NewUniformConstant(name string, lower, upper float64) *Terminal {
+	return NewEphemeralConstant(name, TypeFloat, func() any {
+		return lower + rand.Float64()*(upper-lower)
+	})
+}
+
+// Node is a node of an expression tree. Exactly one of Function and Terminal is set.
+type Node struct {
+	// Function is the primitive of an internal node.
+	Function *Function
+	// Terminal is the primitive of a leaf.
+	Terminal *Terminal
+	// Value holds the value of a constant or ephemeral random constant leaf.
+	Value any
+	// Children holds the arguments of an internal node.
+	Children []*Node
+}
+
+// newLeaf creates a leaf for the given terminal, drawing its value if it is ephemeral.
+func newLeaf(terminal *Terminal) *Node {
+	node := &Node{Terminal: terminal, Value: terminal.Value}
+	if terminal.Generate != nil {
+		node.Value = terminal.Generate()
+	}
+	return node
+}
+
+// Type returns the type of the value produced by the node.
+func (n *Node) Type() Type {
+	if n.Function != nil {
+		return n.Function.ReturnType
+	}
+	return n.Terminal.Type
+}
+
+// IsLeaf reports whether the node is a terminal.
+func (n *Node) IsLeaf() bool {
+	return n.Function == nil
+}
+
+// Size returns the number of nodes of the tree rooted at n.
+func (n *Node) Size() int {
+	size := 1
+	for _, child := range n.Children {
+		size += child.Size()
+	}
+	return size
+}
+
+// Depth returns the depth of the tree rooted at n. A single leaf has depth 0.
+func (n *Node) Depth() int {
+	depth := 0
+	for _, child := range n.Children {
+		depth = max(depth, child.Depth()+1)
+	}
+	return depth
+}
+
+// Clone returns a deep copy of the tree rooted at n. Primitives are shared.
+func (n *Node) Clone() *Node {
+	clone := &Node{Function: n.Function, Terminal: n.Terminal, Value: n.Value}
+	if len(n.Children) > 0 {
+		clone.Children = make([]*Node, len(n.Children))
+		for i, child := range n.Children {
+			clone.Children[i] = child.Clone()
+		}
+	}
+	return clone
+}
+
+// String returns the tree in prefix notation, e.g. "(add x (mul 2.5 x))".
+func (n *Node) String() string {
+	var sb strings.Builder
+	n.write(&sb)
+	return sb.String()
+}
+
+func (n *Node) write(sb *strings.Builder) {
+	if n.IsLeaf() {
+		if n.Terminal.Variable {
+			sb.WriteString(n.Terminal.Name)
+		} else {
+			fmt.Fprintf(sb, "%v", n.Value)
+		}
+		return
+	}
+	sb.WriteString("(")
+	sb.WriteString(n.Function.Name)
+	for _, child := range n.Children {
+		sb.WriteString(" ")
+		child.write(sb)
+	}
+	sb.WriteString(")")
+}
+
+// Evaluate interprets the tree rooted at n, reading variables from env.
+// It returns an error if a variable is missing from env or a function fails.
+func (n *Node) Evaluate(env map[string]any) (any, error) {
+	if n.IsLeaf() {
+		if !n.Terminal.Variable {
+			return n.Value, nil
+		}
+		value, ok := env[n.Terminal.Name]
+		if !ok {
+			return nil, NewGPError(fmt.Sprintf("undefined variable %q", n.Terminal.Name), nil)
+		}
+		return value, nil
+	}
+
+	args := make([]any, len(n.Children))
+	for i, child := range n.Children {
+		value, err := child.Evaluate(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+	value, err := n.Function.Apply(args)
+	if err != nil {
+		return nil, NewGPError(fmt.Sprintf("failed to apply %s", n.Function.Name), err)
+	}
+	return value, nil
+}
+
+// EvaluateFloat interprets a float64 valued tree, see Evaluate.
+func (n *Node) EvaluateFloat(env map[string]any) (float64, error) {
+	value, err := n.Evaluate(env)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := value.(float64)
+	if !ok {
+		return 0, NewGPError(fmt.Sprintf("tree evaluated to %T, expected float64", value), nil)
+	}
+	return f, nil
+}
+
+// position locates a node within a tree.
+type position struct {
+	node   *Node
+	parent *Node
+	index  int // index of node among the children of parent
+	depth  int // depth of node below the root
+}
+
+// positions lists every node of the tree rooted at n in prefix order.
+func (n *Node) positions() []position {
+	var result []position
+	var walk func(node, parent *Node, index, depth int)
+	walk = func(node, parent *Node, index, depth int) {
+		result = append(result, position{node: node, parent: parent, index: index, depth: depth})
+		for i, child := range node.Children {
+			walk(child, node, i, depth+1)
+		}
+	}
+	walk(n, nil, 0, 0)
+	return result
+}
+
+// replace returns the root of the tree after substituting the node at p with subtree.
+func replace(root *Node, p position, subtree *Node) *Node {
+	if p.parent == nil {
+		return subtree
+	}
+	p.parent.Children[p.index] = subtree
+	return root
+}
+
+// PrimitiveSet holds the functions and terminals trees are built from, indexed by type.
+type PrimitiveSet struct {
+	functions map[Type][]*Function
+	terminals map[Type][]*Terminal
+}
+
+// NewPrimitiveSet creates an empty PrimitiveSet.
+func NewPrimitiveSet() *PrimitiveSet {
+	return &PrimitiveSet{
+		functions: make(map[Type][]*Function),
+		terminals: make(map[Type][]*Terminal),
+	}
+}
+
+// AddFunction adds a function to the set.
+// It returns an error if the function has no name, no Apply or untyped arguments.
+func (s *PrimitiveSet) AddFunction(function *Function) error {
+	if function == nil || function.Name == "" || function.Apply == nil || function.ReturnType == "" {
+		return NewGPError("invalid function", fmt.Errorf("function must have a name, a return type and Apply"))
+	}
+	for i, argType := range function.ArgTypes {
+		if argType == "" {
+			return NewGPError("invalid function", fmt.Errorf("argument %d of %s has no type", i, function.Name))
+		}
+	}
+	s.functions[function.ReturnType] = append(s.functions[function.ReturnType], function)
+	return nil
+}
+
+// AddTerminal adds a terminal to the set.
+// It returns an error if the terminal has no name or no type.
+func (s *PrimitiveSet) AddTerminal(terminal *Terminal) error {
+	if terminal == nil || terminal.Name == "" || terminal.Type == "" {
+		return NewGPError("invalid terminal", fmt.Errorf("terminal must have a name and a type"))
+	}
+	s.terminals[terminal.Type] = append(s.terminals[terminal.Type], terminal)
+	return nil
+}
+
+// Functions returns the functions returning the given type.
+func (s *PrimitiveSet) Functions(t Type) []*Function {
+	return s.functions[t]
+}
+
+// Terminals returns the terminals of the given type.
+func (s *PrimitiveSet) Terminals(t Type) []*Terminal {
+	return s.terminals[t]
+}
+
+// NewArithmeticSet creates a float64 primitive set with add, sub, mul and protected div
+// over the given variables and a uniform ephemeral constant in [-1, 1).
+func NewArithmeticSet(variables ...string) *PrimitiveSet {
+	set := NewPrimitiveSet()
+	for _, function := range ArithmeticFunctions() {
+		_ = set.AddFunction(function)
+	}
+	for _, name := range variables {
+		_ = set.AddTerminal(NewVariable(name, TypeFloat))
+	}
+	_ = set.AddTerminal(NewUniformConstant("erc", -1, 1))
+	return set
+}
+
+// ArithmeticFunctions returns the float64 functions add, sub, mul and div. Division is
+// protected: dividing by a value closer to zero than 1e-9 returns 1.
+func ArithmeticFunctions() []*Function {
+	binary := func(name string, op func(a, b float64) float64) *Function {
+		return &Function{
+			Name:       name,
+			ArgTypes:   []Type{TypeFloat, TypeFloat},
+			ReturnType: TypeFloat,
+			Apply: func(args []any) (any, error) {
+				return op(args[0].(float64), args[1].(float64)), nil
+			},
+		}
+	}
+	return []*Function{
+		binary("add", func(a, b float64) float64 { return a + b }),
+		binary("sub", func(a, b float64) float64 { return a - b }),
+		binary("mul", func(a, b float64) float64 { return a * b }),
+		binary("div", func(a, b float64) float64 {
+			if math.Abs(b) < 1e-9 {
+				return 1
+			}
+			return a / b
+		}),
+	}
+}
+
+// GPError represents an error that occurs during genetic programming.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type GPError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *GPError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *GPError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewGPError constructs a *GPError with the provided message and wrapped error.
+func NewGPError(message string, wrapped error) *GPError {
+	return &GPError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
@@ -0,0 +1,174 @@
+package gp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTypedSet is a helper function creating a set with float64 arithmetic and a boolean
+// conditional, so that trees mix two types.
+func newTypedSet(t *testing.T) *PrimitiveSet {
+	t.Helper()
+	set := NewArithmeticSet("x")
+	require.NoError(t, set.AddFunction(&Function{
+		Name:       "if",
+		ArgTypes:   []Type{TypeBool, TypeFloat, TypeFloat},
+		ReturnType: TypeFloat,
+		Apply: func(args []any) (any, error) {
+			if args[0].(bool) {
+				return args[1], nil
+			}
+			return args[2], nil
+		},
+	}))
+	require.NoError(t, set.AddFunction(&Function{
+		Name:       "lt",
+		ArgTypes:   []Type{TypeFloat, TypeFloat},
+		ReturnType: TypeBool,
+		Apply: func(args []any) (any, error) {
+			return args[0].(float64) < args[1].(float64), nil
+		},
+	}))
+	require.NoError(t, set.AddTerminal(NewConstant("true", TypeBool, true)))
+	return set
+}
+
+// function is a helper function looking up a function of the set by name.
+func function(set *PrimitiveSet, t Type, name string) *Function {
+	for _, f := range set.Functions(t) {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// checkTypes is a helper function asserting that every argument has the type expected by its function.
+func checkTypes(t *testing.T, node *Node) {
+	t.Helper()
+	if node.IsLeaf() {
+		assert.Empty(t, node.Children)
+		return
+	}
+	require.Len(t, node.Children, len(node.Function.ArgTypes))
+	for i, child := range node.Children {
+		assert.Equal(t, node.Function.ArgTypes[i], child.Type())
+		checkTypes(t, child)
+	}
+}
+
+func TestNode(t *testing.T) {
+	set := NewArithmeticSet("x")
+	x := NewVariable("x", TypeFloat)
+	// (add x (mul 2 x))
+	tree := &Node{Function: function(set, TypeFloat, "add"), Children: []*Node{
+		{Terminal: x},
+		{Function: function(set, TypeFloat, "mul"), Children: []*Node{
+			{Terminal: NewConstant("two", TypeFloat, 2.0), Value: 2.0},
+			{Terminal: x},
+		}},
+	}}
+
+	t.Run("size depth and string", func(t *testing.T) {
+		assert.Equal(t, 5, tree.Size())
+		assert.Equal(t, 2, tree.Depth())
+		assert.Equal(t, "(add x (mul 2 x))", tree.String())
+		assert.Equal(t, TypeFloat, tree.Type())
+	})
+
+	t.Run("evaluate", func(t *testing.T) {
+		value, err := tree.EvaluateFloat(map[string]any{"x": 3.0})
+		require.NoError(t, err)
+		assert.Equal(t, 9.0, value)
+	})
+
+	t.Run("evaluate with missing variable returns error", func(t *testing.T) {
+		_, err := tree.Evaluate(map[string]any{})
+		var ge *GPError
+		assert.ErrorAs(t, err, &ge)
+	})
+
+	t.Run("protected division", func(t *testing.T) {
+		div := &Node{Function: function(set, TypeFloat, "div"), Children: []*Node{{Terminal: x}, {Terminal: x}}}
+		value, err := div.EvaluateFloat(map[string]any{"x": 0.0})
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, value)
+	})
+
+	t.Run("clone is deep", func(t *testing.T) {
+		clone := tree.Clone()
+		clone.Children[1].Children[0].Value = 5.0
+		assert.Equal(t, "(add x (mul 2 x))", tree.String())
+		assert.Equal(t, "(add x (mul 5 x))", clone.String())
+	})
+}
+
+func TestPrimitiveSet(t *testing.T) {
+	set := NewPrimitiveSet()
+
+	t.Run("rejects invalid primitives", func(t *testing.T) {
+		var ge *GPError
+		assert.ErrorAs(t, set.AddFunction(&Function{Name: "f", ReturnType: TypeFloat}), &ge)
+		assert.ErrorAs(t, set.AddFunction(&Function{Name: "f", ReturnType: TypeFloat, ArgTypes: []Type{""}, Apply: func(args []any) (any, error) { return nil, nil }}), &ge)
+		assert.ErrorAs(t, set.AddTerminal(&Terminal{Name: "x"}), &ge)
+		assert.Empty(t, set.Functions(TypeFloat))
+		assert.Empty(t, set.Terminals(TypeFloat))
+	})
+
+	t.Run("ephemeral constants are drawn per leaf", func(t *testing.T) {
+		erc := NewUniformConstant("erc", 5, 6)
+		leaf := newLeaf(erc)
+		assert.GreaterOrEqual(t, leaf.Value.(float64), 5.0)
+		assert.Less(t, leaf.Value.(float64), 6.0)
+	})
+}
+
+func TestInitialization(t *testing.T) {
+	set := newTypedSet(t)
+
+	t.Run("full trees reach maximum depth", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			tree, err := Full(set, TypeFloat, 4)
+			require.NoError(t, err)
+			assert.Equal(t, 4, tree.Depth())
+			checkTypes(t, tree)
+		}
+	})
+
+	t.Run("grown trees respect maximum depth", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			tree, err := Grow(set, TypeFloat, 4)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, tree.Depth(), 4)
+			checkTypes(t, tree)
+		}
+	})
+
+	t.Run("ramped half-and-half spreads depths", func(t *testing.T) {
+		trees, err := RampedHalfAndHalf(set, TypeFloat, 60, 1, 3)
+		require.NoError(t, err)
+		require.Len(t, trees, 60)
+		depths := map[int]bool{}
+		for _, tree := range trees {
+			assert.GreaterOrEqual(t, tree.Depth(), 0)
+			assert.LessOrEqual(t, tree.Depth(), 3)
+			depths[tree.Depth()] = true
+			checkTypes(t, tree)
+		}
+		assert.True(t, depths[1] && depths[3])
+	})
+
+	t.Run("missing terminal type returns error", func(t *testing.T) {
+		_, err := Full(NewArithmeticSet("x"), TypeBool, 2)
+		var ge *GPError
+		assert.ErrorAs(t, err, &ge)
+	})
+
+	t.Run("invalid depths return error", func(t *testing.T) {
+		_, err := RampedHalfAndHalf(set, TypeFloat, 10, 3, 2)
+		var ge *GPError
+		assert.ErrorAs(t, err, &ge)
+	})
+}
@@ -0,0 +1,336 @@
+// Package binpacking provides the one-dimensional bin packing problem as a benchmark for
+// constraint handling: a parser for OR-Library instances, a permutation encoding with a
+// first-fit decoder which only produces feasible packings, and an assignment encoding whose
+// evaluator measures the capacity violation, together with a repair operator.
+//
+// Fitness is maximized. Both evaluators return the negated number of bins plus the Falkenauer
+// fill term mean((load/capacity)^2), which lies in (0, 1]: fewer bins always mean a higher
+// fitness, and among packings with the same number of bins fuller bins are preferred, which
+// guides the search where the number of bins alone is flat.
+package binpacking
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/constraint"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+var (
+	// ErrInvalidSolution indicates that a chromosome does not encode a packing of an instance.
+	ErrInvalidSolution = errors.New("invalid solution")
+	// ErrUnknownOptimum indicates that the optimal number of bins of an instance is not known.
+	ErrUnknownOptimum = errors.New("unknown optimum")
+)
+
+// BinPackingError represents an error that occurs while loading a bin packing instance or evaluating a packing.
+type BinPackingError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *BinPackingError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *BinPackingError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewBinPackingError constructs a *BinPackingError with the provided message and wrapped error.
+func NewBinPackingError(message string, wrapped error) *BinPackingError {
+	return &BinPackingError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// Instance is a one-dimensional bin packing problem: pack items of the given sizes into as few
+// bins of the given capacity as possible.
+type Instance struct {
+	// Name identifies the instance, e.g. "u120_00".
+	Name string
+	// Capacity is the capacity of every bin.
+	Capacity float64
+	// Sizes holds the size of every item.
+	Sizes []float64
+	// Optimum is the optimal or best known number of bins, 0 if unknown.
+	Optimum int
+}
+
+// NewInstance creates an instance from the bin capacity and the item sizes, which are copied.
+// Sizes must be positive and must not exceed the capacity.
+func NewInstance(name string, capacity float64, sizes []float64) (*Instance, error) {
+	instance := &Instance{Name: name, Capacity: capacity, Sizes: slices.Clone(sizes)}
+	if err := instance.validate(); err != nil {
+		return nil, NewBinPackingError(fmt.Sprintf("cannot create instance %q", name), err)
+	}
+	return instance, nil
+}
+
+// validate is a helper function checking the capacity and the item sizes.
+func (i *Instance) validate() error {
+	if len(i.Sizes) == 0 {
+		return errors.New("instance must have at least 1 item")
+	}
+	if i.Capacity <= 0 {
+		return fmt.Errorf("capacity must be positive, got %g", i.Capacity)
+	}
+	for j, size := range i.Sizes {
+		if size <= 0 || size > i.Capacity {
+			return fmt.Errorf("size of item %d must be within (0, %g], got %g", j, i.Capacity, size)
+		}
+	}
+	return nil
+}
+
+// Items returns the number of items.
+func (i *Instance) Items() int {
+	return len(i.Sizes)
+}
+
+// LowerBound returns the lower bound ceil(sum of sizes / capacity) on the number of bins.
+func (i *Instance) LowerBound() int {
+	total := 0.0
+	for _, size := range i.Sizes {
+		total += size
+	}
+	return int(math.Ceil(total/i.Capacity - 1e-9))
+}
+
+// Gap returns the relative gap (bins - Optimum) / Optimum of a packing's number of bins to the
+// known optimum. Returns ErrUnknownOptimum if the optimum is not known.
+func (i *Instance) Gap(bins int) (float64, error) {
+	if i.Optimum == 0 {
+		return 0, NewBinPackingError(fmt.Sprintf("cannot compute gap of instance %q", i.Name), ErrUnknownOptimum)
+	}
+	return float64(bins-i.Optimum) / float64(i.Optimum), nil
+}
+
+// Decode packs the items in the given order into the first bin with enough room left, opening a
+// new bin if none has. The order must be a permutation of the items. Returns the items of every bin.
+func (i *Instance) Decode(order []int) ([][]int, error) {
+	if err := i.validateOrder(order); err != nil {
+		return nil, NewBinPackingError("cannot decode solution", err)
+	}
+	var bins [][]int
+	var loads []float64
+	for _, item := range order {
+		bin := slices.IndexFunc(loads, func(load float64) bool { return load+i.Sizes[item] <= i.Capacity })
+		if bin < 0 {
+			bin = len(bins)
+			bins = append(bins, nil)
+			loads = append(loads, 0)
+		}
+		bins[bin] = append(bins[bin], item)
+		loads[bin] += i.Sizes[item]
+	}
+	return bins, nil
+}
+
+// validateOrder is a helper function checking that order is a permutation of the items.
+func (i *Instance) validateOrder(order []int) error {
+	if len(order) != i.Items() {
+		return fmt.Errorf("%w: expected an order of %d items, got %d", ErrInvalidSolution, i.Items(), len(order))
+	}
+	seen := make([]bool, len(order))
+	for _, item := range order {
+		if item < 0 || item >= len(order) || seen[item] {
+			return fmt.Errorf("%w: order is not a permutation of the items", ErrInvalidSolution)
+		}
+		seen[item] = true
+	}
+	return nil
+}
+
+// Bins groups the items by the bin they are assigned to and returns the items of every non-empty
+// bin, ordered by bin index. Item j is assigned to bin assignment[j], which must lie in [0, Items()).
+func (i *Instance) Bins(assignment []int) ([][]int, error) {
+	if err := i.validateAssignment(assignment); err != nil {
+		return nil, NewBinPackingError("cannot group items", err)
+	}
+	grouped := make([][]int, i.Items())
+	for item, bin := range assignment {
+		grouped[bin] = append(grouped[bin], item)
+	}
+	return slices.DeleteFunc(grouped, func(items []int) bool { return len(items) == 0 }), nil
+}
+
+// validateAssignment is a helper function checking that every item is assigned to a bin in [0, Items()).
+func (i *Instance) validateAssignment(assignment []int) error {
+	if len(assignment) != i.Items() {
+		return fmt.Errorf("%w: expected an assignment of %d items, got %d", ErrInvalidSolution, i.Items(), len(assignment))
+	}
+	for item, bin := range assignment {
+		if bin < 0 || bin >= len(assignment) {
+			return fmt.Errorf("%w: bin %d of item %d is out of range [0, %d)", ErrInvalidSolution, bin, item, len(assignment))
+		}
+	}
+	return nil
+}
+
+// Load returns the total size of the given items.
+func (i *Instance) Load(items []int) float64 {
+	load := 0.0
+	for _, item := range items {
+		load += i.Sizes[item]
+	}
+	return load
+}
+
+// Violation returns the total overflow of the bins relative to the capacity,
+// sum(max(0, load - capacity)) / capacity, which is 0 for feasible packings.
+func (i *Instance) Violation(bins [][]int) float64 {
+	violation := 0.0
+	for _, items := range bins {
+		violation += constraint.LessEqual(i.Load(items), i.Capacity)
+	}
+	return violation / i.Capacity
+}
+
+// Fitness returns the negated number of bins plus the mean squared fill of the bins, see the
+// package documentation. Overfull bins count as full.
+func (i *Instance) Fitness(bins [][]int) float64 {
+	if len(bins) == 0 {
+		return 0
+	}
+	fill := 0.0
+	for _, items := range bins {
+		ratio := min(i.Load(items)/i.Capacity, 1)
+		fill += ratio * ratio
+	}
+	return -float64(len(bins)) + fill/float64(len(bins))
+}
+
+// PermutationEvaluator evaluates permutations of the items by the first-fit packing they decode
+// to, see Instance.Decode. Every permutation decodes to a feasible packing.
+type PermutationEvaluator struct {
+	instance *Instance
+}
+
+var _ fitness.IFitnessEvaluator[int] = (*PermutationEvaluator)(nil)
+
+// NewPermutationEvaluator creates a PermutationEvaluator for instance.
+func NewPermutationEvaluator(instance *Instance) *PermutationEvaluator {
+	return &PermutationEvaluator{instance: instance}
+}
+
+// Evaluate implements fitness.IFitnessEvaluator, see Instance.Fitness.
+func (p *PermutationEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if chromosome == nil {
+		return 0, NewBinPackingError("cannot evaluate solution", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	bins, err := p.instance.Decode(*chromosome)
+	if err != nil {
+		return 0, err
+	}
+	return p.instance.Fitness(bins), nil
+}
+
+// AssignmentEvaluator evaluates chromosomes assigning item j to bin chromosome[j]. It implements
+// fitness.IConstrainedEvaluator: the fitness follows Instance.Fitness and the violation is the
+// relative overflow of the bins, see Instance.Violation. Combine it with a constraint handling
+// technique such as constraint.PenaltySelector, constraint.FeasibilityTournamentSelector or the
+// repair operator of NewAssignmentRepairer.
+type AssignmentEvaluator struct {
+	instance *Instance
+}
+
+var _ fitness.IConstrainedEvaluator[[]int] = (*AssignmentEvaluator)(nil)
+
+// NewAssignmentEvaluator creates an AssignmentEvaluator for instance.
+func NewAssignmentEvaluator(instance *Instance) *AssignmentEvaluator {
+	return &AssignmentEvaluator{instance: instance}
+}
+
+// Evaluate implements fitness.IGenomeEvaluator and returns the fitness, ignoring the capacity.
+func (a *AssignmentEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	value, _, err := a.EvaluateConstrained(ctx, chromosome)
+	return value, err
+}
+
+// EvaluateConstrained implements fitness.IConstrainedEvaluator.
+func (a *AssignmentEvaluator) EvaluateConstrained(ctx context.Context, chromosome *[]int) (float64, float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+	if chromosome == nil {
+		return 0, 0, NewBinPackingError("cannot evaluate solution", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	bins, err := a.instance.Bins(*chromosome)
+	if err != nil {
+		return 0, 0, err
+	}
+	return a.instance.Fitness(bins), a.instance.Violation(bins), nil
+}
+
+// AssignmentRepairer repairs assignments by removing the largest items from every overfull bin
+// until it fits and packing the removed items, largest first, into the first bin with enough
+// room left, which may be an empty one. Wrapped around an AssignmentEvaluator by
+// constraint.NewRepairingEvaluator, every evaluated packing becomes feasible.
+type AssignmentRepairer struct {
+	instance *Instance
+}
+
+var _ constraint.IRepairer[[]int] = (*AssignmentRepairer)(nil)
+
+// NewAssignmentRepairer creates an AssignmentRepairer for instance.
+func NewAssignmentRepairer(instance *Instance) *AssignmentRepairer {
+	return &AssignmentRepairer{instance: instance}
+}
+
+// Repair implements constraint.IRepairer.
+func (a *AssignmentRepairer) Repair(ctx context.Context, chromosome *[]int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if chromosome == nil {
+		return NewBinPackingError("cannot repair solution", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	assignment := *chromosome
+	if err := a.instance.validateAssignment(assignment); err != nil {
+		return NewBinPackingError("cannot repair solution", err)
+	}
+
+	sizes := a.instance.Sizes
+	bySize := func(x, y int) int { return cmp.Compare(sizes[y], sizes[x]) }
+	contents := make([][]int, len(assignment))
+	loads := make([]float64, len(assignment))
+	for item, bin := range assignment {
+		contents[bin] = append(contents[bin], item)
+		loads[bin] += sizes[item]
+	}
+
+	var removed []int
+	for bin, items := range contents {
+		slices.SortStableFunc(items, bySize)
+		for k := 0; loads[bin] > a.instance.Capacity; k++ {
+			removed = append(removed, items[k])
+			loads[bin] -= sizes[items[k]]
+		}
+	}
+	slices.SortStableFunc(removed, bySize)
+	for _, item := range removed {
+		// The other items occupy at most n-1 of the n bins, so an empty bin is always left and no item exceeds the capacity
+		bin := slices.IndexFunc(loads, func(load float64) bool { return load+sizes[item] <= a.instance.Capacity })
+		assignment[item] = bin
+		loads[bin] += sizes[item]
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package binpacking
+
+import (
+	"cmp"
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/constraint"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+)
+
+// small returns an instance whose optimal packing {0, 2} {1, 3, 4} fills 2 bins exactly.
+func small(t *testing.T) *Instance {
+	t.Helper()
+	instance, err := NewInstance("small", 10, []float64{6, 5, 4, 3, 2})
+	require.NoError(t, err)
+	instance.Optimum = 2
+	return instance
+}
+
+// randomInstance is a helper function creating an instance of items with sizes uniform in [20, 100] and bins of capacity 150.
+func randomInstance(t *testing.T, items int, seed int64) *Instance {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	sizes := make([]float64, items)
+	for j := range sizes {
+		sizes[j] = float64(20 + rng.Intn(81))
+	}
+	instance, err := NewInstance("random", 150, sizes)
+	require.NoError(t, err)
+	return instance
+}
+
+func TestNewInstance(t *testing.T) {
+	t.Run("copies the sizes", func(t *testing.T) {
+		sizes := []float64{1, 2}
+		instance, err := NewInstance("copy", 3, sizes)
+		require.NoError(t, err)
+		sizes[0] = 5
+		assert.Equal(t, []float64{1, 2}, instance.Sizes)
+		assert.Equal(t, 2, instance.Items())
+		assert.Equal(t, 1, instance.LowerBound())
+	})
+
+	t.Run("rejects invalid data", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			capacity float64
+			sizes    []float64
+			message  string
+		}{
+			{"no items", 1, nil, "at least 1 item"},
+			{"zero capacity", 0, []float64{1}, "capacity must be positive"},
+			{"zero size", 1, []float64{0}, "size of item 0 must be within (0, 1]"},
+			{"oversized item", 1, []float64{1, 2}, "size of item 1 must be within (0, 1]"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewInstance(tc.name, tc.capacity, tc.sizes)
+				var binPackingErr *BinPackingError
+				require.ErrorAs(t, err, &binPackingErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestInstance_Solutions(t *testing.T) {
+	instance := small(t)
+
+	t.Run("lower bound and gap", func(t *testing.T) {
+		assert.Equal(t, 2, instance.LowerBound())
+		gap, err := instance.Gap(3)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.5, gap, 1e-12)
+
+		unknown, err := NewInstance("unknown", 1, []float64{1})
+		require.NoError(t, err)
+		_, err = unknown.Gap(1)
+		assert.ErrorIs(t, err, ErrUnknownOptimum)
+	})
+
+	t.Run("decoding packs items first fit", func(t *testing.T) {
+		bins, err := instance.Decode([]int{0, 1, 2, 3, 4})
+		require.NoError(t, err)
+		assert.Equal(t, [][]int{{0, 2}, {1, 3, 4}}, bins)
+
+		bins, err = instance.Decode([]int{4, 3, 2, 1, 0})
+		require.NoError(t, err)
+		assert.Equal(t, [][]int{{4, 3, 2}, {1}, {0}}, bins)
+
+		for _, order := range [][]int{{0, 1, 2, 3}, {0, 1, 2, 3, 3}, {0, 1, 2, 3, 5}} {
+			_, err := instance.Decode(order)
+			assert.ErrorIs(t, err, ErrInvalidSolution)
+		}
+	})
+
+	t.Run("grouping assignments by bin", func(t *testing.T) {
+		bins, err := instance.Bins([]int{3, 1, 3, 1, 1})
+		require.NoError(t, err)
+		assert.Equal(t, [][]int{{1, 3, 4}, {0, 2}}, bins)
+
+		for _, assignment := range [][]int{{0, 0, 0, 0}, {0, 0, 0, 0, 5}, {0, 0, -1, 0, 0}} {
+			_, err := instance.Bins(assignment)
+			assert.ErrorIs(t, err, ErrInvalidSolution)
+		}
+	})
+
+	t.Run("fitness prefers fewer and fuller bins", func(t *testing.T) {
+		optimal := instance.Fitness([][]int{{0, 2}, {1, 3, 4}})
+		assert.InDelta(t, -1.0, optimal, 1e-12)
+		uneven := instance.Fitness([][]int{{4, 3, 2}, {1}, {0}})
+		assert.InDelta(t, -3+(0.81+0.25+0.36)/3, uneven, 1e-12)
+		even := instance.Fitness([][]int{{0, 4}, {1, 3}, {2}})
+		assert.Less(t, uneven, optimal)
+		assert.Less(t, uneven, even)
+	})
+
+	t.Run("violation measures the overflow", func(t *testing.T) {
+		assert.Equal(t, 0.0, instance.Violation([][]int{{0, 2}, {1, 3, 4}}))
+		assert.InDelta(t, 1.0, instance.Violation([][]int{{0, 1, 2, 3, 4}}), 1e-12)
+		assert.InDelta(t, 0.3, instance.Violation([][]int{{0, 1, 4}, {2, 3}}), 1e-12)
+	})
+}
+
+func TestEvaluators(t *testing.T) {
+	instance := small(t)
+	ctx := context.Background()
+
+	t.Run("permutation evaluator decodes first fit", func(t *testing.T) {
+		evaluator := NewPermutationEvaluator(instance)
+		order := []int{0, 1, 2, 3, 4}
+		value, err := evaluator.Evaluate(ctx, &order)
+		require.NoError(t, err)
+		assert.InDelta(t, -1.0, value, 1e-12)
+
+		invalid := []int{0, 0, 1, 2, 3}
+		_, err = evaluator.Evaluate(ctx, &invalid)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+		_, err = evaluator.Evaluate(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+	})
+
+	t.Run("assignment evaluator reports fitness and violation", func(t *testing.T) {
+		evaluator := NewAssignmentEvaluator(instance)
+		assignment := []int{0, 0, 0, 0, 0}
+		value, violation, err := evaluator.EvaluateConstrained(ctx, &assignment)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.0, value, 1e-12)
+		assert.InDelta(t, 1.0, violation, 1e-12)
+
+		value, err = evaluator.Evaluate(ctx, &assignment)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.0, value, 1e-12)
+
+		invalid := []int{0, 0, 0, 0, 5}
+		_, err = evaluator.Evaluate(ctx, &invalid)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+		_, err = evaluator.Evaluate(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+	})
+
+	t.Run("repairing evaluator makes assignments feasible", func(t *testing.T) {
+		evaluator, err := constraint.NewRepairingEvaluator(NewAssignmentRepairer(instance), NewAssignmentEvaluator(instance))
+		require.NoError(t, err)
+		assignment := []int{0, 0, 0, 0, 0}
+		value, violation, err := evaluator.EvaluateConstrained(ctx, &assignment)
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 0, 0, 0}, assignment)
+		assert.Equal(t, 0.0, violation)
+		assert.InDelta(t, -3+(0.81+0.36+0.25)/3, value, 1e-12)
+
+		feasible := []int{4, 2, 4, 2, 2}
+		require.NoError(t, NewAssignmentRepairer(instance).Repair(ctx, &feasible))
+		assert.Equal(t, []int{4, 2, 4, 2, 2}, feasible)
+
+		invalid := []int{0, 0, 0, 0}
+		assert.ErrorIs(t, NewAssignmentRepairer(instance).Repair(ctx, &invalid), ErrInvalidSolution)
+		assert.ErrorIs(t, NewAssignmentRepairer(instance).Repair(ctx, nil), ErrInvalidSolution)
+	})
+
+	t.Run("stop on cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		chromosome := []int{0, 1, 2, 3, 4}
+		_, err := NewPermutationEvaluator(instance).Evaluate(cancelled, &chromosome)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = NewAssignmentEvaluator(instance).Evaluate(cancelled, &chromosome)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, NewAssignmentRepairer(instance).Repair(cancelled, &chromosome), context.Canceled)
+	})
+}
+
+func TestGeneticAlgorithm(t *testing.T) {
+	instance := randomInstance(t, 30, 1)
+	// First fit decreasing is a strong constructive heuristic, which the genetic algorithm should match.
+	decreasing := make([]int, instance.Items())
+	for j := range decreasing {
+		decreasing[j] = j
+	}
+	slices.SortStableFunc(decreasing, func(x, y int) int { return cmp.Compare(instance.Sizes[y], instance.Sizes[x]) })
+	reference, err := instance.Decode(decreasing)
+	require.NoError(t, err)
+
+	t.Run("permutation encoding", func(t *testing.T) {
+		ga, err := executor.NewBuilder[int]().
+			WithRandomPopulation(40, func() []int { return rand.Perm(instance.Items()) }).
+			WithEvaluator(NewPermutationEvaluator(instance)).
+			WithCrossover(crossover.NewOrderCrossover[int]()).
+			WithMutator(mutation.NewSimpleSwapMutator[int](0.3)).
+			WithGenerations(60).
+			Build()
+		require.NoError(t, err)
+		ga.SetProgress(false)
+
+		population, err := ga.Run(context.Background())
+		require.NoError(t, err)
+		best, err := population.BestSolution()
+		require.NoError(t, err)
+		bins, err := instance.Decode(best.Chromosome)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(bins), instance.LowerBound())
+		assert.LessOrEqual(t, len(bins), len(reference))
+	})
+
+	t.Run("assignment encoding with repair", func(t *testing.T) {
+		evaluator, err := constraint.NewRepairingEvaluator(NewAssignmentRepairer(instance), NewAssignmentEvaluator(instance))
+		require.NoError(t, err)
+		ga, err := executor.NewBuilder[int]().
+			WithRandomPopulation(40, func() []int {
+				assignment := make([]int, instance.Items())
+				for j := range assignment {
+					assignment[j] = rand.Intn(len(reference))
+				}
+				return assignment
+			}).
+			WithEvaluator(evaluator).
+			WithCrossover(crossover.NewSinglePointCrossover[int]()).
+			WithMutator(mutation.NewSimpleSwapMutator[int](0.3)).
+			WithGenerations(60).
+			Build()
+		require.NoError(t, err)
+		ga.SetProgress(false)
+
+		population, err := ga.Run(context.Background())
+		require.NoError(t, err)
+		best, err := population.BestSolution()
+		require.NoError(t, err)
+		assert.True(t, best.Feasible())
+		bins, err := instance.Bins(best.Chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, instance.Violation(bins))
+		assert.GreaterOrEqual(t, len(bins), instance.LowerBound())
+		assert.LessOrEqual(t, len(bins), len(reference)+1)
+	})
+}
@@ -0,0 +1,125 @@
+package binpacking
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// tokens reads the whitespace-separated words of an instance file.
+type tokens struct {
+	scanner *bufio.Scanner
+}
+
+func newTokens(r io.Reader) *tokens {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	return &tokens{scanner: scanner}
+}
+
+// word is a helper function reading the next word, describing it by what in errors.
+func (t *tokens) word(what string) (string, error) {
+	if !t.scanner.Scan() {
+		if err := t.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("unexpected end of file, expected %s", what)
+	}
+	return t.scanner.Text(), nil
+}
+
+// float is a helper function reading the next number.
+func (t *tokens) float(what string) (float64, error) {
+	text, err := t.word(what)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", what, text)
+	}
+	return value, nil
+}
+
+// count is a helper function reading the next number as a count of at least minimum.
+func (t *tokens) count(what string, minimum int) (int, error) {
+	value, err := t.float(what)
+	if err != nil {
+		return 0, err
+	}
+	if value != float64(int(value)) || int(value) < minimum {
+		return 0, fmt.Errorf("%s must be an integer of at least %d, got %g", what, minimum, value)
+	}
+	return int(value), nil
+}
+
+// end is a helper function checking that no tokens are left.
+func (t *tokens) end() error {
+	if t.scanner.Scan() {
+		return fmt.Errorf("unexpected data %q at the end of the file", t.scanner.Text())
+	}
+	return t.scanner.Err()
+}
+
+// LoadORLibrary reads the bin packing instances of an OR-Library file, see ParseORLibrary.
+func LoadORLibrary(path string) ([]*Instance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewBinPackingError("cannot load instances", err)
+	}
+	defer file.Close()
+	return ParseORLibrary(file)
+}
+
+// ParseORLibrary reads the bin packing instances of an OR-Library file such as binpack1.txt,
+// whose whitespace-separated words are: the number of instances, and for each instance its
+// identifier, the bin capacity, the number of items n, the best known number of bins and the
+// n item sizes.
+func ParseORLibrary(r io.Reader) ([]*Instance, error) {
+	instances, err := parseORLibrary(newTokens(r))
+	if err != nil {
+		return nil, NewBinPackingError("cannot parse instances", err)
+	}
+	return instances, nil
+}
+
+func parseORLibrary(t *tokens) ([]*Instance, error) {
+	count, err := t.count("number of instances", 1)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]*Instance, count)
+	for k := range instances {
+		name, err := t.word("instance identifier")
+		if err != nil {
+			return nil, fmt.Errorf("instance %d: %w", k+1, err)
+		}
+		instance := &Instance{Name: name}
+		if instance.Capacity, err = t.float("capacity"); err != nil {
+			return nil, fmt.Errorf("instance %s: %w", name, err)
+		}
+		n, err := t.count("number of items", 1)
+		if err != nil {
+			return nil, fmt.Errorf("instance %s: %w", name, err)
+		}
+		if instance.Optimum, err = t.count("best known number of bins", 0); err != nil {
+			return nil, fmt.Errorf("instance %s: %w", name, err)
+		}
+		instance.Sizes = make([]float64, n)
+		for j := range instance.Sizes {
+			if instance.Sizes[j], err = t.float("size"); err != nil {
+				return nil, fmt.Errorf("instance %s: %w", name, err)
+			}
+		}
+		if err := instance.validate(); err != nil {
+			return nil, fmt.Errorf("instance %s: %w", name, err)
+		}
+		instances[k] = instance
+	}
+	if err := t.end(); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
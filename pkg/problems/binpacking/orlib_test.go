@@ -0,0 +1,85 @@
+package binpacking
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// binpack holds two instances in the OR-Library format of binpack1.txt, the second being small.
+const binpack = ` 2
+ u4_00
+ 100 4 2
+ 60
+ 50
+ 40
+ 30
+ small
+ 10 5 2
+ 6
+ 5
+ 4
+ 3
+ 2
+`
+
+func TestParseORLibrary(t *testing.T) {
+	t.Run("reads every instance", func(t *testing.T) {
+		instances, err := ParseORLibrary(strings.NewReader(binpack))
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+
+		first := instances[0]
+		assert.Equal(t, "u4_00", first.Name)
+		assert.Equal(t, 100.0, first.Capacity)
+		assert.Equal(t, []float64{60, 50, 40, 30}, first.Sizes)
+		assert.Equal(t, 2, first.Optimum)
+
+		second := instances[1]
+		assert.Equal(t, "small", second.Name)
+		assert.Equal(t, []float64{6, 5, 4, 3, 2}, second.Sizes)
+		assert.Equal(t, 2, second.Optimum)
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			text    string
+			message string
+		}{
+			{"empty", "", "expected number of instances"},
+			{"no instances", "0", "number of instances must be an integer of at least 1"},
+			{"missing identifier", "1", "instance 1: unexpected end of file, expected instance identifier"},
+			{"fractional count", "1 a 10 2.5 1", "instance a: number of items must be an integer"},
+			{"truncated", "1 a 10 2 1 3", "instance a: unexpected end of file, expected size"},
+			{"invalid number", "1 a 10 2 1 3 x", "invalid size \"x\""},
+			{"trailing data", binpack + " 7", "unexpected data \"7\""},
+			{"oversized item", "1 a 10 1 1 11", "instance a: size of item 0 must be within (0, 10]"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := ParseORLibrary(strings.NewReader(tc.text))
+				var binPackingErr *BinPackingError
+				require.ErrorAs(t, err, &binPackingErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestLoadORLibrary(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "binpack0.txt")
+	require.NoError(t, os.WriteFile(path, []byte(binpack), 0o644))
+
+	instances, err := LoadORLibrary(path)
+	require.NoError(t, err)
+	assert.Len(t, instances, 2)
+
+	_, err = LoadORLibrary(filepath.Join(dir, "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,387 @@
+// Package knapsack provides the 0/1 and the multi-dimensional knapsack problem as benchmarks
+// for constraint handling: a parser for OR-Library instances, a bitstring evaluator measuring
+// the capacity violation for penalty and feasibility-based selection, a greedy repair operator
+// and a permutation encoding whose greedy decoder only produces feasible solutions.
+//
+// The 0/1 knapsack problem is the special case of a single capacity constraint.
+// Fitness is the total profit of the packed items, which is maximized.
+package knapsack
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+	"github.com/tomhoffer/darwinium/pkg/ga/constraint"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+var (
+	// ErrInvalidSolution indicates that a chromosome does not encode a solution of an instance.
+	ErrInvalidSolution = errors.New("invalid solution")
+	// ErrUnknownOptimum indicates that the optimal profit of an instance is not known.
+	ErrUnknownOptimum = errors.New("unknown optimum")
+)
+
+// KnapsackError represents an error that occurs while loading a knapsack instance or evaluating a solution.
+type KnapsackError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *KnapsackError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *KnapsackError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewKnapsackError constructs a *KnapsackError with the provided message and wrapped error.
+func NewKnapsackError(message string, wrapped error) *KnapsackError {
+	return &KnapsackError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// Instance is a multi-dimensional knapsack problem: select items maximizing the total profit such
+// that, in every dimension, the total weight of the selected items does not exceed the capacity.
+type Instance struct {
+	// Name identifies the instance, e.g. "mknap1-1".
+	Name string
+	// Profits holds the profit of every item.
+	Profits []float64
+	// Weights holds the weight of every item in every dimension, indexed by dimension and item.
+	Weights [][]float64
+	// Capacities holds the capacity of every dimension.
+	Capacities []float64
+	// Optimum is the optimal or best known total profit, 0 if unknown.
+	Optimum float64
+}
+
+// NewInstance creates an instance from the profits of n items, the m x n weights and the
+// m capacities, which are copied. Weights and capacities must not be negative.
+func NewInstance(name string, profits []float64, weights [][]float64, capacities []float64) (*Instance, error) {
+	instance := &Instance{Name: name, Profits: slices.Clone(profits), Capacities: slices.Clone(capacities)}
+	for _, row := range weights {
+		instance.Weights = append(instance.Weights, slices.Clone(row))
+	}
+	if err := instance.validate(); err != nil {
+		return nil, NewKnapsackError(fmt.Sprintf("cannot create instance %q", name), err)
+	}
+	return instance, nil
+}
+
+// validate is a helper function checking the dimensions and signs of the instance data.
+func (i *Instance) validate() error {
+	n, m := len(i.Profits), len(i.Capacities)
+	if n == 0 {
+		return errors.New("instance must have at least 1 item")
+	}
+	if m == 0 {
+		return errors.New("instance must have at least 1 capacity")
+	}
+	if len(i.Weights) != m {
+		return fmt.Errorf("expected weights in %d dimensions, got %d", m, len(i.Weights))
+	}
+	for d, row := range i.Weights {
+		if len(row) != n {
+			return fmt.Errorf("expected %d weights in dimension %d, got %d", n, d, len(row))
+		}
+		if slices.ContainsFunc(row, func(w float64) bool { return w < 0 }) {
+			return fmt.Errorf("weights in dimension %d must not be negative", d)
+		}
+		if i.Capacities[d] < 0 {
+			return fmt.Errorf("capacity of dimension %d must not be negative, got %g", d, i.Capacities[d])
+		}
+	}
+	return nil
+}
+
+// efficiencyOrder is a helper function listing the items by decreasing efficiency, the profit
+// per weight relative to the capacities.
+func (i *Instance) efficiencyOrder() []int {
+	n, m := len(i.Profits), len(i.Capacities)
+	efficiency := make([]float64, n)
+	for j := range n {
+		relative := 0.0
+		for d := range m {
+			if i.Capacities[d] > 0 {
+				relative += i.Weights[d][j] / i.Capacities[d]
+			} else if i.Weights[d][j] > 0 {
+				relative += i.Weights[d][j]
+			}
+		}
+		efficiency[j] = i.Profits[j] / max(relative, 1e-12)
+	}
+	order := make([]int, n)
+	for j := range order {
+		order[j] = j
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(efficiency[b], efficiency[a])
+	})
+	return order
+}
+
+// Items returns the number of items.
+func (i *Instance) Items() int {
+	return len(i.Profits)
+}
+
+// Dimensions returns the number of capacity constraints, 1 for the 0/1 knapsack problem.
+func (i *Instance) Dimensions() int {
+	return len(i.Capacities)
+}
+
+// Profit returns the total profit of the selected items.
+func (i *Instance) Profit(selected []bool) float64 {
+	profit := 0.0
+	for j, s := range selected {
+		if s {
+			profit += i.Profits[j]
+		}
+	}
+	return profit
+}
+
+// Loads returns the total weight of the selected items in every dimension.
+func (i *Instance) Loads(selected []bool) []float64 {
+	loads := make([]float64, len(i.Capacities))
+	for j, s := range selected {
+		if s {
+			for d := range loads {
+				loads[d] += i.Weights[d][j]
+			}
+		}
+	}
+	return loads
+}
+
+// Violation returns the total excess weight of the selected items relative to the capacities,
+// sum(max(0, load - capacity) / capacity), which is 0 for feasible selections.
+func (i *Instance) Violation(selected []bool) float64 {
+	violation := 0.0
+	for d, load := range i.Loads(selected) {
+		excess := constraint.LessEqual(load, i.Capacities[d])
+		if i.Capacities[d] > 0 {
+			excess /= i.Capacities[d]
+		}
+		violation += excess
+	}
+	return violation
+}
+
+// Feasible reports whether the selected items fit into the knapsack in every dimension.
+func (i *Instance) Feasible(selected []bool) bool {
+	return i.Violation(selected) == 0
+}
+
+// Gap returns the relative gap (Optimum - profit) / Optimum of a feasible solution's profit to
+// the known optimum. Returns ErrUnknownOptimum if the optimum is not known.
+func (i *Instance) Gap(profit float64) (float64, error) {
+	if i.Optimum == 0 {
+		return 0, NewKnapsackError(fmt.Sprintf("cannot compute gap of instance %q", i.Name), ErrUnknownOptimum)
+	}
+	return (i.Optimum - profit) / i.Optimum, nil
+}
+
+// Decode greedily packs the items in the given order, skipping every item which does not fit
+// any more. The order must be a permutation of the items; the result is always feasible.
+func (i *Instance) Decode(order []int) ([]bool, error) {
+	if err := i.validateOrder(order); err != nil {
+		return nil, NewKnapsackError("cannot decode solution", err)
+	}
+	selected := make([]bool, i.Items())
+	i.fill(selected, order, make([]float64, i.Dimensions()))
+	return selected, nil
+}
+
+// validateOrder is a helper function checking that order is a permutation of the items.
+func (i *Instance) validateOrder(order []int) error {
+	if len(order) != i.Items() {
+		return fmt.Errorf("%w: expected an order of %d items, got %d", ErrInvalidSolution, i.Items(), len(order))
+	}
+	seen := make([]bool, len(order))
+	for _, item := range order {
+		if item < 0 || item >= len(order) || seen[item] {
+			return fmt.Errorf("%w: order is not a permutation of the items", ErrInvalidSolution)
+		}
+		seen[item] = true
+	}
+	return nil
+}
+
+// fill is a helper function adding the unselected items in the given order whenever they fit
+// on top of the loads, which are updated.
+func (i *Instance) fill(selected []bool, order []int, loads []float64) {
+	for _, item := range order {
+		if selected[item] {
+			continue
+		}
+		fits := true
+		for d := range loads {
+			if loads[d]+i.Weights[d][item] > i.Capacities[d] {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			selected[item] = true
+			for d := range loads {
+				loads[d] += i.Weights[d][item]
+			}
+		}
+	}
+}
+
+// Repair makes a selection feasible in place: it drops the selected items of lowest efficiency,
+// the profit per weight relative to the capacities, until all items fit, and then adds the
+// unselected items of highest efficiency which still fit.
+func (i *Instance) Repair(selected []bool) {
+	i.repair(selected, i.efficiencyOrder())
+}
+
+// repair is a helper function implementing Repair for the items listed by decreasing efficiency.
+func (i *Instance) repair(selected []bool, order []int) {
+	loads := i.Loads(selected)
+	overloaded := func() bool {
+		for d := range loads {
+			if loads[d] > i.Capacities[d] {
+				return true
+			}
+		}
+		return false
+	}
+	for k := len(order) - 1; k >= 0 && overloaded(); k-- {
+		item := order[k]
+		if selected[item] {
+			selected[item] = false
+			for d := range loads {
+				loads[d] -= i.Weights[d][item]
+			}
+		}
+	}
+	i.fill(selected, order, loads)
+}
+
+// bits is a helper function converting a bitstring into the selection of items it encodes.
+func (i *Instance) bits(chromosome *bitstring.Bitstring) ([]bool, error) {
+	if chromosome == nil || chromosome.Length != i.Items() {
+		length := 0
+		if chromosome != nil {
+			length = chromosome.Length
+		}
+		return nil, fmt.Errorf("%w: expected %d bits, got %d", ErrInvalidSolution, i.Items(), length)
+	}
+	selected := make([]bool, chromosome.Length)
+	for j := range selected {
+		selected[j] = chromosome.Get(j)
+	}
+	return selected, nil
+}
+
+// BitstringEvaluator evaluates bitstrings whose bit j selects item j. It implements
+// fitness.IConstrainedEvaluator: the fitness is the total profit and the violation is the
+// relative excess weight, see Instance.Violation. Combine it with a constraint handling
+// technique such as constraint.PenaltySelector, constraint.FeasibilityTournamentSelector or
+// the repair operator of NewRepairer.
+type BitstringEvaluator struct {
+	instance *Instance
+}
+
+var _ fitness.IConstrainedEvaluator[bitstring.Bitstring] = (*BitstringEvaluator)(nil)
+
+// NewBitstringEvaluator creates a BitstringEvaluator for instance.
+func NewBitstringEvaluator(instance *Instance) *BitstringEvaluator {
+	return &BitstringEvaluator{instance: instance}
+}
+
+// Evaluate implements fitness.IGenomeEvaluator and returns the total profit, ignoring the capacities.
+func (b *BitstringEvaluator) Evaluate(ctx context.Context, chromosome *bitstring.Bitstring) (float64, error) {
+	value, _, err := b.EvaluateConstrained(ctx, chromosome)
+	return value, err
+}
+
+// EvaluateConstrained implements fitness.IConstrainedEvaluator.
+func (b *BitstringEvaluator) EvaluateConstrained(ctx context.Context, chromosome *bitstring.Bitstring) (float64, float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, 0, err
+	}
+	selected, err := b.instance.bits(chromosome)
+	if err != nil {
+		return 0, 0, NewKnapsackError("cannot evaluate solution", err)
+	}
+	return b.instance.Profit(selected), b.instance.Violation(selected), nil
+}
+
+// Repairer repairs bitstrings by Instance.Repair. Wrapped around a BitstringEvaluator by
+// constraint.NewRepairingEvaluator, every evaluated solution becomes feasible.
+type Repairer struct {
+	instance *Instance
+	order    []int
+}
+
+var _ constraint.IRepairer[bitstring.Bitstring] = (*Repairer)(nil)
+
+// NewRepairer creates a Repairer for instance.
+func NewRepairer(instance *Instance) *Repairer {
+	return &Repairer{instance: instance, order: instance.efficiencyOrder()}
+}
+
+// Repair implements constraint.IRepairer.
+func (r *Repairer) Repair(ctx context.Context, chromosome *bitstring.Bitstring) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	selected, err := r.instance.bits(chromosome)
+	if err != nil {
+		return NewKnapsackError("cannot repair solution", err)
+	}
+	r.instance.repair(selected, r.order)
+	for j, s := range selected {
+		chromosome.Set(j, s)
+	}
+	return nil
+}
+
+// PermutationEvaluator evaluates permutations of the items by the total profit of their greedy
+// decoding, see Instance.Decode. Every permutation decodes to a feasible solution, so the
+// encoding needs no further constraint handling but permutation operators.
+type PermutationEvaluator struct {
+	instance *Instance
+}
+
+var _ fitness.IFitnessEvaluator[int] = (*PermutationEvaluator)(nil)
+
+// NewPermutationEvaluator creates a PermutationEvaluator for instance.
+func NewPermutationEvaluator(instance *Instance) *PermutationEvaluator {
+	return &PermutationEvaluator{instance: instance}
+}
+
+// Evaluate implements fitness.IFitnessEvaluator.
+func (p *PermutationEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if chromosome == nil {
+		return 0, NewKnapsackError("cannot evaluate solution", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	selected, err := p.instance.Decode(*chromosome)
+	if err != nil {
+		return 0, err
+	}
+	return p.instance.Profit(selected), nil
+}
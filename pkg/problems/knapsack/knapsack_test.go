@@ -0,0 +1,293 @@
+package knapsack
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+	"github.com/tomhoffer/darwinium/pkg/ga/constraint"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+)
+
+// small returns a 0/1 knapsack instance whose optimum 10 selects the items 0, 2 and 3.
+func small(t *testing.T) *Instance {
+	t.Helper()
+	instance, err := NewInstance("small", []float64{5, 4, 3, 2}, [][]float64{{3, 3, 2, 1}}, []float64{6})
+	require.NoError(t, err)
+	instance.Optimum = 10
+	return instance
+}
+
+// randomInstance is a helper function creating a random multi-dimensional instance whose
+// capacities are half of the total weights.
+func randomInstance(t *testing.T, items, dimensions int, seed int64) *Instance {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	profits := make([]float64, items)
+	weights := make([][]float64, dimensions)
+	capacities := make([]float64, dimensions)
+	for d := range weights {
+		weights[d] = make([]float64, items)
+		for j := range weights[d] {
+			weights[d][j] = float64(1 + rng.Intn(100))
+			capacities[d] += weights[d][j] / 2
+		}
+	}
+	for j := range profits {
+		profits[j] = float64(1 + rng.Intn(100))
+	}
+	instance, err := NewInstance("random", profits, weights, capacities)
+	require.NoError(t, err)
+	instance.Optimum = bruteForce(instance)
+	return instance
+}
+
+// bruteForce is a helper function computing the optimal profit of a small instance by enumerating all selections.
+func bruteForce(instance *Instance) float64 {
+	n := instance.Items()
+	best := 0.0
+	selected := make([]bool, n)
+	for mask := range 1 << n {
+		for j := range selected {
+			selected[j] = mask&(1<<j) != 0
+		}
+		if instance.Feasible(selected) {
+			best = max(best, instance.Profit(selected))
+		}
+	}
+	return best
+}
+
+func TestNewInstance(t *testing.T) {
+	t.Run("copies the data", func(t *testing.T) {
+		profits := []float64{1, 2}
+		weights := [][]float64{{1, 1}}
+		instance, err := NewInstance("copy", profits, weights, []float64{1})
+		require.NoError(t, err)
+		profits[0], weights[0][0] = 5, 5
+		assert.Equal(t, []float64{1, 2}, instance.Profits)
+		assert.Equal(t, [][]float64{{1, 1}}, instance.Weights)
+		assert.Equal(t, 2, instance.Items())
+		assert.Equal(t, 1, instance.Dimensions())
+	})
+
+	t.Run("rejects invalid data", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			profits    []float64
+			weights    [][]float64
+			capacities []float64
+			message    string
+		}{
+			{"no items", nil, [][]float64{{}}, []float64{1}, "at least 1 item"},
+			{"no capacities", []float64{1}, nil, nil, "at least 1 capacity"},
+			{"missing dimension", []float64{1}, nil, []float64{1}, "expected weights in 1 dimensions"},
+			{"missing weight", []float64{1, 2}, [][]float64{{1}}, []float64{1}, "expected 2 weights in dimension 0"},
+			{"negative weight", []float64{1}, [][]float64{{-1}}, []float64{1}, "must not be negative"},
+			{"negative capacity", []float64{1}, [][]float64{{1}}, []float64{-1}, "must not be negative"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewInstance(tc.name, tc.profits, tc.weights, tc.capacities)
+				var knapsackErr *KnapsackError
+				require.ErrorAs(t, err, &knapsackErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestInstance_Solutions(t *testing.T) {
+	instance := small(t)
+
+	t.Run("profit, loads and violation", func(t *testing.T) {
+		selected := []bool{true, true, true, false}
+		assert.Equal(t, 12.0, instance.Profit(selected))
+		assert.Equal(t, []float64{8}, instance.Loads(selected))
+		assert.InDelta(t, 2.0/6, instance.Violation(selected), 1e-12)
+		assert.False(t, instance.Feasible(selected))
+		assert.True(t, instance.Feasible([]bool{true, false, true, true}))
+	})
+
+	t.Run("gap to the optimum", func(t *testing.T) {
+		gap, err := instance.Gap(9)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.1, gap, 1e-12)
+
+		unknown, err := NewInstance("unknown", []float64{1}, [][]float64{{1}}, []float64{1})
+		require.NoError(t, err)
+		_, err = unknown.Gap(1)
+		assert.ErrorIs(t, err, ErrUnknownOptimum)
+	})
+
+	t.Run("decoding packs items greedily", func(t *testing.T) {
+		selected, err := instance.Decode([]int{1, 0, 2, 3})
+		require.NoError(t, err)
+		assert.Equal(t, []bool{true, true, false, false}, selected)
+
+		for _, order := range [][]int{{0, 1, 2}, {0, 1, 2, 2}, {0, 1, 2, 4}} {
+			_, err := instance.Decode(order)
+			assert.ErrorIs(t, err, ErrInvalidSolution)
+		}
+	})
+
+	t.Run("repair drops inefficient items and adds efficient ones", func(t *testing.T) {
+		selected := []bool{true, true, true, true}
+		instance.Repair(selected)
+		assert.True(t, instance.Feasible(selected))
+		assert.Equal(t, []bool{true, false, true, true}, selected)
+
+		selected = []bool{false, false, false, false}
+		instance.Repair(selected)
+		assert.Equal(t, 10.0, instance.Profit(selected))
+	})
+}
+
+func TestEvaluators(t *testing.T) {
+	instance := small(t)
+	ctx := context.Background()
+
+	t.Run("bitstring evaluator reports profit and violation", func(t *testing.T) {
+		chromosome := bitstring.FromBools([]bool{true, true, true, false})
+		evaluator := NewBitstringEvaluator(instance)
+		profit, violation, err := evaluator.EvaluateConstrained(ctx, &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, 12.0, profit)
+		assert.InDelta(t, 2.0/6, violation, 1e-12)
+
+		profit, err = evaluator.Evaluate(ctx, &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, 12.0, profit)
+
+		short := bitstring.New(3)
+		_, err = evaluator.Evaluate(ctx, &short)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+		_, err = evaluator.Evaluate(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+	})
+
+	t.Run("repairing evaluator makes solutions feasible", func(t *testing.T) {
+		evaluator, err := constraint.NewRepairingEvaluator(NewRepairer(instance), NewBitstringEvaluator(instance))
+		require.NoError(t, err)
+		chromosome := bitstring.FromBools([]bool{true, true, true, true})
+		profit, violation, err := evaluator.EvaluateConstrained(ctx, &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, profit)
+		assert.Equal(t, 0.0, violation)
+		assert.Equal(t, "1011", chromosome.String())
+
+		short := bitstring.New(3)
+		assert.ErrorIs(t, NewRepairer(instance).Repair(ctx, &short), ErrInvalidSolution)
+	})
+
+	t.Run("permutation evaluator decodes greedily", func(t *testing.T) {
+		evaluator := NewPermutationEvaluator(instance)
+		order := []int{3, 2, 0, 1}
+		profit, err := evaluator.Evaluate(ctx, &order)
+		require.NoError(t, err)
+		assert.Equal(t, 10.0, profit)
+
+		invalid := []int{0, 0, 1, 2}
+		_, err = evaluator.Evaluate(ctx, &invalid)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+		_, err = evaluator.Evaluate(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+	})
+
+	t.Run("stop on cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		chromosome := bitstring.New(4)
+		_, err := NewBitstringEvaluator(instance).Evaluate(cancelled, &chromosome)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, NewRepairer(instance).Repair(cancelled, &chromosome), context.Canceled)
+		order := []int{0, 1, 2, 3}
+		_, err = NewPermutationEvaluator(instance).Evaluate(cancelled, &order)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestGeneticAlgorithm(t *testing.T) {
+	instance := randomInstance(t, 16, 3, 1)
+
+	t.Run("bitstring encoding with repair", func(t *testing.T) {
+		evaluator, err := constraint.NewRepairingEvaluator(NewRepairer(instance), NewBitstringEvaluator(instance))
+		require.NoError(t, err)
+		mutator, err := bitstring.NewBitFlipMutator(1.0 / 16)
+		require.NoError(t, err)
+		ga, err := executor.NewGenomeBuilder(bitstring.NewGenome()).
+			WithPopulation(bitstring.NewRandomPopulation(30, 16)).
+			WithEvaluator(evaluator).
+			WithCrossover(bitstring.NewUniformCrossover()).
+			WithMutator(mutator).
+			WithGenerations(30).
+			Build()
+		require.NoError(t, err)
+		ga.SetProgress(false)
+
+		population, err := ga.Run(context.Background())
+		require.NoError(t, err)
+		best, err := population.BestSolution()
+		require.NoError(t, err)
+		assert.True(t, best.Feasible())
+		gap, err := instance.Gap(best.Fitness)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, gap, 0.0)
+		assert.Less(t, gap, 0.1)
+	})
+
+	t.Run("bitstring encoding with feasibility rules", func(t *testing.T) {
+		selector, err := constraint.NewFeasibilityTournamentSelector[bitstring.Bitstring](3, 1)
+		require.NoError(t, err)
+		mutator, err := bitstring.NewBitFlipMutator(1.0 / 16)
+		require.NoError(t, err)
+		ga, err := executor.NewGenomeBuilder(bitstring.NewGenome()).
+			WithPopulation(bitstring.NewRandomPopulation(30, 16)).
+			WithEvaluator(NewBitstringEvaluator(instance)).
+			WithSelector(selector).
+			WithCrossover(bitstring.NewUniformCrossover()).
+			WithMutator(mutator).
+			WithGenerations(30).
+			Build()
+		require.NoError(t, err)
+		ga.SetProgress(false)
+
+		population, err := ga.Run(context.Background())
+		require.NoError(t, err)
+		feasible := 0
+		for _, individual := range population.Individuals {
+			if individual.Feasible() {
+				feasible++
+				assert.LessOrEqual(t, individual.Fitness, instance.Optimum)
+			}
+		}
+		assert.Positive(t, feasible)
+	})
+
+	t.Run("permutation encoding", func(t *testing.T) {
+		ga, err := executor.NewBuilder[int]().
+			WithRandomPopulation(40, func() []int { return rand.Perm(16) }).
+			WithEvaluator(NewPermutationEvaluator(instance)).
+			WithCrossover(crossover.NewOrderCrossover[int]()).
+			WithMutator(mutation.NewSimpleSwapMutator[int](0.3)).
+			WithGenerations(60).
+			Build()
+		require.NoError(t, err)
+		ga.SetProgress(false)
+
+		population, err := ga.Run(context.Background())
+		require.NoError(t, err)
+		best, err := population.BestSolution()
+		require.NoError(t, err)
+		gap, err := instance.Gap(best.Fitness)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, gap, 0.0)
+		assert.Less(t, gap, 0.1)
+	})
+}
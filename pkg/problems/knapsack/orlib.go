@@ -0,0 +1,184 @@
+package knapsack
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// tokens reads the whitespace-separated numbers of an instance file.
+type tokens struct {
+	scanner *bufio.Scanner
+}
+
+func newTokens(r io.Reader) *tokens {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	return &tokens{scanner: scanner}
+}
+
+// float is a helper function reading the next number, describing it by what in errors.
+func (t *tokens) float(what string) (float64, error) {
+	if !t.scanner.Scan() {
+		if err := t.scanner.Err(); err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("unexpected end of file, expected %s", what)
+	}
+	value, err := strconv.ParseFloat(t.scanner.Text(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", what, t.scanner.Text())
+	}
+	return value, nil
+}
+
+// count is a helper function reading the next number as a count of at least minimum.
+func (t *tokens) count(what string, minimum int) (int, error) {
+	value, err := t.float(what)
+	if err != nil {
+		return 0, err
+	}
+	if value != float64(int(value)) || int(value) < minimum {
+		return 0, fmt.Errorf("%s must be an integer of at least %d, got %g", what, minimum, value)
+	}
+	return int(value), nil
+}
+
+// floats is a helper function reading the next n numbers.
+func (t *tokens) floats(what string, n int) ([]float64, error) {
+	values := make([]float64, n)
+	for k := range values {
+		value, err := t.float(what)
+		if err != nil {
+			return nil, err
+		}
+		values[k] = value
+	}
+	return values, nil
+}
+
+// end is a helper function checking that no tokens are left.
+func (t *tokens) end() error {
+	if t.scanner.Scan() {
+		return fmt.Errorf("unexpected data %q at the end of the file", t.scanner.Text())
+	}
+	return t.scanner.Err()
+}
+
+// LoadMKP reads the multi-dimensional knapsack instances of an OR-Library file, see ParseMKP.
+// The instances are named after the file, e.g. "mknap1-1", "mknap1-2", ... for mknap1.txt.
+func LoadMKP(path string) ([]*Instance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewKnapsackError("cannot load instances", err)
+	}
+	defer file.Close()
+	return ParseMKP(file, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// ParseMKP reads the multi-dimensional knapsack instances of an OR-Library file such as mknap1.txt
+// or mknapcb1.txt, whose whitespace-separated numbers are: the number of instances, and for each
+// instance the number of items n, the number of constraints m, the optimal profit (0 if unknown),
+// the n profits, the n weights of each of the m constraints and the m capacities.
+// The instances are named prefix-1, prefix-2, ...
+func ParseMKP(r io.Reader, prefix string) ([]*Instance, error) {
+	instances, err := parseMKP(newTokens(r), prefix)
+	if err != nil {
+		return nil, NewKnapsackError("cannot parse instances", err)
+	}
+	return instances, nil
+}
+
+func parseMKP(t *tokens, prefix string) ([]*Instance, error) {
+	count, err := t.count("number of instances", 1)
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]*Instance, count)
+	for k := range instances {
+		instance := &Instance{Name: fmt.Sprintf("%s-%d", prefix, k+1)}
+		n, err := t.count("number of items", 1)
+		if err != nil {
+			return nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+		}
+		m, err := t.count("number of constraints", 1)
+		if err != nil {
+			return nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+		}
+		if instance.Optimum, err = t.float("optimum"); err != nil {
+			return nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+		}
+		if instance.Profits, err = t.floats("profit", n); err != nil {
+			return nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+		}
+		instance.Weights = make([][]float64, m)
+		for d := range instance.Weights {
+			if instance.Weights[d], err = t.floats("weight", n); err != nil {
+				return nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+			}
+		}
+		if instance.Capacities, err = t.floats("capacity", m); err != nil {
+			return nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+		}
+		if err := instance.validate(); err != nil {
+			return nil, fmt.Errorf("instance %s: %w", instance.Name, err)
+		}
+		instances[k] = instance
+	}
+	if err := t.end(); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// LoadKP reads a 0/1 knapsack instance from a file, see ParseKP. The instance is named after the file.
+func LoadKP(path string) (*Instance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewKnapsackError("cannot load instance", err)
+	}
+	defer file.Close()
+	return ParseKP(file, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// ParseKP reads a 0/1 knapsack instance in the common format of the number of items n and the
+// capacity, followed by the profit and the weight of every item, e.g. the low-dimensional
+// instances of Pisinger. The optimum of these instances is distributed separately and may be
+// set in the Optimum field.
+func ParseKP(r io.Reader, name string) (*Instance, error) {
+	instance, err := parseKP(newTokens(r), name)
+	if err != nil {
+		return nil, NewKnapsackError(fmt.Sprintf("cannot parse instance %q", name), err)
+	}
+	return instance, nil
+}
+
+func parseKP(t *tokens, name string) (*Instance, error) {
+	n, err := t.count("number of items", 1)
+	if err != nil {
+		return nil, err
+	}
+	capacity, err := t.float("capacity")
+	if err != nil {
+		return nil, err
+	}
+	instance := &Instance{Name: name, Profits: make([]float64, n), Weights: [][]float64{make([]float64, n)}, Capacities: []float64{capacity}}
+	for j := range n {
+		values, err := t.floats("profit and weight", 2)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", j+1, err)
+		}
+		instance.Profits[j], instance.Weights[0][j] = values[0], values[1]
+	}
+	if err := t.end(); err != nil {
+		return nil, err
+	}
+	if err := instance.validate(); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
@@ -0,0 +1,106 @@
+package knapsack
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mkp holds two instances in the OR-Library format of mknap1.txt: a multi-dimensional instance
+// with an unknown optimum and the instance of small.
+const mkp = `2
+5 2 0
+10 13 7 8 4
+3 4 2 3 1
+2 3 4 1 2
+7 6
+ 4 1 10
+ 5 4 3 2
+ 3 3 2 1
+ 6
+`
+
+func TestParseMKP(t *testing.T) {
+	t.Run("reads every instance", func(t *testing.T) {
+		instances, err := ParseMKP(strings.NewReader(mkp), "mknap")
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+
+		first := instances[0]
+		assert.Equal(t, "mknap-1", first.Name)
+		assert.Equal(t, []float64{10, 13, 7, 8, 4}, first.Profits)
+		assert.Equal(t, [][]float64{{3, 4, 2, 3, 1}, {2, 3, 4, 1, 2}}, first.Weights)
+		assert.Equal(t, []float64{7, 6}, first.Capacities)
+		assert.Equal(t, 0.0, first.Optimum)
+
+		second := instances[1]
+		assert.Equal(t, "mknap-2", second.Name)
+		assert.Equal(t, 10.0, second.Optimum)
+		assert.Equal(t, second.Optimum, bruteForce(second))
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			text    string
+			message string
+		}{
+			{"empty", "", "expected number of instances"},
+			{"no instances", "0", "number of instances must be an integer of at least 1"},
+			{"fractional count", "1 2.5 1 0", "number of items must be an integer"},
+			{"truncated", "1 2 1 0 1 2 3", "expected weight"},
+			{"invalid number", "1 2 1 0 1 x", "invalid profit \"x\""},
+			{"trailing data", mkp + " 7", "unexpected data \"7\""},
+			{"negative weight", "1 1 1 0 1 -1 1", "instance mknap-1: weights in dimension 0 must not be negative"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := ParseMKP(strings.NewReader(tc.text), "mknap")
+				var knapsackErr *KnapsackError
+				require.ErrorAs(t, err, &knapsackErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestParseKP(t *testing.T) {
+	instance, err := ParseKP(strings.NewReader("4 6\n5 3\n4 3\n3 2\n2 1\n"), "kp")
+	require.NoError(t, err)
+	assert.Equal(t, "kp", instance.Name)
+	assert.Equal(t, []float64{5, 4, 3, 2}, instance.Profits)
+	assert.Equal(t, [][]float64{{3, 3, 2, 1}}, instance.Weights)
+	assert.Equal(t, []float64{6}, instance.Capacities)
+
+	_, err = ParseKP(strings.NewReader("2 6\n5 3\n4"), "kp")
+	assert.ErrorContains(t, err, "item 2: unexpected end of file")
+	_, err = ParseKP(strings.NewReader("1 -6\n5 3\n"), "kp")
+	assert.ErrorContains(t, err, "capacity of dimension 0 must not be negative")
+	_, err = ParseKP(strings.NewReader("1 6\n5 3 1\n"), "kp")
+	assert.ErrorContains(t, err, "unexpected data")
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	mkpPath := filepath.Join(dir, "mknap1.txt")
+	kpPath := filepath.Join(dir, "f1_l-d_kp_4_6")
+	require.NoError(t, os.WriteFile(mkpPath, []byte(mkp), 0o644))
+	require.NoError(t, os.WriteFile(kpPath, []byte("4 6\n5 3\n4 3\n3 2\n2 1\n"), 0o644))
+
+	instances, err := LoadMKP(mkpPath)
+	require.NoError(t, err)
+	assert.Equal(t, "mknap1-2", instances[1].Name)
+
+	instance, err := LoadKP(kpPath)
+	require.NoError(t, err)
+	assert.Equal(t, "f1_l-d_kp_4_6", instance.Name)
+
+	_, err = LoadMKP(filepath.Join(dir, "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = LoadKP(filepath.Join(dir, "missing"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,204 @@
+package maxsat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Formats of DIMACS files, as declared by their problem line.
+const (
+	// CNF files list unweighted clauses.
+	CNF = "cnf"
+	// WCNF files list weighted clauses, each preceded by its weight.
+	WCNF = "wcnf"
+)
+
+// header holds the problem line "p cnf variables clauses" or "p wcnf variables clauses [top]".
+type header struct {
+	format    string
+	variables int
+	clauses   int
+	// top is the weight of hard clauses in the WCNF format before 2022, +Inf if not given.
+	top float64
+}
+
+// parser reads the clauses of a DIMACS file.
+type parser struct {
+	header  *header
+	clauses []Clause
+	comment []string
+	// current holds the clause being read and open whether one is being read.
+	current Clause
+	open    bool
+	// maximum is the largest variable of a literal.
+	maximum int
+}
+
+// Load reads a MaxSAT instance from a DIMACS CNF or WCNF file, see Parse. The instance is named
+// after the file.
+func Load(path string) (*Instance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewMaxSATError("cannot load instance", err)
+	}
+	defer file.Close()
+	return Parse(file, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// Parse reads a MaxSAT instance in one of the DIMACS formats:
+//   - CNF, with the problem line "p cnf <variables> <clauses>" followed by clauses such as
+//     "1 -3 0", each a list of literals terminated by 0. Every clause is soft with weight 1.
+//   - WCNF before 2022, with the problem line "p wcnf <variables> <clauses> [<top>]" followed by
+//     clauses preceded by their weight, such as "4 1 -3 0". Clauses of weight top or more are hard.
+//   - WCNF since 2022, without a problem line, where hard clauses are preceded by "h" instead of
+//     a weight, such as "h 1 -3 0". The number of variables is the largest variable of a literal.
+//
+// Lines starting with "c" are comments and a line starting with "%" ends the file, as in the
+// SATLIB benchmarks. Clauses may span several lines.
+func Parse(r io.Reader, name string) (*Instance, error) {
+	p := &parser{}
+	if err := p.read(r); err != nil {
+		return nil, NewMaxSATError(fmt.Sprintf("cannot parse instance %q", name), err)
+	}
+	variables := p.maximum
+	if p.header != nil {
+		variables = p.header.variables
+	}
+	instance, err := NewInstance(name, variables, p.clauses)
+	if err != nil {
+		return nil, err
+	}
+	instance.Comment = strings.Join(p.comment, "\n")
+	return instance, nil
+}
+
+// read is a helper function reading the comments and clauses of a DIMACS file.
+func (p *parser) read(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), math.MaxInt32)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "":
+			continue
+		case text[0] == 'c':
+			p.comment = append(p.comment, strings.TrimSpace(text[1:]))
+			continue
+		case text[0] == '%':
+			return p.finish()
+		case text[0] == 'p':
+			if err := p.readHeader(text); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+		for _, field := range strings.Fields(text) {
+			if err := p.readField(field); err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return p.finish()
+}
+
+// readHeader is a helper function reading the problem line.
+func (p *parser) readHeader(text string) error {
+	if p.header != nil || len(p.clauses) > 0 || p.open {
+		return errors.New("problem line must precede the clauses and be given once")
+	}
+	fields := strings.Fields(text)
+	if len(fields) < 4 || fields[0] != "p" || (fields[1] != CNF && fields[1] != WCNF) ||
+		(fields[1] == CNF && len(fields) != 4) || len(fields) > 5 {
+		return fmt.Errorf("invalid problem line %q, expected \"p cnf <variables> <clauses>\" or \"p wcnf <variables> <clauses> [<top>]\"", text)
+	}
+	h := &header{format: fields[1], top: math.Inf(1)}
+	var err error
+	if h.variables, err = strconv.Atoi(fields[2]); err != nil || h.variables < 1 {
+		return fmt.Errorf("number of variables must be an integer of at least 1, got %q", fields[2])
+	}
+	if h.clauses, err = strconv.Atoi(fields[3]); err != nil || h.clauses < 0 {
+		return fmt.Errorf("number of clauses must be a non-negative integer, got %q", fields[3])
+	}
+	if len(fields) == 5 {
+		if h.top, err = strconv.ParseFloat(fields[4], 64); err != nil || h.top <= 0 {
+			return fmt.Errorf("top weight must be a positive number, got %q", fields[4])
+		}
+	}
+	p.header = h
+	return nil
+}
+
+// weighted is a helper function reporting whether clauses are preceded by weights.
+func (p *parser) weighted() bool {
+	return p.header == nil || p.header.format == WCNF
+}
+
+// readField is a helper function reading a weight, "h" or a literal of the current clause.
+func (p *parser) readField(field string) error {
+	if !p.open {
+		p.open = true
+		p.current = Clause{Weight: 1}
+		if p.weighted() {
+			return p.readWeight(field)
+		}
+	}
+	lit, err := strconv.Atoi(field)
+	if err != nil {
+		return fmt.Errorf("invalid literal %q", field)
+	}
+	clause := &p.current
+	if lit == 0 {
+		if len(clause.Literals) == 0 {
+			return fmt.Errorf("clause %d is empty", len(p.clauses)+1)
+		}
+		p.clauses = append(p.clauses, *clause)
+		p.open = false
+		return nil
+	}
+	if p.header != nil && (lit < -p.header.variables || lit > p.header.variables) {
+		return fmt.Errorf("literal %d must refer to a variable within [1, %d]", lit, p.header.variables)
+	}
+	p.maximum = max(p.maximum, abs(lit))
+	clause.Literals = append(clause.Literals, lit)
+	return nil
+}
+
+// readWeight is a helper function reading the weight of the current clause of a WCNF file.
+func (p *parser) readWeight(field string) error {
+	clause := &p.current
+	if field == "h" && p.header == nil {
+		clause.Hard = true
+		return nil
+	}
+	weight, err := strconv.ParseFloat(field, 64)
+	if err != nil || weight <= 0 {
+		return fmt.Errorf("weight of clause %d must be a positive number, got %q", len(p.clauses)+1, field)
+	}
+	clause.Weight = weight
+	clause.Hard = p.header != nil && weight >= p.header.top
+	return nil
+}
+
+// finish is a helper function checking that the last clause is terminated and the number of clauses matches the problem line.
+func (p *parser) finish() error {
+	if p.open {
+		return fmt.Errorf("clause %d is not terminated by 0", len(p.clauses)+1)
+	}
+	if p.header == nil && len(p.clauses) == 0 {
+		return errors.New("file contains neither a problem line nor clauses")
+	}
+	if p.header != nil && len(p.clauses) != p.header.clauses {
+		return fmt.Errorf("problem line declares %d clauses, but the file contains %d", p.header.clauses, len(p.clauses))
+	}
+	return nil
+}
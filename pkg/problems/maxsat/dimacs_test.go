@@ -0,0 +1,104 @@
+package maxsat
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cnf is a CNF file in the style of the SATLIB benchmarks, with a clause spanning two lines.
+const cnf = `c small instance
+c
+p cnf 3 3
+ 1 -2 0
+ 2
+ 3 0
+-1 -3 0
+%
+0
+`
+
+func TestParse(t *testing.T) {
+	t.Run("CNF", func(t *testing.T) {
+		instance, err := Parse(strings.NewReader(cnf), "small")
+		require.NoError(t, err)
+		assert.Equal(t, "small", instance.Name)
+		assert.Equal(t, "small instance\n", instance.Comment)
+		assert.Equal(t, 3, instance.Variables())
+		assert.Equal(t, []Clause{
+			{Literals: []int{1, -2}, Weight: 1},
+			{Literals: []int{2, 3}, Weight: 1},
+			{Literals: []int{-1, -3}, Weight: 1},
+		}, instance.Clauses())
+	})
+
+	t.Run("WCNF with top weight", func(t *testing.T) {
+		instance, err := Parse(strings.NewReader("p wcnf 3 4 10\n10 -3 -1 0\n1 1 2 0\n2 -1 0\n3 -2 3 0\n"), "small")
+		require.NoError(t, err)
+		assert.Equal(t, small(t).Clauses()[:3], instance.Clauses()[1:])
+		assert.True(t, instance.Clauses()[0].Hard)
+		assert.Equal(t, 7.0, instance.HardWeight())
+	})
+
+	t.Run("WCNF without top weight", func(t *testing.T) {
+		instance, err := Parse(strings.NewReader("p wcnf 2 2\n10 1 0\n1.5 -2 0\n"), "soft")
+		require.NoError(t, err)
+		assert.Equal(t, []Clause{{Literals: []int{1}, Weight: 10}, {Literals: []int{-2}, Weight: 1.5}}, instance.Clauses())
+	})
+
+	t.Run("WCNF since 2022", func(t *testing.T) {
+		instance, err := Parse(strings.NewReader("c new format\nh -3 -1 0\n1 1 2 0\n2 -1 0\n3 -2 3 0\n"), "small")
+		require.NoError(t, err)
+		assert.Equal(t, 3, instance.Variables())
+		assert.Equal(t, small(t).Clauses()[:3], instance.Clauses()[1:])
+		assert.True(t, instance.Clauses()[0].Hard)
+		assert.Equal(t, []int{-3, -1}, instance.Clauses()[0].Literals)
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			text    string
+			message string
+		}{
+			{"empty", "c nothing\n", "neither a problem line nor clauses"},
+			{"invalid problem line", "p sat 3 1\n", "invalid problem line \"p sat 3 1\""},
+			{"top weight in CNF", "p cnf 3 1 5\n", "invalid problem line"},
+			{"no variables", "p cnf 0 1\n", "line 1: number of variables must be an integer of at least 1"},
+			{"negative top", "p wcnf 1 1 -2\n", "top weight must be a positive number"},
+			{"late problem line", "h 1 0\np wcnf 1 1\n", "line 2: problem line must precede the clauses"},
+			{"invalid literal", "p cnf 2 1\n1 x 0\n", "invalid literal \"x\""},
+			{"unknown variable", "p cnf 2 1\n1 3 0\n", "literal 3 must refer to a variable within [1, 2]"},
+			{"empty clause", "p cnf 2 1\n0\n", "clause 1 is empty"},
+			{"invalid weight", "p wcnf 2 1\n0 1 0\n", "weight of clause 1 must be a positive number, got \"0\""},
+			{"hard clause with header", "p wcnf 2 1 5\nh 1 0\n", "weight of clause 1 must be a positive number, got \"h\""},
+			{"unterminated clause", "p cnf 2 1\n1 2\n", "clause 1 is not terminated by 0"},
+			{"missing clauses", "p cnf 2 2\n1 2 0\n", "declares 2 clauses, but the file contains 1"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := Parse(strings.NewReader(tc.text), tc.name)
+				var maxSATErr *MaxSATError
+				require.ErrorAs(t, err, &maxSATErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "uf3-01.cnf")
+	require.NoError(t, os.WriteFile(path, []byte(cnf), 0o644))
+
+	instance, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "uf3-01", instance.Name)
+
+	_, err = Load(filepath.Join(dir, "missing.cnf"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,263 @@
+// Package maxsat provides the maximum satisfiability problem and its weighted variant as a
+// benchmark for large bitstring genomes: a parser for DIMACS CNF and WCNF files, an evaluator of
+// bitstring assignments, an incremental State which updates the clause satisfaction counts of an
+// assignment on every variable flip, and the WalkSAT local search for memetic runs.
+//
+// Bit i of an assignment holds the value of variable i+1 of the DIMACS file. The cost of an
+// assignment is the total weight of its unsatisfied clauses, where every hard clause weighs more
+// than all soft clauses together, so a single unsatisfied hard clause outweighs any number of
+// unsatisfied soft ones. Fitness is maximized and is the negated cost.
+package maxsat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// ErrInvalidSolution indicates that a chromosome is not an assignment of the variables of an instance.
+var ErrInvalidSolution = errors.New("invalid solution")
+
+// MaxSATError represents an error that occurs while loading a MaxSAT instance or evaluating an assignment.
+type MaxSATError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *MaxSATError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *MaxSATError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewMaxSATError constructs a *MaxSATError with the provided message and wrapped error.
+func NewMaxSATError(message string, wrapped error) *MaxSATError {
+	return &MaxSATError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// Clause is a disjunction of literals.
+type Clause struct {
+	// Literals holds the literals in DIMACS notation: v stands for variable v and -v for its
+	// negation, counting variables from 1.
+	Literals []int
+	// Weight is the weight of a soft clause. It is ignored for hard clauses.
+	Weight float64
+	// Hard marks clauses which every solution must satisfy.
+	Hard bool
+}
+
+// Instance is a (weighted, partial) MaxSAT instance: find an assignment of the variables that
+// satisfies all hard clauses and maximizes the total weight of the satisfied soft clauses.
+// Unweighted instances read from CNF files consist of soft clauses of weight 1.
+type Instance struct {
+	// Name identifies the instance, e.g. "uf20-01".
+	Name string
+	// Comment holds the comment lines of the file the instance was read from.
+	Comment string
+
+	variables int
+	clauses   []Clause
+	// weights holds the cost of every clause when it is unsatisfied.
+	weights []float64
+	// distinct holds the distinct literals of every clause, or nil for clauses containing a
+	// variable and its negation, which every assignment satisfies.
+	distinct [][]int
+	// occurrences lists the clauses containing every literal, see literal.
+	occurrences [][]int
+	hardWeight  float64
+}
+
+// NewInstance creates an instance of the given number of variables from its clauses, which are
+// copied. Literals must be non-zero and refer to variables 1 to variables, soft clauses must have
+// a positive weight.
+func NewInstance(name string, variables int, clauses []Clause) (*Instance, error) {
+	if variables < 1 {
+		return nil, NewMaxSATError(fmt.Sprintf("cannot create instance %q", name), fmt.Errorf("instance must have at least 1 variable, got %d", variables))
+	}
+	instance := &Instance{
+		Name:        name,
+		variables:   variables,
+		clauses:     make([]Clause, len(clauses)),
+		weights:     make([]float64, len(clauses)),
+		distinct:    make([][]int, len(clauses)),
+		occurrences: make([][]int, 2*variables),
+	}
+	softWeight := 0.0
+	for c, clause := range clauses {
+		if len(clause.Literals) == 0 {
+			return nil, NewMaxSATError(fmt.Sprintf("cannot create instance %q", name), fmt.Errorf("clause %d is empty", c+1))
+		}
+		for _, lit := range clause.Literals {
+			if lit == 0 || lit < -variables || lit > variables {
+				return nil, NewMaxSATError(fmt.Sprintf("cannot create instance %q", name), fmt.Errorf("literal %d of clause %d must refer to a variable within [1, %d]", lit, c+1, variables))
+			}
+		}
+		instance.distinct[c] = distinctLiterals(clause.Literals)
+		for _, lit := range instance.distinct[c] {
+			instance.occurrences[literal(lit)] = append(instance.occurrences[literal(lit)], c)
+		}
+		if !clause.Hard {
+			if clause.Weight <= 0 {
+				return nil, NewMaxSATError(fmt.Sprintf("cannot create instance %q", name), fmt.Errorf("weight of soft clause %d must be positive, got %g", c+1, clause.Weight))
+			}
+			softWeight += clause.Weight
+			instance.weights[c] = clause.Weight
+		}
+		instance.clauses[c] = Clause{Literals: slices.Clone(clause.Literals), Weight: clause.Weight, Hard: clause.Hard}
+	}
+	instance.hardWeight = softWeight + 1
+	for c, clause := range instance.clauses {
+		if clause.Hard {
+			instance.weights[c] = instance.hardWeight
+		}
+	}
+	return instance, nil
+}
+
+// distinctLiterals is a helper function returning the sorted distinct literals of a clause, or
+// nil if the clause contains a variable and its negation.
+func distinctLiterals(literals []int) []int {
+	distinct := slices.Compact(slices.Sorted(slices.Values(literals)))
+	for _, lit := range distinct {
+		if lit < 0 && slices.Contains(distinct, -lit) {
+			return nil
+		}
+	}
+	return distinct
+}
+
+// literal is a helper function mapping the DIMACS literal v or -v to the index 2(v-1) or 2(v-1)+1.
+func literal(lit int) int {
+	if lit > 0 {
+		return 2 * (lit - 1)
+	}
+	return 2*(-lit-1) + 1
+}
+
+// Variables returns the number of variables.
+func (i *Instance) Variables() int {
+	return i.variables
+}
+
+// Clauses returns a copy of the clauses.
+func (i *Instance) Clauses() []Clause {
+	clauses := make([]Clause, len(i.clauses))
+	for c, clause := range i.clauses {
+		clauses[c] = Clause{Literals: slices.Clone(clause.Literals), Weight: clause.Weight, Hard: clause.Hard}
+	}
+	return clauses
+}
+
+// HardWeight returns the cost of an unsatisfied hard clause, which exceeds the total weight of
+// the soft clauses.
+func (i *Instance) HardWeight() float64 {
+	return i.hardWeight
+}
+
+// satisfied is a helper function reporting whether the assignment satisfies clause c.
+func (i *Instance) satisfied(assignment bitstring.Bitstring, c int) bool {
+	for _, lit := range i.clauses[c].Literals {
+		if (lit > 0) == assignment.Get(abs(lit)-1) {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// checkAssignment is a helper function checking that the assignment holds one bit per variable.
+func (i *Instance) checkAssignment(assignment bitstring.Bitstring) error {
+	if assignment.Length != i.variables {
+		return fmt.Errorf("%w: expected an assignment of %d variables, got %d", ErrInvalidSolution, i.variables, assignment.Length)
+	}
+	return nil
+}
+
+// Unsatisfied returns the number of hard clauses and the total weight of the soft clauses which the assignment does not satisfy.
+func (i *Instance) Unsatisfied(assignment bitstring.Bitstring) (int, float64, error) {
+	if err := i.checkAssignment(assignment); err != nil {
+		return 0, 0, NewMaxSATError("cannot evaluate assignment", err)
+	}
+	hard, soft := 0, 0.0
+	for c, clause := range i.clauses {
+		if i.satisfied(assignment, c) {
+			continue
+		}
+		if clause.Hard {
+			hard++
+		} else {
+			soft += clause.Weight
+		}
+	}
+	return hard, soft, nil
+}
+
+// Cost returns the total weight of the clauses which the assignment does not satisfy, counting
+// every hard clause with HardWeight.
+func (i *Instance) Cost(assignment bitstring.Bitstring) (float64, error) {
+	hard, soft, err := i.Unsatisfied(assignment)
+	if err != nil {
+		return 0, err
+	}
+	return float64(hard)*i.hardWeight + soft, nil
+}
+
+// Evaluator evaluates bitstring assignments by their negated cost, see Instance.Cost.
+type Evaluator struct {
+	instance *Instance
+}
+
+var _ fitness.IGenomeEvaluator[bitstring.Bitstring] = (*Evaluator)(nil)
+
+// NewEvaluator creates an Evaluator for instance.
+func NewEvaluator(instance *Instance) *Evaluator {
+	return &Evaluator{instance: instance}
+}
+
+// Instance returns the evaluated instance.
+func (e *Evaluator) Instance() *Instance {
+	return e.instance
+}
+
+// Dimension returns the number of variables, which is the length of the evaluated bitstrings.
+func (e *Evaluator) Dimension() int {
+	return e.instance.variables
+}
+
+// Evaluate implements fitness.IGenomeEvaluator.
+func (e *Evaluator) Evaluate(ctx context.Context, chromosome *bitstring.Bitstring) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if chromosome == nil {
+		return 0, NewMaxSATError("cannot evaluate assignment", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	cost, err := e.instance.Cost(*chromosome)
+	if err != nil {
+		return 0, err
+	}
+	return -cost, nil
+}
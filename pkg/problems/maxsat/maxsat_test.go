@@ -0,0 +1,160 @@
+package maxsat
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+)
+
+// small returns a partial weighted instance of 3 variables with the soft clauses (x1 v x2),
+// (-x1) and (-x2 v x3) of weights 1, 2 and 3 and the hard clause (-x3 v -x1).
+func small(t *testing.T) *Instance {
+	t.Helper()
+	instance, err := NewInstance("small", 3, []Clause{
+		{Literals: []int{1, 2}, Weight: 1},
+		{Literals: []int{-1}, Weight: 2},
+		{Literals: []int{-2, 3}, Weight: 3},
+		{Literals: []int{-3, -1}, Hard: true},
+	})
+	require.NoError(t, err)
+	return instance
+}
+
+// planted is a helper function creating a random 3-SAT instance of the given number of variables
+// and clauses, each satisfied by a hidden random assignment.
+func planted(t testing.TB, variables, clauses int, seed int64) *Instance {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	solution := make([]bool, variables)
+	for v := range solution {
+		solution[v] = rng.Intn(2) == 1
+	}
+	list := make([]Clause, 0, clauses)
+	for len(list) < clauses {
+		literals := make([]int, 3)
+		satisfied := false
+		for k := range literals {
+			v := rng.Intn(variables)
+			positive := rng.Intn(2) == 1
+			satisfied = satisfied || positive == solution[v]
+			literals[k] = v + 1
+			if !positive {
+				literals[k] = -literals[k]
+			}
+		}
+		if satisfied {
+			list = append(list, Clause{Literals: literals, Weight: 1})
+		}
+	}
+	instance, err := NewInstance("planted", variables, list)
+	require.NoError(t, err)
+	return instance
+}
+
+func TestNewInstance(t *testing.T) {
+	t.Run("copies the clauses", func(t *testing.T) {
+		clauses := []Clause{{Literals: []int{1, -2}, Weight: 2}, {Literals: []int{2}, Hard: true}}
+		instance, err := NewInstance("copy", 2, clauses)
+		require.NoError(t, err)
+		clauses[0].Literals[0] = 2
+		copied := instance.Clauses()
+		assert.Equal(t, []int{1, -2}, copied[0].Literals)
+		copied[0].Literals[0] = 2
+		assert.Equal(t, []int{1, -2}, instance.Clauses()[0].Literals)
+		assert.Equal(t, 2, instance.Variables())
+		assert.Equal(t, 3.0, instance.HardWeight())
+	})
+
+	t.Run("rejects invalid data", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			variables int
+			clauses   []Clause
+			message   string
+		}{
+			{"no variables", 0, nil, "at least 1 variable"},
+			{"empty clause", 2, []Clause{{Weight: 1}}, "clause 1 is empty"},
+			{"zero literal", 2, []Clause{{Literals: []int{1, 0}, Weight: 1}}, "literal 0 of clause 1"},
+			{"unknown variable", 2, []Clause{{Literals: []int{1}, Weight: 1}, {Literals: []int{-3}, Weight: 1}}, "literal -3 of clause 2 must refer to a variable within [1, 2]"},
+			{"zero weight", 2, []Clause{{Literals: []int{1}}}, "weight of soft clause 1 must be positive"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewInstance(tc.name, tc.variables, tc.clauses)
+				var maxSATErr *MaxSATError
+				require.ErrorAs(t, err, &maxSATErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestInstance_Cost(t *testing.T) {
+	instance := small(t)
+	testCases := []struct {
+		assignment string
+		hard       int
+		soft       float64
+	}{
+		{"000", 0, 1},
+		{"010", 0, 3},
+		{"011", 0, 0},
+		{"101", 1, 2},
+		{"111", 1, 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.assignment, func(t *testing.T) {
+			assignment := fromString(tc.assignment)
+			hard, soft, err := instance.Unsatisfied(assignment)
+			require.NoError(t, err)
+			assert.Equal(t, tc.hard, hard)
+			assert.Equal(t, tc.soft, soft)
+			cost, err := instance.Cost(assignment)
+			require.NoError(t, err)
+			assert.Equal(t, float64(tc.hard)*7+tc.soft, cost)
+		})
+	}
+
+	t.Run("rejects assignments of the wrong length", func(t *testing.T) {
+		_, err := instance.Cost(bitstring.New(4))
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+	})
+}
+
+func TestEvaluator(t *testing.T) {
+	instance := small(t)
+	evaluator := NewEvaluator(instance)
+	ctx := context.Background()
+	assert.Same(t, instance, evaluator.Instance())
+	assert.Equal(t, 3, evaluator.Dimension())
+
+	assignment := fromString("101")
+	value, err := evaluator.Evaluate(ctx, &assignment)
+	require.NoError(t, err)
+	assert.Equal(t, -9.0, value)
+
+	short := bitstring.New(2)
+	_, err = evaluator.Evaluate(ctx, &short)
+	assert.ErrorIs(t, err, ErrInvalidSolution)
+	_, err = evaluator.Evaluate(ctx, nil)
+	assert.ErrorIs(t, err, ErrInvalidSolution)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = evaluator.Evaluate(cancelled, &assignment)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// fromString is a helper function creating a bitstring from a string of '0' and '1', starting with bit 0.
+func fromString(bits string) bitstring.Bitstring {
+	values := make([]bool, len(bits))
+	for i := range bits {
+		values[i] = bits[i] == '1'
+	}
+	return bitstring.FromBools(values)
+}
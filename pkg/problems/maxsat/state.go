@@ -0,0 +1,152 @@
+package maxsat
+
+import (
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+)
+
+// State is an assignment together with the number of true literals of every clause and the set
+// of unsatisfied clauses. Flipping a variable and computing the cost change of a flip only visit
+// the clauses containing the variable, instead of all clauses as Instance.Cost does.
+// A State must not be used concurrently.
+type State struct {
+	instance   *Instance
+	assignment bitstring.Bitstring
+	// trueLiterals holds the number of true literals of every clause.
+	trueLiterals []int
+	// unsatisfied lists the unsatisfied clauses and position the index of every clause in it, or -1.
+	unsatisfied []int
+	position    []int
+	hard        int
+	soft        float64
+}
+
+// NewState creates the state of a copy of the given assignment.
+func NewState(instance *Instance, assignment bitstring.Bitstring) (*State, error) {
+	if err := instance.checkAssignment(assignment); err != nil {
+		return nil, NewMaxSATError("cannot create state", err)
+	}
+	s := &State{
+		instance:     instance,
+		assignment:   assignment.Clone(),
+		trueLiterals: make([]int, len(instance.clauses)),
+		position:     make([]int, len(instance.clauses)),
+	}
+	for c, literals := range instance.distinct {
+		s.position[c] = -1
+		if literals == nil {
+			// Tautologies are satisfied by every assignment and never change
+			s.trueLiterals[c] = 1
+			continue
+		}
+		for _, lit := range literals {
+			if (lit > 0) == s.assignment.Get(abs(lit)-1) {
+				s.trueLiterals[c]++
+			}
+		}
+		if s.trueLiterals[c] == 0 {
+			s.addUnsatisfied(c)
+		}
+	}
+	return s, nil
+}
+
+// Assignment returns a copy of the current assignment.
+func (s *State) Assignment() bitstring.Bitstring {
+	return s.assignment.Clone()
+}
+
+// Value returns the current value of variable v, counting variables from 0 as the bits of the assignment do.
+func (s *State) Value(v int) bool {
+	return s.assignment.Get(v)
+}
+
+// Cost returns the cost of the current assignment, see Instance.Cost.
+func (s *State) Cost() float64 {
+	return float64(s.hard)*s.instance.hardWeight + s.soft
+}
+
+// Unsatisfied returns the number of unsatisfied hard clauses and the total weight of the unsatisfied soft clauses.
+func (s *State) Unsatisfied() (int, float64) {
+	return s.hard, s.soft
+}
+
+// UnsatisfiedClauses returns the number of unsatisfied clauses.
+func (s *State) UnsatisfiedClauses() int {
+	return len(s.unsatisfied)
+}
+
+// Delta returns the change of the cost caused by flipping variable v, without flipping it.
+func (s *State) Delta(v int) float64 {
+	makes, breaks := s.scores(v)
+	return breaks - makes
+}
+
+// scores is a helper function returning the weight of the clauses which flipping variable v
+// would satisfy and the weight of the clauses it would leave unsatisfied.
+func (s *State) scores(v int) (float64, float64) {
+	trueLit, falseLit := s.literals(v)
+	makes, breaks := 0.0, 0.0
+	for _, c := range s.instance.occurrences[trueLit] {
+		if s.trueLiterals[c] == 1 {
+			breaks += s.instance.weights[c]
+		}
+	}
+	for _, c := range s.instance.occurrences[falseLit] {
+		if s.trueLiterals[c] == 0 {
+			makes += s.instance.weights[c]
+		}
+	}
+	return makes, breaks
+}
+
+// literals is a helper function returning the indices of the currently true and false literal of variable v.
+func (s *State) literals(v int) (int, int) {
+	if s.assignment.Get(v) {
+		return 2 * v, 2*v + 1
+	}
+	return 2*v + 1, 2 * v
+}
+
+// Flip inverts variable v and updates the clauses containing it.
+func (s *State) Flip(v int) {
+	trueLit, falseLit := s.literals(v)
+	s.assignment.Flip(v)
+	for _, c := range s.instance.occurrences[trueLit] {
+		s.trueLiterals[c]--
+		if s.trueLiterals[c] == 0 {
+			s.addUnsatisfied(c)
+		}
+	}
+	for _, c := range s.instance.occurrences[falseLit] {
+		if s.trueLiterals[c] == 0 {
+			s.removeUnsatisfied(c)
+		}
+		s.trueLiterals[c]++
+	}
+}
+
+// addUnsatisfied is a helper function adding clause c to the unsatisfied clauses.
+func (s *State) addUnsatisfied(c int) {
+	s.position[c] = len(s.unsatisfied)
+	s.unsatisfied = append(s.unsatisfied, c)
+	if s.instance.clauses[c].Hard {
+		s.hard++
+	} else {
+		s.soft += s.instance.weights[c]
+	}
+}
+
+// removeUnsatisfied is a helper function removing clause c from the unsatisfied clauses by
+// moving the last unsatisfied clause into its place.
+func (s *State) removeUnsatisfied(c int) {
+	last := s.unsatisfied[len(s.unsatisfied)-1]
+	s.unsatisfied[s.position[c]] = last
+	s.position[last] = s.position[c]
+	s.unsatisfied = s.unsatisfied[:len(s.unsatisfied)-1]
+	s.position[c] = -1
+	if s.instance.clauses[c].Hard {
+		s.hard--
+	} else {
+		s.soft -= s.instance.weights[c]
+	}
+}
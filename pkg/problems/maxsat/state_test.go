@@ -0,0 +1,76 @@
+package maxsat
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+)
+
+func TestState(t *testing.T) {
+	t.Run("tracks the cost of the assignment", func(t *testing.T) {
+		instance := small(t)
+		state, err := NewState(instance, fromString("000"))
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, state.Cost())
+		assert.Equal(t, 1, state.UnsatisfiedClauses())
+
+		assert.Equal(t, 1.0, state.Delta(0))
+		state.Flip(0)
+		assert.True(t, state.Value(0))
+		assert.Equal(t, 2.0, state.Cost())
+
+		assert.Equal(t, 7.0, state.Delta(2))
+		state.Flip(2)
+		hard, soft := state.Unsatisfied()
+		assert.Equal(t, 1, hard)
+		assert.Equal(t, 2.0, soft)
+		assert.Equal(t, 9.0, state.Cost())
+		assert.Equal(t, "101", state.Assignment().String())
+	})
+
+	t.Run("copies the assignment", func(t *testing.T) {
+		assignment := fromString("000")
+		state, err := NewState(small(t), assignment)
+		require.NoError(t, err)
+		state.Flip(1)
+		assert.Equal(t, "000", assignment.String())
+		copied := state.Assignment()
+		copied.Flip(0)
+		assert.False(t, state.Value(0))
+	})
+
+	t.Run("agrees with a full evaluation", func(t *testing.T) {
+		clauses := planted(t, 30, 120, 7).Clauses()
+		// Duplicate literals and tautologies must not confuse the counting
+		clauses = append(clauses,
+			Clause{Literals: []int{4, 4, -5}, Weight: 2},
+			Clause{Literals: []int{6, -6}, Weight: 3},
+			Clause{Literals: []int{-7, 8}, Hard: true})
+		instance, err := NewInstance("mixed", 30, clauses)
+		require.NoError(t, err)
+		rng := rand.New(rand.NewSource(1))
+		state, err := NewState(instance, bitstring.Random(30))
+		require.NoError(t, err)
+		for range 1000 {
+			v := rng.Intn(30)
+			before := state.Cost()
+			delta := state.Delta(v)
+			state.Flip(v)
+			expected, err := instance.Cost(state.Assignment())
+			require.NoError(t, err)
+			require.InDelta(t, expected, state.Cost(), 1e-9)
+			require.InDelta(t, delta, state.Cost()-before, 1e-9)
+		}
+	})
+
+	t.Run("rejects assignments of the wrong length", func(t *testing.T) {
+		_, err := NewState(small(t), bitstring.New(4))
+		var maxSATErr *MaxSATError
+		require.ErrorAs(t, err, &maxSATErr)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+	})
+}
@@ -0,0 +1,107 @@
+package maxsat
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+)
+
+// WalkSAT is the WalkSAT local search of Selman, Kautz and Cohen for weighted MaxSAT. Every step
+// picks a random unsatisfied clause and flips one of its variables: a variable whose flip leaves
+// no clause unsatisfied if there is one, otherwise a random variable with probability Noise and
+// the variable whose flip leaves the least weight unsatisfied else. Steps are evaluated
+// incrementally with a State and the search returns the best assignment it visited.
+type WalkSAT struct {
+	instance *Instance
+	noise    float64
+}
+
+var _ localsearch.ILocalSearcher[bitstring.Bitstring] = (*WalkSAT)(nil)
+
+// NewWalkSAT creates a WalkSAT search for instance with the given noise probability within [0, 1].
+// A noise of about 0.5 is a common choice.
+func NewWalkSAT(instance *Instance, noise float64) (*WalkSAT, error) {
+	if noise < 0 || noise > 1 {
+		return nil, NewMaxSATError("cannot create WalkSAT", fmt.Errorf("noise must be within [0, 1], got %g", noise))
+	}
+	return &WalkSAT{instance: instance, noise: noise}, nil
+}
+
+// Search implements localsearch.ILocalSearcher. Every flip counts as one evaluation. If the search
+// improves the chromosome, the final assignment is evaluated once more with the evaluator, so the
+// returned fitness is the evaluator's even if it differs from the negated cost.
+func (w *WalkSAT) Search(ctx context.Context, chromosome *bitstring.Bitstring, current float64, evaluator fitness.IGenomeEvaluator[bitstring.Bitstring], budget int) (float64, int, error) {
+	if chromosome == nil {
+		return current, 0, NewMaxSATError("cannot search", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	state, err := NewState(w.instance, *chromosome)
+	if err != nil {
+		return current, 0, err
+	}
+
+	// trail holds the variables flipped since the best assignment was visited
+	initial := state.Cost()
+	best := initial
+	var trail []int
+	used := 0
+	for ; used < budget-1 && state.UnsatisfiedClauses() > 0; used++ {
+		if err := ctx.Err(); err != nil {
+			return current, used, err
+		}
+		v := w.pick(state)
+		state.Flip(v)
+		trail = append(trail, v)
+		if cost := state.Cost(); cost < best {
+			best = cost
+			trail = trail[:0]
+		}
+	}
+	for _, v := range slices.Backward(trail) {
+		state.Flip(v)
+	}
+	if best == initial {
+		return current, used, nil
+	}
+
+	improved := state.Assignment()
+	value, err := evaluator.Evaluate(ctx, &improved)
+	used++
+	if err != nil {
+		return current, used, err
+	}
+	if value <= current {
+		return current, used, nil
+	}
+	*chromosome = improved
+	return value, used, nil
+}
+
+// pick is a helper function choosing the variable to flip from a random unsatisfied clause.
+func (w *WalkSAT) pick(state *State) int {
+	clause := w.instance.distinct[state.unsatisfied[rand.Intn(len(state.unsatisfied))]]
+	chosen, least, ties := -1, 0.0, 0
+	for _, lit := range clause {
+		v := abs(lit) - 1
+		_, breaks := state.scores(v)
+		switch {
+		case chosen < 0 || breaks < least:
+			chosen, least, ties = v, breaks, 1
+		case breaks == least:
+			// Reservoir sampling breaks ties uniformly at random
+			ties++
+			if rand.Intn(ties) == 0 {
+				chosen = v
+			}
+		}
+	}
+	// A flip which leaves no clause unsatisfied is always taken, so noise only applies if there is none
+	if least > 0 && rand.Float64() < w.noise {
+		return abs(clause[rand.Intn(len(clause))]) - 1
+	}
+	return chosen
+}
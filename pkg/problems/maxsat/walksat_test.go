@@ -0,0 +1,169 @@
+package maxsat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/bitstring"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+)
+
+func TestNewWalkSAT(t *testing.T) {
+	for _, noise := range []float64{-0.1, 1.1} {
+		_, err := NewWalkSAT(small(t), noise)
+		var maxSATErr *MaxSATError
+		require.ErrorAs(t, err, &maxSATErr)
+		assert.ErrorContains(t, err, "noise must be within [0, 1]")
+	}
+}
+
+func TestWalkSAT_Search(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("solves a satisfiable instance", func(t *testing.T) {
+		instance := planted(t, 100, 400, 1)
+		evaluator := NewEvaluator(instance)
+		walkSAT, err := NewWalkSAT(instance, 0.5)
+		require.NoError(t, err)
+		chromosome := bitstring.Random(100)
+		initial, err := evaluator.Evaluate(ctx, &chromosome)
+		require.NoError(t, err)
+
+		value, used, err := walkSAT.Search(ctx, &chromosome, initial, evaluator, 100000)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, value)
+		assert.LessOrEqual(t, used, 100000)
+		cost, err := instance.Cost(chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, cost)
+	})
+
+	t.Run("satisfies hard clauses first", func(t *testing.T) {
+		instance := small(t)
+		evaluator := NewEvaluator(instance)
+		walkSAT, err := NewWalkSAT(instance, 0.2)
+		require.NoError(t, err)
+		chromosome := fromString("101")
+
+		value, _, err := walkSAT.Search(ctx, &chromosome, -9, evaluator, 100)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, value)
+		assert.Equal(t, "011", chromosome.String())
+	})
+
+	t.Run("keeps the chromosome without improvement", func(t *testing.T) {
+		instance := small(t)
+		walkSAT, err := NewWalkSAT(instance, 0.5)
+		require.NoError(t, err)
+		chromosome := fromString("101")
+
+		value, used, err := walkSAT.Search(ctx, &chromosome, -9, NewEvaluator(instance), 1)
+		require.NoError(t, err)
+		assert.Equal(t, -9.0, value)
+		assert.Equal(t, 0, used)
+		assert.Equal(t, "101", chromosome.String())
+
+		optimal := fromString("011")
+		value, used, err = walkSAT.Search(ctx, &optimal, 0, NewEvaluator(instance), 100)
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, value)
+		assert.Equal(t, 0, used)
+	})
+
+	t.Run("rejects invalid chromosomes", func(t *testing.T) {
+		walkSAT, err := NewWalkSAT(small(t), 0.5)
+		require.NoError(t, err)
+		short := bitstring.New(2)
+		_, _, err = walkSAT.Search(ctx, &short, 0, NewEvaluator(small(t)), 10)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+		_, _, err = walkSAT.Search(ctx, nil, 0, NewEvaluator(small(t)), 10)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+	})
+
+	t.Run("stop on cancelled context", func(t *testing.T) {
+		instance := small(t)
+		walkSAT, err := NewWalkSAT(instance, 0.5)
+		require.NoError(t, err)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		chromosome := fromString("101")
+		value, _, err := walkSAT.Search(cancelled, &chromosome, -9, NewEvaluator(instance), 10)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, -9.0, value)
+		assert.Equal(t, "101", chromosome.String())
+	})
+}
+
+func TestGeneticAlgorithm(t *testing.T) {
+	instance := planted(t, 200, 800, 3)
+	walkSAT, err := NewWalkSAT(instance, 0.5)
+	require.NoError(t, err)
+	mutator, err := bitstring.NewBitFlipMutator(1.0 / 200)
+	require.NoError(t, err)
+	ga, err := executor.NewGenomeBuilder(bitstring.NewGenome()).
+		WithPopulation(bitstring.NewRandomPopulation(20, 200)).
+		WithEvaluator(NewEvaluator(instance)).
+		WithCrossover(bitstring.NewUniformCrossover()).
+		WithMutator(mutator).
+		WithLocalSearch(walkSAT, localsearch.Options{Probability: 0.2, Mode: localsearch.Lamarckian, Budget: 2000}).
+		WithGenerations(20).
+		Build()
+	require.NoError(t, err)
+	ga.SetProgress(false)
+
+	population, err := ga.Run(context.Background())
+	require.NoError(t, err)
+	best, err := population.BestSolution()
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, best.Fitness)
+	cost, err := instance.Cost(best.Chromosome)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, cost)
+}
+
+func BenchmarkEvaluator(b *testing.B) {
+	instance := planted(b, 10000, 42000, 1)
+	evaluator := NewEvaluator(instance)
+	chromosome := bitstring.Random(10000)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = evaluator.Evaluate(ctx, &chromosome)
+	}
+}
+
+func BenchmarkState_Flip(b *testing.B) {
+	instance := planted(b, 10000, 42000, 1)
+	state, err := NewState(instance, bitstring.Random(10000))
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		state.Flip(i % 10000)
+	}
+}
+
+func BenchmarkGeneticAlgorithm(b *testing.B) {
+	instance := planted(b, 10000, 42000, 1)
+	mutator, err := bitstring.NewBitFlipMutator(1.0 / 10000)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ga, err := executor.NewGenomeBuilder(bitstring.NewGenome()).
+			WithPopulation(bitstring.NewRandomPopulation(100, 10000)).
+			WithEvaluator(NewEvaluator(instance)).
+			WithCrossover(bitstring.NewUniformCrossover()).
+			WithMutator(mutator).
+			WithGenerations(10).
+			Build()
+		require.NoError(b, err)
+		ga.SetProgress(false)
+		_, _ = ga.Run(context.Background())
+	}
+}
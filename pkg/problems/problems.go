@@ -0,0 +1,283 @@
+// Package problems provides standard benchmark problems for validating operators and tuning
+// parameters.
+//
+// The continuous test functions (Sphere, Rosenbrock, Rastrigin, Ackley, Griewank, Schwefel and Levy)
+// are minimized, while fitness is maximized: a Function evaluates chromosomes to the negated
+// function value, so its global optimum has fitness -OptimalValue(). Shifted and rotated variants
+// in the style of the CEC and BBOB suites are created with Transform and NewShiftedRotated.
+package problems
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// ErrDimensionMismatch indicates that a chromosome or a transformation does not match the dimension of a function.
+var ErrDimensionMismatch = errors.New("dimension mismatch")
+
+// ProblemError represents an error that occurs while creating or evaluating a benchmark problem.
+type ProblemError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *ProblemError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *ProblemError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewProblemError constructs a *ProblemError with the provided message and wrapped error.
+func NewProblemError(message string, wrapped error) *ProblemError {
+	return &ProblemError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// Function is a continuous benchmark function of a fixed dimension with a known global minimum.
+// It implements fitness.IFitnessEvaluator[float64] by evaluating chromosomes to the negated function value.
+type Function struct {
+	name         string
+	dimension    int
+	bounds       *core.Bounds
+	optimum      []float64
+	optimalValue float64
+	value        func(x []float64) float64
+}
+
+var _ fitness.IFitnessEvaluator[float64] = (*Function)(nil)
+
+// newFunction is a helper function creating a function whose search space is [lower, upper]
+// in every dimension and whose global minimum optimalValue lies at the point whose coordinates
+// are all equal to optimum.
+func newFunction(name string, dimension, minDimension int, lower, upper, optimum, optimalValue float64, value func(x []float64) float64) (*Function, error) {
+	if dimension < minDimension {
+		return nil, NewProblemError(fmt.Sprintf("cannot create %s function", name), fmt.Errorf("dimension must be at least %d, but was %d", minDimension, dimension))
+	}
+	bounds, err := core.NewUniformBounds(dimension, lower, upper)
+	if err != nil {
+		return nil, NewProblemError(fmt.Sprintf("cannot create %s function", name), err)
+	}
+	return &Function{
+		name:         name,
+		dimension:    dimension,
+		bounds:       bounds,
+		optimum:      repeat(optimum, dimension),
+		optimalValue: optimalValue,
+		value:        value,
+	}, nil
+}
+
+func repeat(value float64, count int) []float64 {
+	values := make([]float64, count)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
+
+// Name returns the name of the function, e.g. "rastrigin" or "shifted-rotated-rastrigin".
+func (f *Function) Name() string {
+	return f.name
+}
+
+// Dimension returns the number of variables of the function.
+func (f *Function) Dimension() int {
+	return f.dimension
+}
+
+// Bounds returns a copy of the search space of the function.
+func (f *Function) Bounds() *core.Bounds {
+	return &core.Bounds{Lower: slices.Clone(f.bounds.Lower), Upper: slices.Clone(f.bounds.Upper)}
+}
+
+// Optimum returns a copy of the location of the global minimum.
+func (f *Function) Optimum() []float64 {
+	return slices.Clone(f.optimum)
+}
+
+// OptimalValue returns the function value of the global minimum.
+func (f *Function) OptimalValue() float64 {
+	return f.optimalValue
+}
+
+// Value computes the function value at x, which must have Dimension elements.
+func (f *Function) Value(x []float64) float64 {
+	return f.value(x)
+}
+
+// Gap returns the distance of the function value at x from the global minimum, which is 0 at the optimum.
+func (f *Function) Gap(x []float64) float64 {
+	return f.value(x) - f.optimalValue
+}
+
+// Evaluate implements fitness.IFitnessEvaluator by returning the negated function value,
+// so maximizing the fitness minimizes the function.
+func (f *Function) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if chromosome == nil || len(*chromosome) != f.dimension {
+		length := 0
+		if chromosome != nil {
+			length = len(*chromosome)
+		}
+		return 0, NewProblemError(fmt.Sprintf("cannot evaluate %s function", f.name),
+			fmt.Errorf("%w: expected %d genes, got %d", ErrDimensionMismatch, f.dimension, length))
+	}
+	return -f.value(*chromosome), nil
+}
+
+// WithBounds returns a copy of the function searching the given bounds, which must match the dimension
+// and contain the optimum.
+func (f *Function) WithBounds(bounds *core.Bounds) (*Function, error) {
+	if bounds == nil || len(bounds.Lower) != f.dimension || len(bounds.Upper) != f.dimension {
+		return nil, NewProblemError(fmt.Sprintf("cannot change the bounds of %s function", f.name), fmt.Errorf("%w: bounds must have %d dimensions", ErrDimensionMismatch, f.dimension))
+	}
+	validated, err := core.NewBounds(bounds.Lower, bounds.Upper)
+	if err != nil {
+		return nil, NewProblemError(fmt.Sprintf("cannot change the bounds of %s function", f.name), err)
+	}
+	if !validated.Contains(f.optimum) {
+		return nil, NewProblemError(fmt.Sprintf("cannot change the bounds of %s function", f.name), errors.New("bounds must contain the optimum"))
+	}
+	copied := *f
+	copied.bounds = validated
+	return &copied, nil
+}
+
+// NewSphere creates the sphere function sum(x_i^2) on [-5.12, 5.12]^dimension.
+// It is unimodal and separable, with the minimum 0 at the origin.
+func NewSphere(dimension int) (*Function, error) {
+	return newFunction("sphere", dimension, 1, -5.12, 5.12, 0, 0, func(x []float64) float64 {
+		sum := 0.0
+		for _, xi := range x {
+			sum += xi * xi
+		}
+		return sum
+	})
+}
+
+// NewRosenbrock creates the Rosenbrock function sum(100(x_{i+1} - x_i^2)^2 + (1 - x_i)^2) on [-5, 10]^dimension.
+// Its minimum 0 at (1, ..., 1) lies in a narrow, curved valley. The dimension must be at least 2.
+func NewRosenbrock(dimension int) (*Function, error) {
+	return newFunction("rosenbrock", dimension, 2, -5, 10, 1, 0, func(x []float64) float64 {
+		sum := 0.0
+		for i := 0; i < len(x)-1; i++ {
+			a := x[i+1] - x[i]*x[i]
+			b := 1 - x[i]
+			sum += 100*a*a + b*b
+		}
+		return sum
+	})
+}
+
+// NewRastrigin creates the Rastrigin function 10n + sum(x_i^2 - 10cos(2 pi x_i)) on [-5.12, 5.12]^dimension.
+// It is highly multimodal with a regular grid of local minima and the global minimum 0 at the origin.
+func NewRastrigin(dimension int) (*Function, error) {
+	return newFunction("rastrigin", dimension, 1, -5.12, 5.12, 0, 0, func(x []float64) float64 {
+		sum := 10 * float64(len(x))
+		for _, xi := range x {
+			sum += xi*xi - 10*math.Cos(2*math.Pi*xi)
+		}
+		return sum
+	})
+}
+
+// NewAckley creates the Ackley function on [-32.768, 32.768]^dimension. Its nearly flat outer
+// region is covered by local minima around the global minimum 0 at the origin.
+func NewAckley(dimension int) (*Function, error) {
+	return newFunction("ackley", dimension, 1, -32.768, 32.768, 0, 0, func(x []float64) float64 {
+		squares, cosines := 0.0, 0.0
+		for _, xi := range x {
+			squares += xi * xi
+			cosines += math.Cos(2 * math.Pi * xi)
+		}
+		n := float64(len(x))
+		return -20*math.Exp(-0.2*math.Sqrt(squares/n)) - math.Exp(cosines/n) + 20 + math.E
+	})
+}
+
+// NewGriewank creates the Griewank function 1 + sum(x_i^2)/4000 - prod(cos(x_i/sqrt(i))) on [-600, 600]^dimension.
+// Its many regularly distributed local minima surround the global minimum 0 at the origin.
+func NewGriewank(dimension int) (*Function, error) {
+	return newFunction("griewank", dimension, 1, -600, 600, 0, 0, func(x []float64) float64 {
+		sum, product := 0.0, 1.0
+		for i, xi := range x {
+			sum += xi * xi / 4000
+			product *= math.Cos(xi / math.Sqrt(float64(i+1)))
+		}
+		return 1 + sum - product
+	})
+}
+
+// schwefelOptimum is the coordinate of the global minimum of the Schwefel function in every dimension.
+const schwefelOptimum = 420.968746359982
+
+// schwefelConstant is the value of x sin(sqrt(|x|)) at schwefelOptimum.
+const schwefelConstant = 418.982887272433799807913601398
+
+// NewSchwefel creates the Schwefel function 418.9829n - sum(x_i sin(sqrt(|x_i|))) on [-500, 500]^dimension.
+// Its global minimum 0 at (420.9687, ..., 420.9687) lies far from the second best local minima,
+// near the border of the search space.
+func NewSchwefel(dimension int) (*Function, error) {
+	return newFunction("schwefel", dimension, 1, -500, 500, schwefelOptimum, 0, func(x []float64) float64 {
+		sum := schwefelConstant * float64(len(x))
+		for _, xi := range x {
+			sum -= xi * math.Sin(math.Sqrt(math.Abs(xi)))
+		}
+		return sum
+	})
+}
+
+// NewLevy creates the Levy function on [-10, 10]^dimension, a multimodal function with the
+// global minimum 0 at (1, ..., 1).
+func NewLevy(dimension int) (*Function, error) {
+	return newFunction("levy", dimension, 1, -10, 10, 1, 0, func(x []float64) float64 {
+		w := func(i int) float64 {
+			return 1 + (x[i]-1)/4
+		}
+		first := math.Sin(math.Pi * w(0))
+		sum := first * first
+		for i := 0; i < len(x)-1; i++ {
+			wi := w(i)
+			s := math.Sin(math.Pi*wi + 1)
+			sum += (wi - 1) * (wi - 1) * (1 + 10*s*s)
+		}
+		last := w(len(x) - 1)
+		s := math.Sin(2 * math.Pi * last)
+		return sum + (last-1)*(last-1)*(1+s*s)
+	})
+}
+
+// Standard returns every base function of the package in the given dimension, in the order
+// sphere, rosenbrock, rastrigin, ackley, griewank, schwefel and levy.
+func Standard(dimension int) ([]*Function, error) {
+	constructors := []func(int) (*Function, error){NewSphere, NewRosenbrock, NewRastrigin, NewAckley, NewGriewank, NewSchwefel, NewLevy}
+	functions := make([]*Function, 0, len(constructors))
+	for _, constructor := range constructors {
+		function, err := constructor(dimension)
+		if err != nil {
+			return nil, err
+		}
+		functions = append(functions, function)
+	}
+	return functions, nil
+}
@@ -0,0 +1,141 @@
+package problems
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+)
+
+func TestStandard(t *testing.T) {
+	functions, err := Standard(5)
+	require.NoError(t, err)
+	require.Len(t, functions, 7)
+
+	for _, f := range functions {
+		t.Run(f.Name(), func(t *testing.T) {
+			assert.Equal(t, 5, f.Dimension())
+			assert.True(t, f.Bounds().Contains(f.Optimum()))
+			assert.InDelta(t, f.OptimalValue(), f.Value(f.Optimum()), 1e-9)
+			assert.InDelta(t, 0, f.Gap(f.Optimum()), 1e-9)
+
+			// Every random point of the search space is at least as bad as the optimum.
+			rng := rand.New(rand.NewSource(1))
+			for range 100 {
+				x := make([]float64, 5)
+				for i := range x {
+					x[i] = f.Bounds().Lower[i] + rng.Float64()*(f.Bounds().Upper[i]-f.Bounds().Lower[i])
+				}
+				assert.Greater(t, f.Gap(x), 0.0)
+			}
+
+			optimum := f.Optimum()
+			fitness, err := f.Evaluate(context.Background(), &optimum)
+			require.NoError(t, err)
+			assert.InDelta(t, -f.OptimalValue(), fitness, 1e-9)
+		})
+	}
+}
+
+func TestFunctionValues(t *testing.T) {
+	testCases := []struct {
+		create   func(int) (*Function, error)
+		x        []float64
+		expected float64
+	}{
+		{NewSphere, []float64{1, 2, 3}, 14},
+		{NewRosenbrock, []float64{0, 0}, 1},
+		{NewRosenbrock, []float64{2, 4, 16}, 1 + 9},
+		{NewRastrigin, []float64{1, 0}, 1},
+		{NewAckley, []float64{1, 1}, 3.6253849384403627},
+		{NewGriewank, []float64{0, 0}, 0},
+		{NewSchwefel, []float64{0, 0}, 2 * schwefelConstant},
+		{NewLevy, []float64{1, 1, 1}, 0},
+	}
+	for _, tc := range testCases {
+		f, err := tc.create(len(tc.x))
+		require.NoError(t, err)
+		t.Run(f.Name(), func(t *testing.T) {
+			assert.InDelta(t, tc.expected, f.Value(tc.x), 1e-9)
+		})
+	}
+}
+
+func TestFunction_Evaluate(t *testing.T) {
+	f, err := NewSphere(3)
+	require.NoError(t, err)
+
+	t.Run("negates the function value", func(t *testing.T) {
+		x := []float64{1, 1, 1}
+		fitness, err := f.Evaluate(context.Background(), &x)
+		require.NoError(t, err)
+		assert.Equal(t, -3.0, fitness)
+	})
+
+	t.Run("rejects chromosomes of another dimension", func(t *testing.T) {
+		x := []float64{1, 1}
+		_, err := f.Evaluate(context.Background(), &x)
+		assert.ErrorIs(t, err, ErrDimensionMismatch)
+		var problemErr *ProblemError
+		assert.ErrorAs(t, err, &problemErr)
+
+		_, err = f.Evaluate(context.Background(), nil)
+		assert.ErrorIs(t, err, ErrDimensionMismatch)
+	})
+
+	t.Run("stops on cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		x := []float64{1, 1, 1}
+		_, err := f.Evaluate(ctx, &x)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestNewFunction_InvalidDimension(t *testing.T) {
+	_, err := NewRosenbrock(1)
+	assert.ErrorContains(t, err, "dimension must be at least 2")
+	_, err = NewSphere(0)
+	assert.Error(t, err)
+	_, err = Standard(0)
+	assert.Error(t, err)
+}
+
+func TestFunction_WithBounds(t *testing.T) {
+	f, err := NewRastrigin(2)
+	require.NoError(t, err)
+
+	t.Run("narrows the search space", func(t *testing.T) {
+		narrowed, err := f.WithBounds(&core.Bounds{Lower: []float64{-1, -1}, Upper: []float64{1, 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []float64{1, 2}, narrowed.Bounds().Upper)
+		assert.Equal(t, []float64{5.12, 5.12}, f.Bounds().Upper)
+	})
+
+	t.Run("rejects bounds excluding the optimum", func(t *testing.T) {
+		_, err := f.WithBounds(&core.Bounds{Lower: []float64{1, 1}, Upper: []float64{2, 2}})
+		assert.ErrorContains(t, err, "bounds must contain the optimum")
+	})
+
+	t.Run("rejects bounds of another dimension", func(t *testing.T) {
+		_, err := f.WithBounds(&core.Bounds{Lower: []float64{-1}, Upper: []float64{1}})
+		assert.ErrorIs(t, err, ErrDimensionMismatch)
+	})
+
+	t.Run("rejects invalid bounds", func(t *testing.T) {
+		_, err := f.WithBounds(&core.Bounds{Lower: []float64{1, 1}, Upper: []float64{-1, -1}})
+		assert.ErrorIs(t, err, core.ErrInvalidBounds)
+	})
+}
+
+func TestFunction_CopiesAreIndependent(t *testing.T) {
+	f, err := NewLevy(2)
+	require.NoError(t, err)
+	f.Optimum()[0] = 5
+	f.Bounds().Lower[0] = 5
+	assert.Equal(t, []float64{1, 1}, f.Optimum())
+	assert.Equal(t, []float64{-10, -10}, f.Bounds().Lower)
+}
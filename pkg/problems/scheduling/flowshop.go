@@ -0,0 +1,143 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// FlowShop is a permutation flow-shop scheduling problem: every job is processed by the machines
+// 0, 1, ..., Machines()-1 in this order, and every machine processes the jobs in the same order.
+type FlowShop struct {
+	// Name identifies the instance, e.g. "tai20_5-1".
+	Name string
+	// Times holds the processing time of every job on every machine, Times[job][machine].
+	Times [][]int
+	// DueDates holds the due date of every job for the tardiness objectives, nil if there are none.
+	DueDates []int
+	// Weights holds the weight of every job for the weighted tardiness objective, nil if there are none.
+	Weights []float64
+	// Optimum is the optimal or best known makespan, 0 if unknown.
+	Optimum int
+	// LowerBound is a lower bound of the makespan, 0 if unknown.
+	LowerBound int
+}
+
+// NewFlowShop creates a flow-shop instance from the processing times of the jobs on the machines,
+// times[job][machine], which are copied. All jobs need a non-negative time on every machine.
+func NewFlowShop(name string, times [][]int) (*FlowShop, error) {
+	instance := &FlowShop{Name: name, Times: make([][]int, len(times))}
+	for job, row := range times {
+		instance.Times[job] = slices.Clone(row)
+	}
+	if err := instance.validate(); err != nil {
+		return nil, NewSchedulingError(fmt.Sprintf("cannot create instance %q", name), err)
+	}
+	return instance, nil
+}
+
+// validate is a helper function checking the processing times and the optional due dates and weights.
+func (f *FlowShop) validate() error {
+	if len(f.Times) == 0 {
+		return errors.New("instance must have at least 1 job")
+	}
+	if len(f.Times[0]) == 0 {
+		return errors.New("instance must have at least 1 machine")
+	}
+	for job, row := range f.Times {
+		if len(row) != len(f.Times[0]) {
+			return fmt.Errorf("expected %d processing times of job %d, got %d", len(f.Times[0]), job, len(row))
+		}
+		for machine, time := range row {
+			if time < 0 {
+				return fmt.Errorf("processing time of job %d on machine %d must not be negative, got %d", job, machine, time)
+			}
+		}
+	}
+	return checkJobData(len(f.Times), f.DueDates, f.Weights)
+}
+
+// Jobs returns the number of jobs, which is the length of the chromosomes.
+func (f *FlowShop) Jobs() int {
+	return len(f.Times)
+}
+
+// Machines returns the number of machines.
+func (f *FlowShop) Machines() int {
+	return len(f.Times[0])
+}
+
+// Gap returns the relative gap (makespan - Optimum) / Optimum of a makespan to the known optimum.
+// Returns ErrUnknownOptimum if the optimum is not known.
+func (f *FlowShop) Gap(makespan int) (float64, error) {
+	return gap(f.Name, makespan, f.Optimum)
+}
+
+// Decode turns a job order, a permutation of the jobs, into the schedule which starts every
+// operation as early as possible. In a permutation flow shop this schedule is both semi-active
+// and active, so there is no choice of decoder.
+func (f *FlowShop) Decode(order []int) (*Schedule, error) {
+	if err := checkPermutation(order, f.Jobs(), "jobs"); err != nil {
+		return nil, NewSchedulingError("cannot decode solution", err)
+	}
+	machines := f.Machines()
+	schedule := &Schedule{Jobs: f.Jobs(), Machines: machines, Operations: make([]ScheduledOperation, 0, f.Jobs()*machines)}
+	machineReady := make([]int, machines)
+	for _, job := range order {
+		ready := 0
+		for machine, time := range f.Times[job] {
+			start := max(ready, machineReady[machine])
+			ready = start + time
+			machineReady[machine] = ready
+			schedule.Operations = append(schedule.Operations, ScheduledOperation{
+				Job: job, Index: machine, Machine: machine, Start: start, End: ready,
+			})
+		}
+	}
+	return schedule, nil
+}
+
+// FlowShopEvaluator evaluates job orders by the negated objective value of the schedule they decode to.
+type FlowShopEvaluator struct {
+	instance  *FlowShop
+	objective Objective
+}
+
+var _ fitness.IFitnessEvaluator[int] = (*FlowShopEvaluator)(nil)
+
+// NewFlowShopEvaluator creates a FlowShopEvaluator for instance with the given objective.
+// Returns an error if the objective requires due dates or weights the instance does not have.
+func NewFlowShopEvaluator(instance *FlowShop, objective Objective) (*FlowShopEvaluator, error) {
+	if err := checkObjective(objective, instance.DueDates, instance.Weights); err != nil {
+		return nil, NewSchedulingError("cannot create evaluator", err)
+	}
+	return &FlowShopEvaluator{instance: instance, objective: objective}, nil
+}
+
+// Instance returns the evaluated instance.
+func (e *FlowShopEvaluator) Instance() *FlowShop {
+	return e.instance
+}
+
+// Dimension returns the number of jobs, which is the length of the evaluated chromosomes.
+func (e *FlowShopEvaluator) Dimension() int {
+	return e.instance.Jobs()
+}
+
+// Evaluate implements fitness.IFitnessEvaluator.
+func (e *FlowShopEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if chromosome == nil {
+		return 0, NewSchedulingError("cannot evaluate solution", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	schedule, err := e.instance.Decode(*chromosome)
+	if err != nil {
+		return 0, err
+	}
+	return -objectiveValue(schedule, e.objective, e.instance.DueDates, e.instance.Weights), nil
+}
@@ -0,0 +1,199 @@
+package scheduling
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+)
+
+// smallFlowShop returns an instance of 3 jobs on 2 machines whose optimal order 1, 0, 2 follows Johnson's rule.
+func smallFlowShop(t *testing.T) *FlowShop {
+	t.Helper()
+	instance, err := NewFlowShop("small", [][]int{{3, 2}, {1, 4}, {2, 1}})
+	require.NoError(t, err)
+	instance.Optimum = 8
+	return instance
+}
+
+// randomFlowShop is a helper function creating an instance with processing times uniform in
+// [1, 99], whose optimum is found by enumerating all orders.
+func randomFlowShop(t *testing.T, jobs, machines int, seed int64) *FlowShop {
+	t.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	times := make([][]int, jobs)
+	for job := range times {
+		times[job] = make([]int, machines)
+		for machine := range times[job] {
+			times[job][machine] = 1 + rng.Intn(99)
+		}
+	}
+	instance, err := NewFlowShop("random", times)
+	require.NoError(t, err)
+	order := make([]int, jobs)
+	for job := range order {
+		order[job] = job
+	}
+	instance.Optimum = bruteForce(t, instance, order, 0)
+	return instance
+}
+
+// bruteForce is a helper function returning the least makespan of the orders starting with order[:k].
+func bruteForce(t *testing.T, instance *FlowShop, order []int, k int) int {
+	if k == len(order) {
+		schedule, err := instance.Decode(order)
+		require.NoError(t, err)
+		return schedule.Makespan()
+	}
+	best := -1
+	for i := k; i < len(order); i++ {
+		order[k], order[i] = order[i], order[k]
+		if makespan := bruteForce(t, instance, order, k+1); best < 0 || makespan < best {
+			best = makespan
+		}
+		order[k], order[i] = order[i], order[k]
+	}
+	return best
+}
+
+func TestNewFlowShop(t *testing.T) {
+	t.Run("copies the times", func(t *testing.T) {
+		times := [][]int{{1, 2}, {3, 4}}
+		instance, err := NewFlowShop("copy", times)
+		require.NoError(t, err)
+		times[0][0] = 5
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}}, instance.Times)
+		assert.Equal(t, 2, instance.Jobs())
+		assert.Equal(t, 2, instance.Machines())
+	})
+
+	t.Run("rejects invalid data", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			times   [][]int
+			message string
+		}{
+			{"no jobs", nil, "at least 1 job"},
+			{"no machines", [][]int{{}}, "at least 1 machine"},
+			{"missing time", [][]int{{1, 2}, {1}}, "expected 2 processing times of job 1, got 1"},
+			{"negative time", [][]int{{1, -2}}, "processing time of job 0 on machine 1 must not be negative"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewFlowShop(tc.name, tc.times)
+				var schedulingErr *SchedulingError
+				require.ErrorAs(t, err, &schedulingErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestFlowShop_Decode(t *testing.T) {
+	instance := smallFlowShop(t)
+
+	t.Run("starts every operation as early as possible", func(t *testing.T) {
+		schedule, err := instance.Decode([]int{1, 0, 2})
+		require.NoError(t, err)
+		assert.Equal(t, []ScheduledOperation{
+			{Job: 1, Index: 0, Machine: 0, Start: 0, End: 1},
+			{Job: 1, Index: 1, Machine: 1, Start: 1, End: 5},
+			{Job: 0, Index: 0, Machine: 0, Start: 1, End: 4},
+			{Job: 0, Index: 1, Machine: 1, Start: 5, End: 7},
+			{Job: 2, Index: 0, Machine: 0, Start: 4, End: 6},
+			{Job: 2, Index: 1, Machine: 1, Start: 7, End: 8},
+		}, schedule.Operations)
+		gap, err := instance.Gap(schedule.Makespan())
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, gap)
+
+		schedule, err = instance.Decode([]int{0, 1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, 10, schedule.Makespan())
+	})
+
+	t.Run("rejects invalid orders", func(t *testing.T) {
+		for _, order := range [][]int{{0, 1}, {0, 1, 1}, {0, 1, 3}} {
+			_, err := instance.Decode(order)
+			assert.ErrorIs(t, err, ErrInvalidSolution)
+		}
+	})
+}
+
+func TestFlowShopEvaluator(t *testing.T) {
+	instance := smallFlowShop(t)
+	ctx := context.Background()
+
+	t.Run("evaluates by the objective", func(t *testing.T) {
+		order := []int{1, 0, 2}
+		evaluator, err := NewFlowShopEvaluator(instance, Makespan)
+		require.NoError(t, err)
+		assert.Same(t, instance, evaluator.Instance())
+		assert.Equal(t, 3, evaluator.Dimension())
+		value, err := evaluator.Evaluate(ctx, &order)
+		require.NoError(t, err)
+		assert.Equal(t, -8.0, value)
+
+		withDueDates := *instance
+		withDueDates.DueDates = []int{5, 5, 5}
+		evaluator, err = NewFlowShopEvaluator(&withDueDates, TotalTardiness)
+		require.NoError(t, err)
+		value, err = evaluator.Evaluate(ctx, &order)
+		require.NoError(t, err)
+		assert.Equal(t, -5.0, value)
+	})
+
+	t.Run("rejects invalid configurations and chromosomes", func(t *testing.T) {
+		_, err := NewFlowShopEvaluator(instance, WeightedTardiness)
+		assert.ErrorIs(t, err, ErrMissingDueDates)
+
+		evaluator, err := NewFlowShopEvaluator(instance, Makespan)
+		require.NoError(t, err)
+		invalid := []int{0, 0, 1}
+		_, err = evaluator.Evaluate(ctx, &invalid)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+		_, err = evaluator.Evaluate(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		order := []int{0, 1, 2}
+		_, err = evaluator.Evaluate(cancelled, &order)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestFlowShop_GeneticAlgorithm(t *testing.T) {
+	instance := randomFlowShop(t, 8, 4, 1)
+	evaluator, err := NewFlowShopEvaluator(instance, Makespan)
+	require.NoError(t, err)
+
+	ga, err := executor.NewBuilder[int]().
+		WithRandomPopulation(30, func() []int { return rand.Perm(instance.Jobs()) }).
+		WithEvaluator(evaluator).
+		WithCrossover(crossover.NewOrderCrossover[int]()).
+		WithMutator(mutation.NewSimpleSwapMutator[int](0.3)).
+		WithLocalSearch(localsearch.NewSwapHillClimber[int](), localsearch.Options{Probability: 0.1, Mode: localsearch.Lamarckian, Budget: 50}).
+		WithGenerations(30).
+		Build()
+	require.NoError(t, err)
+	ga.SetProgress(false)
+
+	population, err := ga.Run(context.Background())
+	require.NoError(t, err)
+	best, err := population.BestSolution()
+	require.NoError(t, err)
+	schedule, err := instance.Decode(best.Chromosome)
+	require.NoError(t, err)
+	gap, err := instance.Gap(schedule.Makespan())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, gap, 0.0)
+	assert.Less(t, gap, 0.05)
+}
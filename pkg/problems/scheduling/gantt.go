@@ -0,0 +1,107 @@
+package scheduling
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Layout of the Gantt charts written by WriteSVG, in pixels.
+const (
+	chartWidth  = 800
+	labelWidth  = 90
+	titleHeight = 40
+	axisHeight  = 30
+	rowHeight   = 30
+	barHeight   = 22
+	margin      = 10
+)
+
+// WriteSVG writes the schedule as a Gantt chart in SVG format to writer: every machine is a row
+// and every operation a bar colored by its job and labeled with the job number. Hovering a bar
+// shows the job, the operation and its start and end. The title, e.g. the instance name, is
+// shown above the chart together with the makespan.
+func (s *Schedule) WriteSVG(writer io.Writer, title string) error {
+	makespan := max(s.Makespan(), 1)
+	scale := float64(chartWidth) / float64(makespan)
+	width := labelWidth + chartWidth + 2*margin
+	height := titleHeight + s.Machines*rowHeight + axisHeight + margin
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", width, height, width, height)
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	heading := fmt.Sprintf("Makespan %d", s.Makespan())
+	if title != "" {
+		heading = title + ": " + heading
+	}
+	fmt.Fprintf(&sb, `<text x="%d" y="%d" font-size="16">%s</text>`+"\n", margin, titleHeight-15, html.EscapeString(heading))
+
+	for machine := range s.Machines {
+		y := titleHeight + machine*rowHeight
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" dominant-baseline="middle">Machine %d</text>`+"\n", margin, y+rowHeight/2, machine)
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#ddd"/>`+"\n", labelWidth, y+rowHeight, labelWidth+chartWidth, y+rowHeight)
+	}
+	for _, operation := range s.Operations {
+		x := float64(labelWidth) + float64(operation.Start)*scale
+		w := float64(operation.End-operation.Start) * scale
+		y := titleHeight + operation.Machine*rowHeight + (rowHeight-barHeight)/2
+		fmt.Fprintf(&sb, `<g><title>Job %d, operation %d: %d-%d</title>`, operation.Job, operation.Index, operation.Start, operation.End)
+		fmt.Fprintf(&sb, `<rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s" stroke="black" stroke-width="0.5"/>`, x, y, w, barHeight, jobColor(operation.Job))
+		if w >= 16 {
+			fmt.Fprintf(&sb, `<text x="%.2f" y="%d" text-anchor="middle" dominant-baseline="middle">%d</text>`, x+w/2, y+barHeight/2, operation.Job)
+		}
+		sb.WriteString("</g>\n")
+	}
+
+	axis := titleHeight + s.Machines*rowHeight
+	fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", labelWidth, axis, labelWidth+chartWidth, axis)
+	step := tickStep(makespan)
+	for tick := 0; tick <= makespan; tick += step {
+		x := float64(labelWidth) + float64(tick)*scale
+		fmt.Fprintf(&sb, `<line x1="%.2f" y1="%d" x2="%.2f" y2="%d" stroke="black"/>`, x, axis, x, axis+5)
+		fmt.Fprintf(&sb, `<text x="%.2f" y="%d" text-anchor="middle">%d</text>`+"\n", x, axis+18, tick)
+	}
+	sb.WriteString("</svg>\n")
+
+	_, err := io.WriteString(writer, sb.String())
+	return err
+}
+
+// SaveSVG writes the schedule as a Gantt chart in SVG format to the file at path, see WriteSVG.
+func (s *Schedule) SaveSVG(path, title string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return NewSchedulingError("cannot save Gantt chart", err)
+	}
+	if err := s.WriteSVG(file, title); err != nil {
+		file.Close()
+		return NewSchedulingError("cannot save Gantt chart", err)
+	}
+	if err := file.Close(); err != nil {
+		return NewSchedulingError("cannot save Gantt chart", err)
+	}
+	return nil
+}
+
+// jobColor is a helper function returning a color for a job. Hues follow the golden angle, so
+// consecutive jobs get clearly different colors.
+func jobColor(job int) string {
+	return fmt.Sprintf("hsl(%.0f, 65%%, 65%%)", math.Mod(float64(job)*137.508, 360))
+}
+
+// tickStep is a helper function returning a step of 1, 2 or 5 times a power of ten which divides
+// the time axis up to makespan into at most 10 intervals.
+func tickStep(makespan int) int {
+	step := 1
+	for {
+		for _, factor := range []int{1, 2, 5} {
+			if makespan/(step*factor) <= 10 {
+				return step * factor
+			}
+		}
+		step *= 10
+	}
+}
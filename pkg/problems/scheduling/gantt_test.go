@@ -0,0 +1,73 @@
+package scheduling
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_WriteSVG(t *testing.T) {
+	schedule, err := smallJobShop(t).Decode([]int{0, 1, 2, 3}, Active)
+	require.NoError(t, err)
+
+	t.Run("draws a bar per operation", func(t *testing.T) {
+		var sb strings.Builder
+		require.NoError(t, schedule.WriteSVG(&sb, "small <2x2>"))
+		svg := sb.String()
+
+		assert.Contains(t, svg, "small &lt;2x2&gt;: Makespan 7")
+		assert.Contains(t, svg, "Machine 0")
+		assert.Contains(t, svg, "Machine 1")
+		assert.Contains(t, svg, "<title>Job 1, operation 1: 3-7</title>")
+		assert.Equal(t, 4, strings.Count(svg, "<g>"))
+		assert.Equal(t, jobColor(0), jobColor(0))
+		assert.NotEqual(t, jobColor(0), jobColor(1))
+
+		// The chart must be well-formed XML
+		decoder := xml.NewDecoder(strings.NewReader(svg))
+		for {
+			_, err := decoder.Token()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("saves to a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gantt.svg")
+		require.NoError(t, schedule.SaveSVG(path, ""))
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(data), "<svg"))
+		assert.Contains(t, string(data), ">Makespan 7<")
+
+		err = schedule.SaveSVG(filepath.Join(t.TempDir(), "missing", "gantt.svg"), "")
+		var schedulingErr *SchedulingError
+		require.ErrorAs(t, err, &schedulingErr)
+	})
+}
+
+func TestTickStep(t *testing.T) {
+	testCases := []struct {
+		makespan int
+		step     int
+	}{
+		{1, 1},
+		{10, 1},
+		{11, 2},
+		{55, 10},
+		{1278, 200},
+		{5000, 500},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.step, tickStep(tc.makespan), "makespan %d", tc.makespan)
+	}
+}
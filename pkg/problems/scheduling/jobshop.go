@@ -0,0 +1,243 @@
+package scheduling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// JobShop is a job-shop scheduling problem: every job is a sequence of operations, each processed
+// by a given machine, every machine processes one operation at a time and operations are not
+// interrupted.
+type JobShop struct {
+	// Name identifies the instance, e.g. "ft06".
+	Name string
+	// Machines is the number of machines.
+	Machines int
+	// Jobs holds the operations of every job in their processing order.
+	Jobs [][]Operation
+	// DueDates holds the due date of every job for the tardiness objectives, nil if there are none.
+	DueDates []int
+	// Weights holds the weight of every job for the weighted tardiness objective, nil if there are none.
+	Weights []float64
+	// Optimum is the optimal or best known makespan, 0 if unknown.
+	Optimum int
+	// LowerBound is a lower bound of the makespan, 0 if unknown.
+	LowerBound int
+}
+
+// NewJobShop creates a job-shop instance from the operations of its jobs, which are copied.
+// Operations must refer to machines 0 to machines-1 and have non-negative durations.
+func NewJobShop(name string, machines int, jobs [][]Operation) (*JobShop, error) {
+	instance := &JobShop{Name: name, Machines: machines, Jobs: make([][]Operation, len(jobs))}
+	for job, operations := range jobs {
+		instance.Jobs[job] = slices.Clone(operations)
+	}
+	if err := instance.validate(); err != nil {
+		return nil, NewSchedulingError(fmt.Sprintf("cannot create instance %q", name), err)
+	}
+	return instance, nil
+}
+
+// validate is a helper function checking the jobs and their optional due dates and weights.
+func (j *JobShop) validate() error {
+	if j.Machines < 1 {
+		return fmt.Errorf("instance must have at least 1 machine, got %d", j.Machines)
+	}
+	if len(j.Jobs) == 0 {
+		return errors.New("instance must have at least 1 job")
+	}
+	for job, operations := range j.Jobs {
+		if len(operations) == 0 {
+			return fmt.Errorf("job %d has no operations", job)
+		}
+		for index, operation := range operations {
+			if operation.Machine < 0 || operation.Machine >= j.Machines {
+				return fmt.Errorf("machine of operation %d of job %d must be within [0, %d), got %d", index, job, j.Machines, operation.Machine)
+			}
+			if operation.Duration < 0 {
+				return fmt.Errorf("duration of operation %d of job %d must not be negative, got %d", index, job, operation.Duration)
+			}
+		}
+	}
+	return checkJobData(len(j.Jobs), j.DueDates, j.Weights)
+}
+
+// Operations returns the total number of operations, which is the length of the chromosomes.
+func (j *JobShop) Operations() int {
+	total := 0
+	for _, operations := range j.Jobs {
+		total += len(operations)
+	}
+	return total
+}
+
+// Gap returns the relative gap (makespan - Optimum) / Optimum of a makespan to the known optimum.
+// Returns ErrUnknownOptimum if the optimum is not known.
+func (j *JobShop) Gap(makespan int) (float64, error) {
+	return gap(j.Name, makespan, j.Optimum)
+}
+
+// Decode turns a chromosome, a permutation of the operation indices, into a schedule with the
+// given decoder. See the package documentation for the encoding.
+func (j *JobShop) Decode(chromosome []int, decoder Decoder) (*Schedule, error) {
+	sequence, err := j.sequence(chromosome)
+	if err != nil {
+		return nil, NewSchedulingError("cannot decode solution", err)
+	}
+	switch decoder {
+	case SemiActive:
+		return j.semiActive(sequence), nil
+	case Active:
+		return j.active(sequence), nil
+	default:
+		return nil, NewSchedulingError("cannot decode solution", fmt.Errorf("unknown decoder %v", decoder))
+	}
+}
+
+// sequence is a helper function replacing every operation index of a chromosome by its job.
+// Indices 0 to len(Jobs[0])-1 belong to job 0, the following ones to job 1 and so on.
+func (j *JobShop) sequence(chromosome []int) ([]int, error) {
+	if err := checkPermutation(chromosome, j.Operations(), "operations"); err != nil {
+		return nil, err
+	}
+	owner := make([]int, 0, len(chromosome))
+	for job, operations := range j.Jobs {
+		for range operations {
+			owner = append(owner, job)
+		}
+	}
+	sequence := make([]int, len(chromosome))
+	for k, gene := range chromosome {
+		sequence[k] = owner[gene]
+	}
+	return sequence, nil
+}
+
+// timetable tracks the times at which the jobs and machines of a partial schedule become available.
+type timetable struct {
+	schedule     *Schedule
+	next         []int
+	jobReady     []int
+	machineReady []int
+}
+
+func (j *JobShop) newTimetable() *timetable {
+	return &timetable{
+		schedule:     &Schedule{Jobs: len(j.Jobs), Machines: j.Machines, Operations: make([]ScheduledOperation, 0, j.Operations())},
+		next:         make([]int, len(j.Jobs)),
+		jobReady:     make([]int, len(j.Jobs)),
+		machineReady: make([]int, j.Machines),
+	}
+}
+
+// earliest is a helper function returning the earliest start of an operation of job.
+func (t *timetable) earliest(job int, operation Operation) int {
+	return max(t.jobReady[job], t.machineReady[operation.Machine])
+}
+
+// add is a helper function scheduling the next operation of job at its earliest start.
+func (t *timetable) add(job int, operation Operation) {
+	start := t.earliest(job, operation)
+	end := start + operation.Duration
+	t.schedule.Operations = append(t.schedule.Operations, ScheduledOperation{
+		Job: job, Index: t.next[job], Machine: operation.Machine, Start: start, End: end,
+	})
+	t.next[job]++
+	t.jobReady[job] = end
+	t.machineReady[operation.Machine] = end
+}
+
+// semiActive is a helper function scheduling the operations in the order of the sequence.
+func (j *JobShop) semiActive(sequence []int) *Schedule {
+	t := j.newTimetable()
+	for _, job := range sequence {
+		t.add(job, j.Jobs[job][t.next[job]])
+	}
+	return t.schedule
+}
+
+// active is a helper function implementing the Giffler-Thompson algorithm: it repeatedly finds
+// the unscheduled operation of earliest completion and schedules, among the operations on its
+// machine which could start before that completion, the one whose job comes first in the
+// remaining sequence.
+func (j *JobShop) active(sequence []int) *Schedule {
+	t := j.newTimetable()
+	consumed := make([]bool, len(sequence))
+	for range sequence {
+		earliestJob, completion := -1, 0
+		for job, operations := range j.Jobs {
+			if t.next[job] == len(operations) {
+				continue
+			}
+			operation := operations[t.next[job]]
+			if end := t.earliest(job, operation) + operation.Duration; earliestJob < 0 || end < completion {
+				earliestJob, completion = job, end
+			}
+		}
+		machine := j.Jobs[earliestJob][t.next[earliestJob]].Machine
+
+		for k, job := range sequence {
+			if consumed[k] || t.next[job] == len(j.Jobs[job]) {
+				continue
+			}
+			operation := j.Jobs[job][t.next[job]]
+			if operation.Machine == machine && (job == earliestJob || t.earliest(job, operation) < completion) {
+				consumed[k] = true
+				t.add(job, operation)
+				break
+			}
+		}
+	}
+	return t.schedule
+}
+
+// JobShopEvaluator evaluates job-shop chromosomes by the negated objective value of the schedule
+// they decode to.
+type JobShopEvaluator struct {
+	instance  *JobShop
+	decoder   Decoder
+	objective Objective
+}
+
+var _ fitness.IFitnessEvaluator[int] = (*JobShopEvaluator)(nil)
+
+// NewJobShopEvaluator creates a JobShopEvaluator for instance with the given decoder and objective.
+// Returns an error if the objective requires due dates or weights the instance does not have.
+func NewJobShopEvaluator(instance *JobShop, decoder Decoder, objective Objective) (*JobShopEvaluator, error) {
+	if decoder != SemiActive && decoder != Active {
+		return nil, NewSchedulingError("cannot create evaluator", fmt.Errorf("unknown decoder %v", decoder))
+	}
+	if err := checkObjective(objective, instance.DueDates, instance.Weights); err != nil {
+		return nil, NewSchedulingError("cannot create evaluator", err)
+	}
+	return &JobShopEvaluator{instance: instance, decoder: decoder, objective: objective}, nil
+}
+
+// Instance returns the evaluated instance.
+func (e *JobShopEvaluator) Instance() *JobShop {
+	return e.instance
+}
+
+// Dimension returns the number of operations, which is the length of the evaluated chromosomes.
+func (e *JobShopEvaluator) Dimension() int {
+	return e.instance.Operations()
+}
+
+// Evaluate implements fitness.IFitnessEvaluator.
+func (e *JobShopEvaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if chromosome == nil {
+		return 0, NewSchedulingError("cannot evaluate solution", fmt.Errorf("%w: chromosome is nil", ErrInvalidSolution))
+	}
+	schedule, err := e.instance.Decode(*chromosome, e.decoder)
+	if err != nil {
+		return 0, err
+	}
+	return -objectiveValue(schedule, e.objective, e.instance.DueDates, e.instance.Weights), nil
+}
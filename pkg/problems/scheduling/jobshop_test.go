@@ -0,0 +1,251 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+)
+
+// ft06 is the 6x6 instance of Fisher and Thompson in the standard format, whose optimal makespan is 55.
+const ft06 = `6 6
+2 1 0 3 1 6 3 7 5 3 4 6
+1 8 2 5 4 10 5 10 0 10 3 4
+2 5 3 4 5 8 0 9 1 1 4 7
+1 5 0 5 2 5 3 3 4 8 5 9
+2 9 1 3 4 5 5 4 0 3 3 1
+1 3 3 3 5 9 0 10 4 4 2 1
+`
+
+// smallJobShop returns an instance of 2 jobs on 2 machines whose semi-active and active decodings differ.
+func smallJobShop(t *testing.T) *JobShop {
+	t.Helper()
+	instance, err := NewJobShop("small", 2, [][]Operation{
+		{{Machine: 0, Duration: 3}, {Machine: 1, Duration: 2}},
+		{{Machine: 1, Duration: 2}, {Machine: 0, Duration: 4}},
+	})
+	require.NoError(t, err)
+	instance.Optimum = 7
+	return instance
+}
+
+func TestNewJobShop(t *testing.T) {
+	t.Run("copies the jobs", func(t *testing.T) {
+		jobs := [][]Operation{{{Machine: 0, Duration: 1}}}
+		instance, err := NewJobShop("copy", 1, jobs)
+		require.NoError(t, err)
+		jobs[0][0].Duration = 5
+		assert.Equal(t, 1, instance.Jobs[0][0].Duration)
+		assert.Equal(t, 1, instance.Operations())
+	})
+
+	t.Run("rejects invalid data", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			machines int
+			jobs     [][]Operation
+			message  string
+		}{
+			{"no machines", 0, [][]Operation{{{}}}, "at least 1 machine"},
+			{"no jobs", 1, nil, "at least 1 job"},
+			{"empty job", 1, [][]Operation{{{}}, {}}, "job 1 has no operations"},
+			{"unknown machine", 2, [][]Operation{{{Machine: 2, Duration: 1}}}, "machine of operation 0 of job 0 must be within [0, 2), got 2"},
+			{"negative duration", 1, [][]Operation{{{Machine: 0, Duration: -1}}}, "must not be negative"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := NewJobShop(tc.name, tc.machines, tc.jobs)
+				var schedulingErr *SchedulingError
+				require.ErrorAs(t, err, &schedulingErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+
+	t.Run("checks due dates and weights", func(t *testing.T) {
+		instance := smallJobShop(t)
+		instance.DueDates = []int{1}
+		assert.ErrorContains(t, instance.validate(), "expected 2 due dates")
+		instance.DueDates, instance.Weights = []int{1, 2}, []float64{1, -1}
+		assert.ErrorContains(t, instance.validate(), "weight of job 1 must not be negative")
+	})
+}
+
+func TestJobShop_Decode(t *testing.T) {
+	instance := smallJobShop(t)
+
+	t.Run("semi-active", func(t *testing.T) {
+		schedule, err := instance.Decode([]int{0, 1, 2, 3}, SemiActive)
+		require.NoError(t, err)
+		assert.Equal(t, []ScheduledOperation{
+			{Job: 0, Index: 0, Machine: 0, Start: 0, End: 3},
+			{Job: 0, Index: 1, Machine: 1, Start: 3, End: 5},
+			{Job: 1, Index: 0, Machine: 1, Start: 5, End: 7},
+			{Job: 1, Index: 1, Machine: 0, Start: 7, End: 11},
+		}, schedule.Operations)
+		assert.Equal(t, 11, schedule.Makespan())
+
+		// Indices of the same job are interchangeable
+		other, err := instance.Decode([]int{1, 0, 3, 2}, SemiActive)
+		require.NoError(t, err)
+		assert.Equal(t, schedule, other)
+	})
+
+	t.Run("active", func(t *testing.T) {
+		schedule, err := instance.Decode([]int{0, 1, 2, 3}, Active)
+		require.NoError(t, err)
+		assert.Equal(t, []ScheduledOperation{
+			{Job: 1, Index: 0, Machine: 1, Start: 0, End: 2},
+			{Job: 0, Index: 0, Machine: 0, Start: 0, End: 3},
+			{Job: 0, Index: 1, Machine: 1, Start: 3, End: 5},
+			{Job: 1, Index: 1, Machine: 0, Start: 3, End: 7},
+		}, schedule.Operations)
+		gap, err := instance.Gap(schedule.Makespan())
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, gap)
+	})
+
+	t.Run("decoded schedules are feasible", func(t *testing.T) {
+		instances, err := ParseJobShop(strings.NewReader(ft06), "ft06")
+		require.NoError(t, err)
+		ft := instances[0]
+		for range 100 {
+			chromosome := rand.Perm(ft.Operations())
+			semiActive, err := ft.Decode(chromosome, SemiActive)
+			require.NoError(t, err)
+			active, err := ft.Decode(chromosome, Active)
+			require.NoError(t, err)
+			require.NoError(t, checkJobShopSchedule(ft, active))
+			require.NoError(t, checkJobShopSchedule(ft, semiActive))
+			assert.GreaterOrEqual(t, active.Makespan(), 55)
+			assert.GreaterOrEqual(t, semiActive.Makespan(), 55)
+		}
+	})
+
+	t.Run("rejects invalid chromosomes", func(t *testing.T) {
+		for _, chromosome := range [][]int{{0, 1, 2}, {0, 1, 2, 2}, {0, 1, 2, 4}} {
+			_, err := instance.Decode(chromosome, Active)
+			assert.ErrorIs(t, err, ErrInvalidSolution)
+		}
+		_, err := instance.Decode([]int{0, 1, 2, 3}, Decoder(7))
+		assert.ErrorContains(t, err, "unknown decoder")
+	})
+
+	t.Run("gap needs a known optimum", func(t *testing.T) {
+		unknown, err := NewJobShop("unknown", 1, [][]Operation{{{Machine: 0, Duration: 1}}})
+		require.NoError(t, err)
+		_, err = unknown.Gap(1)
+		assert.ErrorIs(t, err, ErrUnknownOptimum)
+	})
+}
+
+// checkJobShopSchedule is a helper function checking that a schedule processes every operation of
+// the instance for its duration, respects the order of the jobs and never overlaps operations on a machine.
+func checkJobShopSchedule(instance *JobShop, schedule *Schedule) error {
+	if len(schedule.Operations) != instance.Operations() {
+		return fmt.Errorf("expected %d operations, got %d", instance.Operations(), len(schedule.Operations))
+	}
+	end := make(map[[2]int]int)
+	for _, operation := range schedule.Operations {
+		expected := instance.Jobs[operation.Job][operation.Index]
+		if operation.Machine != expected.Machine || operation.End-operation.Start != expected.Duration {
+			return fmt.Errorf("operation %+v does not match %+v", operation, expected)
+		}
+		if operation.Index > 0 && operation.Start < end[[2]int{operation.Job, operation.Index - 1}] {
+			return fmt.Errorf("operation %+v starts before its predecessor ends", operation)
+		}
+		end[[2]int{operation.Job, operation.Index}] = operation.End
+		for _, other := range schedule.Operations {
+			if other != operation && other.Machine == operation.Machine && other.Start < operation.End && operation.Start < other.End {
+				return fmt.Errorf("operations %+v and %+v overlap", operation, other)
+			}
+		}
+	}
+	return nil
+}
+
+func TestJobShopEvaluator(t *testing.T) {
+	instance := smallJobShop(t)
+	ctx := context.Background()
+
+	t.Run("evaluates by the objective", func(t *testing.T) {
+		chromosome := []int{0, 1, 2, 3}
+		evaluator, err := NewJobShopEvaluator(instance, SemiActive, Makespan)
+		require.NoError(t, err)
+		assert.Same(t, instance, evaluator.Instance())
+		assert.Equal(t, 4, evaluator.Dimension())
+		value, err := evaluator.Evaluate(ctx, &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, -11.0, value)
+
+		withDueDates := *instance
+		withDueDates.DueDates, withDueDates.Weights = []int{4, 6}, []float64{2, 1}
+		evaluator, err = NewJobShopEvaluator(&withDueDates, Active, WeightedTardiness)
+		require.NoError(t, err)
+		value, err = evaluator.Evaluate(ctx, &chromosome)
+		require.NoError(t, err)
+		assert.Equal(t, -3.0, value)
+	})
+
+	t.Run("rejects invalid configurations", func(t *testing.T) {
+		_, err := NewJobShopEvaluator(instance, Decoder(7), Makespan)
+		assert.ErrorContains(t, err, "unknown decoder")
+		_, err = NewJobShopEvaluator(instance, Active, TotalTardiness)
+		assert.ErrorIs(t, err, ErrMissingDueDates)
+	})
+
+	t.Run("rejects invalid chromosomes", func(t *testing.T) {
+		evaluator, err := NewJobShopEvaluator(instance, Active, Makespan)
+		require.NoError(t, err)
+		invalid := []int{0, 0, 1, 2}
+		_, err = evaluator.Evaluate(ctx, &invalid)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+		_, err = evaluator.Evaluate(ctx, nil)
+		assert.ErrorIs(t, err, ErrInvalidSolution)
+
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		chromosome := []int{0, 1, 2, 3}
+		_, err = evaluator.Evaluate(cancelled, &chromosome)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestJobShop_GeneticAlgorithm(t *testing.T) {
+	instances, err := ParseJobShop(strings.NewReader(ft06), "ft06")
+	require.NoError(t, err)
+	instance := instances[0]
+	instance.Optimum = 55
+	evaluator, err := NewJobShopEvaluator(instance, Active, Makespan)
+	require.NoError(t, err)
+
+	ga, err := executor.NewBuilder[int]().
+		WithRandomPopulation(40, func() []int { return rand.Perm(instance.Operations()) }).
+		WithEvaluator(evaluator).
+		WithCrossover(crossover.NewOrderCrossover[int]()).
+		WithMutator(mutation.NewSimpleSwapMutator[int](0.3)).
+		WithGenerations(40).
+		Build()
+	require.NoError(t, err)
+	ga.SetProgress(false)
+
+	population, err := ga.Run(context.Background())
+	require.NoError(t, err)
+	best, err := population.BestSolution()
+	require.NoError(t, err)
+	schedule, err := instance.Decode(best.Chromosome, Active)
+	require.NoError(t, err)
+	require.NoError(t, checkJobShopSchedule(instance, schedule))
+	gap, err := instance.Gap(schedule.Makespan())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, gap, 0.0)
+	assert.Less(t, gap, 0.1)
+}
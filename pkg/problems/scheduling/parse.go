@@ -0,0 +1,398 @@
+package scheduling
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lines holds the whitespace-separated fields of the non-empty lines of an instance file.
+type lines struct {
+	fields  [][]string
+	numbers []int
+	pos     int
+}
+
+// readLines is a helper function splitting a file into the fields of its non-empty lines.
+func readLines(r io.Reader) (*lines, error) {
+	l := &lines{}
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		if fields := strings.Fields(scanner.Text()); len(fields) > 0 {
+			l.fields = append(l.fields, fields)
+			l.numbers = append(l.numbers, number)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *lines) done() bool {
+	return l.pos >= len(l.fields)
+}
+
+// numeric is a helper function reporting whether all fields of line k are integers.
+func (l *lines) numeric(k int) bool {
+	for _, field := range l.fields[k] {
+		if _, err := strconv.Atoi(field); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// text is a helper function returning line k in lower case.
+func (l *lines) text(k int) string {
+	return strings.ToLower(strings.Join(l.fields[k], " "))
+}
+
+// label is a helper function skipping a line of text such as "Times", describing it by what in errors.
+func (l *lines) label(what string) error {
+	if l.done() {
+		return fmt.Errorf("unexpected end of file, expected %s", what)
+	}
+	if l.numeric(l.pos) {
+		return fmt.Errorf("line %d: expected %s, got %q", l.numbers[l.pos], what, strings.Join(l.fields[l.pos], " "))
+	}
+	l.pos++
+	return nil
+}
+
+// ints is a helper function reading the next count integers, which may span several lines but
+// must end with a line.
+func (l *lines) ints(count int, what string) ([]int, error) {
+	values := make([]int, 0, count)
+	for len(values) < count {
+		if l.done() {
+			return nil, fmt.Errorf("unexpected end of file, expected %s", what)
+		}
+		fields := l.fields[l.pos]
+		if len(values)+len(fields) > count {
+			return nil, fmt.Errorf("line %d: expected %d more values, got %d", l.numbers[l.pos], count-len(values), len(fields))
+		}
+		for _, field := range fields {
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s %q", l.numbers[l.pos], what, field)
+			}
+			values = append(values, value)
+		}
+		l.pos++
+	}
+	return values, nil
+}
+
+// size is a helper function reading the numbers of jobs and machines, both at least 1.
+func (l *lines) size() (int, int, error) {
+	values, err := l.ints(2, "numbers of jobs and machines")
+	if err != nil {
+		return 0, 0, err
+	}
+	if values[0] < 1 || values[1] < 1 {
+		return 0, 0, fmt.Errorf("numbers of jobs and machines must be at least 1, got %d and %d", values[0], values[1])
+	}
+	return values[0], values[1], nil
+}
+
+// pairs is a helper function reading n jobs given as m pairs "machine duration" each.
+func (l *lines) pairs(n, m int) ([][]Operation, error) {
+	values, err := l.ints(2*n*m, "machine or processing time")
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([][]Operation, n)
+	for job := range jobs {
+		jobs[job] = make([]Operation, m)
+		for k := range jobs[job] {
+			jobs[job][k] = Operation{Machine: values[2*(job*m+k)], Duration: values[2*(job*m+k)+1]}
+		}
+	}
+	return jobs, nil
+}
+
+// orLibrary is a helper function reading the instances of an OR-Library file such as
+// jobshop1.txt: every instance starts with a line "instance <name>", followed by lines of text,
+// the numbers of jobs and machines and the jobs as pairs of machine and processing time.
+// Lines before the first instance are ignored.
+func (l *lines) orLibrary(read func(name string, n, m int) error) error {
+	for !l.done() {
+		if fields := l.fields[l.pos]; len(fields) != 2 || fields[0] != "instance" {
+			l.pos++
+			continue
+		}
+		name := l.fields[l.pos][1]
+		for l.pos++; !l.done() && !l.numeric(l.pos); l.pos++ {
+		}
+		n, m, err := l.size()
+		if err != nil {
+			return fmt.Errorf("instance %s: %w", name, err)
+		}
+		if err := read(name, n, m); err != nil {
+			return fmt.Errorf("instance %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// hasInstances is a helper function reporting whether the file contains "instance <name>" lines.
+func (l *lines) hasInstances() bool {
+	for _, fields := range l.fields {
+		if len(fields) == 2 && fields[0] == "instance" {
+			return true
+		}
+	}
+	return false
+}
+
+// standard is a helper function reading a single instance in the standard format: the numbers of
+// jobs and machines, followed by the jobs. Leading lines of text are ignored.
+func (l *lines) standard(read func(n, m int) error) error {
+	for !l.done() && !l.numeric(l.pos) {
+		l.pos++
+	}
+	n, m, err := l.size()
+	if err != nil {
+		return err
+	}
+	if err := read(n, m); err != nil {
+		return err
+	}
+	if !l.done() {
+		return fmt.Errorf("line %d: unexpected data %q", l.numbers[l.pos], strings.Join(l.fields[l.pos], " "))
+	}
+	return nil
+}
+
+// taillardName is a helper function naming instance k of the count instances of a Taillard file
+// name-1, name-2, ..., or name if the file holds a single instance.
+func taillardName(name string, k, count int) string {
+	if count == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s-%d", name, k+1)
+}
+
+// LoadJobShop reads the job-shop instances of a file, see ParseJobShop. Instances without a name
+// in the file are named after the file.
+func LoadJobShop(path string) ([]*JobShop, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewSchedulingError("cannot load instances", err)
+	}
+	defer file.Close()
+	return ParseJobShop(file, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// ParseJobShop reads job-shop instances in one of the formats:
+//   - Taillard, with one or more blocks of a line "Nb of jobs, Nb of Machines, ...", the numbers
+//     of jobs and machines, two seeds and the upper and lower bound of the makespan, a line
+//     "Times" followed by the processing times and a line "Machines" followed by the machines
+//     (counting from 1) of the operations of every job. The instances are named name-1, name-2, ...
+//     or name if there is a single one, and their Optimum and LowerBound are set from the bounds.
+//   - OR-Library, as jobshop1.txt, where every instance starts with a line "instance <name>",
+//     followed by a description, the numbers of jobs and machines and the operations of every
+//     job as pairs of machine (counting from 0) and processing time.
+//   - Standard, a single instance of the numbers of jobs and machines followed by the operations
+//     as in the OR-Library format. It is named name.
+func ParseJobShop(r io.Reader, name string) ([]*JobShop, error) {
+	l, err := readLines(r)
+	if err == nil && l.done() {
+		err = errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, NewSchedulingError("cannot parse instances", err)
+	}
+
+	var instances []*JobShop
+	add := func(instance *JobShop) error {
+		if err := instance.validate(); err != nil {
+			return err
+		}
+		instances = append(instances, instance)
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(l.text(0), "nb of jobs"):
+		err = parseTaillardJobShop(l, add)
+		for k, instance := range instances {
+			instance.Name = taillardName(name, k, len(instances))
+		}
+	case l.hasInstances():
+		err = l.orLibrary(func(instanceName string, n, m int) error {
+			jobs, err := l.pairs(n, m)
+			if err != nil {
+				return err
+			}
+			return add(&JobShop{Name: instanceName, Machines: m, Jobs: jobs})
+		})
+	default:
+		err = l.standard(func(n, m int) error {
+			jobs, err := l.pairs(n, m)
+			if err != nil {
+				return err
+			}
+			return add(&JobShop{Name: name, Machines: m, Jobs: jobs})
+		})
+	}
+	if err != nil {
+		return nil, NewSchedulingError("cannot parse instances", err)
+	}
+	return instances, nil
+}
+
+// parseTaillardJobShop is a helper function reading the blocks of a Taillard job-shop file.
+func parseTaillardJobShop(l *lines, add func(*JobShop) error) error {
+	for k := 1; !l.done(); k++ {
+		if err := l.label("instance header"); err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		values, err := l.ints(6, "instance parameters")
+		if err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		n, m := values[0], values[1]
+		if n < 1 || m < 1 {
+			return fmt.Errorf("instance %d: numbers of jobs and machines must be at least 1, got %d and %d", k, n, m)
+		}
+		if err := l.label("\"Times\""); err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		times, err := l.ints(n*m, "processing time")
+		if err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		if err := l.label("\"Machines\""); err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		machines, err := l.ints(n*m, "machine")
+		if err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		instance := &JobShop{Machines: m, Jobs: make([][]Operation, n), Optimum: values[4], LowerBound: values[5]}
+		for job := range instance.Jobs {
+			instance.Jobs[job] = make([]Operation, m)
+			for index := range instance.Jobs[job] {
+				instance.Jobs[job][index] = Operation{Machine: machines[job*m+index] - 1, Duration: times[job*m+index]}
+			}
+		}
+		if err := add(instance); err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// LoadFlowShop reads the flow-shop instances of a file, see ParseFlowShop. Instances without a
+// name in the file are named after the file.
+func LoadFlowShop(path string) ([]*FlowShop, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewSchedulingError("cannot load instances", err)
+	}
+	defer file.Close()
+	return ParseFlowShop(file, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// ParseFlowShop reads permutation flow-shop instances in one of the formats:
+//   - Taillard, with one or more blocks of a line "number of jobs, number of machines, ...", the
+//     numbers of jobs and machines, a seed and the upper and lower bound of the makespan, and a
+//     line "processing times :" followed by the processing times of the jobs on every machine,
+//     one machine after the other. The instances are named name-1, name-2, ... or name if there
+//     is a single one, and their Optimum and LowerBound are set from the bounds.
+//   - OR-Library, as flowshop1.txt, where every instance starts with a line "instance <name>",
+//     followed by a description, the numbers of jobs and machines and the operations of every
+//     job as pairs of machine and processing time, for the machines 0, 1, ... in this order.
+//   - Standard, a single instance of the numbers of jobs and machines followed by the operations
+//     as in the OR-Library format. It is named name.
+func ParseFlowShop(r io.Reader, name string) ([]*FlowShop, error) {
+	l, err := readLines(r)
+	if err == nil && l.done() {
+		err = errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, NewSchedulingError("cannot parse instances", err)
+	}
+
+	var instances []*FlowShop
+	add := func(instance *FlowShop) error {
+		if err := instance.validate(); err != nil {
+			return err
+		}
+		instances = append(instances, instance)
+		return nil
+	}
+	fromPairs := func(instanceName string, n, m int) error {
+		jobs, err := l.pairs(n, m)
+		if err != nil {
+			return err
+		}
+		instance := &FlowShop{Name: instanceName, Times: make([][]int, n)}
+		for job, operations := range jobs {
+			instance.Times[job] = make([]int, m)
+			for index, operation := range operations {
+				if operation.Machine != index {
+					return fmt.Errorf("operation %d of job %d must be processed by machine %d, got %d", index, job, index, operation.Machine)
+				}
+				instance.Times[job][index] = operation.Duration
+			}
+		}
+		return add(instance)
+	}
+	switch {
+	case strings.HasPrefix(l.text(0), "number of jobs"):
+		err = parseTaillardFlowShop(l, add)
+		for k, instance := range instances {
+			instance.Name = taillardName(name, k, len(instances))
+		}
+	case l.hasInstances():
+		err = l.orLibrary(fromPairs)
+	default:
+		err = l.standard(func(n, m int) error { return fromPairs(name, n, m) })
+	}
+	if err != nil {
+		return nil, NewSchedulingError("cannot parse instances", err)
+	}
+	return instances, nil
+}
+
+// parseTaillardFlowShop is a helper function reading the blocks of a Taillard flow-shop file.
+func parseTaillardFlowShop(l *lines, add func(*FlowShop) error) error {
+	for k := 1; !l.done(); k++ {
+		if err := l.label("instance header"); err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		values, err := l.ints(5, "instance parameters")
+		if err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		n, m := values[0], values[1]
+		if n < 1 || m < 1 {
+			return fmt.Errorf("instance %d: numbers of jobs and machines must be at least 1, got %d and %d", k, n, m)
+		}
+		if err := l.label("\"processing times :\""); err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		times, err := l.ints(n*m, "processing time")
+		if err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+		instance := &FlowShop{Times: make([][]int, n), Optimum: values[3], LowerBound: values[4]}
+		for job := range instance.Times {
+			instance.Times[job] = make([]int, m)
+			for machine := range instance.Times[job] {
+				instance.Times[job][machine] = times[machine*n+job]
+			}
+		}
+		if err := add(instance); err != nil {
+			return fmt.Errorf("instance %d: %w", k, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package scheduling
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taillardJobShop holds two copies of the instance of smallJobShop in the format of Taillard's job-shop files.
+const taillardJobShop = ` Nb of jobs, Nb of Machines, Time seed, Machine seed, Upper bound, Lower bound
+          2           2   840612802   398197754           7           6
+ Times
+  3  2
+  2  4
+ Machines
+  1  2
+  2  1
+ Nb of jobs, Nb of Machines, Time seed, Machine seed, Upper bound, Lower bound
+          2           2   840612802   398197754           7           6
+ Times
+  3  2
+  2  4
+ Machines
+  1  2
+  2  1
+`
+
+// orLibraryJobShop holds ft06 and the instance of smallJobShop in the format of jobshop1.txt.
+const orLibraryJobShop = ` This file contains a set of JSP test instances.
+ +++++++++++++++++++++++++++++
+
+ instance ft06
+
+ +++++++++++++++++++++++++++++
+ Fisher and Thompson 6x6 instance, alternate name (mt06)
+` + ft06 + ` +++++++++++++++++++++++++++++
+
+ instance small
+
+ +++++++++++++++++++++++++++++
+ small instance (Table 1, instance 2)
+ 2 2
+ 0 3 1 2
+ 1 2 0 4
+ +++++++++++++++++++++++++++++
+`
+
+// taillardFlowShop holds the instance of smallFlowShop in the format of Taillard's flow-shop files.
+const taillardFlowShop = `number of jobs, number of machines, initial seed, upper bound and lower bound :
+           3           2   873654221           8           8
+processing times :
+  3  1  2
+  2  4  1
+`
+
+func TestParseJobShop(t *testing.T) {
+	t.Run("Taillard", func(t *testing.T) {
+		instances, err := ParseJobShop(strings.NewReader(taillardJobShop), "tai")
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+		expected := smallJobShop(t)
+		expected.Name, expected.LowerBound = "tai-1", 6
+		assert.Equal(t, expected, instances[0])
+		assert.Equal(t, "tai-2", instances[1].Name)
+
+		single, err := ParseJobShop(strings.NewReader(taillardJobShop[:strings.LastIndex(taillardJobShop, " Nb of jobs")]), "tai")
+		require.NoError(t, err)
+		assert.Equal(t, "tai", single[0].Name)
+	})
+
+	t.Run("OR-Library", func(t *testing.T) {
+		instances, err := ParseJobShop(strings.NewReader(orLibraryJobShop), "jobshop1")
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+		assert.Equal(t, "ft06", instances[0].Name)
+		assert.Equal(t, 36, instances[0].Operations())
+		assert.Equal(t, []Operation{{Machine: 2, Duration: 1}, {Machine: 0, Duration: 3}, {Machine: 1, Duration: 6}, {Machine: 3, Duration: 7}, {Machine: 5, Duration: 3}, {Machine: 4, Duration: 6}}, instances[0].Jobs[0])
+		expected := smallJobShop(t)
+		expected.Optimum = 0
+		assert.Equal(t, expected, instances[1])
+	})
+
+	t.Run("standard", func(t *testing.T) {
+		instances, err := ParseJobShop(strings.NewReader("# comment\n2 2\n0 3 1 2\n1 2 0 4\n"), "small")
+		require.NoError(t, err)
+		expected := smallJobShop(t)
+		expected.Optimum = 0
+		assert.Equal(t, []*JobShop{expected}, instances)
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			text    string
+			message string
+		}{
+			{"empty", "\n\n", "file is empty"},
+			{"no jobs", "0 2\n", "numbers of jobs and machines must be at least 1, got 0 and 2"},
+			{"truncated", "2 2\n0 3 1 2\n1 2\n", "unexpected end of file, expected machine or processing time"},
+			{"invalid number", "1 1\n0 x\n", "line 2: invalid machine or processing time \"x\""},
+			{"overlong row", "1 1\n0 3 1\n", "line 2: expected 2 more values, got 3"},
+			{"trailing data", "1 1\n0 3\n7\n", "line 3: unexpected data \"7\""},
+			{"unknown machine", "1 1\n1 3\n", "machine of operation 0 of job 0 must be within [0, 1), got 1"},
+			{"instance without size", " instance x\n text\n", "instance x: unexpected end of file"},
+			{"Taillard without times", taillardJobShop[:strings.Index(taillardJobShop, " Times")], "instance 1: unexpected end of file, expected \"Times\""},
+			{"Taillard with missing label", strings.Replace(taillardJobShop, " Machines\n", "", 1), "instance 1: line 6: expected \"Machines\", got \"1 2\""},
+			{"Taillard with unknown machine", strings.Replace(taillardJobShop, "  2  1\n", "  3  1\n", 1), "instance 1: machine of operation 0 of job 1 must be within [0, 2), got 2"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := ParseJobShop(strings.NewReader(tc.text), "test")
+				var schedulingErr *SchedulingError
+				require.ErrorAs(t, err, &schedulingErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestParseFlowShop(t *testing.T) {
+	t.Run("Taillard", func(t *testing.T) {
+		instances, err := ParseFlowShop(strings.NewReader(taillardFlowShop+taillardFlowShop), "tai20_5")
+		require.NoError(t, err)
+		require.Len(t, instances, 2)
+		expected := smallFlowShop(t)
+		expected.Name, expected.LowerBound = "tai20_5-1", 8
+		assert.Equal(t, expected, instances[0])
+		assert.Equal(t, "tai20_5-2", instances[1].Name)
+	})
+
+	t.Run("OR-Library and standard", func(t *testing.T) {
+		rows := "3 2\n0 3 1 2\n0 1 1 4\n0 2 1 1\n"
+		instances, err := ParseFlowShop(strings.NewReader(" instance car0\n +++\n example\n"+rows+" +++\n"), "flowshop1")
+		require.NoError(t, err)
+		expected := smallFlowShop(t)
+		expected.Name, expected.Optimum = "car0", 0
+		assert.Equal(t, []*FlowShop{expected}, instances)
+
+		instances, err = ParseFlowShop(strings.NewReader(rows), "small")
+		require.NoError(t, err)
+		expected.Name = "small"
+		assert.Equal(t, []*FlowShop{expected}, instances)
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			text    string
+			message string
+		}{
+			{"empty", "", "file is empty"},
+			{"machine out of order", "1 2\n1 3 0 2\n", "operation 0 of job 0 must be processed by machine 0, got 1"},
+			{"Taillard truncated", taillardFlowShop[:strings.LastIndex(taillardFlowShop, "  2  4")], "instance 1: unexpected end of file, expected processing time"},
+			{"Taillard without label", strings.Replace(taillardFlowShop, "processing times :\n", "", 1), "instance 1: line 3: expected \"processing times :\""},
+			{"Taillard with negative time", strings.Replace(taillardFlowShop, "  3  1  2", " -3  1  2", 1), "instance 1: processing time of job 0 on machine 0 must not be negative"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := ParseFlowShop(strings.NewReader(tc.text), "test")
+				var schedulingErr *SchedulingError
+				require.ErrorAs(t, err, &schedulingErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	jobShopPath := filepath.Join(dir, "ft06.txt")
+	flowShopPath := filepath.Join(dir, "tai3_2.txt")
+	require.NoError(t, os.WriteFile(jobShopPath, []byte(ft06), 0o644))
+	require.NoError(t, os.WriteFile(flowShopPath, []byte(taillardFlowShop), 0o644))
+
+	jobShops, err := LoadJobShop(jobShopPath)
+	require.NoError(t, err)
+	assert.Equal(t, "ft06", jobShops[0].Name)
+
+	flowShops, err := LoadFlowShop(flowShopPath)
+	require.NoError(t, err)
+	assert.Equal(t, "tai3_2", flowShops[0].Name)
+
+	_, err = LoadJobShop(filepath.Join(dir, "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = LoadFlowShop(filepath.Join(dir, "missing.txt"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
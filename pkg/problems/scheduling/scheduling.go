@@ -0,0 +1,242 @@
+// Package scheduling provides the job-shop and the permutation flow-shop scheduling problems:
+// parsers for the Taillard and OR-Library instance formats, decoders of permutation chromosomes
+// into schedules, the makespan and tardiness objectives and a Gantt chart export of schedules as SVG.
+//
+// Both problems use permutation chromosomes, so the permutation operators of the library such as
+// crossover.OrderCrossover, mutation.SimpleSwapMutator and localsearch.SwapHillClimber apply:
+//   - A flow-shop chromosome is the order in which the jobs pass the machines.
+//   - A job-shop chromosome is a permutation of the operation indices, operation-based encoding:
+//     every index stands for its job, and the k-th index of a job in the chromosome stands for
+//     the k-th operation of the job, so every permutation decodes to a feasible schedule.
+//
+// Fitness is maximized and is the negated objective value.
+package scheduling
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidSolution indicates that a chromosome does not encode a schedule of an instance.
+	ErrInvalidSolution = errors.New("invalid solution")
+	// ErrUnknownOptimum indicates that the optimal makespan of an instance is not known.
+	ErrUnknownOptimum = errors.New("unknown optimum")
+	// ErrMissingDueDates indicates that a tardiness objective is used for an instance without due dates.
+	ErrMissingDueDates = errors.New("missing due dates")
+)
+
+// SchedulingError represents an error that occurs while loading a scheduling instance or evaluating a schedule.
+type SchedulingError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *SchedulingError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *SchedulingError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewSchedulingError constructs a *SchedulingError with the provided message and wrapped error.
+func NewSchedulingError(message string, wrapped error) *SchedulingError {
+	return &SchedulingError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// Objective selects the quantity a schedule is evaluated by.
+type Objective int
+
+const (
+	// Makespan is the completion time of the last operation.
+	Makespan Objective = iota
+	// TotalTardiness is the sum of max(0, completion - due date) over the jobs.
+	TotalTardiness
+	// WeightedTardiness is the sum of the tardiness of the jobs weighted by their weights.
+	WeightedTardiness
+)
+
+// String returns the name of the objective.
+func (o Objective) String() string {
+	switch o {
+	case Makespan:
+		return "makespan"
+	case TotalTardiness:
+		return "total-tardiness"
+	case WeightedTardiness:
+		return "weighted-tardiness"
+	default:
+		return fmt.Sprintf("Objective(%d)", int(o))
+	}
+}
+
+// Decoder selects how a job-shop chromosome is turned into a schedule.
+type Decoder int
+
+const (
+	// SemiActive starts every operation in the order of the chromosome as early as its job and
+	// its machine allow, without moving it before operations already on the machine.
+	SemiActive Decoder = iota
+	// Active builds the schedule with the Giffler-Thompson algorithm, which only generates active
+	// schedules, in which no operation could start earlier without delaying another one. Among the
+	// operations competing for a machine it schedules the one which comes first in the chromosome.
+	// The set of active schedules always contains an optimal one and is much smaller.
+	Active
+)
+
+// String returns the name of the decoder.
+func (d Decoder) String() string {
+	switch d {
+	case SemiActive:
+		return "semi-active"
+	case Active:
+		return "active"
+	default:
+		return fmt.Sprintf("Decoder(%d)", int(d))
+	}
+}
+
+// Operation is a step of a job: processing on a machine for a duration.
+type Operation struct {
+	// Machine is the machine processing the operation, counting from 0.
+	Machine int
+	// Duration is the processing time.
+	Duration int
+}
+
+// ScheduledOperation is an operation placed in a schedule.
+type ScheduledOperation struct {
+	// Job is the job of the operation and Index the position of the operation within the job, counting from 0.
+	Job   int
+	Index int
+	// Machine is the machine processing the operation.
+	Machine int
+	// Start and End are the times the processing starts and ends.
+	Start int
+	End   int
+}
+
+// Schedule assigns start times to the operations of the jobs of an instance.
+type Schedule struct {
+	// Jobs and Machines are the numbers of jobs and machines of the instance.
+	Jobs     int
+	Machines int
+	// Operations holds the scheduled operations in the order they were scheduled.
+	Operations []ScheduledOperation
+}
+
+// Makespan returns the completion time of the last operation.
+func (s *Schedule) Makespan() int {
+	makespan := 0
+	for _, operation := range s.Operations {
+		makespan = max(makespan, operation.End)
+	}
+	return makespan
+}
+
+// Completions returns the completion time of every job.
+func (s *Schedule) Completions() []int {
+	completions := make([]int, s.Jobs)
+	for _, operation := range s.Operations {
+		completions[operation.Job] = max(completions[operation.Job], operation.End)
+	}
+	return completions
+}
+
+// Tardiness returns the sum of max(0, completion - due date) over the jobs, each weighted by its
+// weight. Weights may be nil, in which case every job weighs 1.
+func (s *Schedule) Tardiness(dueDates []int, weights []float64) float64 {
+	tardiness := 0.0
+	for job, completion := range s.Completions() {
+		weight := 1.0
+		if weights != nil {
+			weight = weights[job]
+		}
+		tardiness += weight * float64(max(0, completion-dueDates[job]))
+	}
+	return tardiness
+}
+
+// objectiveValue is a helper function evaluating a schedule by an objective, given the due dates
+// and weights of the jobs of its instance.
+func objectiveValue(schedule *Schedule, objective Objective, dueDates []int, weights []float64) float64 {
+	switch objective {
+	case TotalTardiness:
+		return schedule.Tardiness(dueDates, nil)
+	case WeightedTardiness:
+		return schedule.Tardiness(dueDates, weights)
+	default:
+		return float64(schedule.Makespan())
+	}
+}
+
+// checkObjective is a helper function checking that an instance with the given due dates and
+// weights supports the objective.
+func checkObjective(objective Objective, dueDates []int, weights []float64) error {
+	switch objective {
+	case Makespan:
+		return nil
+	case TotalTardiness, WeightedTardiness:
+		if dueDates == nil {
+			return fmt.Errorf("%w: objective %v requires due dates", ErrMissingDueDates, objective)
+		}
+		if objective == WeightedTardiness && weights == nil {
+			return fmt.Errorf("objective %v requires weights", objective)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown objective %v", objective)
+	}
+}
+
+// checkJobData is a helper function checking the due dates and weights of n jobs, which may be nil.
+func checkJobData(n int, dueDates []int, weights []float64) error {
+	if dueDates != nil && len(dueDates) != n {
+		return fmt.Errorf("expected %d due dates, got %d", n, len(dueDates))
+	}
+	if weights != nil && len(weights) != n {
+		return fmt.Errorf("expected %d weights, got %d", n, len(weights))
+	}
+	for job, weight := range weights {
+		if weight < 0 || math.IsNaN(weight) {
+			return fmt.Errorf("weight of job %d must not be negative, got %g", job, weight)
+		}
+	}
+	return nil
+}
+
+// gap is a helper function returning the relative gap of a makespan to the optimum.
+func gap(name string, makespan, optimum int) (float64, error) {
+	if optimum == 0 {
+		return 0, NewSchedulingError(fmt.Sprintf("cannot compute gap of instance %q", name), ErrUnknownOptimum)
+	}
+	return float64(makespan-optimum) / float64(optimum), nil
+}
+
+// checkPermutation is a helper function checking that order is a permutation of 0, ..., n-1.
+func checkPermutation(order []int, n int, what string) error {
+	if len(order) != n {
+		return fmt.Errorf("%w: expected a permutation of %d %s, got %d genes", ErrInvalidSolution, n, what, len(order))
+	}
+	seen := make([]bool, n)
+	for _, gene := range order {
+		if gene < 0 || gene >= n || seen[gene] {
+			return fmt.Errorf("%w: chromosome is not a permutation of the %s", ErrInvalidSolution, what)
+		}
+		seen[gene] = true
+	}
+	return nil
+}
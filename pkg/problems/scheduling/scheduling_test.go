@@ -0,0 +1,48 @@
+package scheduling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnums_String(t *testing.T) {
+	assert.Equal(t, "makespan", Makespan.String())
+	assert.Equal(t, "total-tardiness", TotalTardiness.String())
+	assert.Equal(t, "weighted-tardiness", WeightedTardiness.String())
+	assert.Equal(t, "Objective(7)", Objective(7).String())
+	assert.Equal(t, "semi-active", SemiActive.String())
+	assert.Equal(t, "active", Active.String())
+	assert.Equal(t, "Decoder(7)", Decoder(7).String())
+}
+
+func TestSchedule(t *testing.T) {
+	schedule := &Schedule{Jobs: 2, Machines: 2, Operations: []ScheduledOperation{
+		{Job: 1, Index: 0, Machine: 1, Start: 0, End: 2},
+		{Job: 0, Index: 0, Machine: 0, Start: 0, End: 3},
+		{Job: 0, Index: 1, Machine: 1, Start: 3, End: 5},
+		{Job: 1, Index: 1, Machine: 0, Start: 3, End: 7},
+	}}
+
+	t.Run("makespan and completions", func(t *testing.T) {
+		assert.Equal(t, 7, schedule.Makespan())
+		assert.Equal(t, []int{5, 7}, schedule.Completions())
+	})
+
+	t.Run("tardiness", func(t *testing.T) {
+		assert.Equal(t, 2.0, schedule.Tardiness([]int{4, 6}, nil))
+		assert.Equal(t, 3.0, schedule.Tardiness([]int{4, 6}, []float64{2, 1}))
+		assert.Equal(t, 0.0, schedule.Tardiness([]int{5, 10}, nil))
+	})
+
+	t.Run("objectives", func(t *testing.T) {
+		assert.Equal(t, 7.0, objectiveValue(schedule, Makespan, nil, nil))
+		assert.Equal(t, 2.0, objectiveValue(schedule, TotalTardiness, []int{4, 6}, []float64{2, 1}))
+		assert.Equal(t, 3.0, objectiveValue(schedule, WeightedTardiness, []int{4, 6}, []float64{2, 1}))
+
+		assert.NoError(t, checkObjective(Makespan, nil, nil))
+		assert.ErrorIs(t, checkObjective(TotalTardiness, nil, nil), ErrMissingDueDates)
+		assert.ErrorContains(t, checkObjective(WeightedTardiness, []int{4, 6}, nil), "requires weights")
+		assert.ErrorContains(t, checkObjective(Objective(7), nil, nil), "unknown objective")
+	})
+}
@@ -0,0 +1,176 @@
+package problems
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// orthogonalityTolerance is the largest deviation of R*R^T from the identity accepted for a rotation matrix.
+const orthogonalityTolerance = 1e-8
+
+// Transform creates the function g(x) = f(R(x - shift) + f.Optimum()) + bias in the style of the CEC and
+// BBOB suites. The global minimum of g lies at shift with the value f.OptimalValue() + bias, so an
+// algorithm cannot exploit the optimum being at the origin or on a diagonal, and a rotation makes
+// separable functions non-separable.
+//
+// The shift and the rotation move some points of the bounds outside the bounds of f, where functions
+// such as Schwefel fall below their optimal value, so the argument of f is clamped into its bounds.
+//
+// A nil shift keeps the optimum of f and a nil rotation applies no rotation. The shift must lie
+// within the bounds of f and the rotation must be an orthogonal Dimension x Dimension matrix.
+func Transform(f *Function, shift []float64, rotation [][]float64, bias float64) (*Function, error) {
+	if f == nil {
+		return nil, NewProblemError("cannot transform function", errors.New("function must not be nil"))
+	}
+	message := fmt.Sprintf("cannot transform %s function", f.name)
+	n := f.dimension
+
+	if shift == nil {
+		shift = f.optimum
+	}
+	if len(shift) != n {
+		return nil, NewProblemError(message, fmt.Errorf("%w: shift must have %d elements, got %d", ErrDimensionMismatch, n, len(shift)))
+	}
+	if !f.bounds.Contains(shift) {
+		return nil, NewProblemError(message, errors.New("shift must lie within the bounds"))
+	}
+	if rotation != nil {
+		if err := checkRotation(rotation, n); err != nil {
+			return nil, NewProblemError(message, err)
+		}
+	}
+
+	shift = slices.Clone(shift)
+	rotation = cloneMatrix(rotation)
+	base := f.optimum
+	lower, upper := f.bounds.Lower, f.bounds.Upper
+	value := f.value
+	transformed := func(x []float64) float64 {
+		z := make([]float64, n)
+		for i := range z {
+			if rotation == nil {
+				z[i] = x[i] - shift[i] + base[i]
+			} else {
+				sum := 0.0
+				for j := range x {
+					sum += rotation[i][j] * (x[j] - shift[j])
+				}
+				z[i] = sum + base[i]
+			}
+			z[i] = min(max(z[i], lower[i]), upper[i])
+		}
+		return value(z) + bias
+	}
+
+	name := f.name
+	if rotation != nil {
+		name = "rotated-" + name
+	}
+	if !slices.Equal(shift, f.optimum) {
+		name = "shifted-" + name
+	}
+	return &Function{
+		name:         name,
+		dimension:    n,
+		bounds:       f.Bounds(),
+		optimum:      shift,
+		optimalValue: f.optimalValue + bias,
+		value:        transformed,
+	}, nil
+}
+
+// checkRotation is a helper function verifying that rotation is an orthogonal n x n matrix.
+func checkRotation(rotation [][]float64, n int) error {
+	if len(rotation) != n {
+		return fmt.Errorf("%w: rotation must have %d rows, got %d", ErrDimensionMismatch, n, len(rotation))
+	}
+	for i, row := range rotation {
+		if len(row) != n {
+			return fmt.Errorf("%w: rotation row %d must have %d columns, got %d", ErrDimensionMismatch, i, n, len(row))
+		}
+	}
+	for i := range n {
+		for j := range n {
+			dot := 0.0
+			for k := range n {
+				dot += rotation[i][k] * rotation[j][k]
+			}
+			expected := 0.0
+			if i == j {
+				expected = 1
+			}
+			if math.Abs(dot-expected) > orthogonalityTolerance || math.IsNaN(dot) {
+				return errors.New("rotation must be an orthogonal matrix")
+			}
+		}
+	}
+	return nil
+}
+
+func cloneMatrix(matrix [][]float64) [][]float64 {
+	if matrix == nil {
+		return nil
+	}
+	cloned := make([][]float64, len(matrix))
+	for i, row := range matrix {
+		cloned[i] = slices.Clone(row)
+	}
+	return cloned
+}
+
+// RandomRotation draws a uniformly distributed random rotation of the given dimension by
+// orthonormalizing the rows of a Gaussian matrix with the Gram-Schmidt process.
+func RandomRotation(dimension int, rng *rand.Rand) [][]float64 {
+	rotation := make([][]float64, dimension)
+	for i := range rotation {
+		for {
+			row := make([]float64, dimension)
+			for j := range row {
+				row[j] = rng.NormFloat64()
+			}
+			for _, previous := range rotation[:i] {
+				dot := 0.0
+				for j := range row {
+					dot += row[j] * previous[j]
+				}
+				for j := range row {
+					row[j] -= dot * previous[j]
+				}
+			}
+			norm := 0.0
+			for _, value := range row {
+				norm += value * value
+			}
+			norm = math.Sqrt(norm)
+			// A row almost dependent on the previous ones would lose precision, so it is drawn again.
+			if norm < 1e-6 {
+				continue
+			}
+			for j := range row {
+				row[j] /= norm
+			}
+			rotation[i] = row
+			break
+		}
+	}
+	return rotation
+}
+
+// NewShiftedRotated creates a shifted and rotated variant of f with a random optimum within the
+// central 80% of its bounds and a random rotation, both drawn reproducibly from seed.
+func NewShiftedRotated(f *Function, seed int64) (*Function, error) {
+	if f == nil {
+		return nil, NewProblemError("cannot transform function", errors.New("function must not be nil"))
+	}
+	rng := rand.New(rand.NewSource(seed))
+	shift := make([]float64, f.dimension)
+	for i := range shift {
+		center := (f.bounds.Lower[i] + f.bounds.Upper[i]) / 2
+		radius := 0.8 * (f.bounds.Upper[i] - f.bounds.Lower[i]) / 2
+		shift[i] = center + radius*(2*rng.Float64()-1)
+	}
+	return Transform(f, shift, RandomRotation(f.dimension, rng), 0)
+}
@@ -0,0 +1,122 @@
+package problems
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform(t *testing.T) {
+	sphere, err := NewSphere(2)
+	require.NoError(t, err)
+
+	t.Run("moves the optimum to the shift and adds the bias", func(t *testing.T) {
+		shifted, err := Transform(sphere, []float64{1, -2}, nil, 100)
+		require.NoError(t, err)
+		assert.Equal(t, "shifted-sphere", shifted.Name())
+		assert.Equal(t, []float64{1, -2}, shifted.Optimum())
+		assert.Equal(t, 100.0, shifted.OptimalValue())
+		assert.Equal(t, 100.0, shifted.Value([]float64{1, -2}))
+		assert.Equal(t, 101.0, shifted.Value([]float64{2, -2}))
+		assert.Equal(t, 1.0, shifted.Gap([]float64{2, -2}))
+	})
+
+	t.Run("rotates around the optimum", func(t *testing.T) {
+		rosenbrock, err := NewRosenbrock(2)
+		require.NoError(t, err)
+		// A rotation by 90 degrees maps x - o = (0, 1) to (-1, 0).
+		rotated, err := Transform(rosenbrock, nil, [][]float64{{0, -1}, {1, 0}}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "rotated-rosenbrock", rotated.Name())
+		assert.Equal(t, []float64{1, 1}, rotated.Optimum())
+		assert.Equal(t, 0.0, rotated.Value([]float64{1, 1}))
+		assert.InDelta(t, rosenbrock.Value([]float64{0, 1}), rotated.Value([]float64{1, 2}), 1e-9)
+	})
+
+	t.Run("rejects invalid transformations", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			shift    []float64
+			rotation [][]float64
+			message  string
+		}{
+			{"shift of another dimension", []float64{1}, nil, "dimension mismatch"},
+			{"shift outside the bounds", []float64{10, 0}, nil, "shift must lie within the bounds"},
+			{"rotation of another dimension", nil, [][]float64{{1}}, "dimension mismatch"},
+			{"ragged rotation", nil, [][]float64{{1, 0}, {0}}, "dimension mismatch"},
+			{"scaling instead of rotation", nil, [][]float64{{2, 0}, {0, 1}}, "orthogonal"},
+			{"non-finite rotation", nil, [][]float64{{math.NaN(), 0}, {0, 1}}, "orthogonal"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := Transform(sphere, tc.shift, tc.rotation, 0)
+				var problemErr *ProblemError
+				require.ErrorAs(t, err, &problemErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+
+		_, err := Transform(nil, nil, nil, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("keeps the optimum of functions with an optimum near the border", func(t *testing.T) {
+		schwefel, err := NewSchwefel(1)
+		require.NoError(t, err)
+		shifted, err := Transform(schwefel, []float64{-400}, nil, 0)
+		require.NoError(t, err)
+		for x := -500.0; x <= 500; x += 0.25 {
+			assert.GreaterOrEqual(t, shifted.Gap([]float64{x}), 0.0, "x = %g", x)
+		}
+
+		schwefel, err = NewSchwefel(2)
+		require.NoError(t, err)
+		transformed, err := NewShiftedRotated(schwefel, 7)
+		require.NoError(t, err)
+		for x := -500.0; x <= 500; x += 5 {
+			for y := -500.0; y <= 500; y += 5 {
+				assert.GreaterOrEqual(t, transformed.Gap([]float64{x, y}), 0.0, "x = (%g, %g)", x, y)
+			}
+		}
+	})
+
+	t.Run("copies the transformation", func(t *testing.T) {
+		shift := []float64{1, 1}
+		shifted, err := Transform(sphere, shift, nil, 0)
+		require.NoError(t, err)
+		shift[0] = 0
+		assert.Equal(t, 0.0, shifted.Value([]float64{1, 1}))
+	})
+}
+
+func TestRandomRotation(t *testing.T) {
+	rotation := RandomRotation(6, rand.New(rand.NewSource(3)))
+	assert.NoError(t, checkRotation(rotation, 6))
+	assert.Equal(t, rotation, RandomRotation(6, rand.New(rand.NewSource(3))))
+}
+
+func TestNewShiftedRotated(t *testing.T) {
+	functions, err := Standard(4)
+	require.NoError(t, err)
+
+	for _, f := range functions {
+		t.Run(f.Name(), func(t *testing.T) {
+			transformed, err := NewShiftedRotated(f, 7)
+			require.NoError(t, err)
+			assert.Equal(t, "shifted-rotated-"+f.Name(), transformed.Name())
+			assert.True(t, transformed.Bounds().Contains(transformed.Optimum()))
+			assert.NotEqual(t, f.Optimum(), transformed.Optimum())
+			assert.InDelta(t, f.OptimalValue(), transformed.Value(transformed.Optimum()), 1e-9)
+
+			again, err := NewShiftedRotated(f, 7)
+			require.NoError(t, err)
+			assert.Equal(t, transformed.Optimum(), again.Optimum())
+		})
+	}
+
+	_, err = NewShiftedRotated(nil, 1)
+	assert.Error(t, err)
+}
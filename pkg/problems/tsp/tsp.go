@@ -0,0 +1,199 @@
+// Package tsp provides the traveling salesman problem as a benchmark for permutation operators:
+// a parser for TSPLIB instances and optimal tours, precomputed distance matrices and a
+// tour-length evaluator.
+//
+// Tours are permutations of the city indices 0, ..., Dimension-1 and are closed, i.e. the
+// salesman returns from the last city to the first. TSPLIB files number cities from 1; the
+// parser converts them. Fitness is maximized, so the evaluator returns the negated tour length.
+package tsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+)
+
+// ErrInvalidTour indicates that a tour is not a permutation of the cities of an instance.
+var ErrInvalidTour = errors.New("invalid tour")
+
+// TSPError represents an error that occurs while loading a TSP instance or evaluating a tour.
+type TSPError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *TSPError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *TSPError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewTSPError constructs a *TSPError with the provided message and wrapped error.
+func NewTSPError(message string, wrapped error) *TSPError {
+	return &TSPError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// Instance is a traveling salesman problem with a precomputed matrix of integer distances.
+type Instance struct {
+	// Name is the name of the instance, e.g. "berlin52".
+	Name string
+	// Comment is the free-form comment of the instance file.
+	Comment string
+	// EdgeWeightType is the TSPLIB distance function, e.g. "EUC_2D" or "EXPLICIT".
+	EdgeWeightType string
+	// Coordinates holds the position of every city, nil for instances given by an explicit matrix
+	// without display data.
+	Coordinates [][2]float64
+	distances   [][]int
+}
+
+// NewInstance creates an instance from a square matrix of distances, which is copied.
+// The matrix may be asymmetric.
+func NewInstance(name string, distances [][]int) (*Instance, error) {
+	if len(distances) < 2 {
+		return nil, NewTSPError("cannot create instance", fmt.Errorf("instance must have at least 2 cities, got %d", len(distances)))
+	}
+	copied := make([][]int, len(distances))
+	for i, row := range distances {
+		if len(row) != len(distances) {
+			return nil, NewTSPError("cannot create instance", fmt.Errorf("distance matrix must be square, but row %d has %d columns", i, len(row)))
+		}
+		copied[i] = append([]int{}, row...)
+	}
+	return &Instance{Name: name, EdgeWeightType: Explicit, distances: copied}, nil
+}
+
+// Dimension returns the number of cities of the instance.
+func (i *Instance) Dimension() int {
+	return len(i.distances)
+}
+
+// Distance returns the distance from city a to city b.
+func (i *Instance) Distance(a, b int) int {
+	return i.distances[a][b]
+}
+
+// Distances returns a copy of the distance matrix.
+func (i *Instance) Distances() [][]int {
+	copied := make([][]int, len(i.distances))
+	for a, row := range i.distances {
+		copied[a] = append([]int{}, row...)
+	}
+	return copied
+}
+
+// ValidateTour checks that tour visits every city of the instance exactly once.
+// Returns an error wrapping ErrInvalidTour otherwise.
+func (i *Instance) ValidateTour(tour []int) error {
+	n := i.Dimension()
+	if len(tour) != n {
+		return fmt.Errorf("%w: expected %d cities, got %d", ErrInvalidTour, n, len(tour))
+	}
+	visited := make([]bool, n)
+	for _, city := range tour {
+		if city < 0 || city >= n {
+			return fmt.Errorf("%w: city %d is out of range [0, %d)", ErrInvalidTour, city, n)
+		}
+		if visited[city] {
+			return fmt.Errorf("%w: city %d is visited twice", ErrInvalidTour, city)
+		}
+		visited[city] = true
+	}
+	return nil
+}
+
+// TourLength returns the length of the closed tour, which must be valid, see ValidateTour.
+func (i *Instance) TourLength(tour []int) int {
+	length := 0
+	for k := range tour {
+		length += i.distances[tour[k]][tour[(k+1)%len(tour)]]
+	}
+	return length
+}
+
+// Gap returns the relative optimality gap (length - optimal) / optimal of tour with respect to the
+// optimal tour, e.g. 0.05 for a tour 5% longer than the optimum. Both tours must be valid.
+// As for the package-level Gap, a longer tour has an infinite gap if the optimal tour has length 0.
+func (i *Instance) Gap(tour, optimal []int) (float64, error) {
+	if err := i.ValidateTour(tour); err != nil {
+		return 0, NewTSPError("cannot compute gap", err)
+	}
+	if err := i.ValidateTour(optimal); err != nil {
+		return 0, NewTSPError("cannot compute gap", fmt.Errorf("optimal tour: %w", err))
+	}
+	return Gap(i.TourLength(tour), i.TourLength(optimal)), nil
+}
+
+// Gap returns the relative optimality gap (length - optimal) / optimal of a tour length with
+// respect to a known optimal length, e.g. one published with the instance. The gap of a length
+// equal to the optimum is 0; for an optimum of 0, any other length has an infinite gap.
+func Gap(length, optimal int) float64 {
+	if length == optimal {
+		return 0
+	}
+	if optimal == 0 {
+		return math.Inf(1)
+	}
+	return float64(length-optimal) / float64(optimal)
+}
+
+// RandomTour returns a uniformly random tour. It can seed random populations, e.g.
+// builder.WithRandomPopulation(size, instance.RandomTour).
+func (i *Instance) RandomTour() []int {
+	return rand.Perm(i.Dimension())
+}
+
+// Evaluator evaluates tours of an instance to their negated length, so maximizing the fitness
+// minimizes the tour length.
+type Evaluator struct {
+	instance *Instance
+}
+
+var _ fitness.IFitnessEvaluator[int] = (*Evaluator)(nil)
+
+// NewEvaluator creates an Evaluator of tours of instance.
+func NewEvaluator(instance *Instance) *Evaluator {
+	return &Evaluator{instance: instance}
+}
+
+// Instance returns the evaluated instance.
+func (e *Evaluator) Instance() *Instance {
+	return e.instance
+}
+
+// Dimension returns the number of cities, which is the length of every tour.
+func (e *Evaluator) Dimension() int {
+	return e.instance.Dimension()
+}
+
+// Evaluate implements fitness.IFitnessEvaluator by returning the negated tour length.
+// Chromosomes which are not valid tours are rejected with an error wrapping ErrInvalidTour.
+func (e *Evaluator) Evaluate(ctx context.Context, chromosome *[]int) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if chromosome == nil {
+		return 0, NewTSPError("cannot evaluate tour", fmt.Errorf("%w: chromosome is nil", ErrInvalidTour))
+	}
+	if err := e.instance.ValidateTour(*chromosome); err != nil {
+		return 0, NewTSPError("cannot evaluate tour", err)
+	}
+	return -float64(e.instance.TourLength(*chromosome)), nil
+}
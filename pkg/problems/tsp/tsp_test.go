@@ -0,0 +1,162 @@
+package tsp
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/localsearch"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+)
+
+// square returns an instance of four cities on the corners of a unit square scaled by 10,
+// visited optimally in the order 0, 1, 2, 3.
+func square(t *testing.T) *Instance {
+	t.Helper()
+	instance, err := NewInstance("square", [][]int{
+		{0, 10, 14, 10},
+		{10, 0, 10, 14},
+		{14, 10, 0, 10},
+		{10, 14, 10, 0},
+	})
+	require.NoError(t, err)
+	return instance
+}
+
+func TestNewInstance(t *testing.T) {
+	t.Run("copies the distance matrix", func(t *testing.T) {
+		distances := [][]int{{0, 1}, {2, 0}}
+		instance, err := NewInstance("pair", distances)
+		require.NoError(t, err)
+		distances[0][1] = 5
+		instance.Distances()[1][0] = 5
+		assert.Equal(t, [][]int{{0, 1}, {2, 0}}, instance.Distances())
+		assert.Equal(t, Explicit, instance.EdgeWeightType)
+	})
+
+	t.Run("rejects invalid matrices", func(t *testing.T) {
+		_, err := NewInstance("single", [][]int{{0}})
+		assert.ErrorContains(t, err, "at least 2 cities")
+		_, err = NewInstance("ragged", [][]int{{0, 1}, {1}})
+		assert.ErrorContains(t, err, "must be square")
+	})
+}
+
+func TestInstance_Tours(t *testing.T) {
+	instance := square(t)
+
+	t.Run("tour length includes the way back", func(t *testing.T) {
+		assert.Equal(t, 40, instance.TourLength([]int{0, 1, 2, 3}))
+		assert.Equal(t, 48, instance.TourLength([]int{0, 2, 1, 3}))
+	})
+
+	t.Run("validates tours", func(t *testing.T) {
+		assert.NoError(t, instance.ValidateTour([]int{3, 1, 0, 2}))
+		for _, tour := range [][]int{{0, 1, 2}, {0, 1, 2, 2}, {0, 1, 2, 4}, {-1, 0, 1, 2}} {
+			assert.ErrorIs(t, instance.ValidateTour(tour), ErrInvalidTour)
+		}
+	})
+
+	t.Run("gap to the optimal tour", func(t *testing.T) {
+		gap, err := instance.Gap([]int{0, 2, 1, 3}, []int{0, 1, 2, 3})
+		require.NoError(t, err)
+		assert.InDelta(t, 0.2, gap, 1e-12)
+		assert.InDelta(t, 0.2, Gap(48, 40), 1e-12)
+
+		_, err = instance.Gap([]int{0, 1}, []int{0, 1, 2, 3})
+		assert.ErrorIs(t, err, ErrInvalidTour)
+		_, err = instance.Gap([]int{0, 1, 2, 3}, []int{0, 0, 2, 3})
+		assert.ErrorContains(t, err, "optimal tour")
+	})
+
+	t.Run("gap to an optimum of length 0", func(t *testing.T) {
+		// The tour 0, 1, 2 is free while the reverse tour costs 5 per edge
+		degenerate, err := NewInstance("degenerate", [][]int{{0, 0, 5}, {5, 0, 0}, {0, 5, 0}})
+		require.NoError(t, err)
+		gap, err := degenerate.Gap([]int{1, 2, 0}, []int{0, 1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, 0.0, gap)
+		gap, err = degenerate.Gap([]int{0, 2, 1}, []int{0, 1, 2})
+		require.NoError(t, err)
+		assert.True(t, math.IsInf(gap, 1))
+
+		assert.Equal(t, 0.0, Gap(0, 0))
+		assert.True(t, math.IsInf(Gap(15, 0), 1))
+	})
+
+	t.Run("random tours are valid", func(t *testing.T) {
+		for range 20 {
+			assert.NoError(t, instance.ValidateTour(instance.RandomTour()))
+		}
+	})
+}
+
+func TestEvaluator(t *testing.T) {
+	instance := square(t)
+	evaluator := NewEvaluator(instance)
+	assert.Same(t, instance, evaluator.Instance())
+	assert.Equal(t, 4, evaluator.Dimension())
+
+	t.Run("negates the tour length", func(t *testing.T) {
+		tour := []int{0, 2, 1, 3}
+		fitness, err := evaluator.Evaluate(context.Background(), &tour)
+		require.NoError(t, err)
+		assert.Equal(t, -48.0, fitness)
+	})
+
+	t.Run("rejects invalid tours", func(t *testing.T) {
+		tour := []int{0, 0, 1, 2}
+		_, err := evaluator.Evaluate(context.Background(), &tour)
+		assert.ErrorIs(t, err, ErrInvalidTour)
+		var tspErr *TSPError
+		assert.ErrorAs(t, err, &tspErr)
+
+		_, err = evaluator.Evaluate(context.Background(), nil)
+		assert.ErrorIs(t, err, ErrInvalidTour)
+	})
+
+	t.Run("stops on cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		tour := []int{0, 1, 2, 3}
+		_, err := evaluator.Evaluate(ctx, &tour)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestEvaluator_GeneticAlgorithm(t *testing.T) {
+	instance := parse(t, burma14)
+	tour, err := ParseTour(strings.NewReader(burma14Tour))
+	require.NoError(t, err)
+
+	ga, err := executor.NewBuilder[int]().
+		WithRandomPopulation(30, instance.RandomTour).
+		WithEvaluator(NewEvaluator(instance)).
+		WithCrossover(crossover.NewOrderCrossover[int]()).
+		WithMutator(mutation.NewSimpleSwapMutator[int](0.2)).
+		WithLocalSearch(localsearch.NewTwoOptHillClimber[int](), localsearch.Options{Probability: 0.2, Mode: localsearch.Lamarckian, Budget: 200}).
+		WithGenerations(20).
+		Build()
+	require.NoError(t, err)
+	ga.SetProgress(false)
+
+	population, err := ga.Run(context.Background())
+	require.NoError(t, err)
+	best := slices.MaxFunc(population.Individuals, func(a, b core.Solution[int]) int {
+		return cmp.Compare(a.Fitness, b.Fitness)
+	})
+	require.NoError(t, instance.ValidateTour(best.Chromosome))
+	gap, err := instance.Gap(best.Chromosome, tour.Cities)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, gap, 0.0)
+	assert.Less(t, gap, 0.1)
+}
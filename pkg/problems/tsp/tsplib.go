@@ -0,0 +1,406 @@
+package tsp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Edge weight types of TSPLIB instances supported by Parse.
+const (
+	// Euclidean2D rounds the Euclidean distance to the nearest integer.
+	Euclidean2D = "EUC_2D"
+	// Ceil2D rounds the Euclidean distance up to the next integer.
+	Ceil2D = "CEIL_2D"
+	// Geographical treats coordinates as latitude and longitude in DDD.MM format and
+	// computes distances in kilometers on an idealized sphere.
+	Geographical = "GEO"
+	// PseudoEuclidean is the pseudo-Euclidean distance of the att48 and att532 instances.
+	PseudoEuclidean = "ATT"
+	// Explicit instances list their distances in an EDGE_WEIGHT_SECTION.
+	Explicit = "EXPLICIT"
+)
+
+// Edge weight formats of explicit TSPLIB instances supported by Parse. The column-wise formats of
+// symmetric instances are read as the transposed row-wise formats.
+const (
+	FullMatrix   = "FULL_MATRIX"
+	UpperRow     = "UPPER_ROW"
+	LowerRow     = "LOWER_ROW"
+	UpperDiagRow = "UPPER_DIAG_ROW"
+	LowerDiagRow = "LOWER_DIAG_ROW"
+	UpperCol     = "UPPER_COL"
+	LowerCol     = "LOWER_COL"
+	UpperDiagCol = "UPPER_DIAG_COL"
+	LowerDiagCol = "LOWER_DIAG_COL"
+)
+
+// sections are the keywords of TSPLIB files which are followed by data lines.
+var sections = map[string]bool{
+	"NODE_COORD_SECTION":   true,
+	"EDGE_WEIGHT_SECTION":  true,
+	"DISPLAY_DATA_SECTION": true,
+	"FIXED_EDGES_SECTION":  true,
+	"TOUR_SECTION":         true,
+}
+
+// document is a TSPLIB file split into its specification entries and the fields of its data sections.
+type document struct {
+	header   map[string]string
+	sections map[string][]string
+}
+
+// readDocument is a helper function splitting a TSPLIB file into specification entries such as
+// "DIMENSION : 14" and the whitespace-separated fields of every data section.
+func readDocument(r io.Reader) (*document, error) {
+	doc := &document{header: make(map[string]string), sections: make(map[string][]string)}
+	scanner := bufio.NewScanner(r)
+	section := ""
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if text == "EOF" {
+			break
+		}
+		keyword := strings.TrimSpace(strings.TrimSuffix(text, ":"))
+		if sections[keyword] {
+			section = keyword
+			if _, duplicate := doc.sections[section]; duplicate {
+				return nil, fmt.Errorf("line %d: section %s given twice", line, section)
+			}
+			doc.sections[section] = []string{}
+			continue
+		}
+		if key, value, found := strings.Cut(text, ":"); found && !isNumber(strings.Fields(text)[0]) {
+			doc.header[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			section = ""
+			continue
+		}
+		if section == "" {
+			return nil, fmt.Errorf("line %d: unexpected data %q outside of a section", line, text)
+		}
+		doc.sections[section] = append(doc.sections[section], strings.Fields(text)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func isNumber(field string) bool {
+	_, err := strconv.ParseFloat(field, 64)
+	return err == nil
+}
+
+// dimension is a helper function reading the DIMENSION entry.
+func (d *document) dimension() (int, error) {
+	value, ok := d.header["DIMENSION"]
+	if !ok {
+		return 0, errors.New("missing DIMENSION")
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 2 {
+		return 0, fmt.Errorf("DIMENSION must be an integer of at least 2, got %q", value)
+	}
+	return n, nil
+}
+
+// Load reads a TSPLIB instance from the file at path, see Parse.
+func Load(path string) (*Instance, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewTSPError("cannot load instance", err)
+	}
+	defer file.Close()
+	return Parse(file)
+}
+
+// Parse reads a symmetric (TYPE: TSP) or asymmetric (TYPE: ATSP) TSPLIB instance and precomputes
+// its distance matrix. Supported edge weight types are EUC_2D, CEIL_2D, GEO and ATT with a
+// NODE_COORD_SECTION, and EXPLICIT with an EDGE_WEIGHT_SECTION in any of the formats FULL_MATRIX,
+// UPPER_ROW, LOWER_ROW, UPPER_DIAG_ROW, LOWER_DIAG_ROW and their column-wise counterparts.
+// Asymmetric instances must use FULL_MATRIX.
+func Parse(r io.Reader) (*Instance, error) {
+	doc, err := readDocument(r)
+	if err != nil {
+		return nil, NewTSPError("cannot parse instance", err)
+	}
+	instance, err := doc.instance()
+	if err != nil {
+		return nil, NewTSPError(fmt.Sprintf("cannot parse instance %q", doc.header["NAME"]), err)
+	}
+	return instance, nil
+}
+
+// instance is a helper function building the instance described by a TSPLIB document.
+func (d *document) instance() (*Instance, error) {
+	problemType := d.header["TYPE"]
+	if problemType != "TSP" && problemType != "ATSP" {
+		return nil, fmt.Errorf("TYPE must be TSP or ATSP, got %q", problemType)
+	}
+	n, err := d.dimension()
+	if err != nil {
+		return nil, err
+	}
+	instance := &Instance{Name: d.header["NAME"], Comment: d.header["COMMENT"], EdgeWeightType: d.header["EDGE_WEIGHT_TYPE"]}
+
+	switch instance.EdgeWeightType {
+	case Euclidean2D, Ceil2D, Geographical, PseudoEuclidean:
+		if problemType != "TSP" {
+			return nil, fmt.Errorf("EDGE_WEIGHT_TYPE %s requires TYPE TSP", instance.EdgeWeightType)
+		}
+		if coordType, ok := d.header["NODE_COORD_TYPE"]; ok && coordType != "TWOD_COORDS" {
+			return nil, fmt.Errorf("NODE_COORD_TYPE %s is not supported", coordType)
+		}
+		instance.Coordinates, err = d.coordinates("NODE_COORD_SECTION", n)
+		if err != nil {
+			return nil, err
+		}
+		instance.distances = coordinateDistances(instance.Coordinates, distanceFunctions[instance.EdgeWeightType])
+	case Explicit:
+		format := d.header["EDGE_WEIGHT_FORMAT"]
+		if problemType == "ATSP" && format != FullMatrix {
+			return nil, fmt.Errorf("TYPE ATSP requires EDGE_WEIGHT_FORMAT %s, got %q", FullMatrix, format)
+		}
+		instance.distances, err = explicitDistances(d.sections["EDGE_WEIGHT_SECTION"], n, format)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := d.sections["DISPLAY_DATA_SECTION"]; ok {
+			instance.Coordinates, err = d.coordinates("DISPLAY_DATA_SECTION", n)
+			if err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("EDGE_WEIGHT_TYPE %q is not supported", instance.EdgeWeightType)
+	}
+	return instance, nil
+}
+
+// coordinates is a helper function reading the "index x y" lines of a section for every city.
+func (d *document) coordinates(section string, n int) ([][2]float64, error) {
+	fields, ok := d.sections[section]
+	if !ok {
+		return nil, fmt.Errorf("missing %s", section)
+	}
+	if len(fields) != 3*n {
+		return nil, fmt.Errorf("%s must list %d cities as \"index x y\", got %d values", section, n, len(fields))
+	}
+	coordinates := make([][2]float64, n)
+	seen := make([]bool, n)
+	for k := 0; k < len(fields); k += 3 {
+		index, err := strconv.Atoi(fields[k])
+		if err != nil || index < 1 || index > n {
+			return nil, fmt.Errorf("%s: city index must be within [1, %d], got %q", section, n, fields[k])
+		}
+		if seen[index-1] {
+			return nil, fmt.Errorf("%s: city %d given twice", section, index)
+		}
+		seen[index-1] = true
+		for axis := range 2 {
+			value, err := strconv.ParseFloat(fields[k+1+axis], 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid coordinate %q of city %d", section, fields[k+1+axis], index)
+			}
+			coordinates[index-1][axis] = value
+		}
+	}
+	return coordinates, nil
+}
+
+// distanceFunctions are the TSPLIB distance functions between two coordinates.
+var distanceFunctions = map[string]func(a, b [2]float64) int{
+	Euclidean2D: func(a, b [2]float64) int {
+		return int(math.Floor(math.Hypot(a[0]-b[0], a[1]-b[1]) + 0.5))
+	},
+	Ceil2D: func(a, b [2]float64) int {
+		return int(math.Ceil(math.Hypot(a[0]-b[0], a[1]-b[1])))
+	},
+	PseudoEuclidean: func(a, b [2]float64) int {
+		dx, dy := a[0]-b[0], a[1]-b[1]
+		r := math.Sqrt((dx*dx + dy*dy) / 10)
+		t := math.Floor(r + 0.5)
+		if t < r {
+			t++
+		}
+		return int(t)
+	},
+	Geographical: func(a, b [2]float64) int {
+		const earthRadius = 6378.388
+		latA, lonA := geoRadians(a[0]), geoRadians(a[1])
+		latB, lonB := geoRadians(b[0]), geoRadians(b[1])
+		q1 := math.Cos(lonA - lonB)
+		q2 := math.Cos(latA - latB)
+		q3 := math.Cos(latA + latB)
+		return int(earthRadius*math.Acos(0.5*((1+q1)*q2-(1-q1)*q3)) + 1)
+	},
+}
+
+// geoRadians converts a TSPLIB coordinate in DDD.MM format, degrees followed by minutes, to radians.
+// Like the reference implementations used to compute the published optima, the degrees are truncated
+// and pi is approximated by 3.141592.
+func geoRadians(coordinate float64) float64 {
+	const pi = 3.141592
+	degrees := math.Trunc(coordinate)
+	minutes := coordinate - degrees
+	return pi * (degrees + 5*minutes/3) / 180
+}
+
+// coordinateDistances is a helper function computing the symmetric distance matrix of the coordinates.
+func coordinateDistances(coordinates [][2]float64, distance func(a, b [2]float64) int) [][]int {
+	n := len(coordinates)
+	distances := make([][]int, n)
+	for i := range distances {
+		distances[i] = make([]int, n)
+	}
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			d := distance(coordinates[i], coordinates[j])
+			distances[i][j], distances[j][i] = d, d
+		}
+	}
+	return distances
+}
+
+// explicitDistances is a helper function filling the distance matrix from the values of an
+// EDGE_WEIGHT_SECTION listed in the given format.
+func explicitDistances(fields []string, n int, format string) ([][]int, error) {
+	if fields == nil {
+		return nil, errors.New("missing EDGE_WEIGHT_SECTION")
+	}
+	// Column-wise formats of symmetric matrices list the same values as the transposed row-wise formats.
+	switch format {
+	case UpperCol:
+		format = LowerRow
+	case LowerCol:
+		format = UpperRow
+	case UpperDiagCol:
+		format = LowerDiagRow
+	case LowerDiagCol:
+		format = UpperDiagRow
+	}
+
+	var cells [][2]int
+	for i := range n {
+		var from, to int
+		switch format {
+		case FullMatrix:
+			from, to = 0, n
+		case UpperRow:
+			from, to = i+1, n
+		case LowerRow:
+			from, to = 0, i
+		case UpperDiagRow:
+			from, to = i, n
+		case LowerDiagRow:
+			from, to = 0, i+1
+		default:
+			return nil, fmt.Errorf("EDGE_WEIGHT_FORMAT %q is not supported", format)
+		}
+		for j := from; j < to; j++ {
+			cells = append(cells, [2]int{i, j})
+		}
+	}
+	if len(fields) != len(cells) {
+		return nil, fmt.Errorf("EDGE_WEIGHT_SECTION in format %s must have %d values for %d cities, got %d", format, len(cells), n, len(fields))
+	}
+
+	distances := make([][]int, n)
+	for i := range distances {
+		distances[i] = make([]int, n)
+	}
+	for k, cell := range cells {
+		value, err := strconv.ParseFloat(fields[k], 64)
+		if err != nil || value != math.Trunc(value) {
+			return nil, fmt.Errorf("EDGE_WEIGHT_SECTION: distance must be an integer, got %q", fields[k])
+		}
+		i, j := cell[0], cell[1]
+		distances[i][j] = int(value)
+		if format != FullMatrix {
+			distances[j][i] = int(value)
+		}
+	}
+	return distances, nil
+}
+
+// Tour is a tour read from a TSPLIB tour file, usually the optimal tour of an instance.
+type Tour struct {
+	// Name is the name of the tour, e.g. "berlin52.opt.tour".
+	Name string
+	// Comment is the free-form comment of the tour file, which often states the tour length.
+	Comment string
+	// Cities are the visited cities, numbered from 0.
+	Cities []int
+}
+
+// LoadTour reads a TSPLIB tour from the file at path, see ParseTour.
+func LoadTour(path string) (*Tour, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, NewTSPError("cannot load tour", err)
+	}
+	defer file.Close()
+	return ParseTour(file)
+}
+
+// ParseTour reads a TSPLIB tour file (TYPE: TOUR) such as the .opt.tour files of the TSPLIB library.
+// The cities of its TOUR_SECTION, which ends with -1, are converted to indices starting at 0.
+func ParseTour(r io.Reader) (*Tour, error) {
+	doc, err := readDocument(r)
+	if err != nil {
+		return nil, NewTSPError("cannot parse tour", err)
+	}
+	tour, err := doc.tour()
+	if err != nil {
+		return nil, NewTSPError(fmt.Sprintf("cannot parse tour %q", doc.header["NAME"]), err)
+	}
+	return tour, nil
+}
+
+// tour is a helper function building the tour described by a TSPLIB document.
+func (d *document) tour() (*Tour, error) {
+	if d.header["TYPE"] != "TOUR" {
+		return nil, fmt.Errorf("TYPE must be TOUR, got %q", d.header["TYPE"])
+	}
+	fields, ok := d.sections["TOUR_SECTION"]
+	if !ok {
+		return nil, errors.New("missing TOUR_SECTION")
+	}
+	tour := &Tour{Name: d.header["NAME"], Comment: d.header["COMMENT"]}
+	terminated := false
+	for _, field := range fields {
+		city, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("TOUR_SECTION: invalid city %q", field)
+		}
+		if city == -1 {
+			terminated = true
+			break
+		}
+		if city < 1 {
+			return nil, fmt.Errorf("TOUR_SECTION: cities are numbered from 1, got %d", city)
+		}
+		tour.Cities = append(tour.Cities, city-1)
+	}
+	if !terminated {
+		return nil, errors.New("TOUR_SECTION must end with -1")
+	}
+	if _, ok := d.header["DIMENSION"]; ok {
+		n, err := d.dimension()
+		if err != nil {
+			return nil, err
+		}
+		if n != len(tour.Cities) {
+			return nil, fmt.Errorf("DIMENSION is %d, but TOUR_SECTION lists %d cities", n, len(tour.Cities))
+		}
+	}
+	return tour, nil
+}
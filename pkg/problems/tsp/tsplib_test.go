@@ -0,0 +1,273 @@
+package tsp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// burma14 is the burma14 instance of TSPLIB, whose optimal tour has length 3323.
+const burma14 = `NAME: burma14
+TYPE: TSP
+COMMENT: 14-Staedte in Burma (Zaw Win)
+DIMENSION: 14
+EDGE_WEIGHT_TYPE: GEO
+EDGE_WEIGHT_FORMAT: FUNCTION
+DISPLAY_DATA_TYPE: COORD_DISPLAY
+NODE_COORD_SECTION
+   1  16.47       96.10
+   2  16.47       94.44
+   3  20.09       92.54
+   4  22.39       93.37
+   5  25.23       97.24
+   6  22.00       96.05
+   7  20.47       97.02
+   8  17.20       96.29
+   9  16.30       97.38
+  10  14.05       98.12
+  11  16.53       97.38
+  12  21.52       95.59
+  13  19.41       97.13
+  14  20.09       94.55
+EOF
+`
+
+// burma14Tour is the optimal tour of burma14.
+const burma14Tour = `NAME : burma14.opt.tour
+COMMENT : Optimal tour for burma14 (3323)
+TYPE : TOUR
+DIMENSION : 14
+TOUR_SECTION
+1 2 14 3 4 5 6 12 7 13 8 11 9 10
+-1
+EOF
+`
+
+// parse is a helper function parsing an instance which is expected to be valid.
+func parse(t *testing.T, text string) *Instance {
+	t.Helper()
+	instance, err := Parse(strings.NewReader(text))
+	require.NoError(t, err)
+	return instance
+}
+
+func TestParse(t *testing.T) {
+	t.Run("geographical instance", func(t *testing.T) {
+		instance := parse(t, burma14)
+		assert.Equal(t, "burma14", instance.Name)
+		assert.Equal(t, "14-Staedte in Burma (Zaw Win)", instance.Comment)
+		assert.Equal(t, Geographical, instance.EdgeWeightType)
+		assert.Equal(t, 14, instance.Dimension())
+		assert.Equal(t, [2]float64{20.09, 94.55}, instance.Coordinates[13])
+		assert.Equal(t, 153, instance.Distance(0, 1))
+		assert.Equal(t, 0, instance.Distance(3, 3))
+
+		tour, err := ParseTour(strings.NewReader(burma14Tour))
+		require.NoError(t, err)
+		assert.Equal(t, "burma14.opt.tour", tour.Name)
+		assert.Equal(t, []int{0, 1, 13, 2, 3, 4, 5, 11, 6, 12, 7, 10, 8, 9}, tour.Cities)
+		assert.Equal(t, 3323, instance.TourLength(tour.Cities))
+		assert.Equal(t, 3323, shortestTour(instance))
+	})
+
+	t.Run("euclidean instances", func(t *testing.T) {
+		const square = `NAME: square
+TYPE: TSP
+DIMENSION: 4
+EDGE_WEIGHT_TYPE: %s
+NODE_COORD_SECTION
+1 0 0
+2 3 4
+3 0 8.6
+4 -3 4
+EOF`
+		testCases := []struct {
+			edgeWeightType string
+			distances      []int
+		}{
+			// The distances from city 1 to cities 2, 3 and 4 are 5, 8.6 and 5.
+			{Euclidean2D, []int{5, 9, 5}},
+			{Ceil2D, []int{5, 9, 5}},
+			// sqrt(25/10) = 1.58 and sqrt(73.96/10) = 2.72 are rounded up.
+			{PseudoEuclidean, []int{2, 3, 2}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.edgeWeightType, func(t *testing.T) {
+				instance := parse(t, strings.Replace(square, "%s", tc.edgeWeightType, 1))
+				assert.Equal(t, tc.distances, instance.Distances()[0][1:])
+				assert.Equal(t, instance.Distance(1, 0), instance.Distance(0, 1))
+			})
+		}
+
+		ceil := parse(t, strings.Replace(strings.Replace(square, "%s", Ceil2D, 1), "0 8.6", "0 8.2", 1))
+		euclidean := parse(t, strings.Replace(strings.Replace(square, "%s", Euclidean2D, 1), "0 8.6", "0 8.2", 1))
+		assert.Equal(t, 9, ceil.Distance(0, 2))
+		assert.Equal(t, 8, euclidean.Distance(0, 2))
+	})
+
+	t.Run("explicit matrices", func(t *testing.T) {
+		// All formats describe the symmetric matrix [[0 1 2] [1 0 3] [2 3 0]].
+		testCases := []struct {
+			format string
+			values string
+		}{
+			{FullMatrix, "0 1 2\n1 0 3\n2 3 0"},
+			{UpperRow, "1 2\n3"},
+			{LowerRow, "1\n2 3"},
+			{UpperDiagRow, "0 1 2 0 3 0"},
+			{LowerDiagRow, "0 1 0 2 3 0"},
+			{UpperCol, "1 2 3"},
+			{LowerCol, "1 2 3"},
+			{UpperDiagCol, "0 1 0 2 3 0"},
+			{LowerDiagCol, "0 1 2 0 3 0"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.format, func(t *testing.T) {
+				instance := parse(t, "NAME: small\nTYPE: TSP\nDIMENSION: 3\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: "+tc.format+"\nEDGE_WEIGHT_SECTION\n"+tc.values+"\nEOF\n")
+				assert.Equal(t, [][]int{{0, 1, 2}, {1, 0, 3}, {2, 3, 0}}, instance.Distances())
+				assert.Nil(t, instance.Coordinates)
+			})
+		}
+	})
+
+	t.Run("asymmetric instance with display data", func(t *testing.T) {
+		instance := parse(t, `NAME: asymmetric
+TYPE: ATSP
+DIMENSION: 2
+EDGE_WEIGHT_TYPE: EXPLICIT
+EDGE_WEIGHT_FORMAT: FULL_MATRIX
+EDGE_WEIGHT_SECTION
+0 4
+7 0
+DISPLAY_DATA_SECTION
+1 0 0
+2 1 1
+`)
+		assert.Equal(t, 4, instance.Distance(0, 1))
+		assert.Equal(t, 7, instance.Distance(1, 0))
+		assert.Equal(t, [][2]float64{{0, 0}, {1, 1}}, instance.Coordinates)
+	})
+
+	t.Run("invalid instances", func(t *testing.T) {
+		valid := "NAME: x\nTYPE: TSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: EUC_2D\nNODE_COORD_SECTION\n1 0 0\n2 1 1\n"
+		testCases := []struct {
+			name    string
+			text    string
+			message string
+		}{
+			{"wrong type", strings.Replace(valid, "TYPE: TSP", "TYPE: CVRP", 1), "TYPE must be TSP or ATSP"},
+			{"missing dimension", strings.Replace(valid, "DIMENSION: 2\n", "", 1), "missing DIMENSION"},
+			{"invalid dimension", strings.Replace(valid, "DIMENSION: 2", "DIMENSION: two", 1), "DIMENSION must be an integer"},
+			{"unsupported edge weight type", strings.Replace(valid, "EUC_2D", "EUC_3D", 1), "EDGE_WEIGHT_TYPE \"EUC_3D\" is not supported"},
+			{"missing cities", strings.Replace(valid, "2 1 1\n", "", 1), "must list 2 cities"},
+			{"duplicate city", strings.Replace(valid, "2 1 1", "1 1 1", 1), "city 1 given twice"},
+			{"invalid coordinate", strings.Replace(valid, "2 1 1", "2 1 y", 1), "invalid coordinate"},
+			{"data outside of a section", "1 2 3\n" + valid, "outside of a section"},
+			{"asymmetric coordinates", strings.Replace(valid, "TYPE: TSP", "TYPE: ATSP", 1), "requires TYPE TSP"},
+			{"missing edge weights", "NAME: x\nTYPE: TSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: FULL_MATRIX\n", "missing EDGE_WEIGHT_SECTION"},
+			{"unsupported format", "NAME: x\nTYPE: TSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: FUNCTION\nEDGE_WEIGHT_SECTION\n1\n", "EDGE_WEIGHT_FORMAT \"FUNCTION\" is not supported"},
+			{"too few edge weights", "NAME: x\nTYPE: TSP\nDIMENSION: 3\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEDGE_WEIGHT_SECTION\n1 2\n", "must have 3 values"},
+			{"fractional edge weight", "NAME: x\nTYPE: TSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEDGE_WEIGHT_SECTION\n1.5\n", "must be an integer"},
+			{"asymmetric triangle", "NAME: x\nTYPE: ATSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEDGE_WEIGHT_SECTION\n1\n", "requires EDGE_WEIGHT_FORMAT FULL_MATRIX"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := Parse(strings.NewReader(tc.text))
+				var tspErr *TSPError
+				require.ErrorAs(t, err, &tspErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestParseTour(t *testing.T) {
+	t.Run("cities may span several lines", func(t *testing.T) {
+		tour, err := ParseTour(strings.NewReader("TYPE: TOUR\nTOUR_SECTION\n3\n1\n2\n-1\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []int{2, 0, 1}, tour.Cities)
+	})
+
+	t.Run("invalid tours", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			text    string
+			message string
+		}{
+			{"wrong type", "TYPE: TSP\nTOUR_SECTION\n1 2 -1\n", "TYPE must be TOUR"},
+			{"missing section", "TYPE: TOUR\n", "missing TOUR_SECTION"},
+			{"unterminated", "TYPE: TOUR\nTOUR_SECTION\n1 2\n", "must end with -1"},
+			{"invalid city", "TYPE: TOUR\nTOUR_SECTION\n1 x -1\n", "invalid city"},
+			{"city numbered from 0", "TYPE: TOUR\nTOUR_SECTION\n0 1 -1\n", "numbered from 1"},
+			{"wrong dimension", "TYPE: TOUR\nDIMENSION: 3\nTOUR_SECTION\n1 2 -1\n", "DIMENSION is 3"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := ParseTour(strings.NewReader(tc.text))
+				var tspErr *TSPError
+				require.ErrorAs(t, err, &tspErr)
+				assert.ErrorContains(t, err, tc.message)
+			})
+		}
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	instancePath := filepath.Join(dir, "burma14.tsp")
+	tourPath := filepath.Join(dir, "burma14.opt.tour")
+	require.NoError(t, os.WriteFile(instancePath, []byte(burma14), 0o644))
+	require.NoError(t, os.WriteFile(tourPath, []byte(burma14Tour), 0o644))
+
+	instance, err := Load(instancePath)
+	require.NoError(t, err)
+	tour, err := LoadTour(tourPath)
+	require.NoError(t, err)
+	gap, err := instance.Gap(tour.Cities, tour.Cities)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, gap)
+
+	_, err = Load(filepath.Join(dir, "missing.tsp"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = LoadTour(filepath.Join(dir, "missing.opt.tour"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+// shortestTour is a helper function computing the length of the optimal tour of a small instance
+// with the Held-Karp dynamic program.
+func shortestTour(instance *Instance) int {
+	n := instance.Dimension()
+	const unreachable = int(^uint(0) >> 1)
+	// best[set][last] is the shortest path from city 0 visiting the cities of set and ending at last.
+	best := make([][]int, 1<<n)
+	for set := range best {
+		best[set] = make([]int, n)
+		for last := range best[set] {
+			best[set][last] = unreachable
+		}
+	}
+	best[1][0] = 0
+	for set := 1; set < 1<<n; set += 2 {
+		for last := range n {
+			if best[set][last] == unreachable {
+				continue
+			}
+			for next := 1; next < n; next++ {
+				if set&(1<<next) != 0 {
+					continue
+				}
+				extended := set | 1<<next
+				best[extended][next] = min(best[extended][next], best[set][last]+instance.Distance(last, next))
+			}
+		}
+	}
+	shortest := unreachable
+	for last := 1; last < n; last++ {
+		shortest = min(shortest, best[1<<n-1][last]+instance.Distance(last, 0))
+	}
+	return shortest
+}
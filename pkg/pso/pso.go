@@ -0,0 +1,386 @@
+// Package pso provides a particle swarm optimizer for continuous problems.
+package pso
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/observer"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+// Topology selects which particles share their best positions.
+type Topology int
+
+const (
+	// GlobalBest lets every particle follow the best position found by the whole swarm.
+	GlobalBest Topology = iota
+	// Ring lets every particle follow the best position found by its Config.Neighbors
+	// nearest neighbors on each side of a ring, which slows down convergence but
+	// explores multimodal landscapes better.
+	Ring
+)
+
+// String returns the name of the topology.
+func (t Topology) String() string {
+	switch t {
+	case GlobalBest:
+		return "gbest"
+	case Ring:
+		return "ring"
+	default:
+		return fmt.Sprintf("Topology(%d)", int(t))
+	}
+}
+
+// Variant selects the velocity update rule.
+type Variant int
+
+const (
+	// InertiaWeight updates v = w·v + c1·r1·(p - x) + c2·r2·(g - x), with w decreasing linearly
+	// from Config.InertiaStart to Config.InertiaEnd over Config.MaxIterations.
+	InertiaWeight Variant = iota
+	// Constriction updates v = χ·(v + c1·r1·(p - x) + c2·r2·(g - x)) with Clerc's constriction
+	// coefficient χ derived from φ = c1 + c2 > 4.
+	Constriction
+)
+
+// String returns the name of the variant.
+func (v Variant) String() string {
+	switch v {
+	case InertiaWeight:
+		return "inertia"
+	case Constriction:
+		return "constriction"
+	default:
+		return fmt.Sprintf("Variant(%d)", int(v))
+	}
+}
+
+// Config holds the parameters of a particle swarm optimization run.
+type Config struct {
+	// Bounds delimit the search space. Particles are initialized uniformly within them and
+	// positions leaving them are repaired with BoundaryHandling, zeroing the offending velocity.
+	Bounds *core.Bounds
+	// BoundaryHandling selects how positions leaving Bounds are repaired.
+	BoundaryHandling core.BoundaryHandling
+	// SwarmSize is the number of particles. 0 selects 40.
+	SwarmSize int
+	// Topology selects the neighborhood structure.
+	Topology Topology
+	// Neighbors is the number of neighbors on each side of a particle in the ring topology. 0 selects 1.
+	Neighbors int
+	// Variant selects the velocity update rule.
+	Variant Variant
+	// InertiaStart and InertiaEnd delimit the linearly decreasing inertia weight of the InertiaWeight variant.
+	// When both are 0, they select 0.9 and 0.4.
+	InertiaStart, InertiaEnd float64
+	// C1 and C2 are the cognitive and social acceleration coefficients.
+	C1, C2 float64
+	// VelocityClamp limits every velocity component to the given fraction of the corresponding
+	// search range. 0 disables clamping.
+	VelocityClamp float64
+	// MaxIterations bounds the number of iterations and drives the inertia schedule.
+	MaxIterations int
+	// NumWorkers limits the number of concurrent fitness evaluations. It must be positive or -1 for unlimited.
+	NumWorkers int
+}
+
+// DefaultConfig returns a global-best, inertia weight configuration (w from 0.9 to 0.4, c1=c2=2)
+// within the given bounds.
+func DefaultConfig(bounds *core.Bounds) Config {
+	return Config{
+		Bounds:        bounds,
+		SwarmSize:     40,
+		Topology:      GlobalBest,
+		Variant:       InertiaWeight,
+		InertiaStart:  0.9,
+		InertiaEnd:    0.4,
+		C1:            2,
+		C2:            2,
+		VelocityClamp: 0.5,
+		MaxIterations: 1000,
+		NumWorkers:    1,
+	}
+}
+
+// particle holds the velocity and personal best of a single particle.
+// Its position is stored in the swarm population.
+type particle struct {
+	velocity     []float64
+	personalBest core.Solution[float64]
+}
+
+// Optimizer implements particle swarm optimization. It maximizes the fitness returned by the
+// evaluator, evaluates every iteration in parallel using fitness.EvaluatePopulation and offers
+// the same termination criteria, observers and statistics as the genetic algorithm executor.
+type Optimizer struct {
+	fitnessEvaluator fitness.IFitnessEvaluator[float64]
+	config           Config
+
+	terminationCriterion termination.ITerminationCriterion
+	observers            []observer.IObserver[float64]
+	statistics           *core.Statistics
+
+	swarm       *core.Population[float64]
+	particles   []particle
+	best        *core.Solution[float64]
+	evaluations int
+}
+
+// NewOptimizer creates a new particle swarm optimizer for the given evaluator and configuration.
+func NewOptimizer(fitnessEvaluator fitness.IFitnessEvaluator[float64], config Config) (*Optimizer, error) {
+	if fitnessEvaluator == nil {
+		return nil, NewPSOError("invalid fitness evaluator", fmt.Errorf("fitness evaluator cannot be nil"))
+	}
+	if config.Bounds == nil || config.Bounds.Dimension() == 0 {
+		return nil, NewPSOError("invalid bounds", core.ErrInvalidBounds)
+	}
+	if config.SwarmSize == 0 {
+		config.SwarmSize = 40
+	}
+	if config.Neighbors == 0 {
+		config.Neighbors = 1
+	}
+	if config.InertiaStart == 0 && config.InertiaEnd == 0 {
+		config.InertiaStart, config.InertiaEnd = 0.9, 0.4
+	}
+	if config.SwarmSize < 2 {
+		return nil, NewPSOError("invalid swarm size", fmt.Errorf("swarm size must be at least 2, but was %d", config.SwarmSize))
+	}
+	if config.Neighbors < 0 {
+		return nil, NewPSOError("invalid neighborhood", fmt.Errorf("neighbors cannot be negative, but was %d", config.Neighbors))
+	}
+	if config.InertiaStart < 0 || config.InertiaEnd < 0 {
+		return nil, NewPSOError("invalid inertia weight", fmt.Errorf("inertia weights cannot be negative, but were %g and %g", config.InertiaStart, config.InertiaEnd))
+	}
+	if config.C1 < 0 || config.C2 < 0 {
+		return nil, NewPSOError("invalid acceleration coefficients", fmt.Errorf("c1 and c2 cannot be negative, but were %g and %g", config.C1, config.C2))
+	}
+	if config.Variant == Constriction && config.C1+config.C2 <= 4 {
+		return nil, NewPSOError("invalid acceleration coefficients", fmt.Errorf("constriction requires c1+c2 > 4, but was %g", config.C1+config.C2))
+	}
+	if config.VelocityClamp < 0 {
+		return nil, NewPSOError("invalid velocity clamp", fmt.Errorf("velocity clamp cannot be negative, but was %g", config.VelocityClamp))
+	}
+	if config.MaxIterations <= 0 {
+		return nil, NewPSOError("invalid number of iterations", fmt.Errorf("max iterations must be positive, but was %d", config.MaxIterations))
+	}
+	if config.NumWorkers == 0 || config.NumWorkers < -1 {
+		return nil, NewPSOError("invalid number of workers", fmt.Errorf("number of workers must be positive or -1 for unlimited, but was %d", config.NumWorkers))
+	}
+
+	return &Optimizer{
+		fitnessEvaluator: fitnessEvaluator,
+		config:           config,
+	}, nil
+}
+
+// SetTerminationCriterion configures a criterion which stops Optimize before MaxIterations.
+// The criterion is checked after every evaluated iteration.
+func (o *Optimizer) SetTerminationCriterion(criterion termination.ITerminationCriterion) {
+	o.terminationCriterion = criterion
+}
+
+// AddObserver registers an observer notified after every evaluated iteration.
+// The population passed to observers holds the current particle positions.
+func (o *Optimizer) AddObserver(obs observer.IObserver[float64]) {
+	o.observers = append(o.observers, obs)
+}
+
+// Statistics returns the statistics recorded by the last call to Optimize, or nil before Optimize was called.
+func (o *Optimizer) Statistics() *core.Statistics {
+	return o.statistics
+}
+
+// BestSolution returns the best position found so far, or nil before Optimize was called.
+func (o *Optimizer) BestSolution() *core.Solution[float64] {
+	return o.best
+}
+
+// Evaluations returns the number of fitness evaluations performed so far.
+func (o *Optimizer) Evaluations() int {
+	return o.evaluations
+}
+
+// Optimize runs the swarm until MaxIterations is reached or the termination criterion is met,
+// and returns the personal best positions of all particles.
+func (o *Optimizer) Optimize(ctx context.Context) (*core.Population[float64], error) {
+	bounds := o.config.Bounds
+	n := bounds.Dimension()
+	size := o.config.SwarmSize
+
+	o.statistics = core.NewStatistics()
+	o.evaluations = 0
+	o.best = nil
+
+	individuals := make([]core.Solution[float64], size)
+	o.particles = make([]particle, size)
+	for i := 0; i < size; i++ {
+		position := bounds.Sample()
+		velocity := make([]float64, n)
+		for j := 0; j < n; j++ {
+			// Initialize velocities so that the first step stays within the bounds (SPSO 2011)
+			velocity[j] = (bounds.Lower[j] - position[j]) + rand.Float64()*(bounds.Upper[j]-bounds.Lower[j])
+		}
+		o.clampVelocity(velocity)
+		individuals[i] = core.Solution[float64]{Chromosome: position}
+		o.particles[i].velocity = velocity
+	}
+	o.swarm = &core.Population[float64]{Individuals: individuals}
+
+	for iteration := 0; ; iteration++ {
+		if ctx.Err() != nil {
+			return nil, NewPSOError("context cancelled", ctx.Err())
+		}
+		if err := o.evaluate(ctx); err != nil {
+			return nil, fmt.Errorf("failed to evaluate swarm at iteration %d: %w", iteration, err)
+		}
+
+		generationStatistics, err := core.NewGenerationStatistics(o.swarm, iteration, o.evaluations)
+		if err != nil {
+			return nil, err
+		}
+		generationStatistics = o.statistics.Record(generationStatistics)
+		for _, obs := range o.observers {
+			obs.OnGeneration(generationStatistics, o.swarm)
+		}
+		if iteration+1 >= o.config.MaxIterations || (o.terminationCriterion != nil && o.terminationCriterion.ShouldTerminate(o.statistics)) {
+			break
+		}
+
+		o.move(iteration)
+	}
+
+	personalBests := make([]core.Solution[float64], size)
+	for i := range o.particles {
+		personalBests[i] = *o.particles[i].personalBest.DeepCopy()
+	}
+	return &core.Population[float64]{Individuals: personalBests}, nil
+}
+
+// evaluate evaluates the current positions and updates the personal and global bests.
+func (o *Optimizer) evaluate(ctx context.Context) error {
+	if err := fitness.EvaluatePopulation(ctx, o.fitnessEvaluator, o.swarm, o.config.NumWorkers); err != nil {
+		return NewPSOError("failed to evaluate swarm", err)
+	}
+	o.evaluations += len(o.swarm.Individuals)
+
+	for i := range o.particles {
+		position := &o.swarm.Individuals[i]
+		if o.particles[i].personalBest.Chromosome == nil || position.Fitness > o.particles[i].personalBest.Fitness {
+			o.particles[i].personalBest = *position.DeepCopy()
+		}
+		if o.best == nil || position.Fitness > o.best.Fitness {
+			o.best = position.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// move updates the velocity and position of every particle.
+func (o *Optimizer) move(iteration int) {
+	bounds := o.config.Bounds
+	c1, c2 := o.config.C1, o.config.C2
+
+	inertia, constriction := 1.0, 1.0
+	switch o.config.Variant {
+	case Constriction:
+		phi := c1 + c2
+		constriction = 2 / math.Abs(2-phi-math.Sqrt(phi*phi-4*phi))
+	default:
+		progress := float64(iteration) / float64(max(1, o.config.MaxIterations-1))
+		inertia = o.config.InertiaStart - (o.config.InertiaStart-o.config.InertiaEnd)*progress
+	}
+
+	for i := range o.particles {
+		p := &o.particles[i]
+		position := o.swarm.Individuals[i].Chromosome
+		social := o.neighborhoodBest(i)
+
+		for j := range position {
+			cognitiveTerm := c1 * rand.Float64() * (p.personalBest.Chromosome[j] - position[j])
+			socialTerm := c2 * rand.Float64() * (social[j] - position[j])
+			p.velocity[j] = constriction * (inertia*p.velocity[j] + cognitiveTerm + socialTerm)
+		}
+		o.clampVelocity(p.velocity)
+
+		for j := range position {
+			position[j] += p.velocity[j]
+		}
+		if !bounds.Contains(position) {
+			for j := range position {
+				if position[j] < bounds.Lower[j] || position[j] > bounds.Upper[j] {
+					p.velocity[j] = 0
+				}
+			}
+			bounds.Repair(position, o.config.BoundaryHandling)
+		}
+	}
+}
+
+// neighborhoodBest returns the best personal best position within the neighborhood of particle i.
+func (o *Optimizer) neighborhoodBest(i int) []float64 {
+	if o.config.Topology != Ring {
+		return o.best.Chromosome
+	}
+	size := len(o.particles)
+	best := &o.particles[i].personalBest
+	for offset := 1; offset <= o.config.Neighbors; offset++ {
+		for _, k := range []int{(i + offset) % size, (i - offset + size) % size} {
+			if o.particles[k].personalBest.Fitness > best.Fitness {
+				best = &o.particles[k].personalBest
+			}
+		}
+	}
+	return best.Chromosome
+}
+
+// clampVelocity limits every component of the velocity to VelocityClamp times the search range.
+func (o *Optimizer) clampVelocity(velocity []float64) {
+	if o.config.VelocityClamp == 0 {
+		return
+	}
+	bounds := o.config.Bounds
+	for j := range velocity {
+		limit := o.config.VelocityClamp * (bounds.Upper[j] - bounds.Lower[j])
+		velocity[j] = math.Max(-limit, math.Min(limit, velocity[j]))
+	}
+}
+
+// PSOError represents an error that occurs during a particle swarm optimization.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type PSOError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *PSOError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *PSOError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewPSOError constructs a *PSOError with the provided message and wrapped error.
+func NewPSOError(message string, wrapped error) *PSOError {
+	return &PSOError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
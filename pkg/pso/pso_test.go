@@ -0,0 +1,244 @@
+package pso
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/observer"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+// sphereEvaluator maximizes the negated sphere function, whose optimum 0 lies at the origin.
+type sphereEvaluator struct{}
+
+func (sphereEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	sum := 0.0
+	for _, x := range *chromosome {
+		sum += x * x
+	}
+	return -sum, nil
+}
+
+// failingEvaluator always fails.
+type failingEvaluator struct{}
+
+func (failingEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	return 0, core.ErrFitnessEvaluationFailed
+}
+
+// newBounds is a helper function creating uniform bounds, failing the test on error.
+func newBounds(t testing.TB, dimension int, lower, upper float64) *core.Bounds {
+	t.Helper()
+	bounds, err := core.NewUniformBounds(dimension, lower, upper)
+	require.NoError(t, err)
+	return bounds
+}
+
+func TestNewOptimizer(t *testing.T) {
+	t.Parallel()
+	valid := DefaultConfig(newBounds(t, 3, -5, 5))
+
+	testCases := []struct {
+		name   string
+		modify func(c *Config)
+	}{
+		{"missing bounds", func(c *Config) { c.Bounds = nil }},
+		{"swarm too small", func(c *Config) { c.SwarmSize = 1 }},
+		{"negative neighbors", func(c *Config) { c.Neighbors = -1 }},
+		{"negative coefficient", func(c *Config) { c.C1 = -1 }},
+		{"constriction with small phi", func(c *Config) { c.Variant = Constriction }},
+		{"negative velocity clamp", func(c *Config) { c.VelocityClamp = -0.1 }},
+		{"no iterations", func(c *Config) { c.MaxIterations = 0 }},
+		{"negative inertia", func(c *Config) { c.InertiaEnd = -0.1 }},
+		{"zero workers", func(c *Config) { c.NumWorkers = 0 }},
+		{"negative workers", func(c *Config) { c.NumWorkers = -2 }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			config := valid
+			tc.modify(&config)
+			optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+			assert.Nil(t, optimizer)
+			var pe *PSOError
+			assert.ErrorAs(t, err, &pe)
+		})
+	}
+
+	t.Run("nil evaluator", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewOptimizer(nil, valid)
+		var pe *PSOError
+		assert.ErrorAs(t, err, &pe)
+	})
+
+	t.Run("defaults zero inertia weights", func(t *testing.T) {
+		t.Parallel()
+		config := valid
+		config.InertiaStart, config.InertiaEnd = 0, 0
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+		assert.Equal(t, 0.9, optimizer.config.InertiaStart)
+		assert.Equal(t, 0.4, optimizer.config.InertiaEnd)
+	})
+}
+
+func TestOptimizer_Optimize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		topology Topology
+		variant  Variant
+	}{
+		{"global best with inertia weight", GlobalBest, InertiaWeight},
+		{"global best with constriction", GlobalBest, Constriction},
+		{"ring with inertia weight", Ring, InertiaWeight},
+		{"ring with constriction", Ring, Constriction},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+" converges on the sphere function", func(t *testing.T) {
+			config := DefaultConfig(newBounds(t, 5, -5, 5))
+			config.Topology = tc.topology
+			config.Variant = tc.variant
+			if tc.variant == Constriction {
+				config.C1, config.C2 = 2.05, 2.05
+			}
+			config.MaxIterations = 500
+
+			optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+			require.NoError(t, err)
+
+			personalBests, err := optimizer.Optimize(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, personalBests.Individuals, 40)
+			assert.Greater(t, optimizer.BestSolution().Fitness, -1e-4)
+
+			best, err := personalBests.BestFitness()
+			require.NoError(t, err)
+			assert.Equal(t, optimizer.BestSolution().Fitness, best)
+		})
+	}
+
+	t.Run("records statistics and notifies observers every iteration", func(t *testing.T) {
+		config := DefaultConfig(newBounds(t, 2, -1, 1))
+		config.SwarmSize = 10
+		config.MaxIterations = 15
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+		history := observer.NewHistoryObserver[float64]()
+		optimizer.AddObserver(history)
+
+		_, err = optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, optimizer.Statistics().History, 15)
+		assert.Len(t, history.History(), 15)
+		assert.Equal(t, 150, optimizer.Evaluations())
+
+		last, ok := optimizer.Statistics().Last()
+		require.True(t, ok)
+		assert.Equal(t, optimizer.BestSolution().Fitness, last.BestEverFitness)
+	})
+
+	t.Run("stops on termination criterion", func(t *testing.T) {
+		config := DefaultConfig(newBounds(t, 2, -1, 1))
+		config.SwarmSize = 10
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+		optimizer.SetTerminationCriterion(termination.NewMaxEvaluations(55))
+
+		_, err = optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 60, optimizer.Evaluations())
+	})
+
+	t.Run("keeps every particle within bounds", func(t *testing.T) {
+		bounds := newBounds(t, 3, 1, 2)
+		config := DefaultConfig(bounds)
+		config.VelocityClamp = 0
+		config.MaxIterations = 50
+
+		optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+		require.NoError(t, err)
+		outOfBounds := 0
+		optimizer.AddObserver(observer.FuncObserver[float64](func(statistics core.GenerationStatistics, population *core.Population[float64]) {
+			for _, individual := range population.Individuals {
+				if !bounds.Contains(individual.Chromosome) {
+					outOfBounds++
+				}
+			}
+		}))
+
+		_, err = optimizer.Optimize(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, outOfBounds)
+		assert.InDelta(t, -3, optimizer.BestSolution().Fitness, 1e-3)
+	})
+
+	t.Run("propagates evaluation errors", func(t *testing.T) {
+		optimizer, err := NewOptimizer(failingEvaluator{}, DefaultConfig(newBounds(t, 2, -1, 1)))
+		require.NoError(t, err)
+
+		population, err := optimizer.Optimize(context.Background())
+		assert.Nil(t, population)
+		assert.ErrorIs(t, err, core.ErrFitnessEvaluationFailed)
+	})
+
+	t.Run("stops on cancelled context", func(t *testing.T) {
+		optimizer, err := NewOptimizer(sphereEvaluator{}, DefaultConfig(newBounds(t, 2, -1, 1)))
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = optimizer.Optimize(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestOptimizer_NeighborhoodBest(t *testing.T) {
+	t.Parallel()
+	config := DefaultConfig(newBounds(t, 1, -1, 1))
+	config.Topology = Ring
+	config.SwarmSize = 5
+	optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+	require.NoError(t, err)
+
+	optimizer.particles = make([]particle, 5)
+	for i, f := range []float64{5, 1, 2, 3, 4} {
+		optimizer.particles[i].personalBest = core.Solution[float64]{Chromosome: []float64{float64(i)}, Fitness: f}
+	}
+
+	// Particle 1 sees particles 0 and 2; particle 0 is the best of the three
+	assert.Equal(t, []float64{0}, optimizer.neighborhoodBest(1))
+	// Particle 3 sees particles 2 and 4; particle 4 is the best of the three
+	assert.Equal(t, []float64{4}, optimizer.neighborhoodBest(3))
+	// Particle 4 wraps around to particle 0
+	assert.Equal(t, []float64{0}, optimizer.neighborhoodBest(4))
+}
+
+func TestConstrictionCoefficient(t *testing.T) {
+	t.Parallel()
+	phi := 4.1
+	chi := 2 / math.Abs(2-phi-math.Sqrt(phi*phi-4*phi))
+	assert.InDelta(t, 0.7298, chi, 1e-4)
+}
+
+// BenchmarkOptimizer_Optimize benchmarks 100 iterations of a 40-particle swarm on a 10-dimensional sphere.
+func BenchmarkOptimizer_Optimize(b *testing.B) {
+	config := DefaultConfig(newBounds(b, 10, -5, 5))
+	config.MaxIterations = 100
+
+	optimizer, err := NewOptimizer(sphereEvaluator{}, config)
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = optimizer.Optimize(context.Background())
+	}
+}
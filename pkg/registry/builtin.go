@@ -0,0 +1,259 @@
+package registry
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+	"github.com/tomhoffer/darwinium/pkg/problems"
+)
+
+func init() {
+	registerBuiltins[int]()
+	registerBuiltins[float64]()
+	registerCriteria()
+	registerProblems()
+}
+
+// NoParams are the parameters of operators without parameters.
+type NoParams struct{}
+
+// TournamentParams are the parameters of tournament selection.
+type TournamentParams struct {
+	// Size is the number of individuals competing in every tournament.
+	Size int `json:"size"`
+	// Elites is the number of best individuals copied to the next generation unchanged.
+	Elites int `json:"elites"`
+}
+
+// Validate implements IValidator.
+func (p TournamentParams) Validate() error {
+	if p.Size < 1 {
+		return NewParamError("size", fmt.Sprintf("must be at least 1, got %d", p.Size), nil)
+	}
+	if p.Elites < 0 {
+		return NewParamError("elites", fmt.Sprintf("must not be negative, got %d", p.Elites), nil)
+	}
+	return nil
+}
+
+// RateParams are the parameters of mutation operators applied with a probability.
+type RateParams struct {
+	// Rate is the probability of mutating a chromosome.
+	Rate float64 `json:"rate"`
+}
+
+// Validate implements IValidator.
+func (p RateParams) Validate() error {
+	if p.Rate < 0 || p.Rate > 1 {
+		return NewParamError("rate", fmt.Sprintf("must be within [0, 1], got %v", p.Rate), nil)
+	}
+	return nil
+}
+
+// ElitesParams are the parameters of generational replacement.
+type ElitesParams struct {
+	// Elites is the number of best parents surviving into the next generation.
+	Elites int `json:"elites"`
+}
+
+// Validate implements IValidator.
+func (p ElitesParams) Validate() error {
+	if p.Elites < 0 {
+		return NewParamError("elites", fmt.Sprintf("must not be negative, got %d", p.Elites), nil)
+	}
+	return nil
+}
+
+// SurvivorsParams are the parameters of (mu+lambda) and (mu,lambda) replacement.
+type SurvivorsParams struct {
+	// Mu is the number of survivors, 0 keeps the population size.
+	Mu int `json:"mu"`
+}
+
+// Validate implements IValidator.
+func (p SurvivorsParams) Validate() error {
+	if p.Mu < 0 {
+		return NewParamError("mu", fmt.Sprintf("must not be negative, got %d", p.Mu), nil)
+	}
+	return nil
+}
+
+// MaxEvaluationsParams are the parameters of the max-evaluations criterion.
+type MaxEvaluationsParams struct {
+	Evaluations int `json:"evaluations"`
+}
+
+// Validate implements IValidator.
+func (p MaxEvaluationsParams) Validate() error {
+	if p.Evaluations < 1 {
+		return NewParamError("evaluations", fmt.Sprintf("must be at least 1, got %d", p.Evaluations), nil)
+	}
+	return nil
+}
+
+// TargetFitnessParams are the parameters of the target-fitness criterion.
+type TargetFitnessParams struct {
+	Fitness float64 `json:"fitness"`
+}
+
+// StagnationParams are the parameters of the stagnation criterion.
+type StagnationParams struct {
+	Generations int `json:"generations"`
+}
+
+// Validate implements IValidator.
+func (p StagnationParams) Validate() error {
+	if p.Generations < 1 {
+		return NewParamError("generations", fmt.Sprintf("must be at least 1, got %d", p.Generations), nil)
+	}
+	return nil
+}
+
+// TimeoutParams are the parameters of the timeout criterion.
+type TimeoutParams struct {
+	Duration time.Duration `json:"duration"`
+}
+
+// Validate implements IValidator.
+func (p TimeoutParams) Validate() error {
+	if p.Duration <= 0 {
+		return NewParamError("duration", fmt.Sprintf("must be positive, got %s", p.Duration), nil)
+	}
+	return nil
+}
+
+// MinUniqueGenotypesParams are the parameters of the min-unique-genotypes criterion.
+type MinUniqueGenotypesParams struct {
+	Count int `json:"count"`
+}
+
+// Validate implements IValidator.
+func (p MinUniqueGenotypesParams) Validate() error {
+	if p.Count < 1 {
+		return NewParamError("count", fmt.Sprintf("must be at least 1, got %d", p.Count), nil)
+	}
+	return nil
+}
+
+// ProblemParams are the parameters of the continuous benchmark functions.
+type ProblemParams struct {
+	// Dimension is the number of variables, which must match the chromosome length.
+	Dimension int `json:"dimension"`
+	// Shifted moves the optimum to a random point drawn from Seed.
+	Shifted bool `json:"shifted"`
+	// Rotated applies a random rotation drawn from Seed.
+	Rotated bool `json:"rotated"`
+	// Seed makes the shift and rotation reproducible.
+	Seed int64 `json:"seed"`
+}
+
+// Validate implements IValidator.
+func (p ProblemParams) Validate() error {
+	if p.Dimension < 1 {
+		return NewParamError("dimension", fmt.Sprintf("must be at least 1, got %d", p.Dimension), nil)
+	}
+	return nil
+}
+
+// registerBuiltins is a helper function registering the built-in operators for genes of type T.
+func registerBuiltins[T cmp.Ordered]() {
+	Evaluators[T]().MustRegister("sum", NewFactory("maximizes the sum of all genes", NoParams{}, func(NoParams) (fitness.IFitnessEvaluator[T], error) {
+		return fitness.NewSimpleSumFitnessEvaluator[T](), nil
+	}))
+
+	Selectors[T]().MustRegister("tournament", NewFactory("tournament selection preserving the best individuals as elites", TournamentParams{Size: 5, Elites: 1}, func(p TournamentParams) (selection.ISelector[T], error) {
+		return selection.NewTournamentSelector[T](p.Size, p.Elites)
+	}))
+
+	Crossovers[T]().MustRegister("single-point", NewFactory("exchanges the tails of two parents after a random cut point", NoParams{}, func(NoParams) (crossover.ICrossover[T], error) {
+		return crossover.NewSinglePointCrossover[T](), nil
+	}))
+	Crossovers[T]().MustRegister("order", NewFactory("order crossover (OX) keeping the offspring permutations of the parents' genes", NoParams{}, func(NoParams) (crossover.ICrossover[T], error) {
+		return crossover.NewOrderCrossover[T](), nil
+	}))
+
+	Mutators[T]().MustRegister("swap", NewFactory("swaps two random genes with the given probability", RateParams{Rate: 0.01}, func(p RateParams) (mutation.IMutator[T], error) {
+		return mutation.NewSimpleSwapMutator[T](p.Rate), nil
+	}))
+
+	replacers := Replacers[T]()
+	replacers.MustRegister("generational", NewFactory("offspring replace their parents except for the best elites", ElitesParams{}, func(p ElitesParams) (replacement.IReplacer[T], error) {
+		return replacement.NewGenerationalReplacement[T](p.Elites)
+	}))
+	replacers.MustRegister("plus", NewFactory("(mu+lambda) survivor selection among parents and offspring, mu 0 keeps the population size", SurvivorsParams{}, func(p SurvivorsParams) (replacement.IReplacer[T], error) {
+		return replacement.NewPlusReplacement[T](p.Mu)
+	}))
+	replacers.MustRegister("comma", NewFactory("(mu,lambda) survivor selection among offspring, mu 0 keeps the population size", SurvivorsParams{}, func(p SurvivorsParams) (replacement.IReplacer[T], error) {
+		return replacement.NewCommaReplacement[T](p.Mu)
+	}))
+}
+
+// registerCriteria is a helper function registering the built-in termination criteria.
+func registerCriteria() {
+	criteria := Criteria()
+	criteria.MustRegister("max-evaluations", NewFactory("stops after the given number of fitness evaluations", MaxEvaluationsParams{Evaluations: 10000}, func(p MaxEvaluationsParams) (termination.ITerminationCriterion, error) {
+		return termination.NewMaxEvaluations(p.Evaluations), nil
+	}))
+	criteria.MustRegister("target-fitness", NewFactory("stops once the best fitness reaches the target", TargetFitnessParams{}, func(p TargetFitnessParams) (termination.ITerminationCriterion, error) {
+		return termination.NewTargetFitness(p.Fitness), nil
+	}))
+	criteria.MustRegister("stagnation", NewFactory("stops after the given number of generations without improvement", StagnationParams{Generations: 20}, func(p StagnationParams) (termination.ITerminationCriterion, error) {
+		return termination.NewStagnation(p.Generations), nil
+	}))
+	criteria.MustRegister("timeout", NewFactory("stops once the run has taken the given duration", TimeoutParams{Duration: time.Minute}, func(p TimeoutParams) (termination.ITerminationCriterion, error) {
+		return termination.NewTimeout(p.Duration), nil
+	}))
+	criteria.MustRegister("min-unique-genotypes", NewFactory("stops once fewer distinct chromosomes than count remain", MinUniqueGenotypesParams{Count: 2}, func(p MinUniqueGenotypesParams) (termination.ITerminationCriterion, error) {
+		return termination.NewMinUniqueGenotypes(p.Count), nil
+	}))
+}
+
+// registerProblems is a helper function registering the continuous benchmark functions as evaluators of float64 genes.
+func registerProblems() {
+	problemConstructors := []struct {
+		name        string
+		description string
+		create      func(int) (*problems.Function, error)
+	}{
+		{"sphere", "minimizes the unimodal sphere function", problems.NewSphere},
+		{"rosenbrock", "minimizes the Rosenbrock function with its narrow curved valley", problems.NewRosenbrock},
+		{"rastrigin", "minimizes the highly multimodal Rastrigin function", problems.NewRastrigin},
+		{"ackley", "minimizes the multimodal Ackley function", problems.NewAckley},
+		{"griewank", "minimizes the multimodal Griewank function", problems.NewGriewank},
+		{"schwefel", "minimizes the deceptive Schwefel function", problems.NewSchwefel},
+		{"levy", "minimizes the multimodal Levy function", problems.NewLevy},
+	}
+	evaluators := Evaluators[float64]()
+	for _, constructor := range problemConstructors {
+		evaluators.MustRegister(constructor.name, NewFactory(constructor.description+", optionally shifted and rotated", ProblemParams{Dimension: 10}, func(p ProblemParams) (fitness.IFitnessEvaluator[float64], error) {
+			function, err := constructor.create(p.Dimension)
+			if err != nil {
+				return nil, err
+			}
+			return transformProblem(function, p)
+		}))
+	}
+}
+
+// transformProblem is a helper function applying the shift and rotation requested by p to function.
+func transformProblem(function *problems.Function, p ProblemParams) (*problems.Function, error) {
+	if !p.Shifted && !p.Rotated {
+		return function, nil
+	}
+	transformed, err := problems.NewShiftedRotated(function, p.Seed)
+	if err != nil || (p.Shifted && p.Rotated) {
+		return transformed, err
+	}
+	if p.Shifted {
+		return problems.Transform(function, transformed.Optimum(), nil, 0)
+	}
+	return problems.Transform(function, nil, problems.RandomRotation(function.Dimension(), rand.New(rand.NewSource(p.Seed))), 0)
+}
@@ -0,0 +1,439 @@
+// Package registry provides registries of named, parameterized operators from which
+// configurations and command line flags instantiate components without code changes.
+//
+// Every operator is registered under a name together with a typed parameter struct holding its
+// default parameters. A component is described by a Spec, written e.g. as "tournament{size:5, elites:1}";
+// its parameters are decoded into a copy of the defaults, validated and passed to the factory.
+//
+// The built-in operators are registered for int and float64 genes. Other packages can register
+// their own operators, typically from an init function:
+//
+//	func init() {
+//		registry.Mutators[float64]().MustRegister("gaussian", registry.NewFactory(
+//			"adds gaussian noise to every gene",
+//			GaussianParams{Sigma: 0.1},
+//			func(p GaussianParams) (mutation.IMutator[float64], error) { return NewGaussianMutator(p.Sigma), nil },
+//		))
+//	}
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tomhoffer/darwinium/internal/decode"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/fitness"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/replacement"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+)
+
+var (
+	// ErrUnknownOperator is returned when a spec names an operator which is not registered.
+	ErrUnknownOperator = errors.New("unknown operator")
+	// ErrDuplicateOperator is returned when an operator is registered twice under the same name.
+	ErrDuplicateOperator = errors.New("operator already registered")
+)
+
+// RegistryError represents an error that occurs while registering or building an operator.
+type RegistryError struct {
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *RegistryError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *RegistryError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewRegistryError constructs a *RegistryError with the provided message and wrapped error.
+func NewRegistryError(message string, wrapped error) *RegistryError {
+	return &RegistryError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// ParamError reports an invalid parameter of an operator.
+type ParamError struct {
+	// Param is the name of the parameter, empty if the error concerns the parameters as a whole.
+	Param   string
+	Message string
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *ParamError) Error() string {
+	message := e.Message
+	if e.Param != "" {
+		message = e.Param + ": " + message
+	}
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", message, e.Wrapped)
+	}
+	return message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *ParamError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewParamError constructs a *ParamError for the named parameter with the provided message and wrapped error.
+func NewParamError(param, message string, wrapped error) *ParamError {
+	return &ParamError{
+		Param:   param,
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
+
+// ParamErrors returns every *ParamError contained in err, including errors joined with errors.Join.
+func ParamErrors(err error) []*ParamError {
+	var result []*ParamError
+	var walk func(err error)
+	walk = func(err error) {
+		switch e := err.(type) {
+		case nil:
+		case *ParamError:
+			result = append(result, e)
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+	walk(err)
+	return result
+}
+
+// Kind is the kind of component built by the operators of a registry.
+type Kind string
+
+// Kinds of components, in the order in which Entries lists them.
+const (
+	EvaluatorKind   Kind = "evaluator"
+	SelectionKind   Kind = "selection"
+	CrossoverKind   Kind = "crossover"
+	MutationKind    Kind = "mutation"
+	ReplacementKind Kind = "replacement"
+	TerminationKind Kind = "termination"
+)
+
+var kindOrder = []Kind{EvaluatorKind, SelectionKind, CrossoverKind, MutationKind, ReplacementKind, TerminationKind}
+
+// IValidator is implemented by parameter structs which validate their values.
+type IValidator interface {
+	// Validate checks the parameters.
+	//
+	// Returns:
+	//   - error: nil if the parameters are valid, preferably *ParamError values naming the invalid parameters otherwise
+	Validate() error
+}
+
+// IFactory builds components of type O from named parameters.
+type IFactory[O any] interface {
+	// Description returns a short, human-readable description of the operator.
+	Description() string
+
+	// Defaults returns the default value of every parameter, keyed by parameter name.
+	Defaults() map[string]any
+
+	// Build creates a component from parameters overriding the defaults.
+	//
+	// Parameters:
+	//   - params: parameter values keyed by name, as produced by the YAML and JSON parsers; may be nil
+	//
+	// Returns:
+	//   - O: the built component
+	//   - error: *ParamError values joined with errors.Join if a parameter is unknown or invalid
+	Build(params map[string]any) (O, error)
+}
+
+// factory is the IFactory created by NewFactory.
+type factory[P, O any] struct {
+	description string
+	defaults    P
+	build       func(params P) (O, error)
+}
+
+// NewFactory creates a factory whose parameters are decoded into a copy of defaults, a struct whose
+// fields are named by their json tags. If the parameter struct implements IValidator, it is validated
+// before build is called.
+func NewFactory[P, O any](description string, defaults P, build func(params P) (O, error)) IFactory[O] {
+	if reflect.TypeFor[P]().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("registry: parameters must be a struct, got %s", reflect.TypeFor[P]()))
+	}
+	return &factory[P, O]{description: description, defaults: defaults, build: build}
+}
+
+func (f *factory[P, O]) Description() string {
+	return f.description
+}
+
+func (f *factory[P, O]) Defaults() map[string]any {
+	value := reflect.ValueOf(f.defaults)
+	result := make(map[string]any, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name := decode.FieldName(field)
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if duration, ok := value.Field(i).Interface().(time.Duration); ok {
+			result[name] = duration.String()
+			continue
+		}
+		result[name] = value.Field(i).Interface()
+	}
+	return result
+}
+
+func (f *factory[P, O]) Build(params map[string]any) (O, error) {
+	var zero O
+	decoded := f.defaults
+	if params != nil {
+		var errs []error
+		for _, e := range decode.Decode(params, "", &decoded) {
+			errs = append(errs, NewParamError(e.Path, e.Message, nil))
+		}
+		if len(errs) > 0 {
+			return zero, errors.Join(errs...)
+		}
+	}
+	if validator, ok := any(decoded).(IValidator); ok {
+		if err := validator.Validate(); err != nil {
+			return zero, err
+		}
+	}
+	return f.build(decoded)
+}
+
+// Entry describes a registered operator.
+type Entry struct {
+	Kind        Kind   `json:"kind"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Params holds the default value of every parameter of the operator.
+	Params map[string]any `json:"params"`
+}
+
+// namePattern restricts operator names to those which can be written in a Spec.
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Registry holds the operators building components of type O, keyed by name.
+// It is safe for concurrent use.
+type Registry[O any] struct {
+	kind      Kind
+	mutex     sync.RWMutex
+	factories map[string]IFactory[O]
+}
+
+// NewRegistry creates an empty registry of operators of the given kind.
+func NewRegistry[O any](kind Kind) *Registry[O] {
+	return &Registry[O]{kind: kind, factories: make(map[string]IFactory[O])}
+}
+
+// Kind returns the kind of the operators of the registry.
+func (r *Registry[O]) Kind() Kind {
+	return r.kind
+}
+
+// Register adds an operator under name. Names consist of letters, digits, '.', '_' and '-'
+// and must not be registered already.
+func (r *Registry[O]) Register(name string, factory IFactory[O]) error {
+	if !namePattern.MatchString(name) {
+		return NewRegistryError(fmt.Sprintf("cannot register %s operator", r.kind), fmt.Errorf("invalid name %q", name))
+	}
+	if factory == nil {
+		return NewRegistryError(fmt.Sprintf("cannot register %s operator %q", r.kind, name), errors.New("factory is nil"))
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.factories[name]; ok {
+		return NewRegistryError(fmt.Sprintf("cannot register %s operator %q", r.kind, name), ErrDuplicateOperator)
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+// MustRegister is like Register but panics if the operator cannot be registered.
+// It is intended for registration from init functions.
+func (r *Registry[O]) MustRegister(name string, factory IFactory[O]) {
+	if err := r.Register(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the operator registered under name.
+func (r *Registry[O]) Lookup(name string) (IFactory[O], bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	factory, ok := r.factories[name]
+	return factory, ok
+}
+
+// Names returns the sorted names of the registered operators.
+func (r *Registry[O]) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Entries describes the registered operators, ordered by name.
+func (r *Registry[O]) Entries() []Entry {
+	var entries []Entry
+	for _, name := range r.Names() {
+		factory, _ := r.Lookup(name)
+		entries = append(entries, Entry{Kind: r.kind, Name: name, Description: factory.Description(), Params: factory.Defaults()})
+	}
+	return entries
+}
+
+// Build creates the component described by spec. Returns a *RegistryError wrapping ErrUnknownOperator
+// if no operator is registered under the name, or wrapping the *ParamError values of invalid parameters.
+func (r *Registry[O]) Build(spec Spec) (O, error) {
+	var zero O
+	factory, ok := r.Lookup(spec.Name)
+	if !ok {
+		message := fmt.Sprintf("unknown %s operator %q (available: %s)", r.kind, spec.Name, strings.Join(r.Names(), ", "))
+		return zero, NewRegistryError(message, ErrUnknownOperator)
+	}
+	component, err := factory.Build(spec.Params)
+	if err != nil {
+		return zero, NewRegistryError(fmt.Sprintf("cannot build %s operator %q", r.kind, spec.Name), err)
+	}
+	return component, nil
+}
+
+// Parse parses a spec such as "tournament{size:5, elites:1}" and builds the component it describes.
+func (r *Registry[O]) Parse(text string) (O, error) {
+	spec, err := ParseSpec(text)
+	if err != nil {
+		var zero O
+		return zero, err
+	}
+	return r.Build(spec)
+}
+
+// catalog is implemented by every Registry and used to list the operators of all registries.
+type catalog interface {
+	Entries() []Entry
+}
+
+var (
+	registriesMutex sync.Mutex
+	registries      = make(map[reflect.Type]catalog)
+)
+
+// global is a helper function returning the shared registry of components of type O,
+// creating it on first use.
+func global[O any](kind Kind) *Registry[O] {
+	registriesMutex.Lock()
+	defer registriesMutex.Unlock()
+	key := reflect.TypeFor[O]()
+	if existing, ok := registries[key]; ok {
+		return existing.(*Registry[O])
+	}
+	created := NewRegistry[O](kind)
+	registries[key] = created
+	return created
+}
+
+// Evaluators returns the shared registry of fitness evaluators for genes of type T.
+func Evaluators[T any]() *Registry[fitness.IFitnessEvaluator[T]] {
+	return global[fitness.IFitnessEvaluator[T]](EvaluatorKind)
+}
+
+// Selectors returns the shared registry of selection operators for genes of type T.
+func Selectors[T any]() *Registry[selection.ISelector[T]] {
+	return global[selection.ISelector[T]](SelectionKind)
+}
+
+// Crossovers returns the shared registry of crossover operators for genes of type T.
+func Crossovers[T any]() *Registry[crossover.ICrossover[T]] {
+	return global[crossover.ICrossover[T]](CrossoverKind)
+}
+
+// Mutators returns the shared registry of mutation operators for genes of type T.
+func Mutators[T any]() *Registry[mutation.IMutator[T]] {
+	return global[mutation.IMutator[T]](MutationKind)
+}
+
+// Replacers returns the shared registry of survivor selection strategies for genes of type T.
+func Replacers[T any]() *Registry[replacement.IReplacer[T]] {
+	return global[replacement.IReplacer[T]](ReplacementKind)
+}
+
+// Criteria returns the shared registry of termination criteria.
+func Criteria() *Registry[termination.ITerminationCriterion] {
+	return global[termination.ITerminationCriterion](TerminationKind)
+}
+
+// Entries describes the operators of every shared registry, ordered by kind and name.
+// Operators registered for several gene types are listed once, as described by the registry
+// whose component type name sorts first.
+func Entries() []Entry {
+	registriesMutex.Lock()
+	defer registriesMutex.Unlock()
+	return listEntries(registries)
+}
+
+// listEntries is a helper function listing the operators of the given registries, visiting the
+// registries in the order of their type names so that the listed duplicate does not depend on map order.
+func listEntries(catalogs map[reflect.Type]catalog) []Entry {
+	keys := slices.SortedFunc(maps.Keys(catalogs), func(a, b reflect.Type) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	seen := make(map[Kind]map[string]bool)
+	var entries []Entry
+	for _, key := range keys {
+		for _, entry := range catalogs[key].Entries() {
+			if seen[entry.Kind] == nil {
+				seen[entry.Kind] = make(map[string]bool)
+			}
+			if !seen[entry.Kind][entry.Name] {
+				seen[entry.Kind][entry.Name] = true
+				entries = append(entries, entry)
+			}
+		}
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		if order := slices.Index(kindOrder, a.Kind) - slices.Index(kindOrder, b.Kind); order != 0 {
+			return order
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return entries
+}
@@ -0,0 +1,275 @@
+package registry
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+	"github.com/tomhoffer/darwinium/pkg/ga/termination"
+	"github.com/tomhoffer/darwinium/pkg/problems"
+)
+
+// init registers a third-party operator in a shared registry once, as packages extending the registries do.
+func init() {
+	Criteria().MustRegister("test-never", NewFactory("never stops", NoParams{}, func(NoParams) (termination.ITerminationCriterion, error) {
+		return termination.NewTargetFitness(1e300), nil
+	}))
+}
+
+type scaleParams struct {
+	Factor float64 `json:"factor"`
+	Label  string  `json:"label"`
+}
+
+func (p scaleParams) Validate() error {
+	if p.Factor <= 0 {
+		return NewParamError("factor", "must be positive", nil)
+	}
+	return nil
+}
+
+// scaleRegistry is a helper function returning a registry with a single operator scaling its factor.
+func scaleRegistry(t *testing.T) *Registry[float64] {
+	t.Helper()
+	registry := NewRegistry[float64]("scale")
+	require.NoError(t, registry.Register("scale", NewFactory("multiplies by factor", scaleParams{Factor: 2}, func(p scaleParams) (float64, error) {
+		return 10 * p.Factor, nil
+	})))
+	return registry
+}
+
+func TestRegistry_Register(t *testing.T) {
+	t.Run("rejects invalid and duplicate names", func(t *testing.T) {
+		registry := scaleRegistry(t)
+		factory, ok := registry.Lookup("scale")
+		require.True(t, ok)
+
+		err := registry.Register("scale", factory)
+		assert.ErrorIs(t, err, ErrDuplicateOperator)
+		for _, name := range []string{"", "two words", "tournament{", "-leading"} {
+			assert.Error(t, registry.Register(name, factory), name)
+		}
+		assert.Error(t, registry.Register("nil", nil))
+		assert.Equal(t, []string{"scale"}, registry.Names())
+	})
+
+	t.Run("must register panics on errors", func(t *testing.T) {
+		registry := scaleRegistry(t)
+		factory, _ := registry.Lookup("scale")
+		assert.Panics(t, func() { registry.MustRegister("scale", factory) })
+	})
+
+	t.Run("factories require parameter structs", func(t *testing.T) {
+		assert.Panics(t, func() {
+			NewFactory("invalid", 5, func(int) (float64, error) { return 0, nil })
+		})
+	})
+}
+
+func TestRegistry_Build(t *testing.T) {
+	t.Run("uses defaults for missing parameters", func(t *testing.T) {
+		value, err := scaleRegistry(t).Build(Spec{Name: "scale"})
+		require.NoError(t, err)
+		assert.Equal(t, 20.0, value)
+	})
+
+	t.Run("decodes parameters", func(t *testing.T) {
+		value, err := scaleRegistry(t).Build(Spec{Name: "scale", Params: map[string]any{"factor": 3}})
+		require.NoError(t, err)
+		assert.Equal(t, 30.0, value)
+	})
+
+	t.Run("reports unknown operators", func(t *testing.T) {
+		_, err := scaleRegistry(t).Build(Spec{Name: "shift"})
+		assert.ErrorIs(t, err, ErrUnknownOperator)
+		assert.ErrorContains(t, err, `unknown scale operator "shift" (available: scale)`)
+	})
+
+	t.Run("reports every invalid parameter", func(t *testing.T) {
+		_, err := scaleRegistry(t).Build(Spec{Name: "scale", Params: map[string]any{"factor": "big", "label": 1, "offset": 1}})
+		var registryErr *RegistryError
+		require.ErrorAs(t, err, &registryErr)
+		var params []string
+		for _, paramErr := range ParamErrors(err) {
+			params = append(params, paramErr.Param)
+		}
+		assert.ElementsMatch(t, []string{"factor", "label", "offset"}, params)
+	})
+
+	t.Run("validates decoded parameters", func(t *testing.T) {
+		_, err := scaleRegistry(t).Build(Spec{Name: "scale", Params: map[string]any{"factor": -1}})
+		paramErrors := ParamErrors(err)
+		require.Len(t, paramErrors, 1)
+		assert.Equal(t, "factor", paramErrors[0].Param)
+		assert.Equal(t, "must be positive", paramErrors[0].Message)
+	})
+
+	t.Run("returns errors of the factory", func(t *testing.T) {
+		failure := errors.New("failure")
+		registry := NewRegistry[float64]("scale")
+		registry.MustRegister("failing", NewFactory("always fails", NoParams{}, func(NoParams) (float64, error) {
+			return 0, failure
+		}))
+		_, err := registry.Build(Spec{Name: "failing"})
+		assert.ErrorIs(t, err, failure)
+		assert.Empty(t, ParamErrors(err))
+	})
+
+	t.Run("parses specs", func(t *testing.T) {
+		value, err := scaleRegistry(t).Parse("scale{factor:4}")
+		require.NoError(t, err)
+		assert.Equal(t, 40.0, value)
+
+		_, err = scaleRegistry(t).Parse("scale{factor:4")
+		assert.Error(t, err)
+	})
+}
+
+func TestBuiltins(t *testing.T) {
+	t.Run("builds operators for int and float64 genes", func(t *testing.T) {
+		selector, err := Selectors[int]().Parse("tournament{size:3, elites:0}")
+		require.NoError(t, err)
+		assert.Equal(t, &selection.TournamentSelector[int]{TournamentSize: 3, NumElites: 0}, selector)
+
+		_, err = Mutators[float64]().Parse("swap{rate:0.5}")
+		require.NoError(t, err)
+		for _, name := range []string{"generational", "plus", "comma"} {
+			_, err := Replacers[float64]().Build(Spec{Name: name})
+			assert.NoError(t, err, name)
+		}
+		_, err = Evaluators[int]().Build(Spec{Name: "sum"})
+		assert.NoError(t, err)
+		_, err = Crossovers[float64]().Build(Spec{Name: "single-point"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("builds termination criteria", func(t *testing.T) {
+		criterion, err := Criteria().Parse("timeout{duration:90s}")
+		require.NoError(t, err)
+		assert.Equal(t, termination.NewTimeout(90*time.Second), criterion)
+
+		_, err = Criteria().Parse("stagnation{generations:0}")
+		require.Len(t, ParamErrors(err), 1)
+		assert.Equal(t, "generations", ParamErrors(err)[0].Param)
+	})
+
+	t.Run("validates builtin parameters", func(t *testing.T) {
+		testCases := []struct {
+			spec  string
+			param string
+			build func(string) error
+		}{
+			{"tournament{size:0}", "size", func(s string) error { _, err := Selectors[int]().Parse(s); return err }},
+			{"tournament{elites:-1}", "elites", func(s string) error { _, err := Selectors[int]().Parse(s); return err }},
+			{"swap{rate:1.5}", "rate", func(s string) error { _, err := Mutators[int]().Parse(s); return err }},
+			{"generational{elites:-1}", "elites", func(s string) error { _, err := Replacers[int]().Parse(s); return err }},
+			{"plus{mu:-1}", "mu", func(s string) error { _, err := Replacers[int]().Parse(s); return err }},
+			{"max-evaluations{evaluations:0}", "evaluations", func(s string) error { _, err := Criteria().Parse(s); return err }},
+			{"timeout{duration:-1s}", "duration", func(s string) error { _, err := Criteria().Parse(s); return err }},
+			{"min-unique-genotypes{count:0}", "count", func(s string) error { _, err := Criteria().Parse(s); return err }},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.spec, func(t *testing.T) {
+				paramErrors := ParamErrors(tc.build(tc.spec))
+				require.Len(t, paramErrors, 1)
+				assert.Equal(t, tc.param, paramErrors[0].Param)
+			})
+		}
+	})
+
+	t.Run("third-party operators are registered in the shared registries", func(t *testing.T) {
+		_, err := Criteria().Build(Spec{Name: "test-never"})
+		assert.NoError(t, err)
+		assert.Contains(t, Entries(), Entry{Kind: TerminationKind, Name: "test-never", Description: "never stops", Params: map[string]any{}})
+	})
+}
+
+func TestEntries(t *testing.T) {
+	entries := Entries()
+	require.NotEmpty(t, entries)
+	assert.Equal(t, EvaluatorKind, entries[0].Kind)
+	assert.Equal(t, "ackley", entries[0].Name)
+	assert.Contains(t, entries, Entry{Kind: CrossoverKind, Name: "order", Description: "order crossover (OX) keeping the offspring permutations of the parents' genes", Params: map[string]any{}})
+	assert.Contains(t, entries, Entry{Kind: EvaluatorKind, Name: "sum", Description: "maximizes the sum of all genes", Params: map[string]any{}})
+	assert.Contains(t, entries, Entry{
+		Kind:        EvaluatorKind,
+		Name:        "rastrigin",
+		Description: "minimizes the highly multimodal Rastrigin function, optionally shifted and rotated",
+		Params:      map[string]any{"dimension": 10, "shifted": false, "rotated": false, "seed": int64(0)},
+	})
+	assert.Contains(t, entries, Entry{
+		Kind:        SelectionKind,
+		Name:        "tournament",
+		Description: "tournament selection preserving the best individuals as elites",
+		Params:      map[string]any{"size": 5, "elites": 1},
+	})
+	assert.Contains(t, entries, Entry{
+		Kind:        TerminationKind,
+		Name:        "timeout",
+		Description: "stops once the run has taken the given duration",
+		Params:      map[string]any{"duration": "1m0s"},
+	})
+
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		key := string(entry.Kind) + "/" + entry.Name
+		assert.False(t, seen[key], "%s listed twice", key)
+		seen[key] = true
+	}
+
+	// Duplicates are resolved by the type names of the registries, not by map order
+	ints, floats := NewRegistry[int]("number"), NewRegistry[float64]("number")
+	ints.MustRegister("one", NewFactory("integer one", NoParams{}, func(NoParams) (int, error) { return 1, nil }))
+	floats.MustRegister("one", NewFactory("float one", NoParams{}, func(NoParams) (float64, error) { return 1, nil }))
+	catalogs := map[reflect.Type]catalog{reflect.TypeFor[int](): ints, reflect.TypeFor[float64](): floats}
+	for range 20 {
+		assert.Equal(t, []Entry{{Kind: "number", Name: "one", Description: "float one", Params: map[string]any{}}}, listEntries(catalogs))
+	}
+}
+
+func TestProblems(t *testing.T) {
+	t.Run("builds the benchmark functions with the requested dimension", func(t *testing.T) {
+		evaluator, err := Evaluators[float64]().Parse("rastrigin{dimension:3}")
+		require.NoError(t, err)
+		function, ok := evaluator.(*problems.Function)
+		require.True(t, ok)
+		assert.Equal(t, "rastrigin", function.Name())
+		assert.Equal(t, 3, function.Dimension())
+	})
+
+	t.Run("applies shift and rotation", func(t *testing.T) {
+		testCases := []struct {
+			spec string
+			name string
+		}{
+			{"sphere{shifted:true, seed:4}", "shifted-sphere"},
+			{"sphere{rotated:true, seed:4}", "rotated-sphere"},
+			{"sphere{shifted:true, rotated:true, seed:4}", "shifted-rotated-sphere"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.spec, func(t *testing.T) {
+				evaluator, err := Evaluators[float64]().Parse(tc.spec)
+				require.NoError(t, err)
+				assert.Equal(t, tc.name, evaluator.(*problems.Function).Name())
+			})
+		}
+	})
+
+	t.Run("validates the dimension", func(t *testing.T) {
+		_, err := Evaluators[float64]().Parse("sphere{dimension:0}")
+		require.Len(t, ParamErrors(err), 1)
+		assert.Equal(t, "dimension", ParamErrors(err)[0].Param)
+
+		_, err = Evaluators[float64]().Parse("rosenbrock{dimension:1}")
+		assert.ErrorContains(t, err, "dimension must be at least 2")
+	})
+
+	t.Run("are only available for real genes", func(t *testing.T) {
+		_, err := Evaluators[int]().Parse("sphere")
+		assert.ErrorIs(t, err, ErrUnknownOperator)
+	})
+}
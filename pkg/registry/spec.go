@@ -0,0 +1,129 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec names an operator together with the parameters overriding its defaults.
+type Spec struct {
+	Name   string         `json:"name" yaml:"name"`
+	Params map[string]any `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// ParseSpec parses the compact form of a spec: the operator name, optionally followed by
+// parameters in braces, e.g. "swap", "tournament{}" or "tournament{size:5, elites:1}".
+// Parameter values are parsed as YAML, so "0.5" yields a number and "[1, 2]" a list.
+func ParseSpec(text string) (Spec, error) {
+	text = strings.TrimSpace(text)
+	name, rest, hasParams := strings.Cut(text, "{")
+	spec := Spec{Name: strings.TrimSpace(name)}
+	if !namePattern.MatchString(spec.Name) {
+		return Spec{}, NewRegistryError("invalid spec", fmt.Errorf("invalid operator name in %q", text))
+	}
+	if !hasParams {
+		return spec, nil
+	}
+	body, ok := strings.CutSuffix(rest, "}")
+	if !ok {
+		return Spec{}, NewRegistryError("invalid spec", fmt.Errorf("missing closing brace in %q", text))
+	}
+
+	spec.Params = make(map[string]any)
+	for _, assignment := range splitTopLevel(body) {
+		if strings.TrimSpace(assignment) == "" {
+			continue
+		}
+		key, value, found := strings.Cut(assignment, ":")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return Spec{}, NewRegistryError("invalid spec", fmt.Errorf("expected key:value, got %q", strings.TrimSpace(assignment)))
+		}
+		if _, duplicate := spec.Params[key]; duplicate {
+			return Spec{}, NewRegistryError("invalid spec", fmt.Errorf("parameter %q given twice", key))
+		}
+		var parsed any
+		if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+			return Spec{}, NewRegistryError("invalid spec", NewParamError(key, "cannot parse value", err))
+		}
+		spec.Params[key] = parsed
+	}
+	return spec, nil
+}
+
+// splitTopLevel is a helper function splitting the parameters of a spec at commas which are
+// not nested in brackets, braces or quotes.
+func splitTopLevel(body string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote rune
+	for i, r := range body {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '[' || r == '{':
+			depth++
+		case r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, body[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, body[start:])
+}
+
+// String formats the spec in the compact form accepted by ParseSpec, with parameters sorted by name.
+func (s Spec) String() string {
+	if len(s.Params) == 0 {
+		return s.Name
+	}
+	keys := make([]string, 0, len(s.Params))
+	for key := range s.Params {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	params := make([]string, len(keys))
+	for i, key := range keys {
+		value, err := json.Marshal(s.Params[key])
+		if err != nil {
+			value = []byte(fmt.Sprint(s.Params[key]))
+		}
+		params[i] = key + ":" + string(value)
+	}
+	return s.Name + "{" + strings.Join(params, ", ") + "}"
+}
+
+// UnmarshalText parses the compact form of a spec, so configuration files can write
+// "tournament{size:3}" instead of an object with name and params.
+func (s *Spec) UnmarshalText(text []byte) error {
+	spec, err := ParseSpec(string(text))
+	if err != nil {
+		return err
+	}
+	*s = spec
+	return nil
+}
+
+// UnmarshalJSON decodes a spec from either its compact string form or an object with name and params.
+func (s *Spec) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		return s.UnmarshalText([]byte(text))
+	}
+	type plain Spec
+	var decoded plain
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*s = Spec(decoded)
+	return nil
+}
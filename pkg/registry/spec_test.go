@@ -0,0 +1,62 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected Spec
+	}{
+		{"swap", Spec{Name: "swap"}},
+		{" tournament{} ", Spec{Name: "tournament", Params: map[string]any{}}},
+		{"tournament{size:5, elites:1}", Spec{Name: "tournament", Params: map[string]any{"size": 5, "elites": 1}}},
+		{"timeout{ duration : 30s }", Spec{Name: "timeout", Params: map[string]any{"duration": "30s"}}},
+		{"weighted{weights:[1, 2.5], label:'a, b', nested:{x: 1}}", Spec{Name: "weighted", Params: map[string]any{
+			"weights": []any{1, 2.5},
+			"label":   "a, b",
+			"nested":  map[string]any{"x": 1},
+		}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			spec, err := ParseSpec(tc.text)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, spec)
+		})
+	}
+
+	t.Run("rejects malformed specs", func(t *testing.T) {
+		for _, text := range []string{"", "{size:1}", "two words", "tournament{size:1", "tournament{size}", "tournament{size:1, size:2}", "tournament{size:[1}"} {
+			_, err := ParseSpec(text)
+			assert.ErrorAs(t, err, new(*RegistryError), text)
+		}
+	})
+}
+
+func TestSpec_String(t *testing.T) {
+	spec := Spec{Name: "tournament", Params: map[string]any{"size": 5, "elites": 1}}
+	assert.Equal(t, "tournament{elites:1, size:5}", spec.String())
+	assert.Equal(t, "swap", Spec{Name: "swap"}.String())
+
+	parsed, err := ParseSpec(spec.String())
+	require.NoError(t, err)
+	assert.Equal(t, spec, parsed)
+}
+
+func TestSpec_UnmarshalJSON(t *testing.T) {
+	var specs []Spec
+	err := json.Unmarshal([]byte(`["swap{rate:0.5}", {"name": "tournament", "params": {"size": 3}}]`), &specs)
+	require.NoError(t, err)
+	assert.Equal(t, []Spec{
+		{Name: "swap", Params: map[string]any{"rate": 0.5}},
+		{Name: "tournament", Params: map[string]any{"size": 3.0}},
+	}, specs)
+
+	assert.Error(t, json.Unmarshal([]byte(`"swap{"`), &specs[0]))
+}
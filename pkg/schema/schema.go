@@ -0,0 +1,436 @@
+// Package schema describes heterogeneous chromosomes whose genes have individual names,
+// types and domains, and provides schema-aware initialization, mutation and crossover.
+//
+// Chromosomes described by a schema are []float64 slices, so they work with the executor
+// and every []float64 operator of the library. Integer genes hold integral values, boolean
+// genes hold 0 or 1 and categorical genes hold the index of their value.
+package schema
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+)
+
+// GeneType is the type of the value a gene decodes to.
+type GeneType int
+
+const (
+	// Float genes decode to float64 values within [Lower, Upper].
+	Float GeneType = iota
+	// Int genes decode to int values within [Lower, Upper].
+	Int
+	// Categorical genes decode to one of their Values.
+	Categorical
+	// Bool genes decode to bool values.
+	Bool
+)
+
+// String returns the name of the gene type.
+func (t GeneType) String() string {
+	switch t {
+	case Float:
+		return "float"
+	case Int:
+		return "int"
+	case Categorical:
+		return "categorical"
+	case Bool:
+		return "bool"
+	default:
+		return fmt.Sprintf("GeneType(%d)", int(t))
+	}
+}
+
+// Gene describes a single position of a chromosome.
+type Gene struct {
+	// Name identifies the gene in decoded parameter maps.
+	Name string
+	// Type is the type of the decoded value.
+	Type GeneType
+	// Lower and Upper delimit the values of Float and Int genes.
+	Lower, Upper float64
+	// Values holds the allowed values of Categorical genes. Values must be comparable.
+	Values []any
+	// Step is the mutation step size: the standard deviation of the Gaussian perturbation of
+	// Float genes and the largest change of Int genes. 0 selects a tenth of the range for
+	// Float genes and 1 for Int genes.
+	Step float64
+}
+
+// FloatGene describes a float64 gene within [lower, upper] mutated with the given step size.
+func FloatGene(name string, lower, upper, step float64) Gene {
+	return Gene{Name: name, Type: Float, Lower: lower, Upper: upper, Step: step}
+}
+
+// IntGene describes an int gene within [lower, upper] changing by at most step per mutation.
+func IntGene(name string, lower, upper, step int) Gene {
+	return Gene{Name: name, Type: Int, Lower: float64(lower), Upper: float64(upper), Step: float64(step)}
+}
+
+// CategoricalGene describes a gene taking one of the given values.
+func CategoricalGene(name string, values ...any) Gene {
+	return Gene{Name: name, Type: Categorical, Values: values}
+}
+
+// BoolGene describes a boolean gene.
+func BoolGene(name string) Gene {
+	return Gene{Name: name, Type: Bool}
+}
+
+// validate checks that the gene is well-formed.
+func (g Gene) validate() error {
+	if g.Name == "" {
+		return fmt.Errorf("gene name cannot be empty")
+	}
+	if g.Step < 0 || math.IsNaN(g.Step) {
+		return fmt.Errorf("gene %q: step cannot be negative, but was %g", g.Name, g.Step)
+	}
+	switch g.Type {
+	case Float, Int:
+		if math.IsNaN(g.Lower) || math.IsNaN(g.Upper) || math.IsInf(g.Lower, 0) || math.IsInf(g.Upper, 0) || g.Lower > g.Upper {
+			return fmt.Errorf("gene %q: bounds must be finite with lower <= upper, but were [%g, %g]", g.Name, g.Lower, g.Upper)
+		}
+		if g.Type == Int && (g.Lower != math.Trunc(g.Lower) || g.Upper != math.Trunc(g.Upper)) {
+			return fmt.Errorf("gene %q: integer bounds must be integral, but were [%g, %g]", g.Name, g.Lower, g.Upper)
+		}
+	case Categorical:
+		if len(g.Values) == 0 {
+			return fmt.Errorf("gene %q: categorical gene needs at least one value", g.Name)
+		}
+	case Bool:
+	default:
+		return fmt.Errorf("gene %q: unknown type %s", g.Name, g.Type)
+	}
+	return nil
+}
+
+// domain returns the smallest and largest encoded value of the gene.
+func (g Gene) domain() (float64, float64) {
+	switch g.Type {
+	case Categorical:
+		return 0, float64(len(g.Values) - 1)
+	case Bool:
+		return 0, 1
+	default:
+		return g.Lower, g.Upper
+	}
+}
+
+// Schema describes a chromosome gene by gene.
+type Schema struct {
+	genes []Gene
+	index map[string]int
+}
+
+// New creates a schema from the given genes, in chromosome order.
+// It returns an error if a gene is malformed or two genes share a name.
+func New(genes ...Gene) (*Schema, error) {
+	if len(genes) == 0 {
+		return nil, NewSchemaError("invalid schema", fmt.Errorf("schema needs at least one gene"))
+	}
+	index := make(map[string]int, len(genes))
+	for i, gene := range genes {
+		if err := gene.validate(); err != nil {
+			return nil, NewSchemaError("invalid schema", err)
+		}
+		if _, ok := index[gene.Name]; ok {
+			return nil, NewSchemaError("invalid schema", fmt.Errorf("duplicate gene name %q", gene.Name))
+		}
+		index[gene.Name] = i
+	}
+	return &Schema{genes: append([]Gene{}, genes...), index: index}, nil
+}
+
+// Len returns the number of genes.
+func (s *Schema) Len() int {
+	return len(s.genes)
+}
+
+// Gene returns the gene at the given position.
+func (s *Schema) Gene(position int) Gene {
+	return s.genes[position]
+}
+
+// Index returns the position of the gene with the given name.
+// The boolean is false if the schema has no such gene.
+func (s *Schema) Index(name string) (int, bool) {
+	position, ok := s.index[name]
+	return position, ok
+}
+
+// RandomGene draws a random encoded value for the gene at the given position, uniformly
+// within its domain. It can be passed to core.SolutionFactory.CreateRandomSolutionByPosition.
+func (s *Schema) RandomGene(position int) float64 {
+	gene := s.genes[position]
+	switch gene.Type {
+	case Int:
+		return gene.Lower + float64(rand.Int63n(int64(gene.Upper-gene.Lower)+1))
+	case Categorical:
+		return float64(rand.Intn(len(gene.Values)))
+	case Bool:
+		return float64(rand.Intn(2))
+	default:
+		return gene.Lower + rand.Float64()*(gene.Upper-gene.Lower)
+	}
+}
+
+// NewRandomSolution creates a solution with every gene drawn by RandomGene.
+func (s *Schema) NewRandomSolution() *core.Solution[float64] {
+	return core.NewSolutionFactory[float64]().CreateRandomSolutionByPosition(s.Len(), s.RandomGene)
+}
+
+// NewRandomPopulation creates a population of size random solutions.
+func (s *Schema) NewRandomPopulation(size int) *core.Population[float64] {
+	individuals := make([]core.Solution[float64], size)
+	for i := range individuals {
+		individuals[i] = *s.NewRandomSolution()
+	}
+	return core.NewPopulationFactory[float64]().CreatePopulation(individuals)
+}
+
+// Validate checks that the chromosome matches the schema: it has one value per gene and
+// every value is a valid encoding of its gene.
+func (s *Schema) Validate(chromosome []float64) error {
+	if len(chromosome) != len(s.genes) {
+		return NewSchemaError("invalid chromosome", core.NewInvalidChromosomeError(fmt.Sprintf("expected %d genes, but got %d", len(s.genes), len(chromosome)), nil))
+	}
+	for i, gene := range s.genes {
+		value := chromosome[i]
+		lower, upper := gene.domain()
+		if math.IsNaN(value) || value < lower || value > upper || (gene.Type != Float && value != math.Trunc(value)) {
+			return NewSchemaError("invalid chromosome", core.NewInvalidChromosomeError(fmt.Sprintf("gene %q has invalid value %g", gene.Name, value), nil))
+		}
+	}
+	return nil
+}
+
+// Repair makes the chromosome valid in place by rounding discrete genes to the nearest
+// integer and clipping every gene to its domain.
+// It returns an error if the chromosome length does not match the schema.
+func (s *Schema) Repair(chromosome []float64) error {
+	if len(chromosome) != len(s.genes) {
+		return NewSchemaError("invalid chromosome", core.NewInvalidChromosomeError(fmt.Sprintf("expected %d genes, but got %d", len(s.genes), len(chromosome)), nil))
+	}
+	for i, gene := range s.genes {
+		lower, upper := gene.domain()
+		value := chromosome[i]
+		if math.IsNaN(value) {
+			value = lower
+		}
+		if gene.Type != Float {
+			value = math.Round(value)
+		}
+		chromosome[i] = math.Max(lower, math.Min(upper, value))
+	}
+	return nil
+}
+
+// Decode converts a chromosome into a map from gene names to their values: float64 for
+// Float genes, int for Int genes, bool for Bool genes and the chosen value for Categorical genes.
+// It returns an error if the chromosome does not match the schema.
+func (s *Schema) Decode(chromosome []float64) (map[string]any, error) {
+	if err := s.Validate(chromosome); err != nil {
+		return nil, err
+	}
+	parameters := make(map[string]any, len(s.genes))
+	for i, gene := range s.genes {
+		switch gene.Type {
+		case Int:
+			parameters[gene.Name] = int(chromosome[i])
+		case Categorical:
+			parameters[gene.Name] = gene.Values[int(chromosome[i])]
+		case Bool:
+			parameters[gene.Name] = chromosome[i] == 1
+		default:
+			parameters[gene.Name] = chromosome[i]
+		}
+	}
+	return parameters, nil
+}
+
+// Encode converts a map from gene names to values into a chromosome, the inverse of Decode.
+// It returns an error if a gene is missing, a value has the wrong type or lies outside its domain.
+func (s *Schema) Encode(parameters map[string]any) ([]float64, error) {
+	chromosome := make([]float64, len(s.genes))
+	for i, gene := range s.genes {
+		value, ok := parameters[gene.Name]
+		if !ok {
+			return nil, NewSchemaError("cannot encode parameters", fmt.Errorf("missing gene %q", gene.Name))
+		}
+		encoded, err := gene.encode(value)
+		if err != nil {
+			return nil, NewSchemaError("cannot encode parameters", err)
+		}
+		chromosome[i] = encoded
+	}
+	if err := s.Validate(chromosome); err != nil {
+		return nil, err
+	}
+	return chromosome, nil
+}
+
+// encode converts a decoded value of the gene into its encoded form.
+func (g Gene) encode(value any) (float64, error) {
+	switch g.Type {
+	case Categorical:
+		for i, allowed := range g.Values {
+			if allowed == value {
+				return float64(i), nil
+			}
+		}
+		return 0, fmt.Errorf("gene %q: %v is not an allowed value", g.Name, value)
+	case Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return 0, fmt.Errorf("gene %q: expected bool, but got %T", g.Name, value)
+		}
+		if b {
+			return 1, nil
+		}
+		return 0, nil
+	case Int:
+		i, ok := value.(int)
+		if !ok {
+			return 0, fmt.Errorf("gene %q: expected int, but got %T", g.Name, value)
+		}
+		return float64(i), nil
+	default:
+		f, ok := value.(float64)
+		if !ok {
+			return 0, fmt.Errorf("gene %q: expected float64, but got %T", g.Name, value)
+		}
+		return f, nil
+	}
+}
+
+// Mutator mutates every gene with probability Rate according to its type: Float genes receive
+// Gaussian noise, Int genes move by up to Step, Categorical genes switch to another value and
+// Bool genes flip. Mutated values always stay within the gene's domain.
+type Mutator struct {
+	Schema *Schema
+	Rate   float64
+}
+
+// NewMutator creates a schema-aware mutator changing every gene with the given probability.
+func NewMutator(schema *Schema, rate float64) (*Mutator, error) {
+	if schema == nil {
+		return nil, NewSchemaError("invalid schema", fmt.Errorf("schema cannot be nil"))
+	}
+	if rate < 0 || rate > 1 {
+		return nil, NewSchemaError("invalid mutation rate", fmt.Errorf("mutation rate must be within [0, 1], but was %g", rate))
+	}
+	return &Mutator{Schema: schema, Rate: rate}, nil
+}
+
+// Mutate implements mutation.IMutator. The chromosome is modified in place.
+func (m *Mutator) Mutate(ctx context.Context, chromosome *[]float64) error {
+	if chromosome == nil || len(*chromosome) != m.Schema.Len() {
+		return mutation.NewMutationError("cannot mutate chromosome", core.NewInvalidChromosomeError("chromosome does not match the schema", nil))
+	}
+	if ctx.Err() != nil {
+		return mutation.NewMutationError("context cancelled", ctx.Err())
+	}
+
+	values := *chromosome
+	for i, gene := range m.Schema.genes {
+		if rand.Float64() >= m.Rate {
+			continue
+		}
+		lower, upper := gene.domain()
+		switch gene.Type {
+		case Float:
+			step := gene.Step
+			if step == 0 {
+				step = (upper - lower) / 10
+			}
+			values[i] = math.Max(lower, math.Min(upper, values[i]+rand.NormFloat64()*step))
+		case Int:
+			step := max(1, int64(gene.Step))
+			delta := rand.Int63n(step) + 1
+			if rand.Intn(2) == 0 {
+				delta = -delta
+			}
+			values[i] = math.Max(lower, math.Min(upper, values[i]+float64(delta)))
+		case Categorical:
+			if n := len(gene.Values); n > 1 {
+				// Draw among the other values
+				next := rand.Intn(n - 1)
+				if next >= int(values[i]) {
+					next++
+				}
+				values[i] = float64(next)
+			}
+		case Bool:
+			values[i] = 1 - values[i]
+		}
+	}
+	return nil
+}
+
+// Crossover is a uniform crossover exchanging every gene between the parents with
+// probability 0.5. Genes are exchanged whole, so offspring of valid parents are valid.
+type Crossover struct {
+	Schema *Schema
+}
+
+// NewCrossover creates a schema-aware uniform crossover.
+func NewCrossover(schema *Schema) (*Crossover, error) {
+	if schema == nil {
+		return nil, NewSchemaError("invalid schema", fmt.Errorf("schema cannot be nil"))
+	}
+	return &Crossover{Schema: schema}, nil
+}
+
+// Crossover implements crossover.ICrossover.
+func (c *Crossover) Crossover(parent1, parent2 []float64) ([]float64, []float64, error) {
+	if len(parent1) != c.Schema.Len() || len(parent2) != c.Schema.Len() {
+		return nil, nil, crossover.NewCrossoverError("cannot perform crossover", core.NewInvalidChromosomeError("parent chromosomes do not match the schema", nil))
+	}
+	offspring1 := append([]float64{}, parent1...)
+	offspring2 := append([]float64{}, parent2...)
+	for i := range offspring1 {
+		if rand.Intn(2) == 0 {
+			offspring1[i], offspring2[i] = offspring2[i], offspring1[i]
+		}
+	}
+	return offspring1, offspring2, nil
+}
+
+// SchemaError represents an error that occurs while handling schema-described chromosomes.
+// Message provides a summary of the error, while Wrapped contains the underlying cause, if present.
+type SchemaError struct {
+	// Message describes the error at a high level.
+	Message string
+	// Wrapped holds the underlying error that triggered this error. Can be nil.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Wrapped)
+	}
+	return e.Message
+}
+
+// Unwrap enables errors.Is and errors.As to traverse the error chain.
+func (e *SchemaError) Unwrap() error {
+	if e == nil {
+		return nil
+	}
+	return e.Wrapped
+}
+
+// NewSchemaError constructs a *SchemaError with the provided message and wrapped error.
+func NewSchemaError(message string, wrapped error) *SchemaError {
+	return &SchemaError{
+		Message: message,
+		Wrapped: wrapped,
+	}
+}
@@ -0,0 +1,246 @@
+package schema
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tomhoffer/darwinium/pkg/core"
+	"github.com/tomhoffer/darwinium/pkg/ga/crossover"
+	"github.com/tomhoffer/darwinium/pkg/ga/executor"
+	"github.com/tomhoffer/darwinium/pkg/ga/mutation"
+	"github.com/tomhoffer/darwinium/pkg/ga/selection"
+)
+
+// newHyperparameterSchema is a helper function creating a schema mixing every gene type.
+func newHyperparameterSchema(t testing.TB) *Schema {
+	t.Helper()
+	s, err := New(
+		FloatGene("learning_rate", 1e-4, 1e-1, 0.01),
+		IntGene("layers", 1, 8, 2),
+		CategoricalGene("optimizer", "sgd", "adam", "rmsprop"),
+		BoolGene("batch_norm"),
+	)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name  string
+		genes []Gene
+	}{
+		{"no genes", nil},
+		{"empty name", []Gene{BoolGene("")}},
+		{"duplicate name", []Gene{BoolGene("a"), BoolGene("a")}},
+		{"inverted bounds", []Gene{FloatGene("a", 1, 0, 0)}},
+		{"infinite bounds", []Gene{FloatGene("a", 0, math.Inf(1), 0)}},
+		{"fractional integer bounds", []Gene{{Name: "a", Type: Int, Lower: 0.5, Upper: 2}}},
+		{"negative step", []Gene{FloatGene("a", 0, 1, -1)}},
+		{"categorical without values", []Gene{CategoricalGene("a")}},
+		{"unknown type", []Gene{{Name: "a", Type: GeneType(42)}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New(tc.genes...)
+			assert.Nil(t, s)
+			var se *SchemaError
+			assert.ErrorAs(t, err, &se)
+		})
+	}
+
+	t.Run("valid schema", func(t *testing.T) {
+		s := newHyperparameterSchema(t)
+		assert.Equal(t, 4, s.Len())
+		assert.Equal(t, "optimizer", s.Gene(2).Name)
+		assert.Equal(t, "categorical", s.Gene(2).Type.String())
+		position, ok := s.Index("layers")
+		assert.True(t, ok)
+		assert.Equal(t, 1, position)
+		_, ok = s.Index("dropout")
+		assert.False(t, ok)
+	})
+}
+
+func TestSchema_Random(t *testing.T) {
+	s := newHyperparameterSchema(t)
+
+	population := s.NewRandomPopulation(200)
+	require.Len(t, population.Individuals, 200)
+	seen := map[any]bool{}
+	for _, individual := range population.Individuals {
+		require.NoError(t, s.Validate(individual.Chromosome))
+		parameters, err := s.Decode(individual.Chromosome)
+		require.NoError(t, err)
+		seen[parameters["optimizer"]] = true
+		seen[parameters["layers"]] = true
+	}
+	assert.True(t, seen["sgd"] && seen["adam"] && seen["rmsprop"])
+	assert.True(t, seen[1] && seen[8])
+
+	t.Run("works with the solution factory", func(t *testing.T) {
+		solution := core.NewSolutionFactory[float64]().CreateRandomSolutionByPosition(s.Len(), s.RandomGene)
+		assert.NoError(t, s.Validate(solution.Chromosome))
+	})
+}
+
+func TestSchema_DecodeEncode(t *testing.T) {
+	s := newHyperparameterSchema(t)
+
+	t.Run("round trip", func(t *testing.T) {
+		parameters, err := s.Decode([]float64{0.01, 3, 1, 1})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"learning_rate": 0.01, "layers": 3, "optimizer": "adam", "batch_norm": true}, parameters)
+
+		chromosome, err := s.Encode(parameters)
+		require.NoError(t, err)
+		assert.Equal(t, []float64{0.01, 3, 1, 1}, chromosome)
+	})
+
+	t.Run("invalid chromosomes are rejected", func(t *testing.T) {
+		var ice *core.InvalidChromosomeError
+		for _, chromosome := range [][]float64{
+			{0.01, 3, 1},
+			{0.5, 3, 1, 1},
+			{0.01, 2.5, 1, 1},
+			{0.01, 3, 3, 1},
+			{0.01, 3, 1, math.NaN()},
+		} {
+			_, err := s.Decode(chromosome)
+			assert.ErrorAs(t, err, &ice, "%v", chromosome)
+		}
+	})
+
+	t.Run("invalid parameters are rejected", func(t *testing.T) {
+		var se *SchemaError
+		for _, parameters := range []map[string]any{
+			{"learning_rate": 0.01, "layers": 3, "optimizer": "adam"},
+			{"learning_rate": 0.01, "layers": 3.0, "optimizer": "adam", "batch_norm": true},
+			{"learning_rate": 0.01, "layers": 3, "optimizer": "adagrad", "batch_norm": true},
+			{"learning_rate": 1, "layers": 3, "optimizer": "adam", "batch_norm": "yes"},
+			{"learning_rate": 1.0, "layers": 3, "optimizer": "adam", "batch_norm": true},
+		} {
+			_, err := s.Encode(parameters)
+			assert.ErrorAs(t, err, &se, "%v", parameters)
+		}
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		chromosome := []float64{1, 2.6, -1, 0.4}
+		require.NoError(t, s.Repair(chromosome))
+		assert.Equal(t, []float64{0.1, 3, 0, 0}, chromosome)
+		assert.Error(t, s.Repair([]float64{1}))
+	})
+}
+
+func TestMutator(t *testing.T) {
+	s := newHyperparameterSchema(t)
+
+	t.Run("mutants respect the schema", func(t *testing.T) {
+		mutator, err := NewMutator(s, 1)
+		require.NoError(t, err)
+		for i := 0; i < 200; i++ {
+			chromosome := s.NewRandomSolution().Chromosome
+			before := append([]float64{}, chromosome...)
+			require.NoError(t, mutator.Mutate(context.Background(), &chromosome))
+			require.NoError(t, s.Validate(chromosome))
+			assert.NotEqual(t, before[2], chromosome[2], "categorical gene must change")
+			assert.NotEqual(t, before[3], chromosome[3], "boolean gene must flip")
+			assert.LessOrEqual(t, math.Abs(before[1]-chromosome[1]), 2.0)
+		}
+	})
+
+	t.Run("rate zero keeps the chromosome", func(t *testing.T) {
+		mutator, err := NewMutator(s, 0)
+		require.NoError(t, err)
+		chromosome := []float64{0.01, 3, 1, 1}
+		require.NoError(t, mutator.Mutate(context.Background(), &chromosome))
+		assert.Equal(t, []float64{0.01, 3, 1, 1}, chromosome)
+	})
+
+	t.Run("invalid arguments return error", func(t *testing.T) {
+		var se *SchemaError
+		_, err := NewMutator(nil, 0.5)
+		assert.ErrorAs(t, err, &se)
+		_, err = NewMutator(s, 2)
+		assert.ErrorAs(t, err, &se)
+
+		mutator, err := NewMutator(s, 0.5)
+		require.NoError(t, err)
+		chromosome := []float64{1}
+		var me *mutation.MutationError
+		assert.ErrorAs(t, mutator.Mutate(context.Background(), &chromosome), &me)
+	})
+}
+
+func TestCrossover(t *testing.T) {
+	s := newHyperparameterSchema(t)
+	c, err := NewCrossover(s)
+	require.NoError(t, err)
+
+	parent1, parent2 := []float64{0.01, 1, 0, 0}, []float64{0.02, 8, 2, 1}
+	for i := 0; i < 50; i++ {
+		offspring1, offspring2, err := c.Crossover(parent1, parent2)
+		require.NoError(t, err)
+		require.NoError(t, s.Validate(offspring1))
+		require.NoError(t, s.Validate(offspring2))
+		for j := range offspring1 {
+			assert.ElementsMatch(t, []float64{parent1[j], parent2[j]}, []float64{offspring1[j], offspring2[j]})
+		}
+	}
+	assert.Equal(t, []float64{0.01, 1, 0, 0}, parent1)
+
+	_, _, err = c.Crossover([]float64{1}, parent2)
+	var ce *crossover.CrossoverError
+	assert.ErrorAs(t, err, &ce)
+
+	_, err = NewCrossover(nil)
+	var se *SchemaError
+	assert.ErrorAs(t, err, &se)
+}
+
+// hyperparameterEvaluator rewards a known best configuration.
+type hyperparameterEvaluator struct {
+	schema *Schema
+}
+
+func (h hyperparameterEvaluator) Evaluate(ctx context.Context, chromosome *[]float64) (float64, error) {
+	parameters, err := h.schema.Decode(*chromosome)
+	if err != nil {
+		return 0, err
+	}
+	score := -math.Abs(parameters["learning_rate"].(float64)-0.05) * 10
+	score -= math.Abs(float64(parameters["layers"].(int) - 4))
+	if parameters["optimizer"] == "adam" {
+		score += 2
+	}
+	if parameters["batch_norm"].(bool) {
+		score++
+	}
+	return score, nil
+}
+
+func TestSchemaWithExecutor(t *testing.T) {
+	s := newHyperparameterSchema(t)
+	mutator, err := NewMutator(s, 0.25)
+	require.NoError(t, err)
+	c, err := NewCrossover(s)
+	require.NoError(t, err)
+	selector, err := selection.NewTournamentSelector[float64](3, 2)
+	require.NoError(t, err)
+
+	ga := executor.NewGeneticAlgorithmExecutor(s.NewRandomPopulation(40), hyperparameterEvaluator{schema: s}, mutator, selector, c, 40)
+	finalPopulation, err := ga.Loop(context.Background(), 40)
+	require.NoError(t, err)
+
+	best, err := finalPopulation.BestSolution()
+	require.NoError(t, err)
+	parameters, err := s.Decode(best.Chromosome)
+	require.NoError(t, err)
+	assert.Equal(t, 4, parameters["layers"])
+	assert.Equal(t, "adam", parameters["optimizer"])
+	assert.Equal(t, true, parameters["batch_norm"])
+}